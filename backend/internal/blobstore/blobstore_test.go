@@ -0,0 +1,65 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalPutGetDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("new local: %v", err)
+	}
+
+	key := "recipes/abc/image.jpg"
+	if err := store.Put(ctx, key, strings.NewReader("first")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if err := store.Put(ctx, key, strings.NewReader("second")); err != nil {
+		t.Fatalf("put replace: %v", err)
+	}
+
+	rc, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	data, err := io.ReadAll(rc)
+	_ = rc.Close()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(data) != "second" {
+		t.Fatalf("data=%q, want second", data)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("delete missing: %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("get after delete err=%v, want ErrNotFound", err)
+	}
+}
+
+func TestLocalRejectsInvalidKeys(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("new local: %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../escape", "a/../../b", "a//b", "a/./b", ".hidden", `a\b`} {
+		if err := store.Put(context.Background(), key, strings.NewReader("x")); !errors.Is(err, ErrInvalidKey) {
+			t.Fatalf("put %q err=%v, want ErrInvalidKey", key, err)
+		}
+	}
+}
@@ -29,6 +29,10 @@ type Config struct {
 	LoginRateLimitBurst        int
 	TokenCreateRateLimitPerMin int
 	TokenCreateRateLimitBurst  int
+
+	// Recipe image uploads. Uploads are disabled when BlobStoreDir is empty.
+	BlobStoreDir        string
+	MaxImageUploadBytes int64
 }
 
 // FromEnv loads the backend configuration from environment variables.
@@ -153,6 +157,17 @@ func FromEnv() (Config, error) {
 		cfg.TokenCreateRateLimitBurst = v
 	}
 
+	cfg.BlobStoreDir = os.Getenv("BLOB_STORE_DIR")
+
+	cfg.MaxImageUploadBytes = 10 << 20 // 10 MiB
+	if raw := os.Getenv("MAX_IMAGE_UPLOAD_BYTES"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || v <= 0 {
+			return Config{}, errors.New("MAX_IMAGE_UPLOAD_BYTES must be a positive integer")
+		}
+		cfg.MaxImageUploadBytes = v
+	}
+
 	return cfg, nil
 }
 
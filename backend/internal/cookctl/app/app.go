@@ -47,6 +47,9 @@ const (
 	commandGet      = "get"
 	commandRestore  = "restore"
 	commandTemplate = "template"
+	commandHistory  = "history"
+	commandDiff     = "diff"
+	commandRevert   = "revert"
 )
 
 const isoDateLayout = "2006-01-02"
@@ -250,6 +253,7 @@ type App struct {
 	healthChecked  bool
 	healthURL      string
 	healthErr      error
+	sleep          func(time.Duration) // Replaced in tests; nil means time.Sleep.
 }
 
 // apiURLForToken resolves the API URL for a token and config context.
@@ -897,7 +901,7 @@ func writeTable(w io.Writer, data interface{}) int {
 		return exitOK
 	case []client.ShoppingListItem:
 		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
-		writeLine(writer, "ID\tITEM_ID\tITEM_NAME\tAISLE\tQTY\tUNIT\tPURCHASED\tPURCHASED_AT")
+		writeLine(writer, "ID\tITEM_ID\tITEM_NAME\tAISLE\tQTY\tUNIT\tCOST\tPURCHASED\tPURCHASED_AT")
 		for _, item := range value {
 			writeShoppingListItemRow(writer, item)
 		}
@@ -907,12 +911,134 @@ func writeTable(w io.Writer, data interface{}) int {
 		return exitOK
 	case client.ShoppingListItem:
 		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
-		writeLine(writer, "ID\tITEM_ID\tITEM_NAME\tAISLE\tQTY\tUNIT\tPURCHASED\tPURCHASED_AT")
+		writeLine(writer, "ID\tITEM_ID\tITEM_NAME\tAISLE\tQTY\tUNIT\tCOST\tPURCHASED\tPURCHASED_AT")
 		writeShoppingListItemRow(writer, value)
 		if err := writer.Flush(); err != nil {
 			return exitError
 		}
 		return exitOK
+	case []client.PantryItem:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tITEM_ID\tITEM_NAME\tLOCATION\tQTY\tUNIT\tBEST_BEFORE")
+		for _, item := range value {
+			writePantryItemRow(writer, item)
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.PantryItem:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tITEM_ID\tITEM_NAME\tLOCATION\tQTY\tUNIT\tBEST_BEFORE")
+		writePantryItemRow(writer, value)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case pantryDeleteResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tDELETED")
+		writef(writer, "%s\t%t\n", value.ID, value.Deleted)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case []client.Store:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tNAME\tCREATED_AT")
+		for _, store := range value {
+			writef(writer, "%s\t%s\t%s\n",
+				store.ID,
+				store.Name,
+				store.CreatedAt.Format(time.RFC3339),
+			)
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.Store:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tNAME\tCREATED_AT")
+		writef(writer, "%s\t%s\t%s\n",
+			value.ID,
+			value.Name,
+			value.CreatedAt.Format(time.RFC3339),
+		)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case storeDeleteResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tDELETED")
+		writef(writer, "%s\t%t\n", value.ID, value.Deleted)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case []client.GroceryAisle:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tNAME\tSORT_GROUP\tSORT_ORDER\tNUMBER")
+		for _, aisle := range value {
+			writeGroceryAisleRow(writer, aisle)
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.GroceryAisle:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tNAME\tSORT_GROUP\tSORT_ORDER\tNUMBER")
+		writeGroceryAisleRow(writer, value)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case storeAisleDeleteResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "STORE_ID\tAISLE_ID\tDELETED")
+		writef(writer, "%s\t%s\t%t\n", value.StoreID, value.AisleID, value.Deleted)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case []client.StoreItemAisle:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ITEM_ID\tITEM_NAME\tAISLE_ID\tAISLE")
+		for _, placement := range value {
+			writef(writer, "%s\t%s\t%s\t%s\n",
+				placement.ItemID,
+				placement.ItemName,
+				placement.Aisle.ID,
+				placement.Aisle.Name,
+			)
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.StoreItemAisle:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ITEM_ID\tITEM_NAME\tAISLE_ID\tAISLE")
+		writef(writer, "%s\t%s\t%s\t%s\n",
+			value.ItemID,
+			value.ItemName,
+			value.Aisle.ID,
+			value.Aisle.Name,
+		)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case storeItemDeleteResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "STORE_ID\tITEM_ID\tDELETED")
+		writef(writer, "%s\t%s\t%t\n", value.StoreID, value.ItemID, value.Deleted)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
 	case shoppingListDeleteResult:
 		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
 		writeLine(writer, "ID\tDELETED")
@@ -1012,6 +1138,9 @@ func writeTable(w io.Writer, data interface{}) int {
 		if err := writer.Flush(); err != nil {
 			return exitError
 		}
+		for _, warning := range value.Warnings {
+			writef(w, "warning: %s\n", warning.Message)
+		}
 		return exitOK
 	case mealPlanDeleteResult:
 		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
@@ -1110,6 +1239,263 @@ func writeTable(w io.Writer, data interface{}) int {
 			return exitError
 		}
 		return exitOK
+	case []client.RecipeRevisionSummary:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "REVISION\tTITLE\tCREATED_AT\tCREATED_BY")
+		for _, revision := range value {
+			writef(writer, "%d\t%s\t%s\t%s\n", revision.Revision, revision.Title, revision.CreatedAt.Format(time.RFC3339), revision.CreatedBy)
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.RecipeRevision:
+		if err := writeJSON(w, value.Recipe); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.RecipeRevisionDiff:
+		if err := writeRecipeRevisionDiffTable(w, value); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.RecipeParentDiff:
+		if err := writeRecipeRevisionDiffTable(w, client.RecipeRevisionDiff{
+			Fields:      value.Fields,
+			Tags:        value.Tags,
+			Ingredients: value.Ingredients,
+			Steps:       value.Steps,
+		}); err != nil {
+			return exitError
+		}
+		return exitOK
+	case []client.RecipeDuplicate:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "SCORE\tTITLE_SIM\tOVERLAP\tRECIPE_ID\tRECIPE\tDUPLICATE_ID\tDUPLICATE")
+		for _, pair := range value {
+			writef(writer, "%.2f\t%.2f\t%.2f\t%s\t%s\t%s\t%s\n",
+				pair.Score, pair.TitleSimilarity, pair.IngredientOverlap,
+				pair.Recipe.ID, pair.Recipe.Title, pair.Duplicate.ID, pair.Duplicate.Title)
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.RecipeMatchResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tTITLE\tHAVE\tCOVERAGE\tMISSING")
+		for _, match := range value.Recipes {
+			missing := make([]string, 0, len(match.Missing))
+			for _, item := range match.Missing {
+				missing = append(missing, item.Name)
+			}
+			writef(writer, "%s\t%s\t%d/%d\t%.0f%%\t%s\n",
+				match.ID, match.Title, match.HaveCount, match.IngredientCount, match.Coverage*100, strings.Join(missing, ", "))
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		if len(value.UnknownNames) > 0 {
+			writef(w, "unknown items: %s\n", strings.Join(value.UnknownNames, ", "))
+		}
+		return exitOK
+	case client.ItemMergeResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tNAME\tMERGED_ID\tINGREDIENTS_MOVED\tLIST_ITEMS_MOVED\tLIST_ITEMS_COMBINED\tPANTRY_MOVED\tPANTRY_COMBINED")
+		writef(writer, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\n", value.Item.ID, value.Item.Name, value.MergedItemID,
+			value.RecipeIngredientsMoved, value.ShoppingListItemsMoved, value.ShoppingListItemsCombined,
+			value.PantryItemsMoved, value.PantryItemsCombined)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.TagMergeResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tNAME\tMERGED_ID\tRECIPES_MOVED")
+		writef(writer, "%s\t%s\t%s\t%d\n", value.Tag.ID, value.Tag.Name, value.MergedTagID, value.RecipesMoved)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.RecipeBookMergeResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tNAME\tMERGED_ID\tRECIPES_MOVED")
+		writef(writer, "%s\t%s\t%s\t%d\n", value.RecipeBook.ID, value.RecipeBook.Name, value.MergedRecipeBookID, value.RecipesMoved)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.RecipeMergeResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tTITLE\tMERGED_ID\tTAGS_MOVED\tMEAL_PLANS_MOVED\tSUB_RECIPE_LINES_MOVED\tVARIANTS_MOVED")
+		writef(writer, "%s\t%s\t%s\t%d\t%d\t%d\t%d\n", value.Recipe.ID, value.Recipe.Title,
+			value.MergedRecipeID, value.TagsMoved, value.MealPlanEntriesMoved, value.SubRecipeLinesMoved, value.VariantsMoved)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case []client.RecipeShare:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tCREATED_AT\tEXPIRES_AT")
+		for _, share := range value {
+			expiresAt := "never"
+			if share.ExpiresAt != nil {
+				expiresAt = share.ExpiresAt.Format(time.RFC3339)
+			}
+			writef(writer, "%s\t%s\t%s\n", share.ID, share.CreatedAt.Format(time.RFC3339), expiresAt)
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case recipeShareResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tEXPIRES_AT\tURL")
+		expiresAt := "never"
+		if value.ExpiresAt != nil {
+			expiresAt = value.ExpiresAt.Format(time.RFC3339)
+		}
+		writef(writer, "%s\t%s\t%s\n", value.ID, expiresAt, value.URL)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case recipeUnshareResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "RECIPE_ID\tSHARE_ID\tREVOKED")
+		shareID := value.ShareID
+		if shareID == "" {
+			shareID = "all"
+		}
+		writef(writer, "%s\t%s\t%t\n", value.RecipeID, shareID, value.Revoked)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case []client.RecipeImage:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tSTEP\tTYPE\tSIZE\tURL")
+		for _, image := range value {
+			writeRecipeImageRow(writer, image)
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.RecipeImage:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tSTEP\tTYPE\tSIZE\tURL")
+		writeRecipeImageRow(writer, value)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case []client.ItemPrice:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tDATE\tPRICE\tQTY\tUNIT\tUNIT_PRICE\tSTORE")
+		for _, price := range value {
+			writeItemPriceRow(writer, price)
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case []client.ItemAlias:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tALIAS")
+		for _, alias := range value {
+			writef(writer, "%s\t%s\n", alias.ID, alias.Alias)
+		}
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.ItemAlias:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tALIAS")
+		writef(writer, "%s\t%s\n", value.ID, value.Alias)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case itemAliasDeleteResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tDELETED")
+		writef(writer, "%s\t%t\n", value.ID, value.Deleted)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.ItemAttributes:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ITEM_ID\tATTRIBUTES\tCLASSIFIED")
+		writef(writer, "%s\t%s\t%t\n", value.ItemID, strings.Join(value.Attributes, ", "), value.Classified)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.UserDietaryRestrictions:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "USER_ID\tDIETS\tALLERGENS")
+		writef(writer, "%s\t%s\t%s\n", value.UserID, strings.Join(value.Diets, ", "), strings.Join(value.Allergens, ", "))
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.ItemPrice:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tDATE\tPRICE\tQTY\tUNIT\tUNIT_PRICE\tSTORE")
+		writeItemPriceRow(writer, value)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.RecipeCookEvent:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tCOOKED_AT\tSERVINGS\tRATING\tNOTES")
+		writef(writer, "%s\t%s\t%s\t%s\t%s\n",
+			value.ID,
+			value.CookedAt.Format(time.RFC3339),
+			formatOptionalInt(value.Servings),
+			formatOptionalInt(value.Rating),
+			formatOptionalString(value.Notes),
+		)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case recipeFavoriteResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tFAVORITE")
+		writef(writer, "%s\t%t\n", value.ID, value.Favorite)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case client.RecipePersonalNote:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "RECIPE_ID\tUPDATED_AT\tNOTE")
+		writef(writer, "%s\t%s\t%s\n", value.RecipeID, value.UpdatedAt.Format(time.RFC3339), value.Note)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case recipeNoteClearResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "RECIPE_ID\tCLEARED")
+		writef(writer, "%s\t%t\n", value.RecipeID, value.Cleared)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
+	case recipeImageDeleteResult:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeLine(writer, "ID\tDELETED")
+		writef(writer, "%s\t%t\n", value.ID, value.Deleted)
+		if err := writer.Flush(); err != nil {
+			return exitError
+		}
+		return exitOK
 	case recipeUpsertPayload:
 		if err := writeJSON(w, value); err != nil {
 			return exitError
@@ -1139,7 +1525,11 @@ func writeRecipeDetailTable(w io.Writer, recipe client.RecipeDetail) error {
 	writeLine(writer, "FIELD\tVALUE")
 	writef(writer, "id\t%s\n", recipe.ID)
 	writef(writer, "title\t%s\n", recipe.Title)
-	writef(writer, "servings\t%d\n", recipe.Servings)
+	if recipe.ScaledFrom != nil {
+		writef(writer, "servings\t%d (scaled from %d)\n", recipe.Servings, *recipe.ScaledFrom)
+	} else {
+		writef(writer, "servings\t%d\n", recipe.Servings)
+	}
 	writef(writer, "prep_time_minutes\t%d\n", recipe.PrepTimeMinutes)
 	writef(writer, "total_time_minutes\t%d\n", recipe.TotalTimeMinutes)
 	bookID := ""
@@ -1147,7 +1537,22 @@ func writeRecipeDetailTable(w io.Writer, recipe client.RecipeDetail) error {
 		bookID = *recipe.RecipeBookID
 	}
 	writef(writer, "recipe_book_id\t%s\n", bookID)
+	if recipe.ParentRecipe != nil {
+		writef(writer, "parent\t%s (%s)\n", recipe.ParentRecipe.Title, recipe.ParentRecipe.ID)
+	}
+	for _, variant := range recipe.Variants {
+		writef(writer, "variant\t%s (%s)\n", variant.Title, variant.ID)
+	}
 	writef(writer, "tags\t%s\n", formatRecipeTags(recipe.Tags))
+	writef(writer, "allergens\t%s\n", strings.Join(recipe.Allergens, ", "))
+	writef(writer, "diets\t%s\n", strings.Join(recipe.Diets, ", "))
+	if len(recipe.Unclassified) > 0 {
+		names := make([]string, 0, len(recipe.Unclassified))
+		for _, item := range recipe.Unclassified {
+			names = append(names, item.Name)
+		}
+		writef(writer, "unclassified\t%s\n", strings.Join(names, ", "))
+	}
 	if recipe.SourceURL != nil {
 		writef(writer, "source_url\t%s\n", strings.TrimSpace(*recipe.SourceURL))
 	} else {
@@ -1158,6 +1563,23 @@ func writeRecipeDetailTable(w io.Writer, recipe client.RecipeDetail) error {
 	} else {
 		writeLine(writer, "notes\t")
 	}
+	writef(writer, "favorite\t%t\n", recipe.IsFavorite)
+	if recipe.PersonalNote != nil {
+		writef(writer, "personal_note\t%s\n", strings.TrimSpace(*recipe.PersonalNote))
+	} else {
+		writeLine(writer, "personal_note\t")
+	}
+	writef(writer, "times_cooked\t%d\n", recipe.TimesCooked)
+	if recipe.LastCookedAt != nil {
+		writef(writer, "last_cooked_at\t%s\n", recipe.LastCookedAt.Format(time.RFC3339))
+	} else {
+		writeLine(writer, "last_cooked_at\t")
+	}
+	if recipe.AvgRating != nil {
+		writef(writer, "avg_rating\t%s\n", formatQuantity(*recipe.AvgRating))
+	} else {
+		writeLine(writer, "avg_rating\t")
+	}
 	writef(writer, "updated_at\t%s\n", recipe.UpdatedAt.Format(time.RFC3339))
 	if err := writer.Flush(); err != nil {
 		return err
@@ -1167,9 +1589,18 @@ func writeRecipeDetailTable(w io.Writer, recipe client.RecipeDetail) error {
 	if len(recipe.Ingredients) == 0 {
 		writeLine(w, "  (none)")
 	} else {
+		group := ""
 		for _, ingredient := range recipe.Ingredients {
+			if recipe.ScaledFrom != nil && ingredient.Quantity != nil {
+				// The original line carries the unscaled amount.
+				ingredient.OriginalText = nil
+			}
+			indent := writeGroupHeader(w, &group, ingredient.GroupName)
 			line := formatIngredientLine(ingredient)
-			writef(w, "  %d. %s\n", ingredient.Position, line)
+			writef(w, "%s%d. %s\n", indent, ingredient.Position, line)
+			if ingredient.SubRecipe != nil {
+				writeSubRecipeIngredients(w, *ingredient.SubRecipe, indent+"   ")
+			}
 		}
 	}
 
@@ -1177,13 +1608,200 @@ func writeRecipeDetailTable(w io.Writer, recipe client.RecipeDetail) error {
 	if len(recipe.Steps) == 0 {
 		writeLine(w, "  (none)")
 	} else {
+		group := ""
 		for _, step := range recipe.Steps {
-			writef(w, "  %d. %s\n", step.StepNumber, strings.TrimSpace(step.Instruction))
+			indent := writeGroupHeader(w, &group, step.GroupName)
+			line := strings.TrimSpace(step.Instruction)
+			if cues := formatStepCues(step); cues != "" {
+				line += " (" + cues + ")"
+			}
+			writef(w, "%s%d. %s\n", indent, step.StepNumber, line)
 		}
 	}
+
+	if recipe.Nutrition != nil {
+		writeRecipeNutrition(w, *recipe.Nutrition)
+	}
+	if recipe.Cost != nil {
+		writeRecipeCost(w, *recipe.Cost)
+	}
+	writeIngredientMatches(w, recipe.IngredientMatches)
 	return nil
 }
 
+// writeIngredientMatches lists ingredient names the server folded into an
+// existing item, so a wrong guess is easy to spot.
+func writeIngredientMatches(w io.Writer, matches []client.RecipeIngredientMatch) {
+	if len(matches) == 0 {
+		return
+	}
+	writeLine(w, "matched items:")
+	for _, match := range matches {
+		how := match.Match
+		if match.Similarity != nil {
+			how += " " + formatQuantity(*match.Similarity)
+		}
+		writef(w, "  %d. %s -> %s (%s)\n", match.Position, match.RequestedName, match.ItemName, how)
+	}
+}
+
+// formatStepCues renders a step's timing and temperature, such as
+// "25m-30m at 400°F", or "" when it has neither.
+func formatStepCues(step client.RecipeStep) string {
+	parts := make([]string, 0, 2)
+	if step.DurationSeconds != nil {
+		duration := formatStepDuration(*step.DurationSeconds)
+		if step.DurationMaxSeconds != nil && *step.DurationMaxSeconds > *step.DurationSeconds {
+			duration += "-" + formatStepDuration(*step.DurationMaxSeconds)
+		}
+		parts = append(parts, duration)
+	}
+	if step.Temperature != nil && step.TemperatureUnit != nil {
+		parts = append(parts, fmt.Sprintf("%d°%s", *step.Temperature, *step.TemperatureUnit))
+	}
+	return strings.Join(parts, " at ")
+}
+
+// formatStepDuration renders seconds as "1h 15m", "25m", or "45s".
+func formatStepDuration(seconds int) string {
+	hours, minutes, secs := seconds/3600, seconds%3600/60, seconds%60
+	parts := make([]string, 0, 3)
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	if secs > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%ds", secs))
+	}
+	return strings.Join(parts, " ")
+}
+
+// writeGroupHeader prints a heading when a line starts a new ingredient or
+// step group and returns the indent for the line itself.
+func writeGroupHeader(w io.Writer, current *string, groupName *string) string {
+	name := formatOptionalString(groupName)
+	if name != *current && name != "" {
+		writef(w, "  %s:\n", name)
+	}
+	*current = name
+	if name != "" {
+		return "    "
+	}
+	return "  "
+}
+
+// writeRecipeNutrition renders per-serving nutrition and the ingredients it leaves out.
+func writeRecipeNutrition(w io.Writer, nutrition client.RecipeNutrition) {
+	facts := nutrition.PerServing
+	header := "nutrition (per serving):"
+	if !nutrition.Complete {
+		header = "nutrition (per serving, incomplete):"
+	}
+	writeLine(w, header)
+	writef(w, "  calories %s, protein %sg, fat %sg, carbs %sg, fiber %sg, sodium %smg\n",
+		formatQuantity(facts.Calories), formatQuantity(facts.ProteinG),
+		formatQuantity(facts.FatG), formatQuantity(facts.CarbsG),
+		formatQuantity(facts.FiberG), formatQuantity(facts.SodiumMg))
+	for _, gap := range nutrition.Uncounted {
+		writef(w, "  not counted: %s (%s)\n", gap.ItemName, strings.ReplaceAll(gap.Reason, "_", " "))
+	}
+}
+
+// writeRecipeCost renders the estimated cost and the ingredients it leaves out.
+func writeRecipeCost(w io.Writer, cost client.RecipeCost) {
+	header := "estimated cost:"
+	if !cost.Complete {
+		header = "estimated cost (incomplete):"
+	}
+	writeLine(w, header)
+	writef(w, "  total %s, per serving %s\n", formatCost(cost.Total), formatCost(cost.PerServing))
+	writeCostGaps(w, cost.Uncounted)
+}
+
+// writeCostGaps lists the items a cost estimate could not price.
+func writeCostGaps(w io.Writer, gaps []client.CostGap) {
+	for _, gap := range gaps {
+		writef(w, "  not counted: %s (%s)\n", gap.ItemName, strings.ReplaceAll(gap.Reason, "_", " "))
+	}
+}
+
+// writeRecipeRevisionDiffTable renders one row per changed field, tag, ingredient, or step.
+func writeRecipeRevisionDiffTable(w io.Writer, diff client.RecipeRevisionDiff) error {
+	writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	writeLine(writer, "SECTION\tKEY\tCHANGE\tFROM\tTO")
+	for _, field := range diff.Fields {
+		writef(writer, "field\t%s\tchanged\t%s\t%s\n", field.Field, formatDiffValue(field.From), formatDiffValue(field.To))
+	}
+	for _, id := range diff.Tags.Added {
+		writef(writer, "tag\t%s\tadded\t\t\n", id)
+	}
+	for _, id := range diff.Tags.Removed {
+		writef(writer, "tag\t%s\tremoved\t\t\n", id)
+	}
+	for _, ingredient := range diff.Ingredients {
+		writef(writer, "ingredient\t%d\t%s\t%s\t%s\n", ingredient.Position, ingredient.Change, formatDiffRaw(ingredient.From), formatDiffRaw(ingredient.To))
+	}
+	for _, step := range diff.Steps {
+		writef(writer, "step\t%d\t%s\t%s\t%s\n", step.StepNumber, step.Change,
+			formatDiffStep(step.FromGroupName, step.From), formatDiffStep(step.ToGroupName, step.To))
+	}
+	return writer.Flush()
+}
+
+// formatDiffStep renders a step instruction prefixed with its group, if any.
+func formatDiffStep(groupName, instruction *string) string {
+	text := formatOptionalString(instruction)
+	if group := formatOptionalString(groupName); group != "" {
+		return "[" + group + "] " + text
+	}
+	return text
+}
+
+func formatDiffValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	if text, ok := value.(string); ok {
+		return text
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(raw)
+}
+
+func formatDiffRaw(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	return string(raw)
+}
+
+// writeRecipeImageRow renders a single recipe photo row.
+func writeRecipeImageRow(w io.Writer, image client.RecipeImage) {
+	step := ""
+	if image.StepNumber != nil {
+		step = strconv.Itoa(*image.StepNumber)
+	}
+	writef(w, "%s\t%s\t%s\t%dx%d\t%s\n", image.ID, step, image.ContentType, image.Width, image.Height, image.URL)
+}
+
+// writeItemPriceRow renders a single item price history row.
+func writeItemPriceRow(w io.Writer, price client.ItemPrice) {
+	writef(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		price.ID,
+		price.PricedOn,
+		formatCost(price.Price),
+		formatQuantity(price.Quantity),
+		price.Unit,
+		formatQuantity(price.UnitPrice),
+		formatOptionalString(price.Store),
+	)
+}
+
 // writeShoppingListDetailTable renders a human-readable shopping list detail view.
 func writeShoppingListDetailTable(w io.Writer, list client.ShoppingListDetail) error {
 	writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
@@ -1192,6 +1810,7 @@ func writeShoppingListDetailTable(w io.Writer, list client.ShoppingListDetail) e
 	writef(writer, "date\t%s\n", list.ListDate)
 	writef(writer, "name\t%s\n", list.Name)
 	writef(writer, "notes\t%s\n", formatOptionalString(list.Notes))
+	writef(writer, "store_id\t%s\n", formatOptionalString(list.StoreID))
 	writef(writer, "updated_at\t%s\n", list.UpdatedAt.Format(time.RFC3339))
 	if err := writer.Flush(); err != nil {
 		return err
@@ -1204,34 +1823,76 @@ func writeShoppingListDetailTable(w io.Writer, list client.ShoppingListDetail) e
 	}
 
 	itemWriter := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
-	writeLine(itemWriter, "ITEM_ID\tITEM_NAME\tAISLE\tQTY\tUNIT\tPURCHASED\tPURCHASED_AT")
+	writeLine(itemWriter, "ID\tITEM_ID\tITEM_NAME\tAISLE\tQTY\tUNIT\tCOST\tPURCHASED\tPURCHASED_AT")
 	for _, item := range list.Items {
 		writeShoppingListItemRow(itemWriter, item)
 	}
 	if err := itemWriter.Flush(); err != nil {
 		return err
 	}
+
+	if list.Cost != nil {
+		header := "estimated cost:"
+		if !list.Cost.Complete {
+			header = "estimated cost (incomplete):"
+		}
+		writeLine(w, header)
+		writef(w, "  total %s\n", formatCost(list.Cost.Total))
+		writeCostGaps(w, list.Cost.Uncounted)
+	}
 	return nil
 }
 
+// writeGroceryAisleRow writes one aisle with its ordering fields.
+func writeGroceryAisleRow(w io.Writer, aisle client.GroceryAisle) {
+	writef(w, "%s\t%s\t%d\t%d\t%s\n",
+		aisle.ID,
+		aisle.Name,
+		aisle.SortGroup,
+		aisle.SortOrder,
+		formatOptionalInt(aisle.NumericValue),
+	)
+}
+
 // writeShoppingListItemRow writes a single shopping list item row to a writer.
 func writeShoppingListItemRow(w io.Writer, item client.ShoppingListItem) {
 	purchasedAt := ""
 	if item.PurchasedAt != nil {
 		purchasedAt = item.PurchasedAt.Format(time.RFC3339)
 	}
-	writef(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
+	cost := ""
+	if item.EstimatedCost != nil {
+		cost = formatCost(*item.EstimatedCost)
+	}
+	writef(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
 		item.ID,
 		item.Item.ID,
 		item.Item.Name,
 		formatAisleName(item.Item.Aisle),
 		formatShoppingListQuantity(item.Quantity, item.QuantityText),
 		formatOptionalString(item.Unit),
+		cost,
 		item.IsPurchased,
 		purchasedAt,
 	)
 }
 
+func writePantryItemRow(w io.Writer, item client.PantryItem) {
+	quantity := ""
+	if item.Quantity != nil {
+		quantity = formatQuantity(*item.Quantity)
+	}
+	writef(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		item.ID,
+		item.ItemID,
+		item.ItemName,
+		item.Location,
+		quantity,
+		formatOptionalString(item.Unit),
+		formatOptionalString(item.BestBefore),
+	)
+}
+
 // formatOptionalString returns a trimmed string for optional values.
 func formatOptionalString(value *string) string {
 	if value == nil {
@@ -1240,6 +1901,14 @@ func formatOptionalString(value *string) string {
 	return strings.TrimSpace(*value)
 }
 
+// formatOptionalInt returns a decimal string for optional values.
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
 // formatAisleName returns the aisle name for an item.
 func formatAisleName(aisle *client.GroceryAisle) string {
 	if aisle == nil {
@@ -1256,6 +1925,18 @@ func formatShoppingListQuantity(quantity *float64, quantityText *string) string
 	return formatOptionalString(quantityText)
 }
 
+// writeSubRecipeIngredients lists a sub-recipe's ingredients (for one full
+// batch) under its line, recursing into nested sub-recipes.
+func writeSubRecipeIngredients(w io.Writer, sub client.RecipeSubRecipe, indent string) {
+	writef(w, "%s(%s makes %d servings)\n", indent, sub.Title, sub.Servings)
+	for _, ingredient := range sub.Ingredients {
+		writef(w, "%s- %s\n", indent, formatIngredientLine(ingredient))
+		if ingredient.SubRecipe != nil {
+			writeSubRecipeIngredients(w, *ingredient.SubRecipe, indent+"  ")
+		}
+	}
+}
+
 // formatIngredientLine renders a single ingredient line.
 func formatIngredientLine(ingredient client.RecipeIngredient) string {
 	if ingredient.OriginalText != nil {
@@ -1279,7 +1960,16 @@ func formatIngredientLine(ingredient client.RecipeIngredient) string {
 			parts = append(parts, unit)
 		}
 	}
-	item := strings.TrimSpace(ingredient.Item.Name)
+	item := ""
+	switch {
+	case ingredient.SubRecipe != nil:
+		if ingredient.Quantity != nil && ingredient.Unit == nil {
+			parts = append(parts, "servings of")
+		}
+		item = strings.TrimSpace(ingredient.SubRecipe.Title)
+	case ingredient.Item != nil:
+		item = strings.TrimSpace(ingredient.Item.Name)
+	}
 	if item != "" {
 		parts = append(parts, item)
 	}
@@ -1307,6 +1997,11 @@ func formatQuantity(value float64) string {
 	return strconv.FormatFloat(value, 'f', -1, 64)
 }
 
+// formatCost renders a money amount with two decimals.
+func formatCost(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
 // stringPtrIfNotEmpty returns a pointer for non-empty strings.
 func stringPtrIfNotEmpty(value string) *string {
 	trimmed := strings.TrimSpace(value)
@@ -1456,6 +2151,44 @@ func resolveTagIDsByName(ctx context.Context, api *client.Client, names []string
 	return ids, nil
 }
 
+// resolveItemIDsByName resolves item ids from names or ids, matching names exactly
+// (case-insensitive).
+func resolveItemIDsByName(ctx context.Context, api *client.Client, values []string) ([]string, error) {
+	ids := make([]string, 0, len(values))
+	seen := map[string]struct{}{}
+	for _, value := range values {
+		trimmed := strings.TrimSpace(value)
+		if trimmed == "" {
+			continue
+		}
+		id := trimmed
+		if _, err := uuid.Parse(trimmed); err != nil {
+			items, err := api.Items(ctx, client.ItemListParams{Query: trimmed})
+			if err != nil {
+				return nil, err
+			}
+			matches := make([]client.Item, 0, len(items))
+			for _, item := range items {
+				if strings.EqualFold(item.Name, trimmed) {
+					matches = append(matches, item)
+				}
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no item found matching %q", trimmed)
+			}
+			if len(matches) > 1 {
+				return nil, fmt.Errorf("multiple items match %q", trimmed)
+			}
+			id = matches[0].ID
+		}
+		if _, ok := seen[id]; !ok {
+			ids = append(ids, id)
+			seen[id] = struct{}{}
+		}
+	}
+	return ids, nil
+}
+
 // resolveRecipeID resolves a recipe identifier, using fuzzy title lookup if needed.
 func resolveRecipeID(ctx context.Context, api *client.Client, input string) (string, error) {
 	trimmed := strings.TrimSpace(input)
@@ -59,6 +59,8 @@ func (a *App) runBook(args []string) int {
 		return a.runBookUpdate(args[1:])
 	case commandDelete:
 		return a.runBookDelete(args[1:])
+	case commandMerge:
+		return a.runBookMerge(args[1:])
 	default:
 		usageErrorf(a.stderr, "unknown book command: %s", args[0])
 		printBookUsage(a.stderr)
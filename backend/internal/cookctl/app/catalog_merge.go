@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+// catalogMergeFlags are shared by item, tag, and book merge.
+type catalogMergeFlags struct {
+	into string
+	yes  bool
+}
+
+func catalogMergeFlagSet(name, noun string, out io.Writer, usage func(io.Writer)) (*flag.FlagSet, *catalogMergeFlags) {
+	opts := &catalogMergeFlags{}
+	flags := newFlagSet(name, out, usage)
+	flags.StringVar(&opts.into, "into", "", noun+" id to keep")
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm "+strings.ToLower(noun)+" merge")
+	return flags, opts
+}
+
+func itemMergeFlagSet(out io.Writer) (*flag.FlagSet, *catalogMergeFlags) {
+	return catalogMergeFlagSet("item merge", "Item", out, printItemMergeUsage)
+}
+
+func tagMergeFlagSet(out io.Writer) (*flag.FlagSet, *catalogMergeFlags) {
+	return catalogMergeFlagSet("tag merge", "Tag", out, printTagMergeUsage)
+}
+
+func bookMergeFlagSet(out io.Writer) (*flag.FlagSet, *catalogMergeFlags) {
+	return catalogMergeFlagSet("book merge", "Book", out, printBookMergeUsage)
+}
+
+// runItemMerge folds an item into another and deletes it.
+func (a *App) runItemMerge(args []string) int {
+	return a.runCatalogMerge(args, "item", itemMergeFlagSet, printItemMergeUsage,
+		func(ctx context.Context, api *client.Client, id, target string) (interface{}, error) {
+			return api.MergeItem(ctx, id, target)
+		})
+}
+
+// runTagMerge folds a tag into another and deletes it.
+func (a *App) runTagMerge(args []string) int {
+	return a.runCatalogMerge(args, "tag", tagMergeFlagSet, printTagMergeUsage,
+		func(ctx context.Context, api *client.Client, id, target string) (interface{}, error) {
+			return api.MergeTag(ctx, id, target)
+		})
+}
+
+// runBookMerge folds a recipe book into another and deletes it.
+func (a *App) runBookMerge(args []string) int {
+	return a.runCatalogMerge(args, "book", bookMergeFlagSet, printBookMergeUsage,
+		func(ctx context.Context, api *client.Client, id, target string) (interface{}, error) {
+			return api.MergeRecipeBook(ctx, id, target)
+		})
+}
+
+func (a *App) runCatalogMerge(
+	args []string,
+	noun string,
+	flagSet func(io.Writer) (*flag.FlagSet, *catalogMergeFlags),
+	usage func(io.Writer),
+	merge func(ctx context.Context, api *client.Client, id, target string) (interface{}, error),
+) int {
+	if hasHelpFlag(args) {
+		usage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := flagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, noun+" id is required")
+	}
+	into := strings.TrimSpace(opts.into)
+	if into == "" {
+		return usageError(a.stderr, "--into is required")
+	}
+	if !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := merge(ctx, api, strings.TrimSpace(id), into)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
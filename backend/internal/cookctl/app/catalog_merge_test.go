@@ -0,0 +1,75 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+func TestRunItemMergeRequiresIntoAndYes(t *testing.T) {
+	t.Parallel()
+
+	merged := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/items/item-1/merge-into/item-2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want POST", r.Method)
+		}
+		merged = true
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.ItemMergeResult{
+			Item:                      client.Item{ID: "item-2", Name: "Green onions"},
+			MergedItemID:              "item-1",
+			RecipeIngredientsMoved:    3,
+			ShoppingListItemsCombined: 1,
+		})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runItem([]string{"merge", "item-1", "--yes"}); exitCode != exitUsage {
+		t.Fatalf("exit code without into = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runItem([]string{"merge", "item-1", "--into", "item-2"}); exitCode != exitUsage {
+		t.Fatalf("exit code without yes = %d, want %d", exitCode, exitUsage)
+	}
+	if merged {
+		t.Fatalf("merge ran without confirmation")
+	}
+	if exitCode := app.runItem([]string{"merge", "item-1", "--into", "item-2", "--yes"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !merged {
+		t.Fatalf("item was not merged")
+	}
+	if out := stdout.String(); !strings.Contains(out, "INGREDIENTS_MOVED") || !strings.Contains(out, "Green onions") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestRunTagAndBookMerge(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/tags/tag-1/merge-into/tag-2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.TagMergeResult{Tag: client.Tag{ID: "tag-2", Name: "quick"}, MergedTagID: "tag-1", RecipesMoved: 4})
+	})
+	mux.HandleFunc("/api/v1/recipe-books/book-1/merge-into/book-2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.RecipeBookMergeResult{RecipeBook: client.RecipeBook{ID: "book-2", Name: "Family"}, MergedRecipeBookID: "book-1", RecipesMoved: 2})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runTag([]string{"merge", "tag-1", "--into", "tag-2", "--yes"}); exitCode != exitOK {
+		t.Fatalf("tag exit code = %d, want %d", exitCode, exitOK)
+	}
+	if exitCode := app.runBook([]string{"merge", "book-1", "--into", "book-2", "--yes"}); exitCode != exitOK {
+		t.Fatalf("book exit code = %d, want %d", exitCode, exitOK)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "quick") || !strings.Contains(out, "Family") || !strings.Contains(out, "RECIPES_MOVED") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
@@ -81,6 +81,7 @@ func commandRegistry() []*command {
 				{Name: commandCreate, Usage: printTagCreateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := tagCreateFlagSet(out); return fs }},
 				{Name: commandUpdate, Usage: printTagUpdateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := tagUpdateFlagSet(out); return fs }},
 				{Name: commandDelete, Usage: printTagDeleteUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := tagDeleteFlagSet(out); return fs }},
+				{Name: commandMerge, Usage: printTagMergeUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := tagMergeFlagSet(out); return fs }},
 			},
 		},
 		{
@@ -93,6 +94,25 @@ func commandRegistry() []*command {
 				{Name: commandCreate, Usage: printItemCreateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := itemCreateFlagSet(out); return fs }},
 				{Name: commandUpdate, Usage: printItemUpdateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := itemUpdateFlagSet(out); return fs }},
 				{Name: commandDelete, Usage: printItemDeleteUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := itemDeleteFlagSet(out); return fs }},
+				{Name: commandMerge, Usage: printItemMergeUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := itemMergeFlagSet(out); return fs }},
+				{
+					Name:  commandPrice,
+					Usage: printItemPriceUsage,
+					Subcommands: []*command{
+						{Name: commandList, Usage: printItemPriceListUsage, FlagSet: itemPriceListFlagSet},
+						{Name: commandPriceAdd, Usage: printItemPriceAddUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := itemPriceAddFlagSet(out); return fs }},
+					},
+				},
+				{Name: commandAttributes, Usage: printItemAttributesUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := itemAttributesFlagSet(out); return fs }},
+				{
+					Name:  commandAlias,
+					Usage: printItemAliasUsage,
+					Subcommands: []*command{
+						{Name: commandList, Usage: printItemAliasListUsage, FlagSet: itemAliasListFlagSet},
+						{Name: commandAliasAdd, Usage: printItemAliasAddUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := itemAliasAddFlagSet(out); return fs }},
+						{Name: commandAliasRemove, Usage: printItemAliasRemoveUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := itemAliasRemoveFlagSet(out); return fs }},
+					},
+				},
 			},
 		},
 		{
@@ -105,6 +125,7 @@ func commandRegistry() []*command {
 				{Name: commandCreate, Usage: printBookCreateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := bookCreateFlagSet(out); return fs }},
 				{Name: commandUpdate, Usage: printBookUpdateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := bookUpdateFlagSet(out); return fs }},
 				{Name: commandDelete, Usage: printBookDeleteUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := bookDeleteFlagSet(out); return fs }},
+				{Name: commandMerge, Usage: printBookMergeUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := bookMergeFlagSet(out); return fs }},
 			},
 		},
 		{
@@ -116,6 +137,7 @@ func commandRegistry() []*command {
 				{Name: commandList, Usage: printUserListUsage, FlagSet: userListFlagSet},
 				{Name: commandCreate, Usage: printUserCreateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := userCreateFlagSet(out); return fs }},
 				{Name: "deactivate", Usage: printUserDeactivateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := userDeactivateFlagSet(out); return fs }},
+				{Name: commandRestrictions, Usage: printUserRestrictionsUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := userRestrictionsFlagSet(out); return fs }},
 			},
 		},
 		{
@@ -125,7 +147,7 @@ func commandRegistry() []*command {
 			Run:      (*App).runRecipe,
 			Subcommands: []*command{
 				{Name: commandList, Usage: printRecipeListUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeListFlagSet(out); return fs }},
-				{Name: commandGet, Usage: printRecipeGetUsage, FlagSet: recipeGetFlagSet},
+				{Name: commandGet, Usage: printRecipeGetUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeGetFlagSet(out); return fs }},
 				{Name: commandCreate, Usage: printRecipeCreateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeCreateFlagSet(out); return fs }},
 				{Name: commandUpdate, Usage: printRecipeUpdateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeUpdateFlagSet(out); return fs }},
 				{Name: commandInit, Usage: printRecipeInitUsage, FlagSet: recipeInitFlagSet},
@@ -137,6 +159,28 @@ func commandRegistry() []*command {
 				{Name: commandEdit, Usage: printRecipeEditUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeEditFlagSet(out); return fs }},
 				{Name: commandDelete, Usage: printRecipeDeleteUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeDeleteFlagSet(out); return fs }},
 				{Name: commandRestore, Usage: printRecipeRestoreUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeRestoreFlagSet(out); return fs }},
+				{Name: commandHistory, Usage: printRecipeHistoryUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeHistoryFlagSet(out); return fs }},
+				{Name: commandDiff, Usage: printRecipeDiffUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeDiffFlagSet(out); return fs }},
+				{Name: commandRevert, Usage: printRecipeRevertUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeRevertFlagSet(out); return fs }},
+				{Name: commandDupes, Usage: printRecipeDupesUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeDupesFlagSet(out); return fs }},
+				{Name: commandMerge, Usage: printRecipeMergeUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeMergeFlagSet(out); return fs }},
+				{Name: commandMatch, Usage: printRecipeMatchUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeMatchFlagSet(out); return fs }},
+				{Name: commandShare, Usage: printRecipeShareUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeShareFlagSet(out); return fs }},
+				{Name: commandUnshare, Usage: printRecipeUnshareUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeUnshareFlagSet(out); return fs }},
+				{Name: commandCook, Usage: printRecipeCookUsage, FlagSet: recipeCookFlagSet},
+				{Name: commandCooked, Usage: printRecipeCookedUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeCookedFlagSet(out); return fs }},
+				{Name: commandFav, Usage: printRecipeFavUsage, FlagSet: recipeFavFlagSet},
+				{Name: commandUnfav, Usage: printRecipeUnfavUsage, FlagSet: recipeUnfavFlagSet},
+				{Name: commandNote, Usage: printRecipeNoteUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeNoteFlagSet(out); return fs }},
+				{
+					Name:  commandImage,
+					Usage: printRecipeImageUsage,
+					Subcommands: []*command{
+						{Name: commandList, Usage: printRecipeImageListUsage, FlagSet: recipeImageListFlagSet},
+						{Name: commandImageAdd, Usage: printRecipeImageAddUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeImageAddFlagSet(out); return fs }},
+						{Name: commandImageRemove, Usage: printRecipeImageRemoveUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := recipeImageRemoveFlagSet(out); return fs }},
+					},
+				},
 			},
 		},
 		{
@@ -175,6 +219,49 @@ func commandRegistry() []*command {
 				},
 			},
 		},
+		{
+			Name:     "pantry",
+			Synopsis: "Manage pantry stock",
+			Usage:    printPantryUsage,
+			Run:      (*App).runPantry,
+			Subcommands: []*command{
+				{Name: commandList, Usage: printPantryListUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := pantryListFlagSet(out); return fs }},
+				{Name: commandCreate, Usage: printPantryCreateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := pantryCreateFlagSet(out); return fs }},
+				{Name: commandUpdate, Usage: printPantryUpdateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := pantryUpdateFlagSet(out); return fs }},
+				{Name: commandDelete, Usage: printPantryDeleteUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := pantryDeleteFlagSet(out); return fs }},
+			},
+		},
+		{
+			Name:     "store",
+			Synopsis: "Manage stores and their aisle layouts",
+			Usage:    printStoreUsage,
+			Run:      (*App).runStore,
+			Subcommands: []*command{
+				{Name: commandList, Usage: printStoreListUsage},
+				{Name: commandCreate, Usage: printStoreCreateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := storeCreateFlagSet(out); return fs }},
+				{Name: commandUpdate, Usage: printStoreUpdateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := storeUpdateFlagSet(out); return fs }},
+				{Name: commandDelete, Usage: printStoreDeleteUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := storeDeleteFlagSet(out); return fs }},
+				{
+					Name:  commandStoreAisle,
+					Usage: printStoreAisleUsage,
+					Subcommands: []*command{
+						{Name: commandList, Usage: printStoreAisleListUsage, FlagSet: storeAisleListFlagSet},
+						{Name: commandStoreAdd, Usage: printStoreAisleAddUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := storeAisleAddFlagSet(out); return fs }},
+						{Name: commandUpdate, Usage: printStoreAisleUpdateUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := storeAisleUpdateFlagSet(out); return fs }},
+						{Name: commandStoreRemove, Usage: printStoreAisleRemoveUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := storeAisleRemoveFlagSet(out); return fs }},
+					},
+				},
+				{
+					Name:  commandStoreItem,
+					Usage: printStoreItemUsage,
+					Subcommands: []*command{
+						{Name: commandList, Usage: printStoreItemListUsage, FlagSet: storeItemListFlagSet},
+						{Name: commandStoreSet, Usage: printStoreItemSetUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := storeItemSetFlagSet(out); return fs }},
+						{Name: commandStoreRemove, Usage: printStoreItemRemoveUsage, FlagSet: func(out io.Writer) *flag.FlagSet { fs, _ := storeItemRemoveFlagSet(out); return fs }},
+					},
+				},
+			},
+		},
 		{
 			Name:     "config",
 			Synopsis: "Manage config values",
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+)
+
+const (
+	commandAttributes   = "attributes"
+	commandRestrictions = "restrictions"
+)
+
+type itemAttributesFlags struct {
+	attributes csvStrings
+	clear      bool
+}
+
+type userRestrictionsFlags struct {
+	diets     csvStrings
+	allergens csvStrings
+	clear     bool
+}
+
+func itemAttributesFlagSet(out io.Writer) (*flag.FlagSet, *itemAttributesFlags) {
+	opts := &itemAttributesFlags{}
+	flags := newFlagSet("item attributes", out, printItemAttributesUsage)
+	flags.Var(&opts.attributes, "attribute", "Attribute the item contains, e.g. nuts or dairy (repeatable; replaces existing)")
+	flags.BoolVar(&opts.clear, "clear", false, "Mark the item as containing none of the attributes")
+	return flags, opts
+}
+
+func userRestrictionsFlagSet(out io.Writer) (*flag.FlagSet, *userRestrictionsFlags) {
+	opts := &userRestrictionsFlags{}
+	flags := newFlagSet("user restrictions", out, printUserRestrictionsUsage)
+	flags.Var(&opts.diets, "diet", "Diet the user follows, e.g. vegetarian (repeatable)")
+	flags.Var(&opts.allergens, "allergen", "Allergen the user avoids, e.g. peanuts (repeatable)")
+	flags.BoolVar(&opts.clear, "clear", false, "Remove all restrictions")
+	return flags, opts
+}
+
+// runItemAttributes shows an item's allergen and diet attributes, or replaces
+// them when --attribute or --clear is given.
+func (a *App) runItemAttributes(args []string) int {
+	if hasHelpFlag(args) {
+		printItemAttributesUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := itemAttributesFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "item id is required")
+	}
+	attributes := opts.attributes.Values()
+	if opts.clear && len(attributes) > 0 {
+		return usageError(a.stderr, "attribute and clear cannot be combined")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	if !opts.clear && len(attributes) == 0 {
+		resp, getErr := api.ItemAttributes(ctx, id)
+		if getErr != nil {
+			return a.handleAPIError(getErr)
+		}
+		return writeOutput(a.stdout, a.cfg.Output, resp)
+	}
+
+	resp, err := api.SetItemAttributes(ctx, id, attributes)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runUserRestrictions shows a user's declared diets and allergens, or
+// replaces both lists when any of --diet, --allergen, or --clear is given.
+func (a *App) runUserRestrictions(args []string) int {
+	if hasHelpFlag(args) {
+		printUserRestrictionsUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := userRestrictionsFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "user id is required")
+	}
+	diets, allergens := opts.diets.Values(), opts.allergens.Values()
+	setting := len(diets) > 0 || len(allergens) > 0
+	if opts.clear && setting {
+		return usageError(a.stderr, "clear cannot be combined with diet or allergen")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	if !opts.clear && !setting {
+		resp, getErr := api.UserDietaryRestrictions(ctx, id)
+		if getErr != nil {
+			return a.handleAPIError(getErr)
+		}
+		return writeOutput(a.stdout, a.cfg.Output, resp)
+	}
+
+	resp, err := api.SetUserDietaryRestrictions(ctx, id, diets, allergens)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
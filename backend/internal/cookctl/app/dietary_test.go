@@ -0,0 +1,112 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+func TestRunItemAttributes(t *testing.T) {
+	t.Parallel()
+
+	var puts [][]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/items/item-1/attributes", func(w http.ResponseWriter, r *http.Request) {
+		resp := client.ItemAttributes{ItemID: "item-1", Attributes: []string{"nuts"}}
+		if r.Method == http.MethodPut {
+			var payload struct {
+				Attributes []string `json:"attributes"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			puts = append(puts, payload.Attributes)
+			resp.Attributes = payload.Attributes
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, resp)
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runItem([]string{"attributes", "item-1", "--attribute", "nuts", "--clear"}); exitCode != exitUsage {
+		t.Fatalf("exit code for attribute with clear = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runItem([]string{"attributes", "item-1"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !strings.Contains(stdout.String(), "ATTRIBUTES") || !strings.Contains(stdout.String(), "nuts") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+	if exitCode := app.runItem([]string{"attributes", "item-1", "--attribute", "nuts", "--attribute", "dairy"}); exitCode != exitOK {
+		t.Fatalf("exit code for set = %d, want %d", exitCode, exitOK)
+	}
+	if exitCode := app.runItem([]string{"attributes", "item-1", "--clear"}); exitCode != exitOK {
+		t.Fatalf("exit code for clear = %d, want %d", exitCode, exitOK)
+	}
+
+	if len(puts) != 2 || !slices.Equal(puts[0], []string{"nuts", "dairy"}) || puts[1] == nil || len(puts[1]) != 0 {
+		t.Fatalf("puts = %v, want set then an explicit empty list", puts)
+	}
+}
+
+func TestRunUserRestrictions(t *testing.T) {
+	t.Parallel()
+
+	var got struct {
+		Diets     []string `json:"diets"`
+		Allergens []string `json:"allergens"`
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/users/user-1/dietary-restrictions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Fatalf("method = %s, want PUT", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.UserDietaryRestrictions{UserID: "user-1", Diets: got.Diets, Allergens: got.Allergens})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runUser([]string{"restrictions", "user-1", "--diet", "vegan", "--clear"}); exitCode != exitUsage {
+		t.Fatalf("exit code for diet with clear = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runUser([]string{"restrictions", "user-1", "--diet", "vegan", "--allergen", "peanuts"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !slices.Equal(got.Diets, []string{"vegan"}) || !slices.Equal(got.Allergens, []string{"peanuts"}) {
+		t.Fatalf("payload = %+v", got)
+	}
+	if !strings.Contains(stdout.String(), "ALLERGENS") || !strings.Contains(stdout.String(), "peanuts") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestRunMealPlanCreatePrintsDietaryWarnings(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/meal-plans", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.MealPlanEntry{
+			Date:   "2025-03-01",
+			Recipe: client.MealPlanRecipe{ID: testMealPlanRecipeID, Title: "Pesto pasta"},
+			Warnings: []client.DietaryConflict{
+				{Kind: "allergen", Restriction: "nuts", Attributes: []string{"nuts"}, Message: "recipe contains nuts"},
+			},
+		})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runMealPlan([]string{"create", "--date", "2025-03-01", "--recipe-id", testMealPlanRecipeID}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !strings.Contains(stdout.String(), "warning: recipe contains nuts") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
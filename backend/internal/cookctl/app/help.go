@@ -229,6 +229,16 @@ func printTagDeleteUsage(w io.Writer) {
 	})
 }
 
+func printTagMergeUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl tag merge <id> --into <target-id> --yes",
+		"Tags the tag's recipes with the target tag, then deletes the tag.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := tagMergeFlagSet(out)
+		return flags
+	})
+}
+
 // Item and shopping list usage helpers live in shopping_list_usage.go
 
 func printBookUsage(w io.Writer) {
@@ -266,6 +276,16 @@ func printBookDeleteUsage(w io.Writer) {
 	})
 }
 
+func printBookMergeUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl book merge <id> --into <target-id> --yes",
+		"Moves the book's recipes to the target book, then deletes the book.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := bookMergeFlagSet(out)
+		return flags
+	})
+}
+
 func printUserUsage(w io.Writer) {
 	printCommandUsage(w, "usage: cookctl user <command> [flags]", "user")
 }
@@ -292,6 +312,17 @@ func printUserDeactivateUsage(w io.Writer) {
 	})
 }
 
+func printUserRestrictionsUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl user restrictions <id> [--diet <name>]... [--allergen <name>]... [--clear]",
+		"Shows the user's restrictions when no flags are given; otherwise replaces both lists.",
+		"Diets: dairy-free, gluten-free, nut-free, pescatarian, vegan, vegetarian.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := userRestrictionsFlagSet(out)
+		return flags
+	})
+}
+
 func printRecipeUsage(w io.Writer) {
 	printCommandUsage(w, "usage: cookctl recipe <command> [flags]", "recipe")
 }
@@ -306,7 +337,12 @@ func printRecipeListUsage(w io.Writer) {
 }
 
 func printRecipeGetUsage(w io.Writer) {
-	writeLine(w, "usage: cookctl recipe get <id|title>")
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe get <id|title> [--servings <n>]",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeGetFlagSet(out)
+		return flags
+	})
 }
 
 func printRecipeCreateUsage(w io.Writer) {
@@ -341,7 +377,7 @@ func printRecipeExportUsage(w io.Writer) {
 
 func printRecipeImportUsage(w io.Writer) {
 	printUsageWithFlags(w, []string{
-		"usage: cookctl recipe import [--file <path> | --stdin] [--allow-duplicate]",
+		"usage: cookctl recipe import [--file <path> | --stdin] [--format json|html|jsonld] [--source-url <url>] [--draft] [--allow-duplicate]",
 	}, func(out io.Writer) *flag.FlagSet {
 		flags, _ := recipeImportFlagSet(out)
 		return flags
@@ -390,6 +426,142 @@ func printRecipeRestoreUsage(w io.Writer) {
 	})
 }
 
+func printRecipeHistoryUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe history <id|title> [--revision <n>]",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeHistoryFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeDiffUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe diff <id|title> --from <n> --to <n>",
+		"       cookctl recipe diff <id|title> --parent",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeDiffFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeRevertUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe revert <id|title> --revision <n> --yes",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeRevertFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeCookUsage(w io.Writer) {
+	writeLine(w, "usage: cookctl recipe cook <id|title>")
+	writeLine(w, "Walks through the steps one at a time and runs a countdown for steps with a duration.")
+}
+
+func printRecipeCookedUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe cooked <id|title> [--rating <1-5>] [--note <text>] [--servings <n>] [--date <YYYY-MM-DD>]",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeCookedFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeDupesUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe dupes [<id|title>] [--min-score <0-1>] [--limit <n>]",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeDupesFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeMatchUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe match --have <name[,name...]> [--item-id <id>] [--ignore-staples] [--max-missing <n>] [--limit <n>]",
+		"Lists recipes using the items on hand, best coverage first, with what is missing.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeMatchFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeMergeUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe merge <keep id|title> --from <duplicate id|title> --yes",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeMergeFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeShareUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe share <id|title> [--expires-at <RFC3339>]",
+		"       cookctl recipe share <id|title> --list",
+		"The link token is only shown when the share is created.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeShareFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeUnshareUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe unshare <id|title> (--share-id <id> | --all) --yes",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeUnshareFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeFavUsage(w io.Writer) {
+	writeLine(w, "usage: cookctl recipe fav <id|title>")
+}
+
+func printRecipeUnfavUsage(w io.Writer) {
+	writeLine(w, "usage: cookctl recipe unfav <id|title>")
+}
+
+func printRecipeNoteUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe note <id|title> [--set <text> | --clear --yes]",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeNoteFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeImageUsage(w io.Writer) {
+	writeLine(w, "usage: cookctl recipe image <command> [flags]")
+	printCommandSubcommandsPath(w, "recipe", "image")
+}
+
+func printRecipeImageListUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe image list <id|title>",
+	}, recipeImageListFlagSet)
+}
+
+func printRecipeImageAddUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe image add <id|title> --file <path> [--step <n>]",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeImageAddFlagSet(out)
+		return flags
+	})
+}
+
+func printRecipeImageRemoveUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl recipe image rm <id|title> --image-id <id> --yes",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := recipeImageRemoveFlagSet(out)
+		return flags
+	})
+}
+
 func printMealPlanUsage(w io.Writer) {
 	printCommandUsage(w, "usage: cookctl meal-plan <command> [flags]", "meal-plan")
 }
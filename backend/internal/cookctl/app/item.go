@@ -83,6 +83,14 @@ func (a *App) runItem(args []string) int {
 		return a.runItemUpdate(args[1:])
 	case commandDelete:
 		return a.runItemDelete(args[1:])
+	case commandMerge:
+		return a.runItemMerge(args[1:])
+	case commandPrice:
+		return a.runItemPrice(args[1:])
+	case commandAttributes:
+		return a.runItemAttributes(args[1:])
+	case commandAlias:
+		return a.runItemAlias(args[1:])
 	default:
 		usageErrorf(a.stderr, "unknown item command: %s", args[0])
 		printItemUsage(a.stderr)
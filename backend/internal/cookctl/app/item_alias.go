@@ -0,0 +1,175 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+)
+
+const (
+	commandAlias       = "alias"
+	commandAliasAdd    = "add"
+	commandAliasRemove = "rm"
+)
+
+type itemAliasAddFlags struct {
+	name string
+}
+
+type itemAliasRemoveFlags struct {
+	aliasID string
+	yes     bool
+}
+
+type itemAliasDeleteResult struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+func itemAliasListFlagSet(out io.Writer) *flag.FlagSet {
+	return newFlagSet("item alias list", out, printItemAliasListUsage)
+}
+
+func itemAliasAddFlagSet(out io.Writer) (*flag.FlagSet, *itemAliasAddFlags) {
+	opts := &itemAliasAddFlags{}
+	flags := newFlagSet("item alias add", out, printItemAliasAddUsage)
+	flags.StringVar(&opts.name, "name", "", "Alias (another name for the item, such as scallions)")
+	return flags, opts
+}
+
+func itemAliasRemoveFlagSet(out io.Writer) (*flag.FlagSet, *itemAliasRemoveFlags) {
+	opts := &itemAliasRemoveFlags{}
+	flags := newFlagSet("item alias rm", out, printItemAliasRemoveUsage)
+	flags.StringVar(&opts.aliasID, "alias-id", "", "Alias id")
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm alias deletion")
+	return flags, opts
+}
+
+// runItemAlias routes item alias subcommands.
+func (a *App) runItemAlias(args []string) int {
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		printItemAliasUsage(a.stdout)
+		return exitOK
+	}
+	if len(args) == 0 {
+		printItemAliasUsage(a.stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case commandList:
+		return a.runItemAliasList(args[1:])
+	case commandAliasAdd:
+		return a.runItemAliasAdd(args[1:])
+	case commandAliasRemove:
+		return a.runItemAliasRemove(args[1:])
+	default:
+		usageErrorf(a.stderr, "unknown item alias command: %s", args[0])
+		printItemAliasUsage(a.stderr)
+		return exitUsage
+	}
+}
+
+// runItemAliasList prints an item's aliases.
+func (a *App) runItemAliasList(args []string) int {
+	if hasHelpFlag(args) {
+		printItemAliasListUsage(a.stdout)
+		return exitOK
+	}
+
+	flags := itemAliasListFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "item id is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.ItemAliases(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runItemAliasAdd adds another name for an item.
+func (a *App) runItemAliasAdd(args []string) int {
+	if hasHelpFlag(args) {
+		printItemAliasAddUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := itemAliasAddFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "item id is required")
+	}
+	name := strings.TrimSpace(opts.name)
+	if name == "" {
+		return usageError(a.stderr, "--name is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.AddItemAlias(ctx, strings.TrimSpace(id), name)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runItemAliasRemove deletes an item alias.
+func (a *App) runItemAliasRemove(args []string) int {
+	if hasHelpFlag(args) {
+		printItemAliasRemoveUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := itemAliasRemoveFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "item id is required")
+	}
+	aliasID := strings.TrimSpace(opts.aliasID)
+	if aliasID == "" {
+		return usageError(a.stderr, "--alias-id is required")
+	}
+	if !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	if err := api.DeleteItemAlias(ctx, strings.TrimSpace(id), aliasID); err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, itemAliasDeleteResult{ID: aliasID, Deleted: true})
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+func TestRunItemAliasAddAndList(t *testing.T) {
+	t.Parallel()
+
+	var added string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/items/item-1/aliases", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			var payload struct {
+				Alias string `json:"alias"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			added = payload.Alias
+			w.WriteHeader(http.StatusCreated)
+			writeTestJSON(t, w, client.ItemAlias{ID: "alias-1", ItemID: "item-1", Alias: payload.Alias})
+			return
+		}
+		writeTestJSON(t, w, []client.ItemAlias{{ID: "alias-1", ItemID: "item-1", Alias: "Scallions"}})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runItem([]string{"alias", "add", "item-1"}); exitCode != exitUsage {
+		t.Fatalf("exit code without name = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runItem([]string{"alias", "add", "item-1", "--name", " Scallions "}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if added != "Scallions" {
+		t.Fatalf("alias = %q, want Scallions", added)
+	}
+	if exitCode := app.runItem([]string{"alias", "list", "item-1"}); exitCode != exitOK {
+		t.Fatalf("exit code for list = %d, want %d", exitCode, exitOK)
+	}
+	if !strings.Contains(stdout.String(), "ALIAS") || !strings.Contains(stdout.String(), "Scallions") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestRunItemAliasRemoveRequiresAliasIDAndYes(t *testing.T) {
+	t.Parallel()
+
+	deleted := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/items/item-1/aliases/alias-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("method = %s, want DELETE", r.Method)
+		}
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	app, _ := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runItem([]string{"alias", "rm", "item-1", "--yes"}); exitCode != exitUsage {
+		t.Fatalf("exit code without alias id = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runItem([]string{"alias", "rm", "item-1", "--alias-id", "alias-1"}); exitCode != exitUsage {
+		t.Fatalf("exit code without yes = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runItem([]string{"alias", "rm", "item-1", "--alias-id", "alias-1", "--yes"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !deleted {
+		t.Fatalf("alias was not deleted")
+	}
+}
+
+func TestWriteRecipeDetailTableListsIngredientMatches(t *testing.T) {
+	t.Parallel()
+
+	similarity := 0.857
+	recipe := client.RecipeDetail{
+		ID:    "recipe-1",
+		Title: "Salsa",
+		IngredientMatches: []client.RecipeIngredientMatch{
+			{Position: 1, RequestedName: "scallions", ItemName: "Green onions", Match: "alias"},
+			{Position: 3, RequestedName: "all purpose flour", ItemName: "All-purpose flour", Match: "similar", Similarity: &similarity},
+		},
+	}
+	var out strings.Builder
+	if err := writeRecipeDetailTable(&out, recipe); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	for _, want := range []string{"matched items:", "1. scallions -> Green onions (alias)", "3. all purpose flour -> All-purpose flour (similar 0.857)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+const (
+	commandPrice    = "price"
+	commandPriceAdd = "add"
+)
+
+type itemPriceAddFlags struct {
+	price    float64
+	priceSet bool
+	quantity float64
+	unit     string
+	store    string
+	date     string
+}
+
+func itemPriceListFlagSet(out io.Writer) *flag.FlagSet {
+	return newFlagSet("item price list", out, printItemPriceListUsage)
+}
+
+func itemPriceAddFlagSet(out io.Writer) (*flag.FlagSet, *itemPriceAddFlags) {
+	opts := &itemPriceAddFlags{}
+	flags := newFlagSet("item price add", out, printItemPriceAddUsage)
+	flags.Float64Var(&opts.price, "price", 0, "Price paid")
+	flags.Float64Var(&opts.quantity, "quantity", 1, "Package quantity the price is for")
+	flags.StringVar(&opts.unit, "unit", "", "Package unit (for example lb, kg, or piece)")
+	flags.StringVar(&opts.store, "store", "", "Store name")
+	flags.StringVar(&opts.date, "date", "", "Date priced (YYYY-MM-DD; defaults to today)")
+	return flags, opts
+}
+
+// runItemPrice routes item price subcommands.
+func (a *App) runItemPrice(args []string) int {
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		printItemPriceUsage(a.stdout)
+		return exitOK
+	}
+	if len(args) == 0 {
+		printItemPriceUsage(a.stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case commandList:
+		return a.runItemPriceList(args[1:])
+	case commandPriceAdd:
+		return a.runItemPriceAdd(args[1:])
+	default:
+		usageErrorf(a.stderr, "unknown item price command: %s", args[0])
+		printItemPriceUsage(a.stderr)
+		return exitUsage
+	}
+}
+
+// runItemPriceList prints an item's price history.
+func (a *App) runItemPriceList(args []string) int {
+	if hasHelpFlag(args) {
+		printItemPriceListUsage(a.stdout)
+		return exitOK
+	}
+
+	flags := itemPriceListFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "item id is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.ItemPrices(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runItemPriceAdd records a new price for an item.
+func (a *App) runItemPriceAdd(args []string) int {
+	if hasHelpFlag(args) {
+		printItemPriceAddUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := itemPriceAddFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "item id is required")
+	}
+	flags.Visit(func(flagItem *flag.Flag) {
+		if flagItem.Name == "price" {
+			opts.priceSet = true
+		}
+	})
+	req, err := itemPriceRequestFromFlags(opts)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.AddItemPrice(ctx, strings.TrimSpace(id), req)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// itemPriceRequestFromFlags validates flags and builds the price payload.
+func itemPriceRequestFromFlags(opts *itemPriceAddFlags) (client.ItemPriceRequest, error) {
+	if !opts.priceSet {
+		return client.ItemPriceRequest{}, errors.New("--price is required")
+	}
+	if opts.price < 0 {
+		return client.ItemPriceRequest{}, errors.New("price must be >= 0")
+	}
+	if opts.quantity <= 0 {
+		return client.ItemPriceRequest{}, errors.New("quantity must be positive")
+	}
+	unit := strings.TrimSpace(opts.unit)
+	if unit == "" {
+		return client.ItemPriceRequest{}, errors.New("--unit is required")
+	}
+
+	req := client.ItemPriceRequest{
+		Price:    opts.price,
+		Quantity: opts.quantity,
+		Unit:     unit,
+		Store:    stringPtrIfNotEmpty(opts.store),
+	}
+	if date := strings.TrimSpace(opts.date); date != "" {
+		if _, err := time.Parse(isoDateLayout, date); err != nil {
+			return client.ItemPriceRequest{}, errors.New("date must be YYYY-MM-DD")
+		}
+		req.PricedOn = &date
+	}
+	return req, nil
+}
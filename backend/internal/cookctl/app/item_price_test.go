@@ -0,0 +1,121 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/config"
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/credentials"
+)
+
+func newItemPriceApp(t *testing.T, serverURL string, output config.OutputFormat, stdout, stderr *bytes.Buffer) *App {
+	t.Helper()
+
+	store := credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	return &App{
+		cfg: config.Config{
+			APIURL:  serverURL,
+			Output:  output,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: stdout,
+		stderr: stderr,
+		store:  store,
+	}
+}
+
+func TestRunItemPriceAdd(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/items/item-1/prices", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want POST", r.Method)
+		}
+		var payload client.ItemPriceRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload.Price != 3.49 || payload.Quantity != 2 || payload.Unit != "lb" {
+			t.Fatalf("payload = %+v", payload)
+		}
+		if payload.Store == nil || *payload.Store != "Corner Shop" || payload.PricedOn == nil || *payload.PricedOn != "2025-03-01" {
+			t.Fatalf("payload store/date = %v/%v", payload.Store, payload.PricedOn)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		writeTestJSON(t, w, client.ItemPrice{
+			ID: "price-1", ItemID: "item-1", Price: 3.49, Quantity: 2, Unit: "lb",
+			UnitPrice: 1.745, Store: payload.Store, PricedOn: "2025-03-01",
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	stdout := &bytes.Buffer{}
+	app := newItemPriceApp(t, server.URL, config.OutputTable, stdout, &bytes.Buffer{})
+	exitCode := app.runItem([]string{"price", "add", "item-1", "--price", "3.49", "--quantity", "2", "--unit", "lb",
+		"--store", "Corner Shop", "--date", "2025-03-01"})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !strings.Contains(stdout.String(), "UNIT_PRICE") || !strings.Contains(stdout.String(), "1.745") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestRunItemPriceAddRequiresPriceAndUnit(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"item-1", "--unit", "lb"},
+		{"item-1", "--price", "2"},
+		{"item-1", "--price", "2", "--unit", "lb", "--date", "March 1"},
+	} {
+		stderr := &bytes.Buffer{}
+		app := newItemPriceApp(t, "http://127.0.0.1:0", config.OutputTable, &bytes.Buffer{}, stderr)
+		if exitCode := app.runItemPriceAdd(args); exitCode != exitUsage {
+			t.Fatalf("args %v exit code = %d, want %d", args, exitCode, exitUsage)
+		}
+	}
+}
+
+func TestRunItemPriceList(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/items/item-1/prices", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, []client.ItemPrice{
+			{ID: "price-2", ItemID: "item-1", Price: 3.99, Quantity: 1, Unit: "lb", UnitPrice: 3.99, PricedOn: "2025-03-01"},
+			{ID: "price-1", ItemID: "item-1", Price: 3.49, Quantity: 1, Unit: "lb", UnitPrice: 3.49, PricedOn: "2025-01-05"},
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	stdout := &bytes.Buffer{}
+	app := newItemPriceApp(t, server.URL, config.OutputJSON, stdout, &bytes.Buffer{})
+	if exitCode := app.runItem([]string{"price", "list", "item-1"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+
+	var got []client.ItemPrice
+	if err := json.NewDecoder(bytes.NewReader(stdout.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "price-2" {
+		t.Fatalf("prices = %+v", got)
+	}
+}
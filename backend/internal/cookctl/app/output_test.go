@@ -41,6 +41,80 @@ func TestWriteTableRecipeListNextCursor(t *testing.T) {
 	}
 }
 
+func TestWriteTableRecipeDetailGroups(t *testing.T) {
+	t.Parallel()
+
+	dough := "Dough"
+	sauce := "Sauce"
+	resp := client.RecipeDetail{
+		ID:       testRecipeID,
+		Title:    "Pizza",
+		Servings: 2,
+		Tags:     []client.RecipeTag{},
+		Ingredients: []client.RecipeIngredient{
+			{ID: "ing-1", Position: 1, GroupName: &dough, Item: &client.Item{Name: "flour"}},
+			{ID: "ing-2", Position: 2, GroupName: &dough, Item: &client.Item{Name: "water"}},
+			{ID: "ing-3", Position: 3, GroupName: &sauce, Item: &client.Item{Name: "tomatoes"}},
+			{ID: "ing-4", Position: 4, Item: &client.Item{Name: "basil"}},
+		},
+		Steps: []client.RecipeStep{
+			{ID: "step-1", StepNumber: 1, GroupName: &dough, Instruction: "Knead."},
+			{ID: "step-2", StepNumber: 2, Instruction: "Bake."},
+		},
+		UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	stdout := &bytes.Buffer{}
+	exitCode := writeOutput(stdout, config.OutputTable, resp)
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	wantIngredients := "ingredients:\n  Dough:\n    1. flour\n    2. water\n  Sauce:\n    3. tomatoes\n  4. basil\n"
+	if !strings.Contains(stdout.String(), wantIngredients) {
+		t.Fatalf("expected grouped ingredients, got %q", stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "steps:\n  Dough:\n    1. Knead.\n  2. Bake.\n") {
+		t.Fatalf("expected grouped steps, got %q", stdout.String())
+	}
+}
+
+func TestWriteTableShoppingListDetailCost(t *testing.T) {
+	t.Parallel()
+
+	quantity := 2.0
+	unit := "lb"
+	cost := 5.5
+	resp := client.ShoppingListDetail{
+		ID:       testShoppingListID,
+		Name:     "Weekly shop",
+		ListDate: testShoppingListDate,
+		Items: []client.ShoppingListItem{
+			{ID: "line-1", Item: client.Item{ID: "item-1", Name: "rice"}, Quantity: &quantity, Unit: &unit, EstimatedCost: &cost},
+			{ID: "line-2", Item: client.Item{ID: "item-2", Name: "basil"}},
+		},
+		Cost: &client.ShoppingListCost{
+			Total:     5.5,
+			Uncounted: []client.CostGap{{ItemID: "item-2", ItemName: "basil", Reason: "missing_price"}},
+		},
+	}
+
+	stdout := &bytes.Buffer{}
+	exitCode := writeOutput(stdout, config.OutputTable, resp)
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"COST",
+		"5.50",
+		"estimated cost (incomplete):\n  total 5.50\n  not counted: basil (missing price)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
 func TestHandleAPIErrorJSON(t *testing.T) {
 	t.Parallel()
 
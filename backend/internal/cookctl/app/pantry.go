@@ -0,0 +1,252 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+type pantryListFlags struct {
+	location string
+}
+
+type pantryStockFlags struct {
+	itemID      string
+	quantityRaw string
+	unit        string
+	location    string
+	bestBefore  string
+}
+
+type pantryDeleteFlags struct {
+	yes bool
+}
+
+type pantryDeleteResult struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+func pantryListFlagSet(out io.Writer) (*flag.FlagSet, *pantryListFlags) {
+	opts := &pantryListFlags{}
+	flags := newFlagSet("pantry list", out, printPantryListUsage)
+	flags.StringVar(&opts.location, "location", "", "Only show one location (fridge, freezer, or pantry)")
+	return flags, opts
+}
+
+func pantryCreateFlagSet(out io.Writer) (*flag.FlagSet, *pantryStockFlags) {
+	opts := &pantryStockFlags{}
+	flags := newFlagSet("pantry create", out, printPantryCreateUsage)
+	flags.StringVar(&opts.itemID, "item-id", "", "Item id")
+	addPantryStockFlags(flags, opts)
+	return flags, opts
+}
+
+func pantryUpdateFlagSet(out io.Writer) (*flag.FlagSet, *pantryStockFlags) {
+	opts := &pantryStockFlags{}
+	flags := newFlagSet("pantry update", out, printPantryUpdateUsage)
+	addPantryStockFlags(flags, opts)
+	return flags, opts
+}
+
+func addPantryStockFlags(flags *flag.FlagSet, opts *pantryStockFlags) {
+	flags.StringVar(&opts.quantityRaw, "quantity", "", "Quantity on hand (omit when unknown)")
+	flags.StringVar(&opts.unit, "unit", "", "Quantity unit")
+	flags.StringVar(&opts.location, "location", "", "Storage location: fridge, freezer, or pantry (default pantry)")
+	flags.StringVar(&opts.bestBefore, "best-before", "", "Best-before date (YYYY-MM-DD)")
+}
+
+func pantryDeleteFlagSet(out io.Writer) (*flag.FlagSet, *pantryDeleteFlags) {
+	opts := &pantryDeleteFlags{}
+	flags := newFlagSet("pantry delete", out, printPantryDeleteUsage)
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm pantry item deletion")
+	return flags, opts
+}
+
+// runPantry routes pantry subcommands.
+func (a *App) runPantry(args []string) int {
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		printPantryUsage(a.stdout)
+		return exitOK
+	}
+	if len(args) == 0 {
+		printPantryUsage(a.stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case commandList:
+		return a.runPantryList(args[1:])
+	case commandCreate:
+		return a.runPantryCreate(args[1:])
+	case commandUpdate:
+		return a.runPantryUpdate(args[1:])
+	case commandDelete:
+		return a.runPantryDelete(args[1:])
+	default:
+		usageErrorf(a.stderr, "unknown pantry command: %s", args[0])
+		printPantryUsage(a.stderr)
+		return exitUsage
+	}
+}
+
+// runPantryList lists what is on hand.
+func (a *App) runPantryList(args []string) int {
+	if hasHelpFlag(args) {
+		printPantryListUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := pantryListFlagSet(a.stderr)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.PantryItems(ctx, strings.TrimSpace(opts.location))
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runPantryCreate adds stock to the pantry.
+func (a *App) runPantryCreate(args []string) int {
+	if hasHelpFlag(args) {
+		printPantryCreateUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := pantryCreateFlagSet(a.stderr)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	opts.itemID = strings.TrimSpace(opts.itemID)
+	if opts.itemID == "" {
+		return usageError(a.stderr, "item-id is required")
+	}
+	input, err := opts.input()
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	input.ItemID = opts.itemID
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.CreatePantryItem(ctx, input)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runPantryUpdate replaces a pantry entry's stock details.
+func (a *App) runPantryUpdate(args []string) int {
+	if hasHelpFlag(args) {
+		printPantryUpdateUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := pantryUpdateFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "pantry item id is required")
+	}
+	input, err := opts.input()
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.UpdatePantryItem(ctx, id, input)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runPantryDelete removes a pantry entry.
+func (a *App) runPantryDelete(args []string) int {
+	if hasHelpFlag(args) {
+		printPantryDeleteUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := pantryDeleteFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "pantry item id is required")
+	}
+	if !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	if err := api.DeletePantryItem(ctx, id); err != nil {
+		return a.handleAPIError(err)
+	}
+
+	return writeOutput(a.stdout, a.cfg.Output, pantryDeleteResult{ID: id, Deleted: true})
+}
+
+// input builds the stock payload shared by create and update.
+func (opts *pantryStockFlags) input() (client.PantryItemInput, error) {
+	quantity, err := parseOptionalFloat(opts.quantityRaw)
+	if err != nil || (quantity != nil && *quantity <= 0) {
+		return client.PantryItemInput{}, errors.New("quantity must be a positive number")
+	}
+	input := client.PantryItemInput{
+		Quantity: quantity,
+		Unit:     stringPtrIfNotEmpty(strings.TrimSpace(opts.unit)),
+		Location: stringPtrIfNotEmpty(strings.TrimSpace(opts.location)),
+	}
+	if trimmed := strings.TrimSpace(opts.bestBefore); trimmed != "" {
+		date, dateErr := parseISODate("best-before", trimmed)
+		if dateErr != nil {
+			return client.PantryItemInput{}, dateErr
+		}
+		formatted := date.Format(isoDateLayout)
+		input.BestBefore = &formatted
+	}
+	return input, nil
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+func TestRunPantryCreateAndList(t *testing.T) {
+	t.Parallel()
+
+	var created client.PantryItemInput
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/pantry-items", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		quantity := 2.0
+		unit := "kg"
+		item := client.PantryItem{ID: "pantry-1", ItemID: "item-1", ItemName: "Flour", Quantity: &quantity, Unit: &unit, Location: "pantry"}
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			writeTestJSON(t, w, item)
+			return
+		}
+		if got := r.URL.Query().Get("location"); got != "pantry" {
+			t.Fatalf("location = %q, want pantry", got)
+		}
+		writeTestJSON(t, w, []client.PantryItem{item})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runPantry([]string{"create", "--item-id", "item-1", "--quantity", "0"}); exitCode != exitUsage {
+		t.Fatalf("exit code for zero quantity = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runPantry([]string{"create", "--item-id", "item-1", "--best-before", "soon"}); exitCode != exitUsage {
+		t.Fatalf("exit code for bad date = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runPantry([]string{"create", "--item-id", "item-1", "--quantity", "2", "--unit", "kg", "--best-before", "2025-03-01"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if created.ItemID != "item-1" || created.Quantity == nil || *created.Quantity != 2 || created.BestBefore == nil || *created.BestBefore != "2025-03-01" {
+		t.Fatalf("payload = %+v", created)
+	}
+
+	if exitCode := app.runPantry([]string{"list", "--location", "pantry"}); exitCode != exitOK {
+		t.Fatalf("exit code for list = %d, want %d", exitCode, exitOK)
+	}
+	if !strings.Contains(stdout.String(), "BEST_BEFORE") || !strings.Contains(stdout.String(), "Flour") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestRunPantryDeleteRequiresYes(t *testing.T) {
+	t.Parallel()
+
+	app, _ := newRecipePersonalTestApp(t, http.NewServeMux())
+	if exitCode := app.runPantry([]string{"delete", "pantry-1"}); exitCode != exitUsage {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitUsage)
+	}
+}
+
+func TestRunShoppingListItemsPurchaseRestock(t *testing.T) {
+	t.Parallel()
+
+	var got client.ShoppingListItemPurchaseRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/shopping-lists/"+testShoppingListID+"/items/"+testShoppingItemID, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.ShoppingListItem{ID: testShoppingItemID, Item: client.Item{ID: "item-1", Name: "Milk"}, IsPurchased: true})
+	})
+	app, _ := newRecipePersonalTestApp(t, mux)
+
+	base := []string{"--list-id", testShoppingListID, "--item-id", testShoppingItemID}
+	if exitCode := app.runShoppingListItemsPurchase(append(base, "--restock")); exitCode != exitUsage {
+		t.Fatalf("exit code for restock without purchased = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runShoppingListItemsPurchase(append(base, "--purchased", "--location", "fridge")); exitCode != exitUsage {
+		t.Fatalf("exit code for location without restock = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runShoppingListItemsPurchase(append(base, "--purchased", "--restock", "--location", "fridge")); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !got.IsPurchased || !got.RestockPantry || got.PantryLocation == nil || *got.PantryLocation != "fridge" {
+		t.Fatalf("payload = %+v", got)
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
@@ -25,16 +27,31 @@ type recipeListFlags struct {
 	query          string
 	bookID         string
 	bookName       string
-	tagID          string
-	tagName        string
+	tagIDs         csvStrings
+	tagNames       csvStrings
+	tagMode        string
+	withItems      csvStrings
+	withoutItems   csvStrings
+	diets          csvStrings
+	noAllergens    csvStrings
+	maxTotalTime   int
+	maxPrepTime    int
+	servingsMin    int
+	servingsMax    int
 	includeDeleted bool
+	favorites      bool
 	limit          int
 	cursor         string
+	sort           string
 	all            bool
 	servings       int
 	withCounts     bool
 }
 
+type recipeGetFlags struct {
+	servings int
+}
+
 type recipeCreateFlags struct {
 	filePath       string
 	useStdin       bool
@@ -51,8 +68,17 @@ type recipeImportFlags struct {
 	filePath       string
 	useStdin       bool
 	allowDuplicate bool
+	format         string
+	sourceURL      string
+	draft          bool
 }
 
+const (
+	recipeImportFormatJSON   = "json"
+	recipeImportFormatHTML   = "html"
+	recipeImportFormatJSONLD = "jsonld"
+)
+
 type recipeCloneFlags struct {
 	titleOverride  string
 	allowDuplicate bool
@@ -70,25 +96,69 @@ type recipeRestoreFlags struct {
 	yes bool
 }
 
+type recipeHistoryFlags struct {
+	revision int
+}
+
+type recipeDiffFlags struct {
+	from   int
+	to     int
+	parent bool
+}
+
+type recipeRevertFlags struct {
+	revision int
+	yes      bool
+}
+
+// recipeSortOrders lists the sort values accepted by the recipes list API.
+var recipeSortOrders = []string{
+	"relevance",
+	"title", "-title",
+	"total_time", "-total_time",
+	"prep_time", "-prep_time",
+	"created_at", "-created_at",
+	"updated_at", "-updated_at",
+	"last_cooked", "-last_cooked",
+}
+
+func isRecipeSortOrder(value string) bool {
+	return slices.Contains(recipeSortOrders, value)
+}
+
 func recipeListFlagSet(out io.Writer) (*flag.FlagSet, *recipeListFlags) {
 	opts := &recipeListFlags{}
 	flags := newFlagSet("recipe list", out, printRecipeListUsage)
 	flags.StringVar(&opts.query, "q", "", "Search query")
 	flags.StringVar(&opts.bookID, "book-id", "", "Filter by recipe book id")
 	flags.StringVar(&opts.bookName, "book", "", "Filter by recipe book name")
-	flags.StringVar(&opts.tagID, "tag-id", "", "Filter by tag id")
-	flags.StringVar(&opts.tagName, "tag", "", "Filter by tag name")
+	flags.Var(&opts.tagIDs, "tag-id", "Filter by tag id (repeatable)")
+	flags.Var(&opts.tagNames, "tag", "Filter by tag name (repeatable)")
+	flags.StringVar(&opts.tagMode, "tag-mode", "", "Match all (default) or any of the given tags")
+	flags.Var(&opts.withItems, "with-item", "Only recipes using this item name or id (repeatable)")
+	flags.Var(&opts.withoutItems, "without-item", "Exclude recipes using this item name or id (repeatable)")
+	flags.Var(&opts.diets, "diet", "Only recipes fitting this diet, e.g. vegetarian or gluten-free (repeatable)")
+	flags.Var(&opts.noAllergens, "exclude-allergen", "Exclude recipes containing this allergen, e.g. nuts (repeatable)")
+	flags.IntVar(&opts.maxTotalTime, "max-total-time", 0, "Max total time in minutes")
+	flags.IntVar(&opts.maxPrepTime, "max-prep-time", 0, "Max prep time in minutes")
+	flags.IntVar(&opts.servingsMin, "servings-min", 0, "Minimum servings")
+	flags.IntVar(&opts.servingsMax, "servings-max", 0, "Maximum servings")
 	flags.BoolVar(&opts.includeDeleted, "include-deleted", false, "Include deleted recipes")
+	flags.BoolVar(&opts.favorites, "favorites", false, "Only your favorite recipes")
 	flags.IntVar(&opts.limit, "limit", 0, "Max items per page")
 	flags.StringVar(&opts.cursor, "cursor", "", "Pagination cursor")
+	flags.StringVar(&opts.sort, "sort", "", "Sort order: title, total_time, prep_time, created_at, updated_at, last_cooked (prefix - for descending), or relevance")
 	flags.BoolVar(&opts.all, "all", false, "Fetch all pages")
 	flags.IntVar(&opts.servings, "servings", 0, "Filter by servings count")
 	flags.BoolVar(&opts.withCounts, "with-counts", false, "Include ingredient and step counts")
 	return flags, opts
 }
 
-func recipeGetFlagSet(out io.Writer) *flag.FlagSet {
-	return newFlagSet("recipe get", out, printRecipeGetUsage)
+func recipeGetFlagSet(out io.Writer) (*flag.FlagSet, *recipeGetFlags) {
+	opts := &recipeGetFlags{}
+	flags := newFlagSet("recipe get", out, printRecipeGetUsage)
+	flags.IntVar(&opts.servings, "servings", 0, "Scale ingredient quantities to this many servings")
+	return flags, opts
 }
 
 func recipeCreateFlagSet(out io.Writer) (*flag.FlagSet, *recipeCreateFlags) {
@@ -124,9 +194,12 @@ func recipeExportFlagSet(out io.Writer) *flag.FlagSet {
 func recipeImportFlagSet(out io.Writer) (*flag.FlagSet, *recipeImportFlags) {
 	opts := &recipeImportFlags{}
 	flags := newFlagSet("recipe import", out, printRecipeImportUsage)
-	flags.StringVar(&opts.filePath, "file", "", "Path to recipe JSON")
-	flags.BoolVar(&opts.useStdin, "stdin", false, "Read recipe JSON from stdin")
+	flags.StringVar(&opts.filePath, "file", "", "Path to recipe JSON, HTML, or JSON-LD")
+	flags.BoolVar(&opts.useStdin, "stdin", false, "Read input from stdin")
 	flags.BoolVar(&opts.allowDuplicate, "allow-duplicate", false, "Allow duplicate recipe titles")
+	flags.StringVar(&opts.format, "format", recipeImportFormatJSON, "Input format: json, html, or jsonld")
+	flags.StringVar(&opts.sourceURL, "source-url", "", "Source URL for html/jsonld imports (overrides the page url)")
+	flags.BoolVar(&opts.draft, "draft", false, "Print the parsed html/jsonld payload instead of creating it")
 	return flags, opts
 }
 
@@ -167,6 +240,30 @@ func recipeRestoreFlagSet(out io.Writer) (*flag.FlagSet, *recipeRestoreFlags) {
 	return flags, opts
 }
 
+func recipeHistoryFlagSet(out io.Writer) (*flag.FlagSet, *recipeHistoryFlags) {
+	opts := &recipeHistoryFlags{}
+	flags := newFlagSet("recipe history", out, printRecipeHistoryUsage)
+	flags.IntVar(&opts.revision, "revision", 0, "Show the snapshot stored for this revision")
+	return flags, opts
+}
+
+func recipeDiffFlagSet(out io.Writer) (*flag.FlagSet, *recipeDiffFlags) {
+	opts := &recipeDiffFlags{}
+	flags := newFlagSet("recipe diff", out, printRecipeDiffUsage)
+	flags.IntVar(&opts.from, "from", 0, "Older revision number")
+	flags.IntVar(&opts.to, "to", 0, "Newer revision number")
+	flags.BoolVar(&opts.parent, "parent", false, "Compare with the recipe it was cloned from instead of a revision")
+	return flags, opts
+}
+
+func recipeRevertFlagSet(out io.Writer) (*flag.FlagSet, *recipeRevertFlags) {
+	opts := &recipeRevertFlags{}
+	flags := newFlagSet("recipe revert", out, printRecipeRevertUsage)
+	flags.IntVar(&opts.revision, "revision", 0, "Revision number to revert to")
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm recipe revert")
+	return flags, opts
+}
+
 func (a *App) runRecipe(args []string) int {
 	if len(args) > 0 && isHelpFlag(args[0]) {
 		printRecipeUsage(a.stdout)
@@ -204,6 +301,34 @@ func (a *App) runRecipe(args []string) int {
 		return a.runRecipeDelete(args[1:])
 	case commandRestore:
 		return a.runRecipeRestore(args[1:])
+	case commandHistory:
+		return a.runRecipeHistory(args[1:])
+	case commandDiff:
+		return a.runRecipeDiff(args[1:])
+	case commandRevert:
+		return a.runRecipeRevert(args[1:])
+	case commandDupes:
+		return a.runRecipeDupes(args[1:])
+	case commandMerge:
+		return a.runRecipeMerge(args[1:])
+	case commandMatch:
+		return a.runRecipeMatch(args[1:])
+	case commandShare:
+		return a.runRecipeShare(args[1:])
+	case commandUnshare:
+		return a.runRecipeUnshare(args[1:])
+	case commandCook:
+		return a.runRecipeCook(args[1:])
+	case commandCooked:
+		return a.runRecipeCooked(args[1:])
+	case commandFav:
+		return a.runRecipeFav(args[1:])
+	case commandUnfav:
+		return a.runRecipeUnfav(args[1:])
+	case commandNote:
+		return a.runRecipeNote(args[1:])
+	case commandImage:
+		return a.runRecipeImage(args[1:])
 	default:
 		usageErrorf(a.stderr, "unknown recipe command: %s", args[0])
 		printRecipeUsage(a.stderr)
@@ -230,17 +355,40 @@ func (a *App) runRecipeList(args []string) int {
 	if strings.TrimSpace(opts.bookID) != "" && strings.TrimSpace(opts.bookName) != "" {
 		return usageError(a.stderr, "book and book-id cannot be combined")
 	}
-	if strings.TrimSpace(opts.tagID) != "" && strings.TrimSpace(opts.tagName) != "" {
-		return usageError(a.stderr, "tag and tag-id cannot be combined")
+	if opts.maxTotalTime < 0 || opts.maxPrepTime < 0 {
+		return usageError(a.stderr, "time limits must be positive")
+	}
+	if opts.servingsMin < 0 || opts.servingsMax < 0 {
+		return usageError(a.stderr, "servings-min and servings-max must be positive")
+	}
+	if opts.servingsMin > 0 && opts.servingsMax > 0 && opts.servingsMin > opts.servingsMax {
+		return usageError(a.stderr, "servings-min cannot exceed servings-max")
+	}
+	tagMode := strings.TrimSpace(opts.tagMode)
+	if tagMode != "" && tagMode != "all" && tagMode != "any" {
+		return usageError(a.stderr, "tag-mode must be all or any")
+	}
+	sortOrder := strings.TrimSpace(opts.sort)
+	if sortOrder != "" && !isRecipeSortOrder(sortOrder) {
+		return usageError(a.stderr, "sort must be one of: "+strings.Join(recipeSortOrders, ", "))
 	}
 
 	listParams := client.RecipeListParams{
-		Query:          strings.TrimSpace(opts.query),
-		BookID:         strings.TrimSpace(opts.bookID),
-		TagID:          strings.TrimSpace(opts.tagID),
-		IncludeDeleted: opts.includeDeleted,
-		Limit:          opts.limit,
-		Cursor:         strings.TrimSpace(opts.cursor),
+		Query:            strings.TrimSpace(opts.query),
+		BookID:           strings.TrimSpace(opts.bookID),
+		TagIDs:           opts.tagIDs.Values(),
+		TagMode:          tagMode,
+		Diets:            opts.diets.Values(),
+		ExcludeAllergens: opts.noAllergens.Values(),
+		MaxTotalTime:     opts.maxTotalTime,
+		MaxPrepTime:      opts.maxPrepTime,
+		ServingsMin:      opts.servingsMin,
+		ServingsMax:      opts.servingsMax,
+		IncludeDeleted:   opts.includeDeleted,
+		Favorites:        opts.favorites,
+		Limit:            opts.limit,
+		Cursor:           strings.TrimSpace(opts.cursor),
+		Sort:             sortOrder,
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
@@ -258,12 +406,26 @@ func (a *App) runRecipeList(args []string) int {
 		}
 		listParams.BookID = resolved
 	}
-	if strings.TrimSpace(opts.tagName) != "" {
-		resolved, resolveErr := resolveTagIDByName(ctx, api, opts.tagName)
+	if names := opts.tagNames.Values(); len(names) > 0 {
+		resolved, resolveErr := resolveTagIDsByName(ctx, api, names, false)
+		if resolveErr != nil {
+			return usageError(a.stderr, resolveErr.Error())
+		}
+		listParams.TagIDs = append(listParams.TagIDs, resolved...)
+	}
+	if values := opts.withItems.Values(); len(values) > 0 {
+		resolved, resolveErr := resolveItemIDsByName(ctx, api, values)
+		if resolveErr != nil {
+			return usageError(a.stderr, resolveErr.Error())
+		}
+		listParams.WithItemIDs = resolved
+	}
+	if values := opts.withoutItems.Values(); len(values) > 0 {
+		resolved, resolveErr := resolveItemIDsByName(ctx, api, values)
 		if resolveErr != nil {
 			return usageError(a.stderr, resolveErr.Error())
 		}
-		listParams.TagID = resolved
+		listParams.WithoutItemIDs = resolved
 	}
 	if opts.servings > 0 {
 		opts.all = true
@@ -330,7 +492,7 @@ func (a *App) runRecipeGet(args []string) int {
 		return exitOK
 	}
 
-	flags := recipeGetFlagSet(a.stderr)
+	flags, opts := recipeGetFlagSet(a.stderr)
 
 	id, err := parseIDArgs(flags, args)
 	if err != nil {
@@ -339,6 +501,9 @@ func (a *App) runRecipeGet(args []string) int {
 	if id == "" {
 		return usageError(a.stderr, "recipe id is required")
 	}
+	if opts.servings < 0 {
+		return usageError(a.stderr, "servings must be positive")
+	}
 	id = strings.TrimSpace(id)
 
 	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
@@ -354,7 +519,7 @@ func (a *App) runRecipeGet(args []string) int {
 		return usageError(a.stderr, err.Error())
 	}
 
-	resp, err := api.Recipe(ctx, resolvedID)
+	resp, err := api.RecipeScaled(ctx, resolvedID, opts.servings)
 	if err != nil {
 		return a.handleAPIError(err)
 	}
@@ -601,7 +766,23 @@ func (a *App) runRecipeImport(args []string) int {
 	if opts.filePath != "" && opts.useStdin {
 		return usageError(a.stderr, "provide --file or --stdin")
 	}
+	opts.format = strings.ToLower(strings.TrimSpace(opts.format))
+	opts.sourceURL = strings.TrimSpace(opts.sourceURL)
+	switch opts.format {
+	case recipeImportFormatJSON:
+		if opts.sourceURL != "" || opts.draft {
+			return usageError(a.stderr, "--source-url and --draft require --format html or jsonld")
+		}
+		return a.importRecipePayloads(opts)
+	case recipeImportFormatHTML, recipeImportFormatJSONLD:
+		return a.importRecipeDocument(opts)
+	default:
+		return usageError(a.stderr, "--format must be json, html, or jsonld")
+	}
+}
 
+// importRecipePayloads creates recipes from one or more JSON upsert payloads.
+func (a *App) importRecipePayloads(opts *recipeImportFlags) int {
 	var raw []byte
 	var err error
 	if opts.useStdin {
@@ -626,13 +807,67 @@ func (a *App) runRecipeImport(args []string) int {
 		return exitCode
 	}
 
+	return a.createImportedRecipes(ctx, api, payloads, opts.allowDuplicate)
+}
+
+// importRecipeDocument has the server map a schema.org Recipe found in an
+// HTML page or JSON-LD document to an upsert payload, then creates it.
+func (a *App) importRecipeDocument(opts *recipeImportFlags) int {
+	var raw []byte
+	var err error
+	if opts.useStdin {
+		raw, err = io.ReadAll(a.stdin)
+		if err != nil {
+			return usageError(a.stderr, fmt.Sprintf("read input: %v", err))
+		}
+	} else {
+		//nolint:gosec // Path is user-supplied by design for reading recipe documents.
+		raw, err = os.ReadFile(opts.filePath)
+		if err != nil {
+			return usageError(a.stderr, fmt.Sprintf("read file: %v", err))
+		}
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return usageError(a.stderr, "input is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	var sourceURL *string
+	if opts.sourceURL != "" {
+		sourceURL = &opts.sourceURL
+	}
+	draft, err := api.ImportRecipeDraft(ctx, opts.format, raw, sourceURL)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+
+	if opts.draft {
+		var payload recipeUpsertPayload
+		if err := json.Unmarshal(draft, &payload); err != nil {
+			writeLine(a.stderr, fmt.Sprintf("decode draft: %v", err))
+			return exitError
+		}
+		return writeOutput(a.stdout, a.cfg.Output, payload)
+	}
+
+	return a.createImportedRecipes(ctx, api, []json.RawMessage{draft}, opts.allowDuplicate)
+}
+
+func (a *App) createImportedRecipes(ctx context.Context, api *client.Client, payloads []json.RawMessage, allowDuplicate bool) int {
 	results := make([]recipeImportItemResult, 0, len(payloads))
 	for i, payload := range payloads {
 		title, titleErr := recipeTitleFromJSON(payload)
 		if titleErr != nil {
 			return usageErrorf(a.stderr, "payload %d: %v", i+1, titleErr)
 		}
-		if err := ensureUniqueRecipeTitle(ctx, api, title, opts.allowDuplicate); err != nil {
+		if err := ensureUniqueRecipeTitle(ctx, api, title, allowDuplicate); err != nil {
 			writeLine(a.stderr, fmt.Sprintf("payload %d: %v", i+1, err))
 			return exitConflict
 		}
@@ -738,25 +973,17 @@ func (a *App) runRecipeClone(args []string) int {
 		return a.handleAPIError(err)
 	}
 
-	payload := toUpsertPayload(recipe)
 	title := strings.TrimSpace(opts.titleOverride)
 	if title == "" {
-		title = fmt.Sprintf("%s (copy)", payload.Title)
+		title = fmt.Sprintf("%s (copy)", recipe.Title)
 	}
-	payload.Title = title
 
-	if dupErr := ensureUniqueRecipeTitle(ctx, api, payload.Title, opts.allowDuplicate); dupErr != nil {
+	if dupErr := ensureUniqueRecipeTitle(ctx, api, title, opts.allowDuplicate); dupErr != nil {
 		writeLine(a.stderr, dupErr)
 		return exitConflict
 	}
 
-	raw, err := json.Marshal(payload)
-	if err != nil {
-		writeLine(a.stderr, err)
-		return exitError
-	}
-
-	resp, err := api.CreateRecipe(ctx, raw)
+	resp, err := api.CloneRecipe(ctx, resolvedID, title)
 	if err != nil {
 		return a.handleAPIError(err)
 	}
@@ -949,6 +1176,146 @@ func (a *App) runRecipeRestore(args []string) int {
 	})
 }
 
+func (a *App) runRecipeHistory(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeHistoryUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeHistoryFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	if opts.revision < 0 {
+		return usageError(a.stderr, "revision must be positive")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	if opts.revision > 0 {
+		resp, err := api.RecipeRevision(ctx, resolvedID, opts.revision)
+		if err != nil {
+			return a.handleAPIError(err)
+		}
+		return writeOutput(a.stdout, a.cfg.Output, resp)
+	}
+
+	resp, err := api.RecipeRevisions(ctx, resolvedID)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+func (a *App) runRecipeDiff(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeDiffUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeDiffFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	if opts.parent {
+		if opts.from != 0 || opts.to != 0 {
+			return usageError(a.stderr, "--parent cannot be combined with --from or --to")
+		}
+	} else if opts.from <= 0 || opts.to <= 0 {
+		return usageError(a.stderr, "--from and --to revisions are required")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	if opts.parent {
+		resp, parentErr := api.RecipeParentDiff(ctx, resolvedID)
+		if parentErr != nil {
+			return a.handleAPIError(parentErr)
+		}
+		return writeOutput(a.stdout, a.cfg.Output, resp)
+	}
+
+	resp, err := api.RecipeRevisionDiff(ctx, resolvedID, opts.from, opts.to)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+func (a *App) runRecipeRevert(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeRevertUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeRevertFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	if opts.revision <= 0 {
+		return usageError(a.stderr, "--revision is required")
+	}
+	if !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	resp, err := api.RevertRecipeRevision(ctx, resolvedID, opts.revision)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
 // parseRecipeTagArgs parses recipe tag arguments and flags.
 func parseRecipeTagArgs(args []string) (string, []string, bool, bool, error) {
 	var id string
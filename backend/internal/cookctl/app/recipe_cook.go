@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+const commandCooked = "cooked"
+
+type recipeCookedFlags struct {
+	rating   int
+	note     string
+	servings int
+	date     string
+}
+
+func recipeCookedFlagSet(out io.Writer) (*flag.FlagSet, *recipeCookedFlags) {
+	opts := &recipeCookedFlags{}
+	flags := newFlagSet("recipe cooked", out, printRecipeCookedUsage)
+	flags.IntVar(&opts.rating, "rating", 0, "Rating from 1 to 5")
+	flags.StringVar(&opts.note, "note", "", "Notes about how it turned out")
+	flags.IntVar(&opts.servings, "servings", 0, "Servings made")
+	flags.StringVar(&opts.date, "date", "", "When it was cooked (YYYY-MM-DD or RFC3339; defaults to now)")
+	return flags, opts
+}
+
+func (a *App) runRecipeCooked(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeCookedUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeCookedFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	req, err := recipeCookRequestFromFlags(opts)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	resp, err := api.LogRecipeCook(ctx, resolvedID, req)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// recipeCookRequestFromFlags validates flags and builds the cook log payload.
+// A bare date is taken as local midnight.
+func recipeCookRequestFromFlags(opts *recipeCookedFlags) (client.RecipeCookRequest, error) {
+	var req client.RecipeCookRequest
+	if opts.rating != 0 {
+		if opts.rating < 1 || opts.rating > 5 {
+			return client.RecipeCookRequest{}, errors.New("rating must be between 1 and 5")
+		}
+		rating := opts.rating
+		req.Rating = &rating
+	}
+	if opts.servings < 0 {
+		return client.RecipeCookRequest{}, errors.New("servings must be positive")
+	}
+	if opts.servings > 0 {
+		servings := opts.servings
+		req.Servings = &servings
+	}
+	req.Notes = stringPtrIfNotEmpty(opts.note)
+
+	if date := strings.TrimSpace(opts.date); date != "" {
+		cookedAt, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			cookedAt, err = time.ParseInLocation(isoDateLayout, date, time.Local)
+			if err != nil {
+				return client.RecipeCookRequest{}, errors.New("date must be YYYY-MM-DD or RFC3339")
+			}
+		}
+		formatted := cookedAt.Format(time.RFC3339)
+		req.CookedAt = &formatted
+	}
+	return req, nil
+}
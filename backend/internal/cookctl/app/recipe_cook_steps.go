@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+const commandCook = "cook"
+
+func recipeCookFlagSet(out io.Writer) *flag.FlagSet {
+	return newFlagSet("recipe cook", out, printRecipeCookUsage)
+}
+
+// runRecipeCook walks through a recipe's steps one at a time and runs a
+// countdown for steps that have a duration.
+func (a *App) runRecipeCook(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeCookUsage(a.stdout)
+		return exitOK
+	}
+
+	flags := recipeCookFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+
+	// The timeout covers loading the recipe, not the cooking session.
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, strings.TrimSpace(id))
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	recipe, err := api.Recipe(ctx, resolvedID)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	if len(recipe.Steps) == 0 {
+		return usageError(a.stderr, "recipe has no steps")
+	}
+
+	if err := a.walkRecipeSteps(recipe); err != nil {
+		writeLine(a.stderr, err)
+		return exitError
+	}
+	return exitOK
+}
+
+// walkRecipeSteps prints each step and waits for Enter before the next one.
+// Typing q, or closing stdin, ends the session early.
+func (a *App) walkRecipeSteps(recipe client.RecipeDetail) error {
+	prompter := newPromptInput(a.stdin, a.stdout)
+	writef(a.stdout, "Cooking %s (%d steps). Type q at any prompt to stop.\n", recipe.Title, len(recipe.Steps))
+
+	for i, step := range recipe.Steps {
+		writeLine(a.stdout, "")
+		header := fmt.Sprintf("Step %d/%d", i+1, len(recipe.Steps))
+		if group := formatOptionalString(step.GroupName); group != "" {
+			header += " - " + group
+		}
+		writeLine(a.stdout, header)
+		writef(a.stdout, "  %s\n", strings.TrimSpace(step.Instruction))
+		if cues := formatStepCues(step); cues != "" {
+			writef(a.stdout, "  %s\n", cues)
+		}
+
+		if step.DurationSeconds != nil {
+			label := fmt.Sprintf("Start %s timer? [Enter to start, s to skip]", formatStepDuration(*step.DurationSeconds))
+			answer, done, err := askCookPrompt(prompter, label)
+			if err != nil || done {
+				return err
+			}
+			if answer != "s" {
+				a.runCountdown(time.Duration(*step.DurationSeconds) * time.Second)
+				if step.DurationMaxSeconds != nil && *step.DurationMaxSeconds > *step.DurationSeconds {
+					writef(a.stdout, "  Check now; it may need up to %s more.\n",
+						formatStepDuration(*step.DurationMaxSeconds-*step.DurationSeconds))
+				}
+			}
+		}
+
+		if i < len(recipe.Steps)-1 {
+			if _, done, err := askCookPrompt(prompter, "Next step? [Enter]"); err != nil || done {
+				return err
+			}
+		}
+	}
+
+	writeLine(a.stdout, "")
+	writef(a.stdout, "Done. Log it with: cookctl recipe cooked %s\n", recipe.ID)
+	return nil
+}
+
+// askCookPrompt reads one answer; done is true when the user quits or input
+// ends.
+func askCookPrompt(prompter *promptInput, label string) (string, bool, error) {
+	answer, err := prompter.ask(label)
+	if errors.Is(err, io.EOF) {
+		return "", true, nil
+	}
+	if err != nil {
+		return "", true, err
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer, answer == "q", nil
+}
+
+// runCountdown prints the remaining time once a second until the timer ends.
+func (a *App) runCountdown(total time.Duration) {
+	sleep := a.sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	for remaining := total; remaining > 0; remaining -= time.Second {
+		writef(a.stdout, "\r  %s remaining ", formatCountdown(remaining))
+		sleep(time.Second)
+	}
+	writef(a.stdout, "\r  Time's up!\a%s\n", strings.Repeat(" ", 10))
+}
+
+// formatCountdown renders a duration as m:ss, or h:mm:ss past an hour.
+func formatCountdown(d time.Duration) string {
+	seconds := int(d.Round(time.Second) / time.Second)
+	hours, minutes, secs := seconds/3600, seconds%3600/60, seconds%60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, secs)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, secs)
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/config"
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/credentials"
+)
+
+func newRecipeCookServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	duration := 90
+	durationMax := 120
+	temperature := 400
+	unit := "F"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.RecipeDetail{
+			ID:    testRecipeID,
+			Title: "Toast",
+			Steps: []client.RecipeStep{
+				{ID: "step-1", StepNumber: 1, Instruction: "Slice the bread."},
+				{
+					ID: "step-2", StepNumber: 2, Instruction: "Toast until golden.",
+					DurationSeconds: &duration, DurationMaxSeconds: &durationMax,
+					Temperature: &temperature, TemperatureUnit: &unit,
+				},
+			},
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newRecipeCookApp(t *testing.T, serverURL, input string, stdout *bytes.Buffer, sleeps *int) *App {
+	t.Helper()
+
+	store := credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	return &App{
+		cfg: config.Config{
+			APIURL:  serverURL,
+			Output:  config.OutputTable,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(input),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+		store:  store,
+		sleep:  func(time.Duration) { *sleeps++ },
+	}
+}
+
+func TestRunRecipeCookWalksStepsWithTimer(t *testing.T) {
+	t.Parallel()
+
+	server := newRecipeCookServer(t)
+	stdout := &bytes.Buffer{}
+	sleeps := 0
+	app := newRecipeCookApp(t, server.URL, "\n\n", stdout, &sleeps)
+
+	exitCode := app.runRecipe([]string{"cook", testRecipeID})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if sleeps != 90 {
+		t.Fatalf("sleeps = %d, want 90", sleeps)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"Step 1/2\n  Slice the bread.\n",
+		"Step 2/2\n  Toast until golden.\n  1m 30s-2m at 400°F\n",
+		"Start 1m 30s timer?",
+		"1:30 remaining",
+		"Time's up!",
+		"it may need up to 30s more",
+		"Done. Log it with: cookctl recipe cooked " + testRecipeID,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunRecipeCookStopsOnQuit(t *testing.T) {
+	t.Parallel()
+
+	server := newRecipeCookServer(t)
+	stdout := &bytes.Buffer{}
+	sleeps := 0
+	app := newRecipeCookApp(t, server.URL, "\nq\n", stdout, &sleeps)
+
+	exitCode := app.runRecipe([]string{"cook", testRecipeID})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if sleeps != 0 {
+		t.Fatalf("sleeps = %d, want no timer", sleeps)
+	}
+	if strings.Contains(stdout.String(), "Done.") {
+		t.Fatalf("expected the session to stop early, got %q", stdout.String())
+	}
+}
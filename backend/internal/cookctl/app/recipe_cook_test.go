@@ -0,0 +1,106 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/config"
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/credentials"
+)
+
+func TestRunRecipeCookedLogsCook(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/cooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want POST", r.Method)
+		}
+		var req client.RecipeCookRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.Rating == nil || *req.Rating != 4 || req.Notes == nil || *req.Notes != "More garlic" {
+			t.Fatalf("request = %+v", req)
+		}
+		if req.CookedAt == nil || !strings.HasPrefix(*req.CookedAt, "2025-06-01T00:00:00") {
+			t.Fatalf("cooked_at = %v, want local midnight on 2025-06-01", req.CookedAt)
+		}
+		if req.Servings != nil {
+			t.Fatalf("servings = %v, want omitted", *req.Servings)
+		}
+		rating := 4
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		writeTestJSON(t, w, client.RecipeCookEvent{
+			ID:       "cook-1",
+			RecipeID: testRecipeID,
+			CookedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			Rating:   &rating,
+			Notes:    req.Notes,
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	store := credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputTable,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipe([]string{"cooked", testRecipeID, "--rating", "4", "--note", "More garlic", "--date", "2025-06-01"})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !strings.Contains(stdout.String(), "cook-1") || !strings.Contains(stdout.String(), "More garlic") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestRunRecipeCookedRejectsBadFlags(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string][]string{
+		"rating must be between 1 and 5": {"cooked", testRecipeID, "--rating", "6"},
+		"date must be YYYY-MM-DD":        {"cooked", testRecipeID, "--date", "yesterday"},
+		"recipe id is required":          {"cooked"},
+	}
+	for want, args := range cases {
+		stderr := &bytes.Buffer{}
+		app := &App{
+			cfg:    config.Config{},
+			stdin:  bytes.NewBufferString(""),
+			stdout: &bytes.Buffer{},
+			stderr: stderr,
+			store:  credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json")),
+		}
+
+		exitCode := app.runRecipe(args)
+		if exitCode != exitUsage {
+			t.Fatalf("%v: exit code = %d, want %d", args, exitCode, exitUsage)
+		}
+		if !strings.Contains(stderr.String(), want) {
+			t.Fatalf("%v: stderr = %q, want %q", args, stderr.String(), want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	commandImage       = "image"
+	commandImageAdd    = "add"
+	commandImageRemove = "rm"
+)
+
+type recipeImageAddFlags struct {
+	filePath   string
+	stepNumber int
+}
+
+type recipeImageRemoveFlags struct {
+	imageID string
+	yes     bool
+}
+
+type recipeImageDeleteResult struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+func recipeImageListFlagSet(out io.Writer) *flag.FlagSet {
+	return newFlagSet("recipe image list", out, printRecipeImageListUsage)
+}
+
+func recipeImageAddFlagSet(out io.Writer) (*flag.FlagSet, *recipeImageAddFlags) {
+	opts := &recipeImageAddFlags{}
+	flags := newFlagSet("recipe image add", out, printRecipeImageAddUsage)
+	flags.StringVar(&opts.filePath, "file", "", "Path to a JPEG, PNG, or GIF photo")
+	flags.IntVar(&opts.stepNumber, "step", 0, "Attach the photo to this step number")
+	return flags, opts
+}
+
+func recipeImageRemoveFlagSet(out io.Writer) (*flag.FlagSet, *recipeImageRemoveFlags) {
+	opts := &recipeImageRemoveFlags{}
+	flags := newFlagSet("recipe image rm", out, printRecipeImageRemoveUsage)
+	flags.StringVar(&opts.imageID, "image-id", "", "Photo id")
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm photo deletion")
+	return flags, opts
+}
+
+func (a *App) runRecipeImage(args []string) int {
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		printRecipeImageUsage(a.stdout)
+		return exitOK
+	}
+	if len(args) == 0 {
+		printRecipeImageUsage(a.stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case commandList:
+		return a.runRecipeImageList(args[1:])
+	case commandImageAdd:
+		return a.runRecipeImageAdd(args[1:])
+	case commandImageRemove:
+		return a.runRecipeImageRemove(args[1:])
+	default:
+		usageErrorf(a.stderr, "unknown recipe image command: %s", args[0])
+		printRecipeImageUsage(a.stderr)
+		return exitUsage
+	}
+}
+
+func (a *App) runRecipeImageList(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeImageListUsage(a.stdout)
+		return exitOK
+	}
+
+	flags := recipeImageListFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	resp, err := api.RecipeImages(ctx, resolvedID)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+func (a *App) runRecipeImageAdd(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeImageAddUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeImageAddFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	if strings.TrimSpace(opts.filePath) == "" {
+		return usageError(a.stderr, "--file is required")
+	}
+	if opts.stepNumber < 0 {
+		return usageError(a.stderr, "step must be positive")
+	}
+	id = strings.TrimSpace(id)
+
+	file, err := os.Open(opts.filePath)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	resp, err := api.UploadRecipeImage(ctx, resolvedID, filepath.Base(opts.filePath), file, opts.stepNumber)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+func (a *App) runRecipeImageRemove(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeImageRemoveUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeImageRemoveFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	imageID := strings.TrimSpace(opts.imageID)
+	if imageID == "" {
+		return usageError(a.stderr, "--image-id is required")
+	}
+	if !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	if err := api.DeleteRecipeImage(ctx, resolvedID, imageID); err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, recipeImageDeleteResult{
+		ID:      imageID,
+		Deleted: true,
+	})
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/config"
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/credentials"
+)
+
+func TestRunRecipeImageAddUploadsMultipart(t *testing.T) {
+	t.Parallel()
+
+	photo := filepath.Join(t.TempDir(), "soup.jpg")
+	if err := os.WriteFile(photo, []byte("jpeg-bytes"), 0o600); err != nil {
+		t.Fatalf("write photo: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/images", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want POST", r.Method)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse multipart: %v", err)
+		}
+		if got := r.FormValue("step_number"); got != "2" {
+			t.Fatalf("step_number = %q, want 2", got)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("form file: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		if header.Filename != "soup.jpg" || string(data) != "jpeg-bytes" {
+			t.Fatalf("file = %s %q", header.Filename, data)
+		}
+		step := 2
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(client.RecipeImage{ID: "img-1", StepNumber: &step, ContentType: "image/jpeg"}); err != nil {
+			t.Fatalf("encode response: %v", err)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	store := credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputJSON,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipeImage([]string{"add", testRecipeID, "--file", photo, "--step", "2"})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !strings.Contains(stdout.String(), `"id": "img-1"`) {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestRunRecipeImageAddRequiresFile(t *testing.T) {
+	t.Parallel()
+
+	stderr := &bytes.Buffer{}
+	app := &App{
+		cfg:    config.Config{},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: stderr,
+		store:  credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json")),
+	}
+
+	exitCode := app.runRecipeImage([]string{"add", testRecipeID})
+	if exitCode != exitUsage {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitUsage)
+	}
+	if !strings.Contains(stderr.String(), "--file is required") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestRunRecipeImageRemoveRequiresYes(t *testing.T) {
+	t.Parallel()
+
+	app := &App{
+		cfg:    config.Config{},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: &bytes.Buffer{},
+		store:  credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json")),
+	}
+
+	exitCode := app.runRecipeImage([]string{"rm", testRecipeID, "--image-id", "img-1"})
+	if exitCode != exitUsage {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitUsage)
+	}
+}
+
+func TestRunRecipeImageRemoveSuccess(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/images/img-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("method = %s, want DELETE", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	store := credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputTable,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipeImage([]string{"rm", testRecipeID, "--image-id", "img-1", "--yes"})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+}
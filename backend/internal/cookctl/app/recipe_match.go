@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+const commandMatch = "match"
+
+type recipeMatchFlags struct {
+	have          csvStrings
+	itemIDs       csvStrings
+	ignoreStaples bool
+	maxMissing    int
+	limit         int
+}
+
+func recipeMatchFlagSet(out io.Writer) (*flag.FlagSet, *recipeMatchFlags) {
+	opts := &recipeMatchFlags{}
+	flags := newFlagSet("recipe match", out, printRecipeMatchUsage)
+	flags.Var(&opts.have, "have", "Item names on hand, comma-separated (repeatable)")
+	flags.Var(&opts.itemIDs, "item-id", "Item id on hand (repeatable)")
+	flags.BoolVar(&opts.ignoreStaples, "ignore-staples", false, "Ignore staples such as salt, black pepper, and water")
+	flags.IntVar(&opts.maxMissing, "max-missing", -1, "Only show recipes missing at most this many items")
+	flags.IntVar(&opts.limit, "limit", 0, "Max recipes to return (server default 20)")
+	return flags, opts
+}
+
+// runRecipeMatch lists recipes that can be made, or nearly made, with the
+// items on hand.
+func (a *App) runRecipeMatch(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeMatchUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeMatchFlagSet(a.stderr)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 0 {
+		return usageError(a.stderr, "match does not accept arguments")
+	}
+
+	req := client.RecipeMatchRequest{
+		ItemIDs:       opts.itemIDs.Values(),
+		IgnoreStaples: opts.ignoreStaples,
+		Limit:         opts.limit,
+	}
+	for _, value := range opts.have.Values() {
+		for _, name := range strings.Split(value, ",") {
+			if trimmed := strings.TrimSpace(name); trimmed != "" {
+				req.ItemNames = append(req.ItemNames, trimmed)
+			}
+		}
+	}
+	if len(req.ItemNames) == 0 && len(req.ItemIDs) == 0 {
+		return usageError(a.stderr, "--have or --item-id is required")
+	}
+	if opts.limit < 0 {
+		return usageError(a.stderr, "--limit must be positive")
+	}
+	if opts.maxMissing >= 0 {
+		req.MaxMissing = &opts.maxMissing
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.MatchRecipes(ctx, req)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
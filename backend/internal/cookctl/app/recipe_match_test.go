@@ -0,0 +1,53 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+func TestRunRecipeMatch(t *testing.T) {
+	t.Parallel()
+
+	var got client.RecipeMatchRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/match", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want POST", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.RecipeMatchResult{
+			UnknownNames: []string{"feta"},
+			Recipes: []client.RecipeMatch{{
+				ID:              "recipe-1",
+				Title:           "Spinach omelette",
+				IngredientCount: 3,
+				HaveCount:       2,
+				Coverage:        0.667,
+				Missing:         []client.RecipeMatchItem{{ID: "item-3", Name: "Milk"}},
+			}},
+		})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runRecipe([]string{"match"}); exitCode != exitUsage {
+		t.Fatalf("exit code without items = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runRecipe([]string{"match", "--have", "eggs, spinach", "--have", "feta", "--ignore-staples", "--max-missing", "0"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !slices.Equal(got.ItemNames, []string{"eggs", "spinach", "feta"}) || !got.IgnoreStaples || got.MaxMissing == nil || *got.MaxMissing != 0 {
+		t.Fatalf("payload = %+v", got)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "2/3") || !strings.Contains(out, "67%") || !strings.Contains(out, "Milk") || !strings.Contains(out, "unknown items: feta") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+const (
+	commandDupes = "dupes"
+	commandMerge = "merge"
+)
+
+type recipeDupesFlags struct {
+	minScore float64
+	limit    int
+}
+
+type recipeMergeFlags struct {
+	from string
+	yes  bool
+}
+
+func recipeDupesFlagSet(out io.Writer) (*flag.FlagSet, *recipeDupesFlags) {
+	opts := &recipeDupesFlags{}
+	flags := newFlagSet("recipe dupes", out, printRecipeDupesUsage)
+	flags.Float64Var(&opts.minScore, "min-score", 0.5, "Lowest match score to show (0-1)")
+	flags.IntVar(&opts.limit, "limit", 0, "Max pairs to return (server default 20)")
+	return flags, opts
+}
+
+func recipeMergeFlagSet(out io.Writer) (*flag.FlagSet, *recipeMergeFlags) {
+	opts := &recipeMergeFlags{}
+	flags := newFlagSet("recipe merge", out, printRecipeMergeUsage)
+	flags.StringVar(&opts.from, "from", "", "Duplicate recipe id or title to merge in and delete")
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm recipe merge")
+	return flags, opts
+}
+
+// runRecipeDupes lists likely duplicate recipes, optionally only those of
+// one recipe.
+func (a *App) runRecipeDupes(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeDupesUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeDupesFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	minScoreSet := false
+	flags.Visit(func(flagItem *flag.Flag) {
+		if flagItem.Name == "min-score" {
+			minScoreSet = true
+		}
+	})
+	if opts.minScore < 0 || opts.minScore > 1 {
+		return usageError(a.stderr, "--min-score must be between 0 and 1")
+	}
+	if opts.limit < 0 {
+		return usageError(a.stderr, "--limit must be positive")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	params := client.RecipeDuplicateParams{Limit: opts.limit}
+	if minScoreSet {
+		params.MinScore = &opts.minScore
+	}
+	if id != "" {
+		resolvedID, resolveErr := resolveRecipeID(ctx, api, id)
+		if resolveErr != nil {
+			return usageError(a.stderr, resolveErr.Error())
+		}
+		params.RecipeID = resolvedID
+	}
+
+	resp, err := api.RecipeDuplicates(ctx, params)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runRecipeMerge keeps one recipe and folds a duplicate into it.
+func (a *App) runRecipeMerge(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeMergeUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeMergeFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	from := strings.TrimSpace(opts.from)
+	if from == "" {
+		return usageError(a.stderr, "--from is required")
+	}
+	if !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	keepID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	duplicateID, err := resolveRecipeID(ctx, api, from)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	resp, err := api.MergeRecipe(ctx, keepID, duplicateID)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+const testDuplicateRecipeID = "22222222-2222-2222-2222-222222222222"
+
+func TestRunRecipeDupes(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/duplicates", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("recipe_id") != testRecipeID || query.Get("min_score") != "0.7" || query.Get("limit") != "" {
+			t.Fatalf("query = %q, want recipe_id and min_score only", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, []client.RecipeDuplicate{{
+			Recipe:            client.RecipeRef{ID: testRecipeID, Title: "Beef Chili"},
+			Duplicate:         client.RecipeRef{ID: testDuplicateRecipeID, Title: "Beef Chili (imported)"},
+			Score:             0.912,
+			TitleSimilarity:   0.853,
+			IngredientOverlap: 1,
+		}})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runRecipe([]string{"dupes", testRecipeID, "--min-score", "0.7"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	out := stdout.String()
+	for _, want := range []string{"SCORE", "0.91", "0.85", "1.00", "Beef Chili (imported)", testDuplicateRecipeID} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunRecipeMerge(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/merge", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want POST", r.Method)
+		}
+		var req struct {
+			DuplicateID string `json:"duplicate_id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.DuplicateID != testDuplicateRecipeID {
+			t.Fatalf("duplicate_id = %q, want %q", req.DuplicateID, testDuplicateRecipeID)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.RecipeMergeResult{
+			Recipe:               client.RecipeDetail{ID: testRecipeID, Title: "Beef Chili"},
+			MergedRecipeID:       testDuplicateRecipeID,
+			TagsMoved:            1,
+			MealPlanEntriesMoved: 2,
+		})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runRecipe([]string{"merge", testRecipeID, "--from", testDuplicateRecipeID}); exitCode != exitUsage {
+		t.Fatalf("exit code without --yes = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runRecipe([]string{"merge", testRecipeID, "--from", testDuplicateRecipeID, "--yes"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "MEAL_PLANS_MOVED") || !strings.Contains(out, testDuplicateRecipeID) {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
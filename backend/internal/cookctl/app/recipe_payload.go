@@ -33,11 +33,13 @@ type recipeUpsertPayload struct {
 // recipeIngredientUpsert captures an ingredient payload for recipe upserts.
 type recipeIngredientUpsert struct {
 	Position     int      `json:"position"`
+	GroupName    *string  `json:"group_name"`
 	Quantity     *float64 `json:"quantity"`
 	QuantityText *string  `json:"quantity_text"`
 	Unit         *string  `json:"unit"`
 	ItemID       *string  `json:"item_id"`
 	ItemName     string   `json:"item_name"`
+	SubRecipeID  *string  `json:"sub_recipe_id"`
 	Prep         *string  `json:"prep"`
 	Notes        *string  `json:"notes"`
 	OriginalText *string  `json:"original_text"`
@@ -45,8 +47,13 @@ type recipeIngredientUpsert struct {
 
 // recipeStepUpsert captures a recipe step payload for recipe upserts.
 type recipeStepUpsert struct {
-	StepNumber  int    `json:"step_number"`
-	Instruction string `json:"instruction"`
+	StepNumber         int     `json:"step_number"`
+	GroupName          *string `json:"group_name"`
+	Instruction        string  `json:"instruction"`
+	DurationSeconds    *int    `json:"duration_seconds"`
+	DurationMaxSeconds *int    `json:"duration_max_seconds"`
+	Temperature        *int    `json:"temperature"`
+	TemperatureUnit    *string `json:"temperature_unit"`
 }
 
 // readJSONFile reads a JSON object from a file.
@@ -370,7 +377,8 @@ func (p *promptInput) askInt(label string, defaultValue, minValue int) (int, err
 	}
 }
 
-// askIngredients collects ingredient lines.
+// askIngredients collects ingredient lines. Each line is sent as original_text
+// only so the server splits it into quantity, unit, item, prep, and notes.
 func (p *promptInput) askIngredients() ([]recipeIngredientUpsert, error) {
 	ingredients := []recipeIngredientUpsert{}
 	for {
@@ -387,7 +395,6 @@ func (p *promptInput) askIngredients() ([]recipeIngredientUpsert, error) {
 		trimmed := strings.TrimSpace(line)
 		ingredients = append(ingredients, recipeIngredientUpsert{
 			Position:     len(ingredients) + 1,
-			ItemName:     trimmed,
 			OriginalText: &trimmed,
 		})
 	}
@@ -424,25 +431,35 @@ func toUpsertPayload(recipe client.RecipeDetail) recipeUpsertPayload {
 
 	ingredients := make([]recipeIngredientUpsert, 0, len(recipe.Ingredients))
 	for _, ingredient := range recipe.Ingredients {
-		itemID := stringPtrIfNotEmpty(ingredient.Item.ID)
-		ingredients = append(ingredients, recipeIngredientUpsert{
+		upsert := recipeIngredientUpsert{
 			Position:     ingredient.Position,
+			GroupName:    ingredient.GroupName,
 			Quantity:     ingredient.Quantity,
 			QuantityText: ingredient.QuantityText,
 			Unit:         ingredient.Unit,
-			ItemID:       itemID,
-			ItemName:     ingredient.Item.Name,
 			Prep:         ingredient.Prep,
 			Notes:        ingredient.Notes,
 			OriginalText: ingredient.OriginalText,
-		})
+		}
+		if ingredient.SubRecipe != nil {
+			upsert.SubRecipeID = stringPtrIfNotEmpty(ingredient.SubRecipe.ID)
+		} else if ingredient.Item != nil {
+			upsert.ItemID = stringPtrIfNotEmpty(ingredient.Item.ID)
+			upsert.ItemName = ingredient.Item.Name
+		}
+		ingredients = append(ingredients, upsert)
 	}
 
 	steps := make([]recipeStepUpsert, 0, len(recipe.Steps))
 	for _, step := range recipe.Steps {
 		steps = append(steps, recipeStepUpsert{
-			StepNumber:  step.StepNumber,
-			Instruction: step.Instruction,
+			StepNumber:         step.StepNumber,
+			GroupName:          step.GroupName,
+			Instruction:        step.Instruction,
+			DurationSeconds:    step.DurationSeconds,
+			DurationMaxSeconds: step.DurationMaxSeconds,
+			Temperature:        step.Temperature,
+			TemperatureUnit:    step.TemperatureUnit,
 		})
 	}
 
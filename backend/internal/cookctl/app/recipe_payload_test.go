@@ -142,6 +142,7 @@ func TestToUpsertPayload(t *testing.T) {
 	unit := "cups"
 	itemID := "item-1"
 	ingredientText := "2 cups flour"
+	stepGroup := "Dough"
 	recipe := client.RecipeDetail{
 		Title:            "Bread",
 		Servings:         2,
@@ -155,12 +156,16 @@ func TestToUpsertPayload(t *testing.T) {
 				Position:     1,
 				Quantity:     &quantity,
 				Unit:         &unit,
-				Item:         client.Item{ID: itemID, Name: "Flour"},
+				Item:         &client.Item{ID: itemID, Name: "Flour"},
 				OriginalText: &ingredientText,
 			},
+			{
+				Position:  2,
+				SubRecipe: &client.RecipeSubRecipe{ID: "recipe-starter", Title: "Starter", Servings: 4},
+			},
 		},
 		Steps: []client.RecipeStep{
-			{StepNumber: 1, Instruction: "Mix"},
+			{StepNumber: 1, GroupName: &stepGroup, Instruction: "Mix"},
 		},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -179,4 +184,11 @@ func TestToUpsertPayload(t *testing.T) {
 	if payload.Ingredients[0].Unit == nil || *payload.Ingredients[0].Unit != unit {
 		t.Fatalf("unit = %v, want %s", payload.Ingredients[0].Unit, unit)
 	}
+	starter := payload.Ingredients[1]
+	if starter.SubRecipeID == nil || *starter.SubRecipeID != "recipe-starter" || starter.ItemID != nil || starter.ItemName != "" {
+		t.Fatalf("sub-recipe ingredient = %+v, want only sub_recipe_id", starter)
+	}
+	if payload.Steps[0].GroupName == nil || *payload.Steps[0].GroupName != stepGroup {
+		t.Fatalf("step group = %v, want %s", payload.Steps[0].GroupName, stepGroup)
+	}
 }
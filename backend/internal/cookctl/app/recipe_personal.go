@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+const (
+	commandFav   = "fav"
+	commandUnfav = "unfav"
+	commandNote  = "note"
+)
+
+type recipeNoteFlags struct {
+	set   string
+	clear bool
+	yes   bool
+}
+
+// recipeFavoriteResult reports a favorite toggle.
+type recipeFavoriteResult struct {
+	ID       string `json:"id"`
+	Favorite bool   `json:"favorite"`
+}
+
+// recipeNoteClearResult reports a deleted personal note.
+type recipeNoteClearResult struct {
+	RecipeID string `json:"recipe_id"`
+	Cleared  bool   `json:"cleared"`
+}
+
+func recipeFavFlagSet(out io.Writer) *flag.FlagSet {
+	return newFlagSet("recipe fav", out, printRecipeFavUsage)
+}
+
+func recipeUnfavFlagSet(out io.Writer) *flag.FlagSet {
+	return newFlagSet("recipe unfav", out, printRecipeUnfavUsage)
+}
+
+func recipeNoteFlagSet(out io.Writer) (*flag.FlagSet, *recipeNoteFlags) {
+	opts := &recipeNoteFlags{}
+	flags := newFlagSet("recipe note", out, printRecipeNoteUsage)
+	flags.StringVar(&opts.set, "set", "", "Replace your personal note with this text")
+	flags.BoolVar(&opts.clear, "clear", false, "Delete your personal note")
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm clearing the note")
+	return flags, opts
+}
+
+func (a *App) runRecipeFav(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeFavUsage(a.stdout)
+		return exitOK
+	}
+	return a.setRecipeFavorite(recipeFavFlagSet(a.stderr), args, true)
+}
+
+func (a *App) runRecipeUnfav(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeUnfavUsage(a.stdout)
+		return exitOK
+	}
+	return a.setRecipeFavorite(recipeUnfavFlagSet(a.stderr), args, false)
+}
+
+func (a *App) setRecipeFavorite(flags *flag.FlagSet, args []string, favorite bool) int {
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	if favorite {
+		err = api.FavoriteRecipe(ctx, resolvedID)
+	} else {
+		err = api.UnfavoriteRecipe(ctx, resolvedID)
+	}
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+
+	return writeOutput(a.stdout, a.cfg.Output, recipeFavoriteResult{
+		ID:       resolvedID,
+		Favorite: favorite,
+	})
+}
+
+// runRecipeNote shows, sets, or clears the caller's private note on a recipe.
+func (a *App) runRecipeNote(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeNoteUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeNoteFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	note := strings.TrimSpace(opts.set)
+	setNote := false
+	flags.Visit(func(flagItem *flag.Flag) {
+		if flagItem.Name == "set" {
+			setNote = true
+		}
+	})
+	if setNote && opts.clear {
+		return usageError(a.stderr, "set and clear cannot be combined")
+	}
+	if setNote && note == "" {
+		return usageError(a.stderr, "note cannot be empty; use --clear to delete it")
+	}
+	if opts.clear && !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	var resp client.RecipePersonalNote
+	switch {
+	case opts.clear:
+		if err := api.DeleteRecipePersonalNote(ctx, resolvedID); err != nil {
+			return a.handleAPIError(err)
+		}
+		return writeOutput(a.stdout, a.cfg.Output, recipeNoteClearResult{
+			RecipeID: resolvedID,
+			Cleared:  true,
+		})
+	case setNote:
+		resp, err = api.SetRecipePersonalNote(ctx, resolvedID, note)
+	default:
+		resp, err = api.RecipePersonalNote(ctx, resolvedID)
+	}
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
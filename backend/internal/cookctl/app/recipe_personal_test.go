@@ -0,0 +1,127 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/config"
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/credentials"
+)
+
+func newRecipePersonalTestApp(t *testing.T, mux *http.ServeMux) (*App, *bytes.Buffer) {
+	t.Helper()
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	store := credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	return &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputTable,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}, stdout
+}
+
+func TestRunRecipeFavAndUnfav(t *testing.T) {
+	t.Parallel()
+
+	var methods []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/favorite", func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runRecipe([]string{"fav", testRecipeID}); exitCode != exitOK {
+		t.Fatalf("fav exit code = %d, want %d", exitCode, exitOK)
+	}
+	if exitCode := app.runRecipe([]string{"unfav", testRecipeID}); exitCode != exitOK {
+		t.Fatalf("unfav exit code = %d, want %d", exitCode, exitOK)
+	}
+	if strings.Join(methods, ",") != "PUT,DELETE" {
+		t.Fatalf("methods = %v, want PUT then DELETE", methods)
+	}
+	if !strings.Contains(stdout.String(), "true") || !strings.Contains(stdout.String(), "false") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestRunRecipeNoteSet(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/personal-note", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Fatalf("method = %s, want PUT", r.Method)
+		}
+		var req client.RecipePersonalNoteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.Note != "Use less salt" {
+			t.Fatalf("note = %q", req.Note)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.RecipePersonalNote{
+			RecipeID:  testRecipeID,
+			Note:      req.Note,
+			UpdatedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	exitCode := app.runRecipe([]string{"note", testRecipeID, "--set", "  Use less salt "})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !strings.Contains(stdout.String(), "Use less salt") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestRunRecipeNoteRejectsBadFlags(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string][]string{
+		"note cannot be empty":                     {"note", testRecipeID, "--set", " "},
+		"set and clear cannot be combined":         {"note", testRecipeID, "--set", "x", "--clear"},
+		"confirmation required; re-run with --yes": {"note", testRecipeID, "--clear"},
+	}
+	for want, args := range cases {
+		stderr := &bytes.Buffer{}
+		app := &App{
+			cfg:    config.Config{},
+			stdin:  bytes.NewBufferString(""),
+			stdout: &bytes.Buffer{},
+			stderr: stderr,
+			store:  credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json")),
+		}
+
+		exitCode := app.runRecipe(args)
+		if exitCode != exitUsage {
+			t.Fatalf("%v: exit code = %d, want %d", args, exitCode, exitUsage)
+		}
+		if !strings.Contains(stderr.String(), want) {
+			t.Fatalf("%v: stderr = %q, want %q", args, stderr.String(), want)
+		}
+	}
+}
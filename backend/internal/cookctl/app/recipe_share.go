@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	commandShare   = "share"
+	commandUnshare = "unshare"
+)
+
+type recipeShareFlags struct {
+	expiresAt string
+	list      bool
+}
+
+type recipeUnshareFlags struct {
+	shareID string
+	all     bool
+	yes     bool
+}
+
+// recipeShareResult is a newly created share link with its full URLs.
+type recipeShareResult struct {
+	ID        string     `json:"id"`
+	RecipeID  string     `json:"recipe_id"`
+	URL       string     `json:"url"`
+	APIURL    string     `json:"api_url"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// recipeUnshareResult reports revoked share links.
+type recipeUnshareResult struct {
+	RecipeID string `json:"recipe_id"`
+	ShareID  string `json:"share_id,omitempty"`
+	Revoked  bool   `json:"revoked"`
+}
+
+func recipeShareFlagSet(out io.Writer) (*flag.FlagSet, *recipeShareFlags) {
+	opts := &recipeShareFlags{}
+	flags := newFlagSet("recipe share", out, printRecipeShareUsage)
+	flags.StringVar(&opts.expiresAt, "expires-at", "", "Link expiration (RFC3339); never expires when omitted")
+	flags.BoolVar(&opts.list, "list", false, "List your existing share links instead of creating one")
+	return flags, opts
+}
+
+func recipeUnshareFlagSet(out io.Writer) (*flag.FlagSet, *recipeUnshareFlags) {
+	opts := &recipeUnshareFlags{}
+	flags := newFlagSet("recipe unshare", out, printRecipeUnshareUsage)
+	flags.StringVar(&opts.shareID, "share-id", "", "Share link id to revoke")
+	flags.BoolVar(&opts.all, "all", false, "Revoke all of your share links for the recipe")
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm revoking")
+	return flags, opts
+}
+
+// runRecipeShare creates a public read-only link to a recipe, or lists the
+// caller's existing links with --list.
+func (a *App) runRecipeShare(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeShareUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeShareFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	if opts.list && opts.expiresAt != "" {
+		return usageError(a.stderr, "list and expires-at cannot be combined")
+	}
+	var expiresAt *time.Time
+	if opts.expiresAt != "" {
+		parsed, parseErr := time.Parse(time.RFC3339, opts.expiresAt)
+		if parseErr != nil {
+			return usageError(a.stderr, "expires-at must be RFC3339")
+		}
+		expiresAt = &parsed
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	if opts.list {
+		shares, listErr := api.RecipeShares(ctx, resolvedID)
+		if listErr != nil {
+			return a.handleAPIError(listErr)
+		}
+		return writeOutput(a.stdout, a.cfg.Output, shares)
+	}
+
+	share, err := api.ShareRecipe(ctx, resolvedID, expiresAt)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, recipeShareResult{
+		ID:        share.ID,
+		RecipeID:  share.RecipeID,
+		URL:       api.URL(share.Path),
+		APIURL:    api.URL(share.APIPath),
+		ExpiresAt: share.ExpiresAt,
+	})
+}
+
+// runRecipeUnshare revokes one share link, or all of them with --all.
+func (a *App) runRecipeUnshare(args []string) int {
+	if hasHelpFlag(args) {
+		printRecipeUnshareUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := recipeUnshareFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "recipe id is required")
+	}
+	shareID := strings.TrimSpace(opts.shareID)
+	if (shareID == "") == !opts.all {
+		return usageError(a.stderr, "exactly one of --share-id or --all is required")
+	}
+	if !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resolvedID, err := resolveRecipeID(ctx, api, id)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	if opts.all {
+		err = api.UnshareRecipeAll(ctx, resolvedID)
+	} else {
+		err = api.UnshareRecipe(ctx, resolvedID, shareID)
+	}
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, recipeUnshareResult{
+		RecipeID: resolvedID,
+		ShareID:  shareID,
+		Revoked:  true,
+	})
+}
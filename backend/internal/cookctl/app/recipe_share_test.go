@@ -0,0 +1,102 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+const testShareID = "33333333-3333-3333-3333-333333333333"
+
+func TestRunRecipeShare(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/shares", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want POST", r.Method)
+		}
+		var req struct {
+			ExpiresAt *time.Time `json:"expires_at"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.ExpiresAt == nil || !req.ExpiresAt.Equal(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)) {
+			t.Fatalf("expires_at = %v, want 2030-01-02T03:04:05Z", req.ExpiresAt)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		writeTestJSON(t, w, client.RecipeShare{
+			ID:        testShareID,
+			RecipeID:  testRecipeID,
+			Token:     "tok123",
+			Path:      "/shared/recipes/tok123",
+			APIPath:   "/api/v1/shared/recipes/tok123",
+			ExpiresAt: req.ExpiresAt,
+			CreatedAt: time.Now(),
+		})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runRecipe([]string{"share", testRecipeID, "--expires-at", "tomorrow"}); exitCode != exitUsage {
+		t.Fatalf("exit code for bad expires-at = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runRecipe([]string{"share", testRecipeID, "--expires-at", "2030-01-02T03:04:05Z"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, app.cfg.APIURL+"/shared/recipes/tok123") || !strings.Contains(out, "2030-01-02T03:04:05Z") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestRunRecipeUnshare(t *testing.T) {
+	t.Parallel()
+
+	var deleted []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/shares/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("method = %s, want DELETE", r.Method)
+		}
+		deleted = append(deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/shares", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("method = %s, want DELETE", r.Method)
+		}
+		deleted = append(deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runRecipe([]string{"unshare", testRecipeID, "--yes"}); exitCode != exitUsage {
+		t.Fatalf("exit code without target = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runRecipe([]string{"unshare", testRecipeID, "--share-id", testShareID}); exitCode != exitUsage {
+		t.Fatalf("exit code without --yes = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runRecipe([]string{"unshare", testRecipeID, "--share-id", testShareID, "--yes"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if exitCode := app.runRecipe([]string{"unshare", testRecipeID, "--all", "--yes"}); exitCode != exitOK {
+		t.Fatalf("exit code for --all = %d, want %d", exitCode, exitOK)
+	}
+
+	want := []string{
+		"/api/v1/recipes/" + testRecipeID + "/shares/" + testShareID,
+		"/api/v1/recipes/" + testRecipeID + "/shares",
+	}
+	if len(deleted) != len(want) || deleted[0] != want[0] || deleted[1] != want[1] {
+		t.Fatalf("deleted = %v, want %v", deleted, want)
+	}
+	if !strings.Contains(stdout.String(), "REVOKED") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -123,6 +124,112 @@ func TestRunRecipeGet(t *testing.T) {
 	}
 }
 
+func TestRunRecipeGetServings(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("servings"); got != "6" {
+			t.Errorf("servings query = %q, want 6", got)
+		}
+		scaledFrom := 2
+		quantity := 3.0
+		originalText := "1 carrot"
+		stockServings := 2.0
+		stockWater := 2.0
+		liters := "l"
+		resp := client.RecipeDetail{
+			ID:               testRecipeID,
+			Title:            "Soup",
+			Servings:         6,
+			ScaledFrom:       &scaledFrom,
+			PrepTimeMinutes:  5,
+			TotalTimeMinutes: 20,
+			Tags:             []client.RecipeTag{},
+			Ingredients: []client.RecipeIngredient{
+				{ID: "ing-1", Position: 1, Quantity: &quantity, Item: &client.Item{ID: "item-1", Name: "carrot"}, OriginalText: &originalText},
+				{ID: "ing-3", Position: 2, Quantity: &stockServings, SubRecipe: &client.RecipeSubRecipe{
+					ID:       "recipe-stock",
+					Title:    "Stock",
+					Servings: 4,
+					Ingredients: []client.RecipeIngredient{
+						{ID: "ing-4", Position: 1, Quantity: &stockWater, Unit: &liters, Item: &client.Item{ID: "item-2", Name: "water"}},
+					},
+				}},
+			},
+			Steps: []client.RecipeStep{},
+			Nutrition: &client.RecipeNutrition{
+				PerServing: client.NutritionFacts{Calories: 41, ProteinG: 0.9, CarbsG: 9.6},
+				Uncounted:  []client.RecipeNutritionGap{{IngredientID: "ing-2", ItemName: "salt", Reason: "missing_quantity"}},
+			},
+			CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			CreatedBy: "user-1",
+			UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedBy: "user-1",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, resp)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	credsPath := filepath.Join(t.TempDir(), "credentials.json")
+	store := credentials.NewStore(credsPath)
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputTable,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipeGet([]string{testRecipeID, "--servings", "6"})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !strings.Contains(stdout.String(), "6 (scaled from 2)") {
+		t.Fatalf("expected scaled servings in output, got %q", stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "3 carrot") {
+		t.Fatalf("expected scaled ingredient in output, got %q", stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "2. 2 servings of Stock\n     (Stock makes 4 servings)\n     - 2 l water\n") {
+		t.Fatalf("expected nested sub-recipe in output, got %q", stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "nutrition (per serving, incomplete):\n  calories 41, protein 0.9g") {
+		t.Fatalf("expected nutrition section in output, got %q", stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "not counted: salt (missing quantity)") {
+		t.Fatalf("expected uncounted ingredient in output, got %q", stdout.String())
+	}
+}
+
+func TestRunRecipeGetRejectsNegativeServings(t *testing.T) {
+	t.Parallel()
+
+	stderr := &bytes.Buffer{}
+	app := &App{
+		cfg:    config.Config{Output: config.OutputJSON, Timeout: 5 * time.Second},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: stderr,
+	}
+
+	exitCode := app.runRecipeGet([]string{testRecipeID, "--servings", "-1"})
+	if exitCode != exitUsage {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitUsage)
+	}
+}
+
 func TestRunRecipeCreate(t *testing.T) {
 	t.Parallel()
 
@@ -617,7 +724,8 @@ func TestRunRecipeCreateInteractive(t *testing.T) {
 				Title       string `json:"title"`
 				Servings    int    `json:"servings"`
 				Ingredients []struct {
-					ItemName string `json:"item_name"`
+					ItemName     string `json:"item_name"`
+					OriginalText string `json:"original_text"`
 				} `json:"ingredients"`
 				Steps []struct {
 					Instruction string `json:"instruction"`
@@ -629,7 +737,7 @@ func TestRunRecipeCreateInteractive(t *testing.T) {
 			if payload.Title != "Soup" || payload.Servings != 2 {
 				t.Fatalf("payload title/servings = %s/%d", payload.Title, payload.Servings)
 			}
-			if len(payload.Ingredients) != 1 || payload.Ingredients[0].ItemName != "Water" {
+			if len(payload.Ingredients) != 1 || payload.Ingredients[0].ItemName != "" || payload.Ingredients[0].OriginalText != "2 cups water" {
 				t.Fatalf("unexpected ingredients: %#v", payload.Ingredients)
 			}
 			if len(payload.Steps) != 1 || payload.Steps[0].Instruction != "Boil" {
@@ -673,7 +781,7 @@ func TestRunRecipeCreateInteractive(t *testing.T) {
 		"",
 		"",
 		"",
-		"Water",
+		"2 cups water",
 		"",
 		"Boil",
 		"",
@@ -773,6 +881,163 @@ func TestRunRecipeImportArray(t *testing.T) {
 	}
 }
 
+func TestRunRecipeImportHTML(t *testing.T) {
+	t.Parallel()
+
+	const page = `<script type="application/ld+json">{"@type":"Recipe","name":"Soup"}</script>`
+	draft := `{"title":"Soup","servings":1,"prep_time_minutes":0,"total_time_minutes":0,"source_url":"https://example.com/soup","notes":null,"recipe_book_id":null,"tag_ids":[],"ingredients":[],"steps":[{"step_number":1,"instruction":"Boil"}]}`
+
+	created := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/import", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		var req struct {
+			Format    string  `json:"format"`
+			Content   string  `json:"content"`
+			SourceURL *string `json:"source_url"`
+			Create    bool    `json:"create"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.Format != "html" || req.Content != page || req.Create {
+			t.Fatalf("unexpected import request: %+v", req)
+		}
+		if req.SourceURL == nil || *req.SourceURL != "https://example.com/soup" {
+			t.Fatalf("source_url = %v", req.SourceURL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(draft))
+	})
+	mux.HandleFunc("/api/v1/recipes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			writeTestJSON(t, w, client.RecipeListResponse{Items: []client.RecipeListItem{}})
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+			if string(body) != draft {
+				t.Fatalf("create payload = %s, want draft", body)
+			}
+			created = true
+			w.Header().Set("Content-Type", "application/json")
+			writeTestJSON(t, w, client.RecipeDetail{ID: testRecipeID, Title: "Soup"})
+		default:
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	credsPath := filepath.Join(t.TempDir(), "credentials.json")
+	store := credentials.NewStore(credsPath)
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputJSON,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(page),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipeImport([]string{"--stdin", "--format", "html", "--source-url", "https://example.com/soup"})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !created {
+		t.Fatalf("expected recipe to be created")
+	}
+
+	var got recipeImportResult
+	if err := json.NewDecoder(bytes.NewReader(stdout.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(got.Items) != 1 || got.Items[0].ID != testRecipeID {
+		t.Fatalf("unexpected items: %+v", got.Items)
+	}
+}
+
+func TestRunRecipeImportDraftPrintsPayload(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/import", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"title":"Soup","servings":4,"prep_time_minutes":0,"total_time_minutes":0,"source_url":null,"notes":null,"recipe_book_id":null,"tag_ids":[],"ingredients":[],"steps":[]}`))
+	})
+	mux.HandleFunc("/api/v1/recipes", func(_ http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected recipes call: %s", r.Method)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	credsPath := filepath.Join(t.TempDir(), "credentials.json")
+	store := credentials.NewStore(credsPath)
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputJSON,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(`{"@type":"Recipe","name":"Soup","recipeYield":"4"}`),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipeImport([]string{"--stdin", "--format", "jsonld", "--draft"})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+
+	var got recipeUpsertPayload
+	if err := json.NewDecoder(bytes.NewReader(stdout.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if got.Title != "Soup" || got.Servings != 4 {
+		t.Fatalf("unexpected draft: %+v", got)
+	}
+}
+
+func TestRunRecipeImportRejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	stderr := &bytes.Buffer{}
+	app := &App{
+		cfg:    config.Config{},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: stderr,
+		store:  credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json")),
+	}
+
+	exitCode := app.runRecipeImport([]string{"--stdin", "--format", "xml"})
+	if exitCode != exitUsage {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitUsage)
+	}
+	if !strings.Contains(stderr.String(), "--format") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
 func TestRunRecipeTagCreatesMissing(t *testing.T) {
 	t.Parallel()
 
@@ -882,40 +1147,44 @@ func TestRunRecipeClone(t *testing.T) {
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/api/v1/recipes", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			resp := client.RecipeListResponse{Items: []client.RecipeListItem{}}
-			w.Header().Set("Content-Type", "application/json")
-			writeTestJSON(t, w, resp)
-		case http.MethodPost:
-			var payload struct {
-				Title string `json:"title"`
-			}
-			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-				t.Fatalf("decode payload: %v", err)
-			}
-			if payload.Title != "Soup (copy)" {
-				t.Fatalf("title = %q, want Soup (copy)", payload.Title)
-			}
-			resp := client.RecipeDetail{
-				ID:               testMealPlanRecipeID,
-				Title:            payload.Title,
-				Servings:         2,
-				PrepTimeMinutes:  5,
-				TotalTimeMinutes: 20,
-				Tags:             []client.RecipeTag{},
-				Ingredients:      []client.RecipeIngredient{},
-				Steps:            []client.RecipeStep{{StepNumber: 1, Instruction: "Boil"}},
-				CreatedAt:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-				CreatedBy:        "user-1",
-				UpdatedAt:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-				UpdatedBy:        "user-1",
-			}
-			w.Header().Set("Content-Type", "application/json")
-			writeTestJSON(t, w, resp)
-		default:
+		if r.Method != http.MethodGet {
 			t.Fatalf("unexpected method: %s", r.Method)
 		}
+		resp := client.RecipeListResponse{Items: []client.RecipeListItem{}}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, resp)
+	})
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/clone", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want POST", r.Method)
+		}
+		var payload struct {
+			Title string `json:"title"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload.Title != "Soup (copy)" {
+			t.Fatalf("title = %q, want Soup (copy)", payload.Title)
+		}
+		resp := client.RecipeDetail{
+			ID:               testMealPlanRecipeID,
+			Title:            payload.Title,
+			Servings:         2,
+			PrepTimeMinutes:  5,
+			TotalTimeMinutes: 20,
+			ParentRecipe:     &client.RecipeRef{ID: testRecipeID, Title: "Soup"},
+			Tags:             []client.RecipeTag{},
+			Ingredients:      []client.RecipeIngredient{},
+			Steps:            []client.RecipeStep{{StepNumber: 1, Instruction: "Boil"}},
+			CreatedAt:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			CreatedBy:        "user-1",
+			UpdatedAt:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedBy:        "user-1",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		writeTestJSON(t, w, resp)
 	})
 	mux.HandleFunc("/api/v1/recipes/"+testRecipeID, func(w http.ResponseWriter, r *http.Request) {
 		resp := client.RecipeDetail{
@@ -1070,6 +1339,203 @@ func TestRunRecipeRestoreSuccess(t *testing.T) {
 	}
 }
 
+func TestRunRecipeHistoryTable(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/revisions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("method = %s, want GET", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode([]client.RecipeRevisionSummary{
+			{Revision: 2, Title: "Soup v2", CreatedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), CreatedBy: "u1"},
+			{Revision: 1, Title: "Soup", CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), CreatedBy: "u1"},
+		}); err != nil {
+			t.Fatalf("encode response: %v", err)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	store := credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputTable,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipeHistory([]string{testRecipeID})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "REVISION") || !strings.Contains(out, "Soup v2") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestRunRecipeDiffRequiresRevisions(t *testing.T) {
+	t.Parallel()
+
+	stderr := &bytes.Buffer{}
+	app := &App{
+		cfg:    config.Config{},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: stderr,
+		store:  credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json")),
+	}
+
+	exitCode := app.runRecipeDiff([]string{testRecipeID, "--from", "1"})
+	if exitCode != exitUsage {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitUsage)
+	}
+	if !strings.Contains(stderr.String(), "--from and --to") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestRunRecipeDiffJSON(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/revisions/diff", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("from"); got != "1" {
+			t.Fatalf("from = %q, want 1", got)
+		}
+		if got := r.URL.Query().Get("to"); got != "3" {
+			t.Fatalf("to = %q, want 3", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"from":1,"to":3,"fields":[{"field":"title","from":"Soup","to":"Stew"}],"tags":{"added":[],"removed":[]},"ingredients":[],"steps":[]}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	store := credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputJSON,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipeDiff([]string{testRecipeID, "--from", "1", "--to", "3"})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+
+	var got client.RecipeRevisionDiff
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(got.Fields) != 1 || got.Fields[0].Field != "title" {
+		t.Fatalf("fields = %+v, want title change", got.Fields)
+	}
+}
+
+func TestRunRecipeDiffParent(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/parent/diff", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"parent_id":"` + testMealPlanRecipeID + `","fields":[{"field":"title","from":"Chili","to":"Chili (no beans)"}],"tags":{"added":[],"removed":[]},"ingredients":[{"position":2,"change":"removed","from":{"position":2,"item_name":"kidney beans"},"to":null}],"steps":[]}`))
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runRecipeDiff([]string{testRecipeID, "--parent", "--from", "1"}); exitCode != exitUsage {
+		t.Fatalf("exit code with --from = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runRecipeDiff([]string{testRecipeID, "--parent"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	out := stdout.String()
+	for _, want := range []string{"Chili (no beans)", "ingredient", "removed", "kidney beans"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunRecipeRevertRequiresYes(t *testing.T) {
+	t.Parallel()
+
+	app := &App{
+		cfg:    config.Config{},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: &bytes.Buffer{},
+		store:  credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json")),
+	}
+
+	exitCode := app.runRecipeRevert([]string{testRecipeID, "--revision", "1"})
+	if exitCode != exitUsage {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitUsage)
+	}
+}
+
+func TestRunRecipeRevertSuccess(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes/"+testRecipeID+"/revisions/2/revert", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want POST", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(client.RecipeDetail{ID: testRecipeID, Title: "Soup"}); err != nil {
+			t.Fatalf("encode response: %v", err)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	store := credentials.NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputJSON,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipeRevert([]string{testRecipeID, "--revision", "2", "--yes"})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+}
+
 func TestRunRecipeInitTemplate(t *testing.T) {
 	t.Parallel()
 
@@ -1322,3 +1788,134 @@ printf '%s' '{"title":"Soup","servings":2,"prep_time_minutes":5,"total_time_minu
 		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
 	}
 }
+
+func TestRunRecipeListSort(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/recipes", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("sort"); got != "-total_time" {
+			t.Fatalf("sort = %q, want -total_time", got)
+		}
+		resp := client.RecipeListResponse{Items: []client.RecipeListItem{}}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, resp)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	credsPath := filepath.Join(t.TempDir(), "credentials.json")
+	store := credentials.NewStore(credsPath)
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputJSON,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipeList([]string{"--sort", "-total_time"})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+}
+
+func TestRunRecipeListRejectsUnknownSort(t *testing.T) {
+	t.Parallel()
+
+	stderr := &bytes.Buffer{}
+	app := &App{
+		cfg:    config.Config{Output: config.OutputJSON, Timeout: 5 * time.Second},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: stderr,
+	}
+
+	exitCode := app.runRecipeList([]string{"--sort", "name"})
+	if exitCode != exitUsage {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitUsage)
+	}
+	if !strings.Contains(stderr.String(), "sort must be one of") {
+		t.Fatalf("stderr = %q, want sort usage error", stderr.String())
+	}
+}
+
+func TestRunRecipeListAdvancedFilters(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/items", func(w http.ResponseWriter, r *http.Request) {
+		resp := []client.Item{{ID: "item-peanut", Name: "Peanuts"}, {ID: "item-peanut-oil", Name: "Peanut oil"}}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, resp)
+	})
+	mux.HandleFunc("/api/v1/recipes", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query["tag_id"]; len(got) != 2 || got[0] != "tag-veg" || got[1] != "tag-quick" {
+			t.Fatalf("tag_id = %v, want [tag-veg tag-quick]", got)
+		}
+		if got := query.Get("tag_mode"); got != "any" {
+			t.Fatalf("tag_mode = %q, want any", got)
+		}
+		if got := query["without_item"]; len(got) != 1 || got[0] != "item-peanut" {
+			t.Fatalf("without_item = %v, want [item-peanut]", got)
+		}
+		if got := query["diet"]; len(got) != 1 || got[0] != "vegetarian" {
+			t.Fatalf("diet = %v, want [vegetarian]", got)
+		}
+		if got := query["exclude_allergen"]; len(got) != 1 || got[0] != "sesame" {
+			t.Fatalf("exclude_allergen = %v, want [sesame]", got)
+		}
+		if got := query.Get("max_total_time"); got != "30" {
+			t.Fatalf("max_total_time = %q, want 30", got)
+		}
+		if got := query.Get("servings_min"); got != "2" {
+			t.Fatalf("servings_min = %q, want 2", got)
+		}
+		resp := client.RecipeListResponse{Items: []client.RecipeListItem{}}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, resp)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	credsPath := filepath.Join(t.TempDir(), "credentials.json")
+	store := credentials.NewStore(credsPath)
+	if err := store.Save(credentials.Credentials{Token: "pat_abc"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	app := &App{
+		cfg: config.Config{
+			APIURL:  server.URL,
+			Output:  config.OutputJSON,
+			Timeout: 5 * time.Second,
+		},
+		stdin:  bytes.NewBufferString(""),
+		stdout: &bytes.Buffer{},
+		stderr: &bytes.Buffer{},
+		store:  store,
+	}
+
+	exitCode := app.runRecipeList([]string{
+		"--tag-id", "tag-veg",
+		"--tag-id", "tag-quick",
+		"--tag-mode", "any",
+		"--without-item", "peanuts",
+		"--diet", "vegetarian",
+		"--exclude-allergen", "sesame",
+		"--max-total-time", "30",
+		"--servings-min", "2",
+	})
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+}
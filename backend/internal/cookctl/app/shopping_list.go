@@ -15,15 +15,17 @@ type shoppingListListFlags struct {
 }
 
 type shoppingListCreateFlags struct {
-	date  string
-	name  string
-	notes string
+	date    string
+	name    string
+	notes   string
+	storeID string
 }
 
 type shoppingListUpdateFlags struct {
-	date  string
-	name  string
-	notes string
+	date    string
+	name    string
+	notes   string
+	storeID string
 }
 
 type shoppingListDeleteFlags struct {
@@ -38,17 +40,21 @@ type shoppingListItemsAddFlags struct {
 }
 
 type shoppingListItemsFromRecipesFlags struct {
-	recipeIDs csvStrings
+	recipeIDs     csvStrings
+	excludePantry bool
 }
 
 type shoppingListItemsFromMealPlanFlags struct {
-	date string
+	date          string
+	excludePantry bool
 }
 
 type shoppingListItemsPurchaseFlags struct {
 	listID    string
 	itemID    string
 	purchased bool
+	restock   bool
+	location  string
 }
 
 type shoppingListItemsDeleteFlags struct {
@@ -71,6 +77,7 @@ func shoppingListCreateFlagSet(out io.Writer) (*flag.FlagSet, *shoppingListCreat
 	flags.StringVar(&opts.date, "date", "", "Shopping list date (YYYY-MM-DD)")
 	flags.StringVar(&opts.name, "name", "", "Shopping list name")
 	flags.StringVar(&opts.notes, "notes", "", "Shopping list notes")
+	flags.StringVar(&opts.storeID, "store", "", "Store id whose aisle layout orders the list")
 	return flags, opts
 }
 
@@ -80,6 +87,7 @@ func shoppingListUpdateFlagSet(out io.Writer) (*flag.FlagSet, *shoppingListUpdat
 	flags.StringVar(&opts.date, "date", "", "Shopping list date (YYYY-MM-DD)")
 	flags.StringVar(&opts.name, "name", "", "Shopping list name")
 	flags.StringVar(&opts.notes, "notes", "", "Shopping list notes")
+	flags.StringVar(&opts.storeID, "store", "", "Store id whose aisle layout orders the list")
 	return flags, opts
 }
 
@@ -108,6 +116,7 @@ func shoppingListItemsFromRecipesFlagSet(out io.Writer) (*flag.FlagSet, *shoppin
 	opts := &shoppingListItemsFromRecipesFlags{}
 	flags := newFlagSet("shopping-list items from-recipes", out, printShoppingListItemsFromRecipesUsage)
 	flags.Var(&opts.recipeIDs, "recipe-id", "Recipe id (repeatable)")
+	flags.BoolVar(&opts.excludePantry, "exclude-pantry", false, "Subtract what is already in the pantry")
 	return flags, opts
 }
 
@@ -115,6 +124,7 @@ func shoppingListItemsFromMealPlanFlagSet(out io.Writer) (*flag.FlagSet, *shoppi
 	opts := &shoppingListItemsFromMealPlanFlags{}
 	flags := newFlagSet("shopping-list items from-meal-plan", out, printShoppingListItemsFromMealPlanUsage)
 	flags.StringVar(&opts.date, "date", "", "Meal plan date (YYYY-MM-DD)")
+	flags.BoolVar(&opts.excludePantry, "exclude-pantry", false, "Subtract what is already in the pantry")
 	return flags, opts
 }
 
@@ -124,6 +134,8 @@ func shoppingListItemsPurchaseFlagSet(out io.Writer) (*flag.FlagSet, *shoppingLi
 	flags.StringVar(&opts.listID, "list-id", "", "Shopping list id")
 	flags.StringVar(&opts.itemID, "item-id", "", "Shopping list item id")
 	flags.BoolVar(&opts.purchased, "purchased", false, "Mark item as purchased")
+	flags.BoolVar(&opts.restock, "restock", false, "Add the purchased quantity to the pantry (requires --purchased)")
+	flags.StringVar(&opts.location, "location", "", "Pantry location for --restock: fridge, freezer, or pantry (default pantry)")
 	return flags, opts
 }
 
@@ -236,7 +248,7 @@ func (a *App) runShoppingListCreate(args []string) int {
 		return exitCode
 	}
 
-	resp, err := api.CreateShoppingList(ctx, listDate.Format(isoDateLayout), opts.name, stringPtrIfNotEmpty(opts.notes))
+	resp, err := api.CreateShoppingList(ctx, listDate.Format(isoDateLayout), opts.name, stringPtrIfNotEmpty(opts.notes), stringPtrIfNotEmpty(opts.storeID))
 	if err != nil {
 		return a.handleAPIError(err)
 	}
@@ -310,7 +322,7 @@ func (a *App) runShoppingListUpdate(args []string) int {
 		return exitCode
 	}
 
-	resp, err := api.UpdateShoppingList(ctx, id, listDate.Format(isoDateLayout), opts.name, stringPtrIfNotEmpty(opts.notes))
+	resp, err := api.UpdateShoppingList(ctx, id, listDate.Format(isoDateLayout), opts.name, stringPtrIfNotEmpty(opts.notes), stringPtrIfNotEmpty(opts.storeID))
 	if err != nil {
 		return a.handleAPIError(err)
 	}
@@ -482,7 +494,7 @@ func (a *App) runShoppingListItemsFromRecipes(args []string) int {
 	}
 
 	resp, exitCode := a.withShoppingListClient(func(ctx context.Context, api *client.Client) (interface{}, error) {
-		return api.AddShoppingListItemsFromRecipes(ctx, listID, ids)
+		return api.AddShoppingListItemsFromRecipes(ctx, listID, ids, opts.excludePantry)
 	})
 	if exitCode != exitOK {
 		return exitCode
@@ -512,7 +524,7 @@ func (a *App) runShoppingListItemsFromMealPlan(args []string) int {
 	}
 
 	resp, exitCode := a.withShoppingListClient(func(ctx context.Context, api *client.Client) (interface{}, error) {
-		return api.AddShoppingListItemsFromMealPlan(ctx, listID, planDate.Format(isoDateLayout))
+		return api.AddShoppingListItemsFromMealPlan(ctx, listID, planDate.Format(isoDateLayout), opts.excludePantry)
 	})
 	if exitCode != exitOK {
 		return exitCode
@@ -540,9 +552,20 @@ func (a *App) runShoppingListItemsPurchase(args []string) int {
 	if opts.itemID == "" {
 		return usageError(a.stderr, "item-id is required")
 	}
+	if opts.restock && !opts.purchased {
+		return usageError(a.stderr, "restock requires purchased")
+	}
+	location := stringPtrIfNotEmpty(strings.TrimSpace(opts.location))
+	if location != nil && !opts.restock {
+		return usageError(a.stderr, "location requires restock")
+	}
 
 	resp, exitCode := a.withShoppingListClient(func(ctx context.Context, api *client.Client) (interface{}, error) {
-		return api.UpdateShoppingListItemPurchase(ctx, opts.listID, opts.itemID, opts.purchased)
+		return api.UpdateShoppingListItemPurchase(ctx, opts.listID, opts.itemID, client.ShoppingListItemPurchaseRequest{
+			IsPurchased:    opts.purchased,
+			RestockPantry:  opts.restock,
+			PantryLocation: location,
+		})
 	})
 	if exitCode != exitOK {
 		return exitCode
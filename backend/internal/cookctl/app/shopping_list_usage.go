@@ -45,6 +45,78 @@ func printItemDeleteUsage(w io.Writer) {
 	})
 }
 
+func printItemMergeUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl item merge <id> --into <target-id> --yes",
+		"Moves recipes, shopping list lines, pantry stock, prices, and aliases to the target item,",
+		"adding quantities where lines collide, then deletes the item and keeps its name as an alias.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := itemMergeFlagSet(out)
+		return flags
+	})
+}
+
+func printItemPriceUsage(w io.Writer) {
+	writeLine(w, "usage: cookctl item price <command> [flags]")
+	printCommandSubcommandsPath(w, "item", "price")
+}
+
+func printItemPriceListUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl item price list <id>",
+	}, itemPriceListFlagSet)
+}
+
+func printItemPriceAddUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl item price add <id> --price <amount> --unit <unit> [--quantity <n>] [--store <name>] [--date <YYYY-MM-DD>]",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := itemPriceAddFlagSet(out)
+		return flags
+	})
+}
+
+func printItemAttributesUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl item attributes <id> [--attribute <name>]... [--clear]",
+		"Shows the item's attributes when no flags are given.",
+		"Attributes: dairy, eggs, fish, gluten, honey, meat, nuts, peanuts, sesame, shellfish, soy.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := itemAttributesFlagSet(out)
+		return flags
+	})
+}
+
+func printItemAliasUsage(w io.Writer) {
+	writeLine(w, "usage: cookctl item alias <command> [flags]")
+	printCommandSubcommandsPath(w, "item", "alias")
+}
+
+func printItemAliasListUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl item alias list <id>",
+	}, itemAliasListFlagSet)
+}
+
+func printItemAliasAddUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl item alias add <id> --name <alias>",
+		"Ingredient names equal to the alias resolve to this item when recipes are saved.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := itemAliasAddFlagSet(out)
+		return flags
+	})
+}
+
+func printItemAliasRemoveUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl item alias rm <id> --alias-id <alias-id> --yes",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := itemAliasRemoveFlagSet(out)
+		return flags
+	})
+}
+
 func printShoppingListUsage(w io.Writer) {
 	printCommandUsage(w, "usage: cookctl shopping-list <command> [flags]", "shopping-list")
 }
@@ -60,7 +132,8 @@ func printShoppingListListUsage(w io.Writer) {
 
 func printShoppingListCreateUsage(w io.Writer) {
 	printUsageWithFlags(w, []string{
-		"usage: cookctl shopping-list create --date <date> --name <name> [--notes <text>]",
+		"usage: cookctl shopping-list create --date <date> --name <name> [--notes <text>] [--store <store-id>]",
+		"Lists with a store are grouped and ordered by that store's aisles.",
 	}, func(out io.Writer) *flag.FlagSet {
 		flags, _ := shoppingListCreateFlagSet(out)
 		return flags
@@ -73,7 +146,8 @@ func printShoppingListGetUsage(w io.Writer) {
 
 func printShoppingListUpdateUsage(w io.Writer) {
 	printUsageWithFlags(w, []string{
-		"usage: cookctl shopping-list update <id> --date <date> --name <name> [--notes <text>]",
+		"usage: cookctl shopping-list update <id> --date <date> --name <name> [--notes <text>] [--store <store-id>]",
+		"Replaces the list details; omitting --store returns the list to the default aisle layout.",
 	}, func(out io.Writer) *flag.FlagSet {
 		flags, _ := shoppingListUpdateFlagSet(out)
 		return flags
@@ -109,7 +183,7 @@ func printShoppingListItemsCreateUsage(w io.Writer) {
 
 func printShoppingListItemsFromRecipesUsage(w io.Writer) {
 	printUsageWithFlags(w, []string{
-		"usage: cookctl shopping-list items from-recipes <list-id> --recipe-id <id> [--recipe-id <id>]",
+		"usage: cookctl shopping-list items from-recipes <list-id> --recipe-id <id> [--recipe-id <id>] [--exclude-pantry]",
 	}, func(out io.Writer) *flag.FlagSet {
 		flags, _ := shoppingListItemsFromRecipesFlagSet(out)
 		return flags
@@ -118,7 +192,7 @@ func printShoppingListItemsFromRecipesUsage(w io.Writer) {
 
 func printShoppingListItemsFromMealPlanUsage(w io.Writer) {
 	printUsageWithFlags(w, []string{
-		"usage: cookctl shopping-list items from-meal-plan <list-id> --date <date>",
+		"usage: cookctl shopping-list items from-meal-plan <list-id> --date <date> [--exclude-pantry]",
 	}, func(out io.Writer) *flag.FlagSet {
 		flags, _ := shoppingListItemsFromMealPlanFlagSet(out)
 		return flags
@@ -127,7 +201,7 @@ func printShoppingListItemsFromMealPlanUsage(w io.Writer) {
 
 func printShoppingListItemsPurchaseUsage(w io.Writer) {
 	printUsageWithFlags(w, []string{
-		"usage: cookctl shopping-list items purchase --list-id <id> --item-id <id> [--purchased]",
+		"usage: cookctl shopping-list items purchase --list-id <id> --item-id <id> [--purchased] [--restock [--location <name>]]",
 	}, func(out io.Writer) *flag.FlagSet {
 		flags, _ := shoppingListItemsPurchaseFlagSet(out)
 		return flags
@@ -142,3 +216,151 @@ func printShoppingListItemsDeleteUsage(w io.Writer) {
 		return flags
 	})
 }
+
+func printPantryUsage(w io.Writer) {
+	printCommandUsage(w, "usage: cookctl pantry <command> [flags]", "pantry")
+}
+
+func printPantryListUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl pantry list [--location <name>]",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := pantryListFlagSet(out)
+		return flags
+	})
+}
+
+func printPantryCreateUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl pantry create --item-id <id> [--quantity <n>] [--unit <unit>] [--location <name>] [--best-before <YYYY-MM-DD>]",
+		"Stock matching an existing entry (item, location, unit, best-before) is added to it.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := pantryCreateFlagSet(out)
+		return flags
+	})
+}
+
+func printPantryUpdateUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl pantry update <id> [--quantity <n>] [--unit <unit>] [--location <name>] [--best-before <YYYY-MM-DD>]",
+		"Replaces the entry's stock details; omitted flags are cleared.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := pantryUpdateFlagSet(out)
+		return flags
+	})
+}
+
+func printPantryDeleteUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl pantry delete <id> --yes",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := pantryDeleteFlagSet(out)
+		return flags
+	})
+}
+
+func printStoreUsage(w io.Writer) {
+	printCommandUsage(w, "usage: cookctl store <command> [flags]", "store")
+}
+
+func printStoreListUsage(w io.Writer) {
+	writeLine(w, "usage: cookctl store list")
+}
+
+func printStoreCreateUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl store create --name <name>",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := storeCreateFlagSet(out)
+		return flags
+	})
+}
+
+func printStoreUpdateUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl store update <id> --name <name>",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := storeUpdateFlagSet(out)
+		return flags
+	})
+}
+
+func printStoreDeleteUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl store delete <id> --yes",
+		"Deletes the store's aisles and item placements; its shopping lists fall back to the default layout.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := storeDeleteFlagSet(out)
+		return flags
+	})
+}
+
+func printStoreAisleUsage(w io.Writer) {
+	writeLine(w, "usage: cookctl store aisle <command> [flags]")
+	printCommandSubcommandsPath(w, "store", "aisle")
+}
+
+func printStoreAisleListUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl store aisle list <store-id>",
+	}, storeAisleListFlagSet)
+}
+
+func printStoreAisleAddUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl store aisle add <store-id> --name <name> [--sort-group <0-2>] [--sort-order <n>] [--numeric-value <n>]",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := storeAisleAddFlagSet(out)
+		return flags
+	})
+}
+
+func printStoreAisleUpdateUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl store aisle update <store-id> --aisle-id <aisle-id> --name <name> [--sort-group <0-2>] [--sort-order <n>] [--numeric-value <n>]",
+		"Replaces the aisle's name and ordering; omitted flags use their defaults.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := storeAisleUpdateFlagSet(out)
+		return flags
+	})
+}
+
+func printStoreAisleRemoveUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl store aisle rm <store-id> --aisle-id <aisle-id> --yes",
+		"Items placed in the aisle lose their placement in the store.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := storeAisleRemoveFlagSet(out)
+		return flags
+	})
+}
+
+func printStoreItemUsage(w io.Writer) {
+	writeLine(w, "usage: cookctl store item <command> [flags]")
+	printCommandSubcommandsPath(w, "store", "item")
+}
+
+func printStoreItemListUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl store item list <store-id>",
+	}, storeItemListFlagSet)
+}
+
+func printStoreItemSetUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl store item set <store-id> --item-id <id> --aisle-id <aisle-id>",
+		"Places the item in one of the store's aisles, replacing any earlier placement.",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := storeItemSetFlagSet(out)
+		return flags
+	})
+}
+
+func printStoreItemRemoveUsage(w io.Writer) {
+	printUsageWithFlags(w, []string{
+		"usage: cookctl store item rm <store-id> --item-id <id> --yes",
+	}, func(out io.Writer) *flag.FlagSet {
+		flags, _ := storeItemRemoveFlagSet(out)
+		return flags
+	})
+}
@@ -0,0 +1,633 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+const (
+	commandStoreAisle  = "aisle"
+	commandStoreItem   = "item"
+	commandStoreAdd    = "add"
+	commandStoreSet    = "set"
+	commandStoreRemove = "rm"
+)
+
+type storeCreateFlags struct {
+	name string
+}
+
+type storeUpdateFlags struct {
+	name string
+}
+
+type storeDeleteFlags struct {
+	yes bool
+}
+
+// storeAisleFlags are shared by store aisle add and update.
+type storeAisleFlags struct {
+	name            string
+	sortGroup       int
+	sortOrder       int
+	numericValueRaw string
+}
+
+type storeAisleUpdateFlags struct {
+	*storeAisleFlags
+	aisleID string
+}
+
+type storeAisleRemoveFlags struct {
+	aisleID string
+	yes     bool
+}
+
+type storeItemSetFlags struct {
+	itemID  string
+	aisleID string
+}
+
+type storeItemRemoveFlags struct {
+	itemID string
+	yes    bool
+}
+
+type storeDeleteResult struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+type storeAisleDeleteResult struct {
+	StoreID string `json:"store_id"`
+	AisleID string `json:"aisle_id"`
+	Deleted bool   `json:"deleted"`
+}
+
+type storeItemDeleteResult struct {
+	StoreID string `json:"store_id"`
+	ItemID  string `json:"item_id"`
+	Deleted bool   `json:"deleted"`
+}
+
+func storeCreateFlagSet(out io.Writer) (*flag.FlagSet, *storeCreateFlags) {
+	opts := &storeCreateFlags{}
+	flags := newFlagSet("store create", out, printStoreCreateUsage)
+	flags.StringVar(&opts.name, "name", "", "Store name")
+	return flags, opts
+}
+
+func storeUpdateFlagSet(out io.Writer) (*flag.FlagSet, *storeUpdateFlags) {
+	opts := &storeUpdateFlags{}
+	flags := newFlagSet("store update", out, printStoreUpdateUsage)
+	flags.StringVar(&opts.name, "name", "", "Store name")
+	return flags, opts
+}
+
+func storeDeleteFlagSet(out io.Writer) (*flag.FlagSet, *storeDeleteFlags) {
+	opts := &storeDeleteFlags{}
+	flags := newFlagSet("store delete", out, printStoreDeleteUsage)
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm store deletion")
+	return flags, opts
+}
+
+func storeAisleListFlagSet(out io.Writer) *flag.FlagSet {
+	return newFlagSet("store aisle list", out, printStoreAisleListUsage)
+}
+
+func storeAisleFlagSet(name string, out io.Writer, usage func(io.Writer)) (*flag.FlagSet, *storeAisleFlags) {
+	opts := &storeAisleFlags{}
+	flags := newFlagSet(name, out, usage)
+	flags.StringVar(&opts.name, "name", "", "Aisle name")
+	flags.IntVar(&opts.sortGroup, "sort-group", 1, "Sort group (0 first, 1 middle, 2 last)")
+	flags.IntVar(&opts.sortOrder, "sort-order", 0, "Order within the sort group")
+	flags.StringVar(&opts.numericValueRaw, "numeric-value", "", "Aisle number, ordering aisles with the same sort order")
+	return flags, opts
+}
+
+func storeAisleAddFlagSet(out io.Writer) (*flag.FlagSet, *storeAisleFlags) {
+	return storeAisleFlagSet("store aisle add", out, printStoreAisleAddUsage)
+}
+
+func storeAisleUpdateFlagSet(out io.Writer) (*flag.FlagSet, *storeAisleUpdateFlags) {
+	flags, aisle := storeAisleFlagSet("store aisle update", out, printStoreAisleUpdateUsage)
+	opts := &storeAisleUpdateFlags{storeAisleFlags: aisle}
+	flags.StringVar(&opts.aisleID, "aisle-id", "", "Aisle id in this store")
+	return flags, opts
+}
+
+func storeAisleRemoveFlagSet(out io.Writer) (*flag.FlagSet, *storeAisleRemoveFlags) {
+	opts := &storeAisleRemoveFlags{}
+	flags := newFlagSet("store aisle rm", out, printStoreAisleRemoveUsage)
+	flags.StringVar(&opts.aisleID, "aisle-id", "", "Aisle id in this store")
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm aisle deletion")
+	return flags, opts
+}
+
+func storeItemListFlagSet(out io.Writer) *flag.FlagSet {
+	return newFlagSet("store item list", out, printStoreItemListUsage)
+}
+
+func storeItemSetFlagSet(out io.Writer) (*flag.FlagSet, *storeItemSetFlags) {
+	opts := &storeItemSetFlags{}
+	flags := newFlagSet("store item set", out, printStoreItemSetUsage)
+	flags.StringVar(&opts.itemID, "item-id", "", "Item id")
+	flags.StringVar(&opts.aisleID, "aisle-id", "", "Aisle id in this store")
+	return flags, opts
+}
+
+func storeItemRemoveFlagSet(out io.Writer) (*flag.FlagSet, *storeItemRemoveFlags) {
+	opts := &storeItemRemoveFlags{}
+	flags := newFlagSet("store item rm", out, printStoreItemRemoveUsage)
+	flags.StringVar(&opts.itemID, "item-id", "", "Item id")
+	flags.BoolVar(&opts.yes, "yes", false, "Confirm placement removal")
+	return flags, opts
+}
+
+// runStore routes store subcommands.
+func (a *App) runStore(args []string) int {
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		printStoreUsage(a.stdout)
+		return exitOK
+	}
+	if len(args) == 0 {
+		printStoreUsage(a.stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case commandList:
+		return a.runStoreList(args[1:])
+	case commandCreate:
+		return a.runStoreCreate(args[1:])
+	case commandUpdate:
+		return a.runStoreUpdate(args[1:])
+	case commandDelete:
+		return a.runStoreDelete(args[1:])
+	case commandStoreAisle:
+		return a.runStoreAisle(args[1:])
+	case commandStoreItem:
+		return a.runStoreItem(args[1:])
+	default:
+		usageErrorf(a.stderr, "unknown store command: %s", args[0])
+		printStoreUsage(a.stderr)
+		return exitUsage
+	}
+}
+
+// runStoreList prints stores by name.
+func (a *App) runStoreList(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreListUsage(a.stdout)
+		return exitOK
+	}
+
+	flags := newFlagSet("store list", a.stderr, printStoreListUsage)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.Stores(ctx)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runStoreCreate creates a store.
+func (a *App) runStoreCreate(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreCreateUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := storeCreateFlagSet(a.stderr)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	name := strings.TrimSpace(opts.name)
+	if name == "" {
+		return usageError(a.stderr, "--name is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.CreateStore(ctx, name)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runStoreUpdate renames a store.
+func (a *App) runStoreUpdate(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreUpdateUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := storeUpdateFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "store id is required")
+	}
+	name := strings.TrimSpace(opts.name)
+	if name == "" {
+		return usageError(a.stderr, "--name is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.UpdateStore(ctx, strings.TrimSpace(id), name)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runStoreDelete deletes a store with its aisles and item placements.
+func (a *App) runStoreDelete(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreDeleteUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := storeDeleteFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "store id is required")
+	}
+	if !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	id = strings.TrimSpace(id)
+	if err := api.DeleteStore(ctx, id); err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, storeDeleteResult{ID: id, Deleted: true})
+}
+
+// runStoreAisle routes store aisle subcommands.
+func (a *App) runStoreAisle(args []string) int {
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		printStoreAisleUsage(a.stdout)
+		return exitOK
+	}
+	if len(args) == 0 {
+		printStoreAisleUsage(a.stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case commandList:
+		return a.runStoreAisleList(args[1:])
+	case commandStoreAdd:
+		return a.runStoreAisleAdd(args[1:])
+	case commandUpdate:
+		return a.runStoreAisleUpdate(args[1:])
+	case commandStoreRemove:
+		return a.runStoreAisleRemove(args[1:])
+	default:
+		usageErrorf(a.stderr, "unknown store aisle command: %s", args[0])
+		printStoreAisleUsage(a.stderr)
+		return exitUsage
+	}
+}
+
+// runStoreAisleList prints a store's aisles in sort order.
+func (a *App) runStoreAisleList(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreAisleListUsage(a.stdout)
+		return exitOK
+	}
+
+	flags := storeAisleListFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "store id is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.StoreAisles(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runStoreAisleAdd adds an aisle to a store's layout.
+func (a *App) runStoreAisleAdd(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreAisleAddUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := storeAisleAddFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "store id is required")
+	}
+	input, err := opts.input()
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.CreateStoreAisle(ctx, strings.TrimSpace(id), input)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runStoreAisleUpdate replaces the name and ordering of an aisle in a store.
+func (a *App) runStoreAisleUpdate(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreAisleUpdateUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := storeAisleUpdateFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "store id is required")
+	}
+	aisleID := strings.TrimSpace(opts.aisleID)
+	if aisleID == "" {
+		return usageError(a.stderr, "--aisle-id is required")
+	}
+	input, err := opts.input()
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.UpdateStoreAisle(ctx, strings.TrimSpace(id), aisleID, input)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runStoreAisleRemove deletes an aisle from a store and the placements in it.
+func (a *App) runStoreAisleRemove(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreAisleRemoveUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := storeAisleRemoveFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "store id is required")
+	}
+	aisleID := strings.TrimSpace(opts.aisleID)
+	if aisleID == "" {
+		return usageError(a.stderr, "--aisle-id is required")
+	}
+	if !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	id = strings.TrimSpace(id)
+	if err := api.DeleteStoreAisle(ctx, id, aisleID); err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, storeAisleDeleteResult{StoreID: id, AisleID: aisleID, Deleted: true})
+}
+
+// runStoreItem routes store item subcommands.
+func (a *App) runStoreItem(args []string) int {
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		printStoreItemUsage(a.stdout)
+		return exitOK
+	}
+	if len(args) == 0 {
+		printStoreItemUsage(a.stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case commandList:
+		return a.runStoreItemList(args[1:])
+	case commandStoreSet:
+		return a.runStoreItemSet(args[1:])
+	case commandStoreRemove:
+		return a.runStoreItemRemove(args[1:])
+	default:
+		usageErrorf(a.stderr, "unknown store item command: %s", args[0])
+		printStoreItemUsage(a.stderr)
+		return exitUsage
+	}
+}
+
+// runStoreItemList prints the items placed in a store's aisles.
+func (a *App) runStoreItemList(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreItemListUsage(a.stdout)
+		return exitOK
+	}
+
+	flags := storeItemListFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "store id is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.StoreItemAisles(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runStoreItemSet places an item in one of a store's aisles.
+func (a *App) runStoreItemSet(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreItemSetUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := storeItemSetFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "store id is required")
+	}
+	itemID := strings.TrimSpace(opts.itemID)
+	if itemID == "" {
+		return usageError(a.stderr, "--item-id is required")
+	}
+	aisleID := strings.TrimSpace(opts.aisleID)
+	if aisleID == "" {
+		return usageError(a.stderr, "--aisle-id is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	resp, err := api.SetStoreItemAisle(ctx, strings.TrimSpace(id), itemID, aisleID)
+	if err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, resp)
+}
+
+// runStoreItemRemove removes an item's placement in a store.
+func (a *App) runStoreItemRemove(args []string) int {
+	if hasHelpFlag(args) {
+		printStoreItemRemoveUsage(a.stdout)
+		return exitOK
+	}
+
+	flags, opts := storeItemRemoveFlagSet(a.stderr)
+	id, err := parseIDArgs(flags, args)
+	if err != nil {
+		return usageError(a.stderr, err.Error())
+	}
+	if id == "" {
+		return usageError(a.stderr, "store id is required")
+	}
+	itemID := strings.TrimSpace(opts.itemID)
+	if itemID == "" {
+		return usageError(a.stderr, "--item-id is required")
+	}
+	if !opts.yes {
+		return usageError(a.stderr, "confirmation required; re-run with --yes")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+
+	api, exitCode := a.authedClient(ctx)
+	if exitCode != exitOK {
+		return exitCode
+	}
+
+	id = strings.TrimSpace(id)
+	if err := api.DeleteStoreItemAisle(ctx, id, itemID); err != nil {
+		return a.handleAPIError(err)
+	}
+	return writeOutput(a.stdout, a.cfg.Output, storeItemDeleteResult{StoreID: id, ItemID: itemID, Deleted: true})
+}
+
+// input validates the aisle flags into a request payload.
+func (opts *storeAisleFlags) input() (client.GroceryAisleInput, error) {
+	name := strings.TrimSpace(opts.name)
+	if name == "" {
+		return client.GroceryAisleInput{}, errors.New("--name is required")
+	}
+	input := client.GroceryAisleInput{
+		Name:      name,
+		SortGroup: opts.sortGroup,
+		SortOrder: opts.sortOrder,
+	}
+	if raw := strings.TrimSpace(opts.numericValueRaw); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return client.GroceryAisleInput{}, errors.New("--numeric-value must be a non-negative integer")
+		}
+		input.NumericValue = &value
+	}
+	return input, nil
+}
@@ -0,0 +1,161 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/saiaj/cooking_app/backend/internal/cookctl/client"
+)
+
+func TestRunStoreAisleAddParsesFlags(t *testing.T) {
+	t.Parallel()
+
+	var got client.GroceryAisleInput
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/stores/store-1/aisles", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want POST", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeTestJSON(t, w, client.GroceryAisle{ID: "aisle-1", Name: got.Name, SortGroup: got.SortGroup, SortOrder: got.SortOrder, NumericValue: got.NumericValue})
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runStore([]string{"aisle", "add", "store-1", "--sort-order", "2"}); exitCode != exitUsage {
+		t.Fatalf("exit code without name = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runStore([]string{"aisle", "add", "store-1", "--name", "Dairy", "--numeric-value", "x"}); exitCode != exitUsage {
+		t.Fatalf("exit code for bad numeric value = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runStore([]string{"aisle", "add", "store-1", "--name", " Dairy ", "--sort-order", "2", "--numeric-value", "7"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if got.Name != "Dairy" || got.SortGroup != 1 || got.SortOrder != 2 || got.NumericValue == nil || *got.NumericValue != 7 {
+		t.Fatalf("payload = %+v", got)
+	}
+	if !strings.Contains(stdout.String(), "SORT_GROUP") || !strings.Contains(stdout.String(), "Dairy") {
+		t.Fatalf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestRunStoreAisleUpdateAndRemoveUseStorePath(t *testing.T) {
+	t.Parallel()
+
+	var updated client.GroceryAisleInput
+	deleted := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/stores/store-1/aisles/aisle-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			writeTestJSON(t, w, client.GroceryAisle{ID: "aisle-1", Name: updated.Name, SortGroup: updated.SortGroup, SortOrder: updated.SortOrder})
+		case http.MethodDelete:
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("method = %s", r.Method)
+		}
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runStore([]string{"aisle", "update", "store-1", "--name", "Bread"}); exitCode != exitUsage {
+		t.Fatalf("exit code without aisle id = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runStore([]string{"aisle", "update", "store-1", "--aisle-id", "aisle-1", "--name", "Bread", "--sort-order", "3"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if updated.Name != "Bread" || updated.SortOrder != 3 {
+		t.Fatalf("payload = %+v", updated)
+	}
+	if exitCode := app.runStore([]string{"aisle", "rm", "store-1", "--aisle-id", "aisle-1"}); exitCode != exitUsage {
+		t.Fatalf("exit code without --yes = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runStore([]string{"aisle", "rm", "store-1", "--aisle-id", "aisle-1", "--yes"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !deleted || !strings.Contains(stdout.String(), "AISLE_ID") {
+		t.Fatalf("deleted = %t, output = %q", deleted, stdout.String())
+	}
+}
+
+func TestRunStoreItemSetAndRemove(t *testing.T) {
+	t.Parallel()
+
+	var aisleID string
+	deleted := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/stores/store-1/items/item-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			var payload struct {
+				AisleID string `json:"aisle_id"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			aisleID = payload.AisleID
+			w.Header().Set("Content-Type", "application/json")
+			writeTestJSON(t, w, client.StoreItemAisle{ItemID: "item-1", ItemName: "Milk", Aisle: client.GroceryAisle{ID: payload.AisleID, Name: "Dairy"}})
+		case http.MethodDelete:
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	})
+	app, stdout := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runStore([]string{"item", "set", "store-1", "--item-id", "item-1"}); exitCode != exitUsage {
+		t.Fatalf("exit code without aisle id = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runStore([]string{"item", "set", "store-1", "--item-id", "item-1", "--aisle-id", "aisle-1"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if aisleID != "aisle-1" || !strings.Contains(stdout.String(), "Milk") {
+		t.Fatalf("aisle id = %q, output = %q", aisleID, stdout.String())
+	}
+	if exitCode := app.runStore([]string{"item", "rm", "store-1", "--item-id", "item-1"}); exitCode != exitUsage {
+		t.Fatalf("exit code without yes = %d, want %d", exitCode, exitUsage)
+	}
+	if exitCode := app.runStore([]string{"item", "rm", "store-1", "--item-id", "item-1", "--yes"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if !deleted {
+		t.Fatalf("placement was not removed")
+	}
+}
+
+func TestRunShoppingListCreateSendsStore(t *testing.T) {
+	t.Parallel()
+
+	var storeID *string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/shopping-lists", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			StoreID *string `json:"store_id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		storeID = payload.StoreID
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		writeTestJSON(t, w, client.ShoppingList{ID: "list-1", ListDate: "2025-02-09", Name: "Shop", StoreID: payload.StoreID})
+	})
+	app, _ := newRecipePersonalTestApp(t, mux)
+
+	if exitCode := app.runShoppingList([]string{"create", "--date", "2025-02-09", "--name", "Shop", "--store", "store-1"}); exitCode != exitOK {
+		t.Fatalf("exit code = %d, want %d", exitCode, exitOK)
+	}
+	if storeID == nil || *storeID != "store-1" {
+		t.Fatalf("store_id = %v, want store-1", storeID)
+	}
+}
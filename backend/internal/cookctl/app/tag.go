@@ -59,6 +59,8 @@ func (a *App) runTag(args []string) int {
 		return a.runTagUpdate(args[1:])
 	case commandDelete:
 		return a.runTagDelete(args[1:])
+	case commandMerge:
+		return a.runTagMerge(args[1:])
 	default:
 		usageErrorf(a.stderr, "unknown tag command: %s", args[0])
 		printTagUsage(a.stderr)
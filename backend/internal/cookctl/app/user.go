@@ -54,6 +54,8 @@ func (a *App) runUser(args []string) int {
 		return a.runUserCreate(args[1:])
 	case "deactivate":
 		return a.runUserDeactivate(args[1:])
+	case commandRestrictions:
+		return a.runUserRestrictions(args[1:])
 	default:
 		usageErrorf(a.stderr, "unknown user command: %s", args[0])
 		printUserUsage(a.stderr)
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -67,6 +68,20 @@ type RecipeBook struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// TagMergeResult reports a merge into the kept tag.
+type TagMergeResult struct {
+	Tag          Tag    `json:"tag"`
+	MergedTagID  string `json:"merged_tag_id"`
+	RecipesMoved int    `json:"recipes_moved"`
+}
+
+// RecipeBookMergeResult reports a merge into the kept recipe book.
+type RecipeBookMergeResult struct {
+	RecipeBook         RecipeBook `json:"recipe_book"`
+	MergedRecipeBookID string     `json:"merged_recipe_book_id"`
+	RecipesMoved       int        `json:"recipes_moved"`
+}
+
 // User represents a user in the system.
 type User struct {
 	ID          string    `json:"id"`
@@ -76,6 +91,15 @@ type User struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+// UserDietaryRestrictions lists the diets a user follows and the allergens
+// they avoid.
+type UserDietaryRestrictions struct {
+	UserID    string     `json:"user_id"`
+	Diets     []string   `json:"diets"`
+	Allergens []string   `json:"allergens"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
 // RecipeTag represents a tag attached to a recipe.
 type RecipeTag struct {
 	ID   string `json:"id"`
@@ -91,6 +115,28 @@ type GroceryAisle struct {
 	NumericValue *int   `json:"numeric_value"`
 }
 
+// GroceryAisleInput creates or updates an aisle.
+type GroceryAisleInput struct {
+	Name         string `json:"name"`
+	SortGroup    int    `json:"sort_group"`
+	SortOrder    int    `json:"sort_order"`
+	NumericValue *int   `json:"numeric_value"`
+}
+
+// Store is a store with its own aisle layout.
+type Store struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StoreItemAisle is the aisle an item sits in at a store.
+type StoreItemAisle struct {
+	ItemID   string       `json:"item_id"`
+	ItemName string       `json:"item_name"`
+	Aisle    GroceryAisle `json:"aisle"`
+}
+
 // Item represents a structured ingredient item.
 type Item struct {
 	ID       string        `json:"id"`
@@ -99,25 +145,125 @@ type Item struct {
 	Aisle    *GroceryAisle `json:"aisle"`
 }
 
+// ItemPriceRequest records what quantity of unit cost. Nil fields are
+// omitted; the server defaults priced_on to today.
+type ItemPriceRequest struct {
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit"`
+	Store    *string `json:"store,omitempty"`
+	PricedOn *string `json:"priced_on,omitempty"`
+}
+
+// ItemPrice is one entry in an item's price history.
+type ItemPrice struct {
+	ID        string    `json:"id"`
+	ItemID    string    `json:"item_id"`
+	Price     float64   `json:"price"`
+	Quantity  float64   `json:"quantity"`
+	Unit      string    `json:"unit"`
+	UnitPrice float64   `json:"unit_price"`
+	Store     *string   `json:"store"`
+	PricedOn  string    `json:"priced_on"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ItemAlias is another name an item goes by.
+type ItemAlias struct {
+	ID        string    `json:"id"`
+	ItemID    string    `json:"item_id"`
+	Alias     string    `json:"alias"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ItemMergeResult reports what moved onto the kept item. Combined lines were
+// added onto one of the kept item's existing lines.
+type ItemMergeResult struct {
+	Item                      Item   `json:"item"`
+	MergedItemID              string `json:"merged_item_id"`
+	RecipeIngredientsMoved    int    `json:"recipe_ingredients_moved"`
+	ShoppingListItemsMoved    int    `json:"shopping_list_items_moved"`
+	ShoppingListItemsCombined int    `json:"shopping_list_items_combined"`
+	PantryItemsMoved          int    `json:"pantry_items_moved"`
+	PantryItemsCombined       int    `json:"pantry_items_combined"`
+}
+
+// ItemAttributes lists what an item contains for allergen and diet purposes.
+type ItemAttributes struct {
+	ItemID     string   `json:"item_id"`
+	Attributes []string `json:"attributes"`
+	Classified bool     `json:"classified"`
+}
+
 // ShoppingList represents a shopping list summary.
 type ShoppingList struct {
 	ID        string    `json:"id"`
 	ListDate  string    `json:"list_date"`
 	Name      string    `json:"name"`
 	Notes     *string   `json:"notes"`
+	StoreID   *string   `json:"store_id"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // ShoppingListItem represents an item on a shopping list.
 type ShoppingListItem struct {
-	ID           string     `json:"id"`
-	Item         Item       `json:"item"`
-	Quantity     *float64   `json:"quantity"`
-	QuantityText *string    `json:"quantity_text"`
-	Unit         *string    `json:"unit"`
-	IsPurchased  bool       `json:"is_purchased"`
-	PurchasedAt  *time.Time `json:"purchased_at"`
+	ID            string     `json:"id"`
+	Item          Item       `json:"item"`
+	Quantity      *float64   `json:"quantity"`
+	QuantityText  *string    `json:"quantity_text"`
+	Unit          *string    `json:"unit"`
+	IsPurchased   bool       `json:"is_purchased"`
+	PurchasedAt   *time.Time `json:"purchased_at"`
+	EstimatedCost *float64   `json:"estimated_cost"`
+	// PantryItem is set when a purchase restocked the pantry.
+	PantryItem *PantryItem `json:"pantry_item,omitempty"`
+}
+
+// ShoppingListItemPurchaseRequest marks a list item purchased or not.
+// RestockPantry adds the purchased quantity to the pantry at PantryLocation.
+type ShoppingListItemPurchaseRequest struct {
+	IsPurchased    bool    `json:"is_purchased"`
+	RestockPantry  bool    `json:"restock_pantry,omitempty"`
+	PantryLocation *string `json:"pantry_location,omitempty"`
+}
+
+// PantryItemInput describes stock on hand. A nil quantity means the amount is
+// unknown; the server defaults location to pantry.
+type PantryItemInput struct {
+	ItemID     string   `json:"item_id,omitempty"`
+	Quantity   *float64 `json:"quantity"`
+	Unit       *string  `json:"unit"`
+	Location   *string  `json:"location"`
+	BestBefore *string  `json:"best_before"`
+}
+
+// PantryItem is one pantry entry.
+type PantryItem struct {
+	ID         string    `json:"id"`
+	ItemID     string    `json:"item_id"`
+	ItemName   string    `json:"item_name"`
+	Quantity   *float64  `json:"quantity"`
+	Unit       *string   `json:"unit"`
+	Location   string    `json:"location"`
+	BestBefore *string   `json:"best_before"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CostGap names an item left out of a cost estimate and why.
+type CostGap struct {
+	IngredientID string `json:"ingredient_id,omitempty"`
+	ItemID       string `json:"item_id,omitempty"`
+	ItemName     string `json:"item_name"`
+	Reason       string `json:"reason"`
+}
+
+// ShoppingListCost is the estimated total of a shopping list.
+type ShoppingListCost struct {
+	Total     float64   `json:"total"`
+	Complete  bool      `json:"complete"`
+	Uncounted []CostGap `json:"uncounted"`
 }
 
 // ShoppingListDetail represents a shopping list with its items.
@@ -126,44 +272,117 @@ type ShoppingListDetail struct {
 	ListDate  string             `json:"list_date"`
 	Name      string             `json:"name"`
 	Notes     *string            `json:"notes"`
+	StoreID   *string            `json:"store_id"`
 	Items     []ShoppingListItem `json:"items"`
+	Cost      *ShoppingListCost  `json:"cost,omitempty"`
 	CreatedAt time.Time          `json:"created_at"`
 	UpdatedAt time.Time          `json:"updated_at"`
 }
 
 // RecipeListItem is a summary of a recipe for list responses.
 type RecipeListItem struct {
-	ID               string      `json:"id"`
-	Title            string      `json:"title"`
-	Servings         int         `json:"servings"`
-	PrepTimeMinutes  int         `json:"prep_time_minutes"`
-	TotalTimeMinutes int         `json:"total_time_minutes"`
-	SourceURL        *string     `json:"source_url"`
-	Notes            *string     `json:"notes"`
-	RecipeBookID     *string     `json:"recipe_book_id"`
-	Tags             []RecipeTag `json:"tags"`
-	DeletedAt        *time.Time  `json:"deleted_at"`
-	UpdatedAt        time.Time   `json:"updated_at"`
+	ID               string        `json:"id"`
+	Title            string        `json:"title"`
+	Servings         int           `json:"servings"`
+	PrepTimeMinutes  int           `json:"prep_time_minutes"`
+	TotalTimeMinutes int           `json:"total_time_minutes"`
+	SourceURL        *string       `json:"source_url"`
+	Notes            *string       `json:"notes"`
+	RecipeBookID     *string       `json:"recipe_book_id"`
+	Tags             []RecipeTag   `json:"tags"`
+	ImageURL         *string       `json:"image_url"`
+	ThumbnailURL     *string       `json:"thumbnail_url"`
+	IsFavorite       bool          `json:"is_favorite"`
+	DeletedAt        *time.Time    `json:"deleted_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+	LastCookedAt     *time.Time    `json:"last_cooked_at"`
+	TimesCooked      int           `json:"times_cooked"`
+	AvgRating        *float64      `json:"avg_rating"`
+	Allergens        []string      `json:"allergens"`
+	Diets            []string      `json:"diets"`
+	Unclassified     []DietaryItem `json:"unclassified_items"`
 }
 
 // RecipeIngredient represents an ingredient line on a recipe detail.
 type RecipeIngredient struct {
-	ID           string   `json:"id"`
-	Position     int      `json:"position"`
-	Quantity     *float64 `json:"quantity"`
-	QuantityText *string  `json:"quantity_text"`
-	Unit         *string  `json:"unit"`
-	Item         Item     `json:"item"`
-	Prep         *string  `json:"prep"`
-	Notes        *string  `json:"notes"`
-	OriginalText *string  `json:"original_text"`
+	ID           string           `json:"id"`
+	Position     int              `json:"position"`
+	GroupName    *string          `json:"group_name"`
+	Quantity     *float64         `json:"quantity"`
+	QuantityText *string          `json:"quantity_text"`
+	Unit         *string          `json:"unit"`
+	Item         *Item            `json:"item"`
+	SubRecipe    *RecipeSubRecipe `json:"sub_recipe"`
+	Prep         *string          `json:"prep"`
+	Notes        *string          `json:"notes"`
+	OriginalText *string          `json:"original_text"`
+}
+
+// RecipeSubRecipe represents a recipe used as an ingredient of another recipe.
+type RecipeSubRecipe struct {
+	ID          string             `json:"id"`
+	Title       string             `json:"title"`
+	Servings    int                `json:"servings"`
+	Deleted     bool               `json:"deleted"`
+	Ingredients []RecipeIngredient `json:"ingredients"`
 }
 
 // RecipeStep represents a recipe instruction step.
 type RecipeStep struct {
-	ID          string `json:"id"`
-	StepNumber  int    `json:"step_number"`
-	Instruction string `json:"instruction"`
+	ID                 string  `json:"id"`
+	StepNumber         int     `json:"step_number"`
+	GroupName          *string `json:"group_name"`
+	Instruction        string  `json:"instruction"`
+	DurationSeconds    *int    `json:"duration_seconds"`
+	DurationMaxSeconds *int    `json:"duration_max_seconds"`
+	Temperature        *int    `json:"temperature"`
+	TemperatureUnit    *string `json:"temperature_unit"`
+}
+
+// RecipeImage represents a photo attached to a recipe or one of its steps.
+type RecipeImage struct {
+	ID           string    `json:"id"`
+	StepNumber   *int      `json:"step_number"`
+	ContentType  string    `json:"content_type"`
+	ByteSize     int64     `json:"byte_size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	CreatedAt    time.Time `json:"created_at"`
+	CreatedBy    string    `json:"created_by"`
+}
+
+// NutritionFacts holds calories, macronutrients, fiber, and sodium.
+type NutritionFacts struct {
+	Calories float64 `json:"calories"`
+	ProteinG float64 `json:"protein_g"`
+	FatG     float64 `json:"fat_g"`
+	CarbsG   float64 `json:"carbs_g"`
+	FiberG   float64 `json:"fiber_g"`
+	SodiumMg float64 `json:"sodium_mg"`
+}
+
+// RecipeNutritionGap names an ingredient left out of recipe nutrition and why.
+type RecipeNutritionGap struct {
+	IngredientID string `json:"ingredient_id"`
+	ItemName     string `json:"item_name"`
+	Reason       string `json:"reason"`
+}
+
+// RecipeNutrition represents computed per-serving nutrition for a recipe.
+type RecipeNutrition struct {
+	PerServing NutritionFacts       `json:"per_serving"`
+	Complete   bool                 `json:"complete"`
+	Uncounted  []RecipeNutritionGap `json:"uncounted"`
+}
+
+// RecipeCost represents a recipe's estimated cost from item prices.
+type RecipeCost struct {
+	Total      float64   `json:"total"`
+	PerServing float64   `json:"per_serving"`
+	Complete   bool      `json:"complete"`
+	Uncounted  []CostGap `json:"uncounted"`
 }
 
 // RecipeDetail represents a full recipe detail response.
@@ -171,19 +390,80 @@ type RecipeDetail struct {
 	ID               string             `json:"id"`
 	Title            string             `json:"title"`
 	Servings         int                `json:"servings"`
+	ScaledFrom       *int               `json:"scaled_from_servings,omitempty"`
 	PrepTimeMinutes  int                `json:"prep_time_minutes"`
 	TotalTimeMinutes int                `json:"total_time_minutes"`
 	SourceURL        *string            `json:"source_url"`
 	Notes            *string            `json:"notes"`
 	RecipeBookID     *string            `json:"recipe_book_id"`
+	ParentRecipe     *RecipeRef         `json:"parent_recipe"`
+	Variants         []RecipeRef        `json:"variants"`
 	Tags             []RecipeTag        `json:"tags"`
 	Ingredients      []RecipeIngredient `json:"ingredients"`
 	Steps            []RecipeStep       `json:"steps"`
+	Images           []RecipeImage      `json:"images"`
+	Nutrition        *RecipeNutrition   `json:"nutrition,omitempty"`
+	Cost             *RecipeCost        `json:"cost,omitempty"`
+	IsFavorite       bool               `json:"is_favorite"`
+	PersonalNote     *string            `json:"personal_note"`
 	CreatedAt        time.Time          `json:"created_at"`
 	CreatedBy        string             `json:"created_by"`
 	UpdatedAt        time.Time          `json:"updated_at"`
 	UpdatedBy        string             `json:"updated_by"`
 	DeletedAt        *time.Time         `json:"deleted_at"`
+	LastCookedAt     *time.Time         `json:"last_cooked_at"`
+	TimesCooked      int                `json:"times_cooked"`
+	AvgRating        *float64           `json:"avg_rating"`
+	Allergens        []string           `json:"allergens"`
+	Diets            []string           `json:"diets"`
+	Unclassified     []DietaryItem      `json:"unclassified_items"`
+	// IngredientMatches is only returned by create, update, and import.
+	IngredientMatches []RecipeIngredientMatch `json:"ingredient_matches,omitempty"`
+}
+
+// RecipeIngredientMatch is an ingredient name the server resolved to an
+// existing item by alias, singular/plural form, or similarity.
+type RecipeIngredientMatch struct {
+	Position      int      `json:"position"`
+	RequestedName string   `json:"requested_name"`
+	ItemID        string   `json:"item_id"`
+	ItemName      string   `json:"item_name"`
+	Match         string   `json:"match"`
+	Similarity    *float64 `json:"similarity"`
+}
+
+// RecipeCookRequest records that a recipe was cooked. Nil fields are omitted;
+// the server defaults cooked_at to now.
+type RecipeCookRequest struct {
+	CookedAt *string `json:"cooked_at,omitempty"`
+	Servings *int    `json:"servings,omitempty"`
+	Rating   *int    `json:"rating,omitempty"`
+	Notes    *string `json:"notes,omitempty"`
+}
+
+// RecipeCookEvent represents one entry in a recipe's cook log.
+type RecipeCookEvent struct {
+	ID        string    `json:"id"`
+	RecipeID  string    `json:"recipe_id"`
+	CookedAt  time.Time `json:"cooked_at"`
+	Servings  *int      `json:"servings"`
+	Rating    *int      `json:"rating"`
+	Notes     *string   `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
+
+// RecipePersonalNoteRequest sets the caller's private note on a recipe.
+type RecipePersonalNoteRequest struct {
+	Note string `json:"note"`
+}
+
+// RecipePersonalNote is the caller's private note on a recipe.
+type RecipePersonalNote struct {
+	RecipeID  string    `json:"recipe_id"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // RecipeListResponse represents the paginated recipe list response.
@@ -192,6 +472,150 @@ type RecipeListResponse struct {
 	NextCursor *string          `json:"next_cursor"`
 }
 
+// RecipeDuplicateParams filters duplicate recipe detection.
+type RecipeDuplicateParams struct {
+	RecipeID string
+	// MinScore overrides the server default (0.5) when set.
+	MinScore *float64
+	Limit    int
+}
+
+// RecipeRef names a recipe.
+type RecipeRef struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// RecipeDuplicate is a pair of recipes that are likely the same dish.
+type RecipeDuplicate struct {
+	Recipe            RecipeRef `json:"recipe"`
+	Duplicate         RecipeRef `json:"duplicate"`
+	Score             float64   `json:"score"`
+	TitleSimilarity   float64   `json:"title_similarity"`
+	IngredientOverlap float64   `json:"ingredient_overlap"`
+}
+
+// RecipeMatchRequest lists the items on hand by id or name. MaxMissing and
+// Limit are left to the server when unset.
+type RecipeMatchRequest struct {
+	ItemIDs       []string `json:"item_ids,omitempty"`
+	ItemNames     []string `json:"item_names,omitempty"`
+	IgnoreStaples bool     `json:"ignore_staples,omitempty"`
+	MaxMissing    *int     `json:"max_missing,omitempty"`
+	Limit         int      `json:"limit,omitempty"`
+}
+
+// RecipeMatchItem names an item in a recipe match.
+type RecipeMatchItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// RecipeMatch is a recipe ranked by how many of its items are on hand.
+type RecipeMatch struct {
+	ID              string            `json:"id"`
+	Title           string            `json:"title"`
+	IngredientCount int               `json:"ingredient_count"`
+	HaveCount       int               `json:"have_count"`
+	Coverage        float64           `json:"coverage"`
+	Missing         []RecipeMatchItem `json:"missing"`
+}
+
+// RecipeMatchResult holds ranked recipes plus the names that matched no item.
+type RecipeMatchResult struct {
+	Items        []RecipeMatchItem `json:"items"`
+	UnknownNames []string          `json:"unknown_names"`
+	Recipes      []RecipeMatch     `json:"recipes"`
+}
+
+// RecipeMergeResult reports a merge into the kept recipe.
+type RecipeMergeResult struct {
+	Recipe               RecipeDetail `json:"recipe"`
+	MergedRecipeID       string       `json:"merged_recipe_id"`
+	TagsMoved            int          `json:"tags_moved"`
+	MealPlanEntriesMoved int          `json:"meal_plan_entries_moved"`
+	SubRecipeLinesMoved  int          `json:"sub_recipe_lines_moved"`
+	VariantsMoved        int          `json:"variants_moved"`
+}
+
+// RecipeShare is a public, read-only share link for a recipe. Token and
+// the paths are only returned when the share is created.
+type RecipeShare struct {
+	ID        string     `json:"id"`
+	RecipeID  string     `json:"recipe_id"`
+	Token     string     `json:"token,omitempty"`
+	Path      string     `json:"path,omitempty"`
+	APIPath   string     `json:"api_path,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// RecipeRevisionSummary represents one entry in a recipe's revision history.
+type RecipeRevisionSummary struct {
+	Revision  int       `json:"revision"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
+
+// RecipeRevision represents a stored recipe snapshot.
+type RecipeRevision struct {
+	Revision  int             `json:"revision"`
+	CreatedAt time.Time       `json:"created_at"`
+	CreatedBy string          `json:"created_by"`
+	Recipe    json.RawMessage `json:"recipe"`
+}
+
+// RecipeFieldChange represents a changed scalar recipe field.
+type RecipeFieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// RecipeTagsDiff lists tag ids added or removed between revisions.
+type RecipeTagsDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// RecipeIngredientChange represents an ingredient line that differs between revisions.
+type RecipeIngredientChange struct {
+	Position int             `json:"position"`
+	Change   string          `json:"change"`
+	From     json.RawMessage `json:"from"`
+	To       json.RawMessage `json:"to"`
+}
+
+// RecipeStepChange represents a step that differs between revisions.
+type RecipeStepChange struct {
+	StepNumber    int     `json:"step_number"`
+	Change        string  `json:"change"`
+	From          *string `json:"from"`
+	To            *string `json:"to"`
+	FromGroupName *string `json:"from_group_name"`
+	ToGroupName   *string `json:"to_group_name"`
+}
+
+// RecipeRevisionDiff represents the structured diff between two revisions.
+type RecipeRevisionDiff struct {
+	From        int                      `json:"from"`
+	To          int                      `json:"to"`
+	Fields      []RecipeFieldChange      `json:"fields"`
+	Tags        RecipeTagsDiff           `json:"tags"`
+	Ingredients []RecipeIngredientChange `json:"ingredients"`
+	Steps       []RecipeStepChange       `json:"steps"`
+}
+
+// RecipeParentDiff compares a recipe's parent ("from") with the recipe ("to").
+type RecipeParentDiff struct {
+	ParentID    string                   `json:"parent_id"`
+	Fields      []RecipeFieldChange      `json:"fields"`
+	Tags        RecipeTagsDiff           `json:"tags"`
+	Ingredients []RecipeIngredientChange `json:"ingredients"`
+	Steps       []RecipeStepChange       `json:"steps"`
+}
+
 // MealPlanRecipe represents a recipe summary attached to a meal plan entry.
 type MealPlanRecipe struct {
 	ID    string `json:"id"`
@@ -202,6 +626,25 @@ type MealPlanRecipe struct {
 type MealPlanEntry struct {
 	Date   string         `json:"date"`
 	Recipe MealPlanRecipe `json:"recipe"`
+	// Warnings is only returned when creating an entry.
+	Warnings []DietaryConflict `json:"warnings,omitempty"`
+}
+
+// DietaryConflict explains why a recipe does not fit a declared diet or
+// allergen restriction, or for kind "unknown" which unclassified items keep
+// it from being checked.
+type DietaryConflict struct {
+	Kind        string        `json:"kind"`
+	Restriction string        `json:"restriction"`
+	Attributes  []string      `json:"attributes"`
+	Items       []DietaryItem `json:"items"`
+	Message     string        `json:"message"`
+}
+
+// DietaryItem is an item whose allergen and diet attributes were never set.
+type DietaryItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 // MealPlanListResponse represents the meal plan entries in a date range.
@@ -211,12 +654,23 @@ type MealPlanListResponse struct {
 
 // RecipeListParams defines optional filters for listing recipes.
 type RecipeListParams struct {
-	Query          string
-	BookID         string
-	TagID          string
-	IncludeDeleted bool
-	Limit          int
-	Cursor         string
+	Query            string
+	BookID           string
+	TagIDs           []string
+	TagMode          string
+	WithItemIDs      []string
+	WithoutItemIDs   []string
+	Diets            []string
+	ExcludeAllergens []string
+	MaxTotalTime     int
+	MaxPrepTime      int
+	ServingsMin      int
+	ServingsMax      int
+	IncludeDeleted   bool
+	Favorites        bool
+	Limit            int
+	Cursor           string
+	Sort             string
 }
 
 // ItemListParams defines optional filters for listing items.
@@ -402,6 +856,16 @@ func (c *Client) DeleteTag(ctx context.Context, id string) error {
 	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
 }
 
+// MergeTag moves id's recipes to targetID and deletes id.
+func (c *Client) MergeTag(ctx context.Context, id, targetID string) (TagMergeResult, error) {
+	path := fmt.Sprintf("/api/v1/tags/%s/merge-into/%s", url.PathEscape(id), url.PathEscape(targetID))
+	var out TagMergeResult
+	if err := c.doJSON(ctx, http.MethodPost, path, nil, &out); err != nil {
+		return TagMergeResult{}, err
+	}
+	return out, nil
+}
+
 // RecipeBooks lists recipe books.
 func (c *Client) RecipeBooks(ctx context.Context) ([]RecipeBook, error) {
 	var out []RecipeBook
@@ -446,6 +910,16 @@ func (c *Client) DeleteRecipeBook(ctx context.Context, id string) error {
 	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
 }
 
+// MergeRecipeBook moves id's recipes to targetID and deletes id.
+func (c *Client) MergeRecipeBook(ctx context.Context, id, targetID string) (RecipeBookMergeResult, error) {
+	path := fmt.Sprintf("/api/v1/recipe-books/%s/merge-into/%s", url.PathEscape(id), url.PathEscape(targetID))
+	var out RecipeBookMergeResult
+	if err := c.doJSON(ctx, http.MethodPost, path, nil, &out); err != nil {
+		return RecipeBookMergeResult{}, err
+	}
+	return out, nil
+}
+
 // Users lists users.
 func (c *Client) Users(ctx context.Context) ([]User, error) {
 	var out []User
@@ -479,6 +953,33 @@ func (c *Client) DeactivateUser(ctx context.Context, id string) error {
 	return c.doJSON(ctx, http.MethodPut, path, nil, nil)
 }
 
+// UserDietaryRestrictions fetches the restrictions a user has declared.
+func (c *Client) UserDietaryRestrictions(ctx context.Context, id string) (UserDietaryRestrictions, error) {
+	path := fmt.Sprintf("/api/v1/users/%s/dietary-restrictions", url.PathEscape(id))
+	var out UserDietaryRestrictions
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return UserDietaryRestrictions{}, err
+	}
+	return out, nil
+}
+
+// SetUserDietaryRestrictions replaces the restrictions a user has declared.
+func (c *Client) SetUserDietaryRestrictions(ctx context.Context, id string, diets, allergens []string) (UserDietaryRestrictions, error) {
+	path := fmt.Sprintf("/api/v1/users/%s/dietary-restrictions", url.PathEscape(id))
+	payload := struct {
+		Diets     []string `json:"diets"`
+		Allergens []string `json:"allergens"`
+	}{
+		Diets:     diets,
+		Allergens: allergens,
+	}
+	var out UserDietaryRestrictions
+	if err := c.doJSON(ctx, http.MethodPut, path, payload, &out); err != nil {
+		return UserDietaryRestrictions{}, err
+	}
+	return out, nil
+}
+
 // Recipes lists recipes with optional filters.
 func (c *Client) Recipes(ctx context.Context, params RecipeListParams) (RecipeListResponse, error) {
 	query := url.Values{}
@@ -488,18 +989,51 @@ func (c *Client) Recipes(ctx context.Context, params RecipeListParams) (RecipeLi
 	if params.BookID != "" {
 		query.Set("book_id", params.BookID)
 	}
-	if params.TagID != "" {
-		query.Set("tag_id", params.TagID)
+	for _, id := range params.TagIDs {
+		query.Add("tag_id", id)
+	}
+	if params.TagMode != "" {
+		query.Set("tag_mode", params.TagMode)
+	}
+	for _, id := range params.WithItemIDs {
+		query.Add("with_item", id)
+	}
+	for _, id := range params.WithoutItemIDs {
+		query.Add("without_item", id)
+	}
+	for _, diet := range params.Diets {
+		query.Add("diet", diet)
+	}
+	for _, allergen := range params.ExcludeAllergens {
+		query.Add("exclude_allergen", allergen)
+	}
+	if params.MaxTotalTime > 0 {
+		query.Set("max_total_time", fmt.Sprintf("%d", params.MaxTotalTime))
+	}
+	if params.MaxPrepTime > 0 {
+		query.Set("max_prep_time", fmt.Sprintf("%d", params.MaxPrepTime))
+	}
+	if params.ServingsMin > 0 {
+		query.Set("servings_min", fmt.Sprintf("%d", params.ServingsMin))
+	}
+	if params.ServingsMax > 0 {
+		query.Set("servings_max", fmt.Sprintf("%d", params.ServingsMax))
 	}
 	if params.IncludeDeleted {
 		query.Set("include_deleted", "true")
 	}
+	if params.Favorites {
+		query.Set("favorites", "true")
+	}
 	if params.Limit > 0 {
 		query.Set("limit", fmt.Sprintf("%d", params.Limit))
 	}
 	if params.Cursor != "" {
 		query.Set("cursor", params.Cursor)
 	}
+	if params.Sort != "" {
+		query.Set("sort", params.Sort)
+	}
 
 	var out RecipeListResponse
 	if err := c.doJSONWithQuery(ctx, "/api/v1/recipes", query, &out); err != nil {
@@ -603,6 +1137,93 @@ func (c *Client) DeleteItem(ctx context.Context, id string) error {
 	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
 }
 
+// MergeItem moves everything that uses id to targetID, deletes id, and keeps
+// its name as an alias of targetID.
+func (c *Client) MergeItem(ctx context.Context, id, targetID string) (ItemMergeResult, error) {
+	path := fmt.Sprintf("/api/v1/items/%s/merge-into/%s", url.PathEscape(id), url.PathEscape(targetID))
+	var out ItemMergeResult
+	if err := c.doJSON(ctx, http.MethodPost, path, nil, &out); err != nil {
+		return ItemMergeResult{}, err
+	}
+	return out, nil
+}
+
+// ItemPrices returns an item's price history, newest first.
+func (c *Client) ItemPrices(ctx context.Context, id string) ([]ItemPrice, error) {
+	path := fmt.Sprintf("/api/v1/items/%s/prices", url.PathEscape(id))
+	var out []ItemPrice
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddItemPrice records a price for an item.
+func (c *Client) AddItemPrice(ctx context.Context, id string, req ItemPriceRequest) (ItemPrice, error) {
+	path := fmt.Sprintf("/api/v1/items/%s/prices", url.PathEscape(id))
+	var out ItemPrice
+	if err := c.doJSON(ctx, http.MethodPost, path, req, &out); err != nil {
+		return ItemPrice{}, err
+	}
+	return out, nil
+}
+
+// ItemAliases returns an item's aliases in alphabetical order.
+func (c *Client) ItemAliases(ctx context.Context, id string) ([]ItemAlias, error) {
+	path := fmt.Sprintf("/api/v1/items/%s/aliases", url.PathEscape(id))
+	var out []ItemAlias
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddItemAlias adds another name an item goes by.
+func (c *Client) AddItemAlias(ctx context.Context, id, alias string) (ItemAlias, error) {
+	path := fmt.Sprintf("/api/v1/items/%s/aliases", url.PathEscape(id))
+	payload := struct {
+		Alias string `json:"alias"`
+	}{
+		Alias: alias,
+	}
+	var out ItemAlias
+	if err := c.doJSON(ctx, http.MethodPost, path, payload, &out); err != nil {
+		return ItemAlias{}, err
+	}
+	return out, nil
+}
+
+// DeleteItemAlias removes an item alias.
+func (c *Client) DeleteItemAlias(ctx context.Context, id, aliasID string) error {
+	path := fmt.Sprintf("/api/v1/items/%s/aliases/%s", url.PathEscape(id), url.PathEscape(aliasID))
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// ItemAttributes fetches an item's allergen and diet attributes.
+func (c *Client) ItemAttributes(ctx context.Context, id string) (ItemAttributes, error) {
+	path := fmt.Sprintf("/api/v1/items/%s/attributes", url.PathEscape(id))
+	var out ItemAttributes
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return ItemAttributes{}, err
+	}
+	return out, nil
+}
+
+// SetItemAttributes replaces an item's allergen and diet attributes.
+func (c *Client) SetItemAttributes(ctx context.Context, id string, attributes []string) (ItemAttributes, error) {
+	path := fmt.Sprintf("/api/v1/items/%s/attributes", url.PathEscape(id))
+	payload := struct {
+		Attributes []string `json:"attributes"`
+	}{
+		Attributes: attributes,
+	}
+	var out ItemAttributes
+	if err := c.doJSON(ctx, http.MethodPut, path, payload, &out); err != nil {
+		return ItemAttributes{}, err
+	}
+	return out, nil
+}
+
 // ShoppingLists lists shopping lists within a date range.
 func (c *Client) ShoppingLists(ctx context.Context, start, end string) ([]ShoppingList, error) {
 	query := url.Values{}
@@ -615,16 +1236,19 @@ func (c *Client) ShoppingLists(ctx context.Context, start, end string) ([]Shoppi
 	return out, nil
 }
 
-// CreateShoppingList creates a new shopping list.
-func (c *Client) CreateShoppingList(ctx context.Context, listDate, name string, notes *string) (ShoppingList, error) {
+// CreateShoppingList creates a new shopping list. A non-nil storeID orders
+// the list by that store's aisles.
+func (c *Client) CreateShoppingList(ctx context.Context, listDate, name string, notes, storeID *string) (ShoppingList, error) {
 	payload := struct {
 		ListDate string  `json:"list_date"`
 		Name     string  `json:"name"`
 		Notes    *string `json:"notes"`
+		StoreID  *string `json:"store_id"`
 	}{
 		ListDate: listDate,
 		Name:     name,
 		Notes:    notes,
+		StoreID:  storeID,
 	}
 	var out ShoppingList
 	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/shopping-lists", payload, &out); err != nil {
@@ -643,16 +1267,19 @@ func (c *Client) ShoppingList(ctx context.Context, id string) (ShoppingListDetai
 	return out, nil
 }
 
-// UpdateShoppingList updates a shopping list by id.
-func (c *Client) UpdateShoppingList(ctx context.Context, id, listDate, name string, notes *string) (ShoppingList, error) {
+// UpdateShoppingList updates a shopping list by id. A nil storeID returns the
+// list to the default aisle layout.
+func (c *Client) UpdateShoppingList(ctx context.Context, id, listDate, name string, notes, storeID *string) (ShoppingList, error) {
 	payload := struct {
 		ListDate string  `json:"list_date"`
 		Name     string  `json:"name"`
 		Notes    *string `json:"notes"`
+		StoreID  *string `json:"store_id"`
 	}{
 		ListDate: listDate,
 		Name:     name,
 		Notes:    notes,
+		StoreID:  storeID,
 	}
 	path := fmt.Sprintf("/api/v1/shopping-lists/%s", url.PathEscape(id))
 	var out ShoppingList
@@ -694,11 +1321,14 @@ func (c *Client) AddShoppingListItems(ctx context.Context, listID string, items
 }
 
 // AddShoppingListItemsFromRecipes adds items from recipes to a shopping list.
-func (c *Client) AddShoppingListItemsFromRecipes(ctx context.Context, listID string, recipeIDs []string) ([]ShoppingListItem, error) {
+// Pantry stock is subtracted first when excludePantry is set.
+func (c *Client) AddShoppingListItemsFromRecipes(ctx context.Context, listID string, recipeIDs []string, excludePantry bool) ([]ShoppingListItem, error) {
 	payload := struct {
-		RecipeIDs []string `json:"recipe_ids"`
+		RecipeIDs     []string `json:"recipe_ids"`
+		ExcludePantry bool     `json:"exclude_pantry,omitempty"`
 	}{
-		RecipeIDs: recipeIDs,
+		RecipeIDs:     recipeIDs,
+		ExcludePantry: excludePantry,
 	}
 	path := fmt.Sprintf("/api/v1/shopping-lists/%s/items/from-recipes", url.PathEscape(listID))
 	var out []ShoppingListItem
@@ -709,11 +1339,14 @@ func (c *Client) AddShoppingListItemsFromRecipes(ctx context.Context, listID str
 }
 
 // AddShoppingListItemsFromMealPlan adds items from a meal plan date.
-func (c *Client) AddShoppingListItemsFromMealPlan(ctx context.Context, listID, date string) ([]ShoppingListItem, error) {
+// Pantry stock is subtracted first when excludePantry is set.
+func (c *Client) AddShoppingListItemsFromMealPlan(ctx context.Context, listID, date string, excludePantry bool) ([]ShoppingListItem, error) {
 	payload := struct {
-		Date string `json:"date"`
+		Date          string `json:"date"`
+		ExcludePantry bool   `json:"exclude_pantry,omitempty"`
 	}{
-		Date: date,
+		Date:          date,
+		ExcludePantry: excludePantry,
 	}
 	path := fmt.Sprintf("/api/v1/shopping-lists/%s/items/from-meal-plan", url.PathEscape(listID))
 	var out []ShoppingListItem
@@ -724,15 +1357,10 @@ func (c *Client) AddShoppingListItemsFromMealPlan(ctx context.Context, listID, d
 }
 
 // UpdateShoppingListItemPurchase updates purchase state for a list item.
-func (c *Client) UpdateShoppingListItemPurchase(ctx context.Context, listID, itemID string, isPurchased bool) (ShoppingListItem, error) {
-	payload := struct {
-		IsPurchased bool `json:"is_purchased"`
-	}{
-		IsPurchased: isPurchased,
-	}
+func (c *Client) UpdateShoppingListItemPurchase(ctx context.Context, listID, itemID string, req ShoppingListItemPurchaseRequest) (ShoppingListItem, error) {
 	path := fmt.Sprintf("/api/v1/shopping-lists/%s/items/%s", url.PathEscape(listID), url.PathEscape(itemID))
 	var out ShoppingListItem
-	if err := c.doJSON(ctx, http.MethodPatch, path, payload, &out); err != nil {
+	if err := c.doJSON(ctx, http.MethodPatch, path, req, &out); err != nil {
 		return ShoppingListItem{}, err
 	}
 	return out, nil
@@ -744,6 +1372,156 @@ func (c *Client) DeleteShoppingListItem(ctx context.Context, listID, itemID stri
 	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
 }
 
+// PantryItems lists pantry entries, optionally for one location.
+func (c *Client) PantryItems(ctx context.Context, location string) ([]PantryItem, error) {
+	query := url.Values{}
+	if location != "" {
+		query.Set("location", location)
+	}
+	var out []PantryItem
+	if err := c.doJSONWithQuery(ctx, "/api/v1/pantry-items", query, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreatePantryItem adds stock to the pantry.
+func (c *Client) CreatePantryItem(ctx context.Context, input PantryItemInput) (PantryItem, error) {
+	var out PantryItem
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/pantry-items", input, &out); err != nil {
+		return PantryItem{}, err
+	}
+	return out, nil
+}
+
+// UpdatePantryItem replaces a pantry entry's stock details. ItemID is ignored.
+func (c *Client) UpdatePantryItem(ctx context.Context, id string, input PantryItemInput) (PantryItem, error) {
+	input.ItemID = ""
+	path := fmt.Sprintf("/api/v1/pantry-items/%s", url.PathEscape(id))
+	var out PantryItem
+	if err := c.doJSON(ctx, http.MethodPut, path, input, &out); err != nil {
+		return PantryItem{}, err
+	}
+	return out, nil
+}
+
+// DeletePantryItem removes a pantry entry by id.
+func (c *Client) DeletePantryItem(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/api/v1/pantry-items/%s", url.PathEscape(id))
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// Stores lists stores by name.
+func (c *Client) Stores(ctx context.Context) ([]Store, error) {
+	var out []Store
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/stores", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateStore creates a store with an empty aisle layout.
+func (c *Client) CreateStore(ctx context.Context, name string) (Store, error) {
+	payload := struct {
+		Name string `json:"name"`
+	}{
+		Name: name,
+	}
+	var out Store
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/stores", payload, &out); err != nil {
+		return Store{}, err
+	}
+	return out, nil
+}
+
+// UpdateStore renames a store.
+func (c *Client) UpdateStore(ctx context.Context, id, name string) (Store, error) {
+	payload := struct {
+		Name string `json:"name"`
+	}{
+		Name: name,
+	}
+	path := fmt.Sprintf("/api/v1/stores/%s", url.PathEscape(id))
+	var out Store
+	if err := c.doJSON(ctx, http.MethodPut, path, payload, &out); err != nil {
+		return Store{}, err
+	}
+	return out, nil
+}
+
+// DeleteStore deletes a store with its aisles and item placements.
+func (c *Client) DeleteStore(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/api/v1/stores/%s", url.PathEscape(id))
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// StoreAisles lists a store's aisles in sort order.
+func (c *Client) StoreAisles(ctx context.Context, storeID string) ([]GroceryAisle, error) {
+	path := fmt.Sprintf("/api/v1/stores/%s/aisles", url.PathEscape(storeID))
+	var out []GroceryAisle
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateStoreAisle adds an aisle to a store's layout.
+func (c *Client) CreateStoreAisle(ctx context.Context, storeID string, input GroceryAisleInput) (GroceryAisle, error) {
+	path := fmt.Sprintf("/api/v1/stores/%s/aisles", url.PathEscape(storeID))
+	var out GroceryAisle
+	if err := c.doJSON(ctx, http.MethodPost, path, input, &out); err != nil {
+		return GroceryAisle{}, err
+	}
+	return out, nil
+}
+
+// UpdateStoreAisle replaces the name and ordering of an aisle in a store's layout.
+func (c *Client) UpdateStoreAisle(ctx context.Context, storeID, aisleID string, input GroceryAisleInput) (GroceryAisle, error) {
+	path := fmt.Sprintf("/api/v1/stores/%s/aisles/%s", url.PathEscape(storeID), url.PathEscape(aisleID))
+	var out GroceryAisle
+	if err := c.doJSON(ctx, http.MethodPut, path, input, &out); err != nil {
+		return GroceryAisle{}, err
+	}
+	return out, nil
+}
+
+// DeleteStoreAisle deletes an aisle from a store's layout.
+func (c *Client) DeleteStoreAisle(ctx context.Context, storeID, aisleID string) error {
+	path := fmt.Sprintf("/api/v1/stores/%s/aisles/%s", url.PathEscape(storeID), url.PathEscape(aisleID))
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// StoreItemAisles lists the items placed in a store's aisles.
+func (c *Client) StoreItemAisles(ctx context.Context, storeID string) ([]StoreItemAisle, error) {
+	path := fmt.Sprintf("/api/v1/stores/%s/items", url.PathEscape(storeID))
+	var out []StoreItemAisle
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetStoreItemAisle places an item in one of a store's aisles.
+func (c *Client) SetStoreItemAisle(ctx context.Context, storeID, itemID, aisleID string) (StoreItemAisle, error) {
+	payload := struct {
+		AisleID string `json:"aisle_id"`
+	}{
+		AisleID: aisleID,
+	}
+	path := fmt.Sprintf("/api/v1/stores/%s/items/%s", url.PathEscape(storeID), url.PathEscape(itemID))
+	var out StoreItemAisle
+	if err := c.doJSON(ctx, http.MethodPut, path, payload, &out); err != nil {
+		return StoreItemAisle{}, err
+	}
+	return out, nil
+}
+
+// DeleteStoreItemAisle removes an item's placement in a store.
+func (c *Client) DeleteStoreItemAisle(ctx context.Context, storeID, itemID string) error {
+	path := fmt.Sprintf("/api/v1/stores/%s/items/%s", url.PathEscape(storeID), url.PathEscape(itemID))
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
 // Recipe returns the full recipe detail by id.
 func (c *Client) Recipe(ctx context.Context, id string) (RecipeDetail, error) {
 	path := fmt.Sprintf("/api/v1/recipes/%s", id)
@@ -754,6 +1532,22 @@ func (c *Client) Recipe(ctx context.Context, id string) (RecipeDetail, error) {
 	return out, nil
 }
 
+// RecipeScaled returns recipe detail with quantities scaled to servings.
+// A non-positive servings value returns the recipe as stored.
+func (c *Client) RecipeScaled(ctx context.Context, id string, servings int) (RecipeDetail, error) {
+	if servings <= 0 {
+		return c.Recipe(ctx, id)
+	}
+	path := fmt.Sprintf("/api/v1/recipes/%s", id)
+	query := url.Values{}
+	query.Set("servings", strconv.Itoa(servings))
+	var out RecipeDetail
+	if err := c.doJSONWithQuery(ctx, path, query, &out); err != nil {
+		return RecipeDetail{}, err
+	}
+	return out, nil
+}
+
 // CreateRecipe creates a recipe from a raw JSON payload.
 func (c *Client) CreateRecipe(ctx context.Context, payload json.RawMessage) (RecipeDetail, error) {
 	var out RecipeDetail
@@ -763,6 +1557,26 @@ func (c *Client) CreateRecipe(ctx context.Context, payload json.RawMessage) (Rec
 	return out, nil
 }
 
+// ImportRecipeDraft parses a schema.org Recipe from HTML or JSON-LD content
+// (format "html" or "jsonld") and returns the create payload without saving it.
+func (c *Client) ImportRecipeDraft(ctx context.Context, format string, content []byte, sourceURL *string) (json.RawMessage, error) {
+	payload := struct {
+		Format    string  `json:"format"`
+		Content   string  `json:"content"`
+		SourceURL *string `json:"source_url"`
+		Create    bool    `json:"create"`
+	}{
+		Format:    format,
+		Content:   string(content),
+		SourceURL: sourceURL,
+	}
+	var out json.RawMessage
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/recipes/import", payload, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UpdateRecipe updates a recipe by id from a raw JSON payload.
 func (c *Client) UpdateRecipe(ctx context.Context, id string, payload json.RawMessage) (RecipeDetail, error) {
 	path := fmt.Sprintf("/api/v1/recipes/%s", id)
@@ -785,6 +1599,265 @@ func (c *Client) RestoreRecipe(ctx context.Context, id string) error {
 	return c.doJSON(ctx, http.MethodPut, path, nil, nil)
 }
 
+// RecipeDuplicates lists likely duplicate recipe pairs, best match first.
+func (c *Client) RecipeDuplicates(ctx context.Context, params RecipeDuplicateParams) ([]RecipeDuplicate, error) {
+	query := url.Values{}
+	if params.RecipeID != "" {
+		query.Set("recipe_id", params.RecipeID)
+	}
+	if params.MinScore != nil {
+		query.Set("min_score", strconv.FormatFloat(*params.MinScore, 'f', -1, 64))
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	var out []RecipeDuplicate
+	if err := c.doJSONWithQuery(ctx, "/api/v1/recipes/duplicates", query, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MatchRecipes ranks recipes by how many of their items are on hand.
+func (c *Client) MatchRecipes(ctx context.Context, req RecipeMatchRequest) (RecipeMatchResult, error) {
+	var out RecipeMatchResult
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/recipes/match", req, &out); err != nil {
+		return RecipeMatchResult{}, err
+	}
+	return out, nil
+}
+
+// MergeRecipe folds duplicateID into id and soft-deletes the duplicate.
+func (c *Client) MergeRecipe(ctx context.Context, id, duplicateID string) (RecipeMergeResult, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/merge", id)
+	payload := struct {
+		DuplicateID string `json:"duplicate_id"`
+	}{DuplicateID: duplicateID}
+	var out RecipeMergeResult
+	if err := c.doJSON(ctx, http.MethodPost, path, payload, &out); err != nil {
+		return RecipeMergeResult{}, err
+	}
+	return out, nil
+}
+
+// RecipeShares lists the caller's share links for a recipe.
+func (c *Client) RecipeShares(ctx context.Context, id string) ([]RecipeShare, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/shares", id)
+	var out []RecipeShare
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShareRecipe creates a public share link. A nil expiresAt never expires.
+func (c *Client) ShareRecipe(ctx context.Context, id string, expiresAt *time.Time) (RecipeShare, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/shares", id)
+	payload := struct {
+		ExpiresAt *time.Time `json:"expires_at"`
+	}{ExpiresAt: expiresAt}
+	var out RecipeShare
+	if err := c.doJSON(ctx, http.MethodPost, path, payload, &out); err != nil {
+		return RecipeShare{}, err
+	}
+	return out, nil
+}
+
+// UnshareRecipe revokes one share link.
+func (c *Client) UnshareRecipe(ctx context.Context, id, shareID string) error {
+	path := fmt.Sprintf("/api/v1/recipes/%s/shares/%s", id, shareID)
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// UnshareRecipeAll revokes every share link the caller created for a recipe.
+func (c *Client) UnshareRecipeAll(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/api/v1/recipes/%s/shares", id)
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// URL returns the absolute URL of a server path, such as a share page.
+func (c *Client) URL(path string) string {
+	return c.baseURL.ResolveReference(&url.URL{Path: path}).String()
+}
+
+// RecipeRevisions lists a recipe's revisions, newest first.
+func (c *Client) RecipeRevisions(ctx context.Context, id string) ([]RecipeRevisionSummary, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/revisions", id)
+	var out []RecipeRevisionSummary
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecipeRevision returns a single recipe revision snapshot.
+func (c *Client) RecipeRevision(ctx context.Context, id string, revision int) (RecipeRevision, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/revisions/%d", id, revision)
+	var out RecipeRevision
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return RecipeRevision{}, err
+	}
+	return out, nil
+}
+
+// RecipeRevisionDiff compares two recipe revisions.
+func (c *Client) RecipeRevisionDiff(ctx context.Context, id string, from, to int) (RecipeRevisionDiff, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/revisions/diff", id)
+	query := url.Values{}
+	query.Set("from", strconv.Itoa(from))
+	query.Set("to", strconv.Itoa(to))
+	var out RecipeRevisionDiff
+	if err := c.doJSONWithQuery(ctx, path, query, &out); err != nil {
+		return RecipeRevisionDiff{}, err
+	}
+	return out, nil
+}
+
+// CloneRecipe copies a recipe on the server, recording the original as the
+// copy's parent. An empty title lets the server name it "<title> (copy)".
+func (c *Client) CloneRecipe(ctx context.Context, id, title string) (RecipeDetail, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/clone", id)
+	payload := struct {
+		Title *string `json:"title"`
+	}{}
+	if title != "" {
+		payload.Title = &title
+	}
+	var out RecipeDetail
+	if err := c.doJSON(ctx, http.MethodPost, path, payload, &out); err != nil {
+		return RecipeDetail{}, err
+	}
+	return out, nil
+}
+
+// RecipeParentDiff compares a recipe with the recipe it was cloned from.
+func (c *Client) RecipeParentDiff(ctx context.Context, id string) (RecipeParentDiff, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/parent/diff", id)
+	var out RecipeParentDiff
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return RecipeParentDiff{}, err
+	}
+	return out, nil
+}
+
+// RevertRecipeRevision restores a recipe to an earlier revision.
+func (c *Client) RevertRecipeRevision(ctx context.Context, id string, revision int) (RecipeDetail, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/revisions/%d/revert", id, revision)
+	var out RecipeDetail
+	if err := c.doJSON(ctx, http.MethodPost, path, nil, &out); err != nil {
+		return RecipeDetail{}, err
+	}
+	return out, nil
+}
+
+// LogRecipeCook records that a recipe was cooked.
+func (c *Client) LogRecipeCook(ctx context.Context, id string, req RecipeCookRequest) (RecipeCookEvent, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/cooks", id)
+	var out RecipeCookEvent
+	if err := c.doJSON(ctx, http.MethodPost, path, req, &out); err != nil {
+		return RecipeCookEvent{}, err
+	}
+	return out, nil
+}
+
+// FavoriteRecipe marks a recipe as one of the caller's favorites.
+func (c *Client) FavoriteRecipe(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/api/v1/recipes/%s/favorite", id)
+	return c.doJSON(ctx, http.MethodPut, path, nil, nil)
+}
+
+// UnfavoriteRecipe removes a recipe from the caller's favorites.
+func (c *Client) UnfavoriteRecipe(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/api/v1/recipes/%s/favorite", id)
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// RecipePersonalNote fetches the caller's private note on a recipe.
+func (c *Client) RecipePersonalNote(ctx context.Context, id string) (RecipePersonalNote, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/personal-note", id)
+	var out RecipePersonalNote
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return RecipePersonalNote{}, err
+	}
+	return out, nil
+}
+
+// SetRecipePersonalNote creates or replaces the caller's private note on a recipe.
+func (c *Client) SetRecipePersonalNote(ctx context.Context, id, note string) (RecipePersonalNote, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/personal-note", id)
+	var out RecipePersonalNote
+	if err := c.doJSON(ctx, http.MethodPut, path, RecipePersonalNoteRequest{Note: note}, &out); err != nil {
+		return RecipePersonalNote{}, err
+	}
+	return out, nil
+}
+
+// DeleteRecipePersonalNote removes the caller's private note on a recipe.
+func (c *Client) DeleteRecipePersonalNote(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/api/v1/recipes/%s/personal-note", id)
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// RecipeImages lists the photos attached to a recipe.
+func (c *Client) RecipeImages(ctx context.Context, id string) ([]RecipeImage, error) {
+	path := fmt.Sprintf("/api/v1/recipes/%s/images", id)
+	var out []RecipeImage
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UploadRecipeImage uploads a photo for a recipe. A positive stepNumber
+// attaches the photo to that step.
+func (c *Client) UploadRecipeImage(ctx context.Context, id, filename string, data io.Reader, stepNumber int) (RecipeImage, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if stepNumber > 0 {
+		if err := writer.WriteField("step_number", strconv.Itoa(stepNumber)); err != nil {
+			return RecipeImage{}, fmt.Errorf("encode request: %w", err)
+		}
+	}
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return RecipeImage{}, fmt.Errorf("encode request: %w", err)
+	}
+	if _, err := io.Copy(part, data); err != nil {
+		return RecipeImage{}, fmt.Errorf("encode request: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return RecipeImage{}, fmt.Errorf("encode request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/v1/recipes/%s/images", id)
+	req, err := c.newRequest(ctx, http.MethodPost, path, &body)
+	if err != nil {
+		return RecipeImage{}, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := c.do(req)
+	if err != nil {
+		return RecipeImage{}, err
+	}
+	defer c.closeBody(resp)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return RecipeImage{}, readAPIError(resp)
+	}
+	var out RecipeImage
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return RecipeImage{}, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
+// DeleteRecipeImage removes a photo from a recipe.
+func (c *Client) DeleteRecipeImage(ctx context.Context, recipeID, imageID string) error {
+	path := fmt.Sprintf("/api/v1/recipes/%s/images/%s", recipeID, imageID)
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
 func (c *Client) doJSON(ctx context.Context, method, path string, body interface{}, out interface{}) error {
 	var payload io.Reader
 	if body != nil {
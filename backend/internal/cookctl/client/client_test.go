@@ -1106,7 +1106,7 @@ func TestCreateShoppingList(t *testing.T) {
 		t.Fatalf("New returned error: %v", err)
 	}
 
-	resp, err := api.CreateShoppingList(context.Background(), "2025-02-03", "Weekly", nil)
+	resp, err := api.CreateShoppingList(context.Background(), "2025-02-03", "Weekly", nil, nil)
 	if err != nil {
 		t.Fatalf("CreateShoppingList returned error: %v", err)
 	}
@@ -1191,7 +1191,7 @@ func TestUpdateShoppingList(t *testing.T) {
 		t.Fatalf("New returned error: %v", err)
 	}
 
-	resp, err := api.UpdateShoppingList(context.Background(), "list-1", "2025-02-04", "Updated", nil)
+	resp, err := api.UpdateShoppingList(context.Background(), "list-1", "2025-02-04", "Updated", nil, nil)
 	if err != nil {
 		t.Fatalf("UpdateShoppingList returned error: %v", err)
 	}
@@ -1308,7 +1308,8 @@ func TestAddShoppingListItemsFromRecipes(t *testing.T) {
 			t.Fatalf("path = %s, want /api/v1/shopping-lists/list-1/items/from-recipes", r.URL.Path)
 		}
 		var payload struct {
-			RecipeIDs []string `json:"recipe_ids"`
+			RecipeIDs     []string `json:"recipe_ids"`
+			ExcludePantry bool     `json:"exclude_pantry"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 			t.Fatalf("decode payload: %v", err)
@@ -1316,6 +1317,9 @@ func TestAddShoppingListItemsFromRecipes(t *testing.T) {
 		if len(payload.RecipeIDs) != 2 {
 			t.Fatalf("recipe_ids length = %d, want 2", len(payload.RecipeIDs))
 		}
+		if !payload.ExcludePantry {
+			t.Fatalf("exclude_pantry = false, want true")
+		}
 		resp := []ShoppingListItem{{ID: "list-item-1", Item: Item{ID: "item-1", Name: "milk"}}}
 		w.Header().Set("Content-Type", "application/json")
 		writeJSON(t, w, resp)
@@ -1327,7 +1331,7 @@ func TestAddShoppingListItemsFromRecipes(t *testing.T) {
 		t.Fatalf("New returned error: %v", err)
 	}
 
-	_, err = api.AddShoppingListItemsFromRecipes(context.Background(), "list-1", []string{"recipe-1", "recipe-2"})
+	_, err = api.AddShoppingListItemsFromRecipes(context.Background(), "list-1", []string{"recipe-1", "recipe-2"}, true)
 	if err != nil {
 		t.Fatalf("AddShoppingListItemsFromRecipes returned error: %v", err)
 	}
@@ -1363,7 +1367,7 @@ func TestAddShoppingListItemsFromMealPlan(t *testing.T) {
 		t.Fatalf("New returned error: %v", err)
 	}
 
-	_, err = api.AddShoppingListItemsFromMealPlan(context.Background(), "list-1", "2025-02-10")
+	_, err = api.AddShoppingListItemsFromMealPlan(context.Background(), "list-1", "2025-02-10", false)
 	if err != nil {
 		t.Fatalf("AddShoppingListItemsFromMealPlan returned error: %v", err)
 	}
@@ -1403,7 +1407,7 @@ func TestUpdateShoppingListItemPurchase(t *testing.T) {
 		t.Fatalf("New returned error: %v", err)
 	}
 
-	resp, err := api.UpdateShoppingListItemPurchase(context.Background(), "list-1", "item-1", true)
+	resp, err := api.UpdateShoppingListItemPurchase(context.Background(), "list-1", "item-1", ShoppingListItemPurchaseRequest{IsPurchased: true})
 	if err != nil {
 		t.Fatalf("UpdateShoppingListItemPurchase returned error: %v", err)
 	}
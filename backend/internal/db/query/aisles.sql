@@ -1,4 +1,5 @@
 -- name: ListGroceryAisles :many
+-- Lists the default layout; store aisles are listed by ListGroceryAislesByStoreID.
 SELECT
   id,
   name,
@@ -8,8 +9,10 @@ SELECT
   created_at,
   created_by,
   updated_at,
-  updated_by
+  updated_by,
+  store_id
 FROM grocery_aisles
+WHERE store_id IS NULL
 ORDER BY sort_group ASC, sort_order ASC, name ASC;
 
 -- name: GetGroceryAisleByID :one
@@ -22,24 +25,44 @@ SELECT
   created_at,
   created_by,
   updated_at,
-  updated_by
+  updated_by,
+  store_id
 FROM grocery_aisles
 WHERE id = $1;
 
+-- name: ListGroceryAislesByStoreID :many
+SELECT
+  id,
+  name,
+  sort_group,
+  sort_order,
+  numeric_value,
+  created_at,
+  created_by,
+  updated_at,
+  updated_by,
+  store_id
+FROM grocery_aisles
+WHERE store_id = $1
+ORDER BY sort_group ASC, sort_order ASC, name ASC;
+
 -- name: CreateGroceryAisle :one
 INSERT INTO grocery_aisles (
   name,
   sort_group,
   sort_order,
   numeric_value,
+  store_id,
   created_by,
   updated_by
 ) VALUES (
-  $1, $2, $3, $4, $5, $6
+  $1, $2, $3, $4, $5, $6, $7
 )
 RETURNING *;
 
 -- name: UpdateGroceryAisleByID :one
+-- Updates an aisle in the given store's layout, or the default layout when
+-- store_id is null.
 UPDATE grocery_aisles
 SET name = $2,
     sort_group = $3,
@@ -48,8 +71,12 @@ SET name = $2,
     updated_at = now(),
     updated_by = $6
 WHERE id = $1
+  AND store_id IS NOT DISTINCT FROM sqlc.narg(store_id)::uuid
 RETURNING *;
 
 -- name: DeleteGroceryAisleByID :execrows
+-- Deletes an aisle in the given store's layout, or the default layout when
+-- store_id is null.
 DELETE FROM grocery_aisles
-WHERE id = $1;
+WHERE id = $1
+  AND store_id IS NOT DISTINCT FROM sqlc.narg(store_id)::uuid;
@@ -0,0 +1,61 @@
+-- name: ListItemAliasesByItemID :many
+SELECT *
+FROM item_aliases
+WHERE item_id = $1
+ORDER BY alias ASC;
+
+-- name: CreateItemAlias :one
+INSERT INTO item_aliases (
+  item_id,
+  alias,
+  created_by
+)
+VALUES (
+  sqlc.arg(item_id),
+  sqlc.arg(alias),
+  sqlc.arg(created_by)
+)
+RETURNING *;
+
+-- name: DeleteItemAlias :execrows
+DELETE FROM item_aliases
+WHERE item_id = $1 AND id = $2;
+
+-- name: GetItemByAlias :one
+SELECT i.id, i.name
+FROM item_aliases a
+JOIN items i ON i.id = a.item_id
+WHERE a.alias = $1;
+
+-- name: FindSimilarItem :one
+-- Returns the item whose name or one of its aliases is closest to name by
+-- trigram similarity, provided it reaches min_similarity.
+SELECT i.id, i.name, m.similarity
+FROM (
+  SELECT items.id AS item_id, similarity(items.name, sqlc.arg(name)::text)::float8 AS similarity
+  FROM items
+  WHERE items.name % sqlc.arg(name)::text
+  UNION ALL
+  SELECT item_aliases.item_id, similarity(item_aliases.alias, sqlc.arg(name)::text)::float8
+  FROM item_aliases
+  WHERE item_aliases.alias % sqlc.arg(name)::text
+) m
+JOIN items i ON i.id = m.item_id
+WHERE m.similarity >= sqlc.arg(min_similarity)::float8
+ORDER BY m.similarity DESC, i.name ASC
+LIMIT 1;
+
+-- name: EnsureItemAlias :exec
+-- Keeps a merged item's name resolving to the item it was merged into. An
+-- existing alias with the same name is left alone.
+INSERT INTO item_aliases (
+  item_id,
+  alias,
+  created_by
+)
+VALUES (
+  sqlc.arg(item_id),
+  sqlc.arg(alias),
+  sqlc.arg(created_by)
+)
+ON CONFLICT (alias) DO NOTHING;
@@ -0,0 +1,57 @@
+-- name: ListItemAttributes :many
+SELECT attribute
+FROM item_attributes
+WHERE item_id = $1
+ORDER BY attribute ASC;
+
+-- name: IsItemClassified :one
+SELECT EXISTS (
+  SELECT 1
+  FROM item_attribute_classifications
+  WHERE item_id = $1
+) AS classified;
+
+-- name: ReplaceItemAttributes :exec
+-- Makes the item's attributes exactly the given set, keeping rows that are
+-- already present, and marks the item classified even when the set is empty.
+WITH removed AS (
+  DELETE FROM item_attributes
+  WHERE item_id = sqlc.arg(item_id)::uuid
+    AND attribute <> ALL(sqlc.arg(attributes)::text[])
+),
+classified AS (
+  INSERT INTO item_attribute_classifications (item_id, classified_by)
+  VALUES (sqlc.arg(item_id)::uuid, sqlc.arg(created_by)::uuid)
+  ON CONFLICT (item_id) DO UPDATE
+  SET classified_at = now(),
+      classified_by = EXCLUDED.classified_by
+)
+INSERT INTO item_attributes (
+  item_id,
+  attribute,
+  created_by
+)
+SELECT sqlc.arg(item_id)::uuid, a.attribute, sqlc.arg(created_by)::uuid
+FROM unnest(sqlc.arg(attributes)::text[]) AS a(attribute)
+ON CONFLICT (item_id, attribute) DO NOTHING;
+
+-- name: ListRecipeAttributesByRecipeIDs :many
+-- Lists the item attributes each recipe inherits from its ingredients,
+-- including those of sub-recipes.
+SELECT
+  r.id AS recipe_id,
+  a.attribute::text AS attribute
+FROM unnest(sqlc.arg(recipe_ids)::uuid[]) AS r(id)
+CROSS JOIN LATERAL recipe_item_attributes(r.id) AS a(attribute)
+ORDER BY r.id, a.attribute ASC;
+
+-- name: ListRecipeUnclassifiedItemsByRecipeIDs :many
+-- Lists the items of each recipe, including those of sub-recipes, that have
+-- no attributes set yet.
+SELECT
+  r.id AS recipe_id,
+  u.item_id::uuid AS item_id,
+  u.item_name::text AS item_name
+FROM unnest(sqlc.arg(recipe_ids)::uuid[]) AS r(id)
+CROSS JOIN LATERAL recipe_unclassified_items(r.id) AS u(item_id, item_name)
+ORDER BY r.id, u.item_name ASC;
@@ -0,0 +1,148 @@
+-- name: MergeShoppingListItemQuantities :execrows
+-- Adds each source line onto the target line in the same list and unit,
+-- the way UpsertShoppingListItem adds repeated items. The merged line stays
+-- purchased only when both were. The caller deletes the source lines after.
+UPDATE shopping_list_items target
+SET quantity = CASE
+    WHEN target.quantity IS NULL THEN source.quantity
+    WHEN source.quantity IS NULL THEN target.quantity
+    ELSE target.quantity + source.quantity
+  END,
+  quantity_text = COALESCE(target.quantity_text, source.quantity_text),
+  is_purchased = target.is_purchased AND source.is_purchased,
+  purchased_at = CASE
+    WHEN target.is_purchased AND source.is_purchased THEN GREATEST(target.purchased_at, source.purchased_at)
+    ELSE NULL
+  END,
+  updated_at = now(),
+  updated_by = sqlc.arg(user_id)::uuid
+FROM shopping_list_items source
+WHERE target.item_id = sqlc.arg(target_id)::uuid
+  AND source.item_id = sqlc.arg(source_id)::uuid
+  AND source.shopping_list_id = target.shopping_list_id
+  AND source.unit IS NOT DISTINCT FROM target.unit;
+
+-- name: DeleteMergedShoppingListItems :exec
+DELETE FROM shopping_list_items source
+USING shopping_list_items target
+WHERE target.item_id = sqlc.arg(target_id)::uuid
+  AND source.item_id = sqlc.arg(source_id)::uuid
+  AND source.shopping_list_id = target.shopping_list_id
+  AND source.unit IS NOT DISTINCT FROM target.unit;
+
+-- name: ListRecipeIDsByItemID :many
+-- Recipes with an ingredient line using the item, so a merge can record
+-- a revision of each.
+SELECT DISTINCT recipe_id
+FROM recipe_ingredients
+WHERE item_id = $1
+ORDER BY recipe_id;
+
+-- name: MoveShoppingListItems :execrows
+UPDATE shopping_list_items
+SET item_id = sqlc.arg(target_id)::uuid,
+    updated_at = now(),
+    updated_by = sqlc.arg(user_id)::uuid
+WHERE item_id = sqlc.arg(source_id)::uuid;
+
+-- name: MergePantryItemQuantities :execrows
+-- Adds each source entry onto the target entry with the same location,
+-- unit, and best-before date, as UpsertPantryItem does. The caller deletes
+-- the source entries after.
+UPDATE pantry_items target
+SET quantity = CASE
+    WHEN target.quantity IS NULL THEN source.quantity
+    WHEN source.quantity IS NULL THEN target.quantity
+    ELSE target.quantity + source.quantity
+  END,
+  updated_at = now(),
+  updated_by = sqlc.arg(user_id)::uuid
+FROM pantry_items source
+WHERE target.item_id = sqlc.arg(target_id)::uuid
+  AND source.item_id = sqlc.arg(source_id)::uuid
+  AND source.location = target.location
+  AND source.unit IS NOT DISTINCT FROM target.unit
+  AND source.best_before IS NOT DISTINCT FROM target.best_before;
+
+-- name: DeleteMergedPantryItems :exec
+DELETE FROM pantry_items source
+USING pantry_items target
+WHERE target.item_id = sqlc.arg(target_id)::uuid
+  AND source.item_id = sqlc.arg(source_id)::uuid
+  AND source.location = target.location
+  AND source.unit IS NOT DISTINCT FROM target.unit
+  AND source.best_before IS NOT DISTINCT FROM target.best_before;
+
+-- name: MovePantryItems :execrows
+UPDATE pantry_items
+SET item_id = sqlc.arg(target_id)::uuid,
+    updated_at = now(),
+    updated_by = sqlc.arg(user_id)::uuid
+WHERE item_id = sqlc.arg(source_id)::uuid;
+
+-- name: MoveShoppingListPantryReservations :exec
+-- Moves pantry stock set against lists for the source item onto the target,
+-- adding quantities where the target has a reservation in the same list and
+-- unit.
+WITH moved AS (
+  DELETE FROM shopping_list_pantry_reservations
+  WHERE item_id = sqlc.arg(source_id)::uuid
+  RETURNING shopping_list_id, unit, quantity
+)
+INSERT INTO shopping_list_pantry_reservations (shopping_list_id, item_id, unit, quantity)
+SELECT moved.shopping_list_id, sqlc.arg(target_id)::uuid, moved.unit, moved.quantity
+FROM moved
+ON CONFLICT (shopping_list_id, item_id, unit) DO UPDATE
+SET quantity = shopping_list_pantry_reservations.quantity + EXCLUDED.quantity;
+
+-- name: MoveRecipeIngredientItems :execrows
+UPDATE recipe_ingredients
+SET item_id = sqlc.arg(target_id)::uuid
+WHERE item_id = sqlc.arg(source_id)::uuid;
+
+-- name: MoveItemPrices :exec
+UPDATE item_prices
+SET item_id = sqlc.arg(target_id)::uuid
+WHERE item_id = sqlc.arg(source_id)::uuid;
+
+-- name: MoveItemNutrition :exec
+-- Keeps the target's nutrition when it has its own.
+UPDATE item_nutrition
+SET item_id = sqlc.arg(target_id)::uuid
+WHERE item_id = sqlc.arg(source_id)::uuid
+  AND NOT EXISTS (
+    SELECT 1
+    FROM item_nutrition existing
+    WHERE existing.item_id = sqlc.arg(target_id)::uuid
+  );
+
+-- name: MoveItemAttributes :exec
+-- The target ends up with the union of both items' attributes, so no
+-- allergen is lost in a merge. Its classification is left alone: the source
+-- being classified says nothing about the target's own attributes, so an
+-- unclassified target stays unclassified.
+INSERT INTO item_attributes (item_id, attribute, created_by)
+SELECT sqlc.arg(target_id)::uuid, ia.attribute, sqlc.arg(user_id)::uuid
+FROM item_attributes ia
+WHERE ia.item_id = sqlc.arg(source_id)::uuid
+ON CONFLICT (item_id, attribute) DO NOTHING;
+
+-- name: MoveItemAliases :exec
+UPDATE item_aliases
+SET item_id = sqlc.arg(target_id)::uuid
+WHERE item_id = sqlc.arg(source_id)::uuid;
+
+-- name: MoveStoreItemAisles :exec
+-- Keeps the source item's store placements for stores where the target has
+-- none. The rest are removed when the source item is deleted.
+UPDATE store_item_aisles AS src
+SET item_id = sqlc.arg(target_id)::uuid,
+  updated_at = now(),
+  updated_by = sqlc.arg(user_id)::uuid
+WHERE src.item_id = sqlc.arg(source_id)::uuid
+  AND NOT EXISTS (
+    SELECT 1
+    FROM store_item_aisles dst
+    WHERE dst.store_id = src.store_id
+      AND dst.item_id = sqlc.arg(target_id)::uuid
+  );
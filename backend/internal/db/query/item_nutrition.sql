@@ -0,0 +1,54 @@
+-- name: GetItemNutrition :one
+SELECT *
+FROM item_nutrition
+WHERE item_id = $1;
+
+-- name: UpsertItemNutrition :one
+INSERT INTO item_nutrition (
+  item_id,
+  reference_quantity,
+  reference_unit,
+  calories,
+  protein_g,
+  fat_g,
+  carbs_g,
+  fiber_g,
+  sodium_mg,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+ON CONFLICT (item_id) DO UPDATE
+SET reference_quantity = EXCLUDED.reference_quantity,
+    reference_unit = EXCLUDED.reference_unit,
+    calories = EXCLUDED.calories,
+    protein_g = EXCLUDED.protein_g,
+    fat_g = EXCLUDED.fat_g,
+    carbs_g = EXCLUDED.carbs_g,
+    fiber_g = EXCLUDED.fiber_g,
+    sodium_mg = EXCLUDED.sodium_mg,
+    updated_at = now(),
+    updated_by = EXCLUDED.updated_by
+RETURNING *;
+
+-- name: DeleteItemNutrition :execrows
+DELETE FROM item_nutrition
+WHERE item_id = $1;
+
+-- name: ListItemNutritionByRecipeID :many
+-- Covers the items of nested sub-recipes as well.
+WITH RECURSIVE tree AS (
+  SELECT sqlc.arg(recipe_id)::uuid AS recipe_id
+  UNION
+  SELECT ri.sub_recipe_id
+  FROM recipe_ingredients ri
+  JOIN tree t ON t.recipe_id = ri.recipe_id
+  WHERE ri.sub_recipe_id IS NOT NULL
+)
+SELECT n.*
+FROM item_nutrition n
+WHERE n.item_id IN (
+  SELECT ri.item_id
+  FROM recipe_ingredients ri
+  JOIN tree t ON t.recipe_id = ri.recipe_id
+);
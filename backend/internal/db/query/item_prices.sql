@@ -0,0 +1,60 @@
+-- name: CreateItemPrice :one
+INSERT INTO item_prices (
+  item_id,
+  price,
+  quantity,
+  unit,
+  store,
+  priced_on,
+  created_by
+)
+VALUES (
+  sqlc.arg(item_id),
+  sqlc.arg(price),
+  sqlc.arg(quantity),
+  sqlc.arg(unit),
+  sqlc.narg(store),
+  COALESCE(sqlc.narg(priced_on)::date, current_date),
+  sqlc.arg(created_by)
+)
+RETURNING *;
+
+-- name: ListItemPricesByItemID :many
+SELECT *
+FROM item_prices
+WHERE item_id = $1
+ORDER BY priced_on DESC, created_at DESC;
+
+-- name: DeleteItemPrice :execrows
+DELETE FROM item_prices
+WHERE item_id = $1 AND id = $2;
+
+-- name: ListLatestItemPricesByRecipeID :many
+-- Costs use each item's most recent price. Items of nested sub-recipes are
+-- included.
+WITH RECURSIVE tree AS (
+  SELECT sqlc.arg(recipe_id)::uuid AS recipe_id
+  UNION
+  SELECT ri.sub_recipe_id
+  FROM recipe_ingredients ri
+  JOIN tree t ON t.recipe_id = ri.recipe_id
+  WHERE ri.sub_recipe_id IS NOT NULL
+)
+SELECT DISTINCT ON (p.item_id) p.*
+FROM item_prices p
+WHERE p.item_id IN (
+  SELECT ri.item_id
+  FROM recipe_ingredients ri
+  JOIN tree t ON t.recipe_id = ri.recipe_id
+)
+ORDER BY p.item_id, p.priced_on DESC, p.created_at DESC;
+
+-- name: ListLatestItemPricesByShoppingListID :many
+SELECT DISTINCT ON (p.item_id) p.*
+FROM item_prices p
+WHERE p.item_id IN (
+  SELECT sli.item_id
+  FROM shopping_list_items sli
+  WHERE sli.shopping_list_id = $1
+)
+ORDER BY p.item_id, p.priced_on DESC, p.created_at DESC;
@@ -0,0 +1,93 @@
+-- name: ListPantryItems :many
+SELECT
+  p.id,
+  p.item_id,
+  i.name AS item_name,
+  p.quantity,
+  p.unit,
+  p.location,
+  p.best_before,
+  p.created_at,
+  p.updated_at
+FROM pantry_items p
+JOIN items i ON i.id = p.item_id
+WHERE sqlc.narg(location)::text IS NULL
+  OR p.location = sqlc.narg(location)::text
+ORDER BY p.location ASC, i.name ASC, p.best_before ASC NULLS LAST, p.id ASC;
+
+-- name: UpsertPantryItem :one
+-- Stock matching an existing entry is added to it. A missing quantity takes
+-- the other side's, as shopping list items do.
+WITH upserted AS (
+  INSERT INTO pantry_items (
+    item_id,
+    quantity,
+    unit,
+    location,
+    best_before,
+    created_by,
+    updated_by
+  )
+  VALUES (
+    sqlc.arg(item_id),
+    sqlc.arg(quantity),
+    sqlc.arg(unit),
+    sqlc.arg(location),
+    sqlc.arg(best_before),
+    sqlc.arg(created_by),
+    sqlc.arg(updated_by)
+  )
+  ON CONFLICT ON CONSTRAINT pantry_items_stock_unique DO UPDATE
+  SET quantity = CASE
+      WHEN pantry_items.quantity IS NULL THEN EXCLUDED.quantity
+      WHEN EXCLUDED.quantity IS NULL THEN pantry_items.quantity
+      ELSE pantry_items.quantity + EXCLUDED.quantity
+    END,
+    updated_at = now(),
+    updated_by = EXCLUDED.updated_by
+  RETURNING *
+)
+SELECT
+  upserted.id,
+  upserted.item_id,
+  i.name AS item_name,
+  upserted.quantity,
+  upserted.unit,
+  upserted.location,
+  upserted.best_before,
+  upserted.created_at,
+  upserted.updated_at
+FROM upserted
+JOIN items i ON i.id = upserted.item_id;
+
+-- name: UpdatePantryItem :one
+UPDATE pantry_items AS p
+SET quantity = sqlc.arg(quantity),
+    unit = sqlc.arg(unit),
+    location = sqlc.arg(location),
+    best_before = sqlc.arg(best_before),
+    updated_at = now(),
+    updated_by = sqlc.arg(updated_by)
+FROM items i
+WHERE p.id = sqlc.arg(id)
+  AND i.id = p.item_id
+RETURNING
+  p.id,
+  p.item_id,
+  i.name AS item_name,
+  p.quantity,
+  p.unit,
+  p.location,
+  p.best_before,
+  p.created_at,
+  p.updated_at;
+
+-- name: DeletePantryItem :execrows
+DELETE FROM pantry_items
+WHERE id = $1;
+
+-- name: ListPantryItemsByItemIDs :many
+SELECT *
+FROM pantry_items
+WHERE item_id = ANY(sqlc.arg(item_ids)::uuid[])
+ORDER BY item_id, best_before ASC NULLS LAST, created_at ASC;
@@ -23,3 +23,22 @@ RETURNING *;
 DELETE FROM recipe_books
 WHERE id = $1;
 
+
+-- name: GetRecipeBookByID :one
+SELECT *
+FROM recipe_books
+WHERE id = $1;
+
+-- name: ListRecipeIDsByRecipeBookID :many
+SELECT id
+FROM recipes
+WHERE recipe_book_id = $1
+ORDER BY id;
+
+-- name: MoveRecipesToRecipeBook :execrows
+-- Soft-deleted recipes move too so the source book can be deleted.
+UPDATE recipes
+SET recipe_book_id = sqlc.arg(target_id)::uuid,
+    updated_at = now(),
+    updated_by = sqlc.arg(user_id)::uuid
+WHERE recipe_book_id = sqlc.arg(source_id)::uuid;
@@ -0,0 +1,54 @@
+-- name: CreateRecipeCookEvent :one
+INSERT INTO recipe_cook_events (
+  recipe_id,
+  cooked_at,
+  servings,
+  rating,
+  notes,
+  created_by,
+  updated_by
+)
+VALUES (
+  sqlc.arg(recipe_id),
+  COALESCE(sqlc.narg(cooked_at)::timestamptz, now()),
+  sqlc.narg(servings),
+  sqlc.narg(rating),
+  sqlc.narg(notes),
+  sqlc.arg(user_id),
+  sqlc.arg(user_id)
+)
+RETURNING *;
+
+-- name: ListRecipeCookEventsByRecipeID :many
+SELECT *
+FROM recipe_cook_events
+WHERE recipe_id = $1
+ORDER BY cooked_at DESC, id DESC;
+
+-- name: GetRecipeCookEvent :one
+SELECT *
+FROM recipe_cook_events
+WHERE recipe_id = $1 AND id = $2;
+
+-- name: UpdateRecipeCookEvent :one
+UPDATE recipe_cook_events
+SET cooked_at = COALESCE(sqlc.narg(cooked_at)::timestamptz, cooked_at),
+    servings = sqlc.narg(servings),
+    rating = sqlc.narg(rating),
+    notes = sqlc.narg(notes),
+    updated_at = now(),
+    updated_by = sqlc.arg(updated_by)
+WHERE recipe_id = sqlc.arg(recipe_id) AND id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteRecipeCookEvent :execrows
+DELETE FROM recipe_cook_events
+WHERE recipe_id = $1 AND id = $2;
+
+-- name: GetRecipeCookStats :one
+SELECT
+  max(cooked_at)::timestamptz AS last_cooked_at,
+  count(*)::int AS times_cooked,
+  avg(rating) AS avg_rating
+FROM recipe_cook_events
+WHERE recipe_id = $1;
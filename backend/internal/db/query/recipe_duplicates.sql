@@ -0,0 +1,150 @@
+-- name: ListRecipeDuplicateCandidates :many
+-- Pairs of live recipes whose titles are trigram-similar or that share at
+-- least one ingredient item. Pairs are scored as in the handler (title
+-- similarity weighted by title_weight plus the Jaccard overlap of their
+-- items, or the title alone when either has no items) so that only those
+-- reaching min_score are returned, best first, at most row_limit of them.
+-- When recipe_id is set, only pairs involving that recipe are returned, with
+-- it first.
+WITH live AS (
+  SELECT r.id, r.title
+  FROM recipes r
+  WHERE r.deleted_at IS NULL
+),
+recipe_items AS (
+  SELECT DISTINCT ri.recipe_id, ri.item_id
+  FROM recipe_ingredients ri
+  JOIN live l ON l.id = ri.recipe_id
+  WHERE ri.item_id IS NOT NULL
+),
+item_counts AS (
+  SELECT recipe_id, count(*)::int AS item_count
+  FROM recipe_items
+  GROUP BY recipe_id
+),
+shared_items AS (
+  SELECT a.recipe_id, b.recipe_id AS other_id, count(*)::int AS shared_count
+  FROM recipe_items a
+  JOIN recipe_items b ON b.item_id = a.item_id AND b.recipe_id <> a.recipe_id
+  WHERE (sqlc.narg(recipe_id)::uuid IS NULL AND a.recipe_id < b.recipe_id)
+    OR a.recipe_id = sqlc.narg(recipe_id)::uuid
+  GROUP BY a.recipe_id, b.recipe_id
+),
+similar_titles AS (
+  SELECT a.id AS recipe_id, b.id AS other_id
+  FROM live a
+  JOIN live b ON b.title % a.title AND b.id <> a.id
+  WHERE (sqlc.narg(recipe_id)::uuid IS NULL AND a.id < b.id)
+    OR a.id = sqlc.narg(recipe_id)::uuid
+),
+candidates AS (
+  SELECT
+    a.id AS recipe_id,
+    a.title AS recipe_title,
+    b.id AS other_id,
+    b.title AS other_title,
+    similarity(a.title, b.title)::float8 AS title_similarity,
+    COALESCE(s.shared_count, 0) AS shared_items,
+    COALESCE(ac.item_count, 0) AS recipe_items,
+    COALESCE(bc.item_count, 0) AS other_items
+  FROM (
+    SELECT recipe_id, other_id FROM shared_items
+    UNION
+    SELECT recipe_id, other_id FROM similar_titles
+  ) pairs
+  JOIN live a ON a.id = pairs.recipe_id
+  JOIN live b ON b.id = pairs.other_id
+  LEFT JOIN shared_items s ON s.recipe_id = pairs.recipe_id AND s.other_id = pairs.other_id
+  LEFT JOIN item_counts ac ON ac.recipe_id = a.id
+  LEFT JOIN item_counts bc ON bc.recipe_id = b.id
+),
+scored AS (
+  SELECT
+    c.recipe_id,
+    c.recipe_title,
+    c.other_id,
+    c.other_title,
+    c.title_similarity,
+    c.shared_items,
+    c.recipe_items,
+    c.other_items,
+    CASE
+      WHEN c.recipe_items > 0 AND c.other_items > 0 THEN
+        sqlc.arg(title_weight)::float8 * c.title_similarity
+        + (1 - sqlc.arg(title_weight)::float8) * c.shared_items::float8 / (c.recipe_items + c.other_items - c.shared_items)
+      ELSE c.title_similarity
+    END AS score
+  FROM candidates c
+)
+SELECT
+  recipe_id,
+  recipe_title,
+  other_id,
+  other_title,
+  title_similarity,
+  shared_items::int AS shared_items,
+  recipe_items::int AS recipe_items,
+  other_items::int AS other_items
+FROM scored
+WHERE round(score::numeric, 3)::float8 >= sqlc.arg(min_score)::float8
+ORDER BY score DESC, recipe_title ASC, other_title ASC
+LIMIT sqlc.arg(row_limit)::int;
+
+-- name: MoveRecipeTags :execrows
+-- Copies the source recipe's tags onto the target, skipping tags it
+-- already has. The caller removes the source rows afterwards.
+INSERT INTO recipe_tags (recipe_id, tag_id, created_by, updated_by)
+SELECT sqlc.arg(target_id)::uuid, rt.tag_id, sqlc.arg(user_id)::uuid, sqlc.arg(user_id)::uuid
+FROM recipe_tags rt
+WHERE rt.recipe_id = sqlc.arg(source_id)::uuid
+ON CONFLICT (recipe_id, tag_id) DO NOTHING;
+
+-- name: MoveMealPlanEntries :execrows
+-- Points meal-plan entries at the target recipe unless the same user already
+-- planned the target on that date.
+UPDATE meal_plan_entries mpe
+SET recipe_id = sqlc.arg(target_id)::uuid,
+    updated_at = now(),
+    updated_by = sqlc.arg(user_id)::uuid
+WHERE mpe.recipe_id = sqlc.arg(source_id)::uuid
+  AND NOT EXISTS (
+    SELECT 1
+    FROM meal_plan_entries existing
+    WHERE existing.user_id = mpe.user_id
+      AND existing.plan_date = mpe.plan_date
+      AND existing.recipe_id = sqlc.arg(target_id)::uuid
+  );
+
+-- name: DeleteMealPlanEntriesByRecipeID :exec
+DELETE FROM meal_plan_entries
+WHERE recipe_id = $1;
+
+-- name: ListRecipeIDsBySubRecipeID :many
+-- Recipes that use the given recipe as a sub-recipe line.
+SELECT DISTINCT ri.recipe_id
+FROM recipe_ingredients ri
+WHERE ri.sub_recipe_id = $1
+ORDER BY ri.recipe_id;
+
+-- name: MoveSubRecipeReferences :execrows
+-- Points sub-recipe lines that use the source recipe at the target instead.
+UPDATE recipe_ingredients
+SET sub_recipe_id = sqlc.arg(target_id)::uuid,
+    updated_at = now(),
+    updated_by = sqlc.arg(user_id)::uuid
+WHERE sub_recipe_id = sqlc.arg(source_id)::uuid;
+
+-- name: MoveRecipeVariants :execrows
+-- Re-parents variants of the source recipe onto the target. When the target
+-- is itself a variant of the source it takes over the source's parent, since
+-- a recipe cannot be its own parent.
+UPDATE recipes r
+SET parent_recipe_id = CASE
+      WHEN r.id = sqlc.arg(target_id)::uuid THEN NULLIF(source.parent_recipe_id, sqlc.arg(target_id)::uuid)
+      ELSE sqlc.arg(target_id)::uuid
+    END,
+    updated_at = now(),
+    updated_by = sqlc.arg(user_id)::uuid
+FROM recipes source
+WHERE source.id = sqlc.arg(source_id)::uuid
+  AND r.parent_recipe_id = source.id;
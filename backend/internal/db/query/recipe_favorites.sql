@@ -0,0 +1,15 @@
+-- name: AddRecipeFavorite :exec
+INSERT INTO recipe_favorites (user_id, recipe_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, recipe_id) DO NOTHING;
+
+-- name: DeleteRecipeFavorite :exec
+DELETE FROM recipe_favorites
+WHERE user_id = $1 AND recipe_id = $2;
+
+-- name: IsRecipeFavorite :one
+SELECT EXISTS (
+  SELECT 1
+  FROM recipe_favorites
+  WHERE user_id = $1 AND recipe_id = $2
+) AS is_favorite;
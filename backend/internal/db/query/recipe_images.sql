@@ -0,0 +1,39 @@
+-- name: CreateRecipeImage :one
+INSERT INTO recipe_images (
+  id,
+  recipe_id,
+  step_number,
+  content_type,
+  byte_size,
+  width,
+  height,
+  blob_key,
+  thumbnail_key,
+  created_by
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, recipe_id, step_number, content_type, byte_size, width, height, blob_key, thumbnail_key, created_at, created_by;
+
+-- name: ListRecipeImagesByRecipeID :many
+SELECT id, recipe_id, step_number, content_type, byte_size, width, height, blob_key, thumbnail_key, created_at, created_by
+FROM recipe_images
+WHERE recipe_id = $1
+ORDER BY step_number ASC NULLS FIRST, created_at ASC, id ASC;
+
+-- name: ListRecipeCoverImagesByRecipeIDs :many
+SELECT DISTINCT ON (recipe_id)
+  recipe_id,
+  id
+FROM recipe_images
+WHERE recipe_id = ANY($1::uuid[])
+ORDER BY recipe_id, step_number ASC NULLS FIRST, created_at ASC, id ASC;
+
+-- name: GetRecipeImage :one
+SELECT id, recipe_id, step_number, content_type, byte_size, width, height, blob_key, thumbnail_key, created_at, created_by
+FROM recipe_images
+WHERE recipe_id = $1 AND id = $2;
+
+-- name: DeleteRecipeImage :one
+DELETE FROM recipe_images
+WHERE recipe_id = $1 AND id = $2
+RETURNING id, recipe_id, step_number, content_type, byte_size, width, height, blob_key, thumbnail_key, created_at, created_by;
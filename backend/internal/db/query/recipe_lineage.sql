@@ -0,0 +1,11 @@
+-- name: GetRecipeSnapshot :one
+-- Returns the recipe's current state in the same shape as a revision
+-- snapshot, or NULL when the recipe does not exist.
+SELECT recipe_snapshot(sqlc.arg(recipe_id)::uuid)::jsonb AS snapshot;
+
+-- name: ListRecipeVariants :many
+-- Lists the live recipes that were copied from the given recipe.
+SELECT id, title
+FROM recipes
+WHERE parent_recipe_id = $1 AND deleted_at IS NULL
+ORDER BY title ASC, id ASC;
@@ -0,0 +1,62 @@
+-- name: ListItemsByIDsOrNames :many
+-- Resolves the items a cook has on hand. Each requested name is tried as
+-- each of its lookups in priority order, item names before aliases, and
+-- resolves to the first item found; requested_names lists the requested
+-- names that resolved to the item.
+WITH wanted AS (
+  SELECT w.lookup, w.requested, w.priority
+  FROM unnest(
+    sqlc.arg(lookups)::text[],
+    sqlc.arg(requested)::text[],
+    sqlc.arg(priorities)::int[]
+  ) AS w(lookup, requested, priority)
+),
+named AS (
+  SELECT DISTINCT ON (w.requested) w.requested, m.item_id
+  FROM wanted w
+  JOIN LATERAL (
+    SELECT i.id AS item_id, 0 AS source
+    FROM items i
+    WHERE i.name = w.lookup::citext
+    UNION ALL
+    SELECT a.item_id, 1
+    FROM item_aliases a
+    WHERE a.alias = w.lookup::citext
+  ) m ON true
+  ORDER BY w.requested, w.priority, m.source
+)
+SELECT
+  i.id,
+  i.name,
+  array_remove(array_agg(DISTINCT n.requested), NULL)::text[] AS requested_names
+FROM items i
+LEFT JOIN named n ON n.item_id = i.id
+WHERE i.id = ANY(sqlc.arg(item_ids)::uuid[])
+   OR n.item_id IS NOT NULL
+GROUP BY i.id, i.name
+ORDER BY i.name ASC;
+
+-- name: ListRecipeMatchItems :many
+-- Lists the distinct items each live recipe needs, including the items of
+-- its sub-recipes. Deleted sub-recipes contribute nothing.
+WITH RECURSIVE tree AS (
+  SELECT r.id AS root_id, r.id AS recipe_id
+  FROM recipes r
+  WHERE r.deleted_at IS NULL
+  UNION
+  SELECT t.root_id, sr.id
+  FROM recipe_ingredients ri
+  JOIN tree t ON t.recipe_id = ri.recipe_id
+  JOIN recipes sr ON sr.id = ri.sub_recipe_id
+  WHERE sr.deleted_at IS NULL
+)
+SELECT DISTINCT
+  r.id AS recipe_id,
+  r.title AS recipe_title,
+  i.id AS item_id,
+  i.name AS item_name
+FROM tree t
+JOIN recipes r ON r.id = t.root_id
+JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+JOIN items i ON i.id = ri.item_id
+ORDER BY r.id, i.name;
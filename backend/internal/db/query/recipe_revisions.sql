@@ -0,0 +1,78 @@
+-- name: CreateRecipeRevision :exec
+INSERT INTO recipe_revisions (recipe_id, revision_number, snapshot, created_by)
+SELECT
+  sqlc.arg(recipe_id)::uuid,
+  COALESCE(MAX(rr.revision_number), 0) + 1,
+  recipe_snapshot(sqlc.arg(recipe_id)::uuid),
+  sqlc.arg(created_by)::uuid
+FROM recipe_revisions rr
+WHERE rr.recipe_id = sqlc.arg(recipe_id)::uuid;
+
+-- name: CreateRecipeRevisions :exec
+-- Records a revision of each recipe, as CreateRecipeRevision does, for
+-- changes that touch many recipes at once. recipe_ids must be distinct.
+INSERT INTO recipe_revisions (recipe_id, revision_number, snapshot, created_by)
+SELECT
+  r.id,
+  COALESCE((
+    SELECT MAX(rr.revision_number)
+    FROM recipe_revisions rr
+    WHERE rr.recipe_id = r.id
+  ), 0) + 1,
+  recipe_snapshot(r.id),
+  sqlc.arg(created_by)::uuid
+FROM unnest(sqlc.arg(recipe_ids)::uuid[]) AS r(id);
+
+-- name: ListRecipeRevisions :many
+SELECT
+  revision_number,
+  (snapshot->>'title')::text AS title,
+  created_at,
+  created_by
+FROM recipe_revisions
+WHERE recipe_id = $1
+ORDER BY revision_number DESC;
+
+-- name: GetRecipeRevision :one
+SELECT id, recipe_id, revision_number, snapshot, created_at, created_by
+FROM recipe_revisions
+WHERE recipe_id = $1 AND revision_number = $2;
+
+-- name: MoveRecipeRevisionItems :exec
+-- Points ingredients in every stored snapshot at the target item, so
+-- reverting to a revision from before an item merge still finds its item.
+UPDATE recipe_revisions rr
+SET snapshot = jsonb_set(rr.snapshot, '{ingredients}', (
+  SELECT jsonb_agg(
+    CASE
+      WHEN e.ingredient->'item_id' = to_jsonb(sqlc.arg(source_id)::uuid)
+        THEN jsonb_set(e.ingredient, '{item_id}', to_jsonb(sqlc.arg(target_id)::uuid))
+      ELSE e.ingredient
+    END
+    ORDER BY e.ord
+  )
+  FROM jsonb_array_elements(rr.snapshot->'ingredients') WITH ORDINALITY AS e(ingredient, ord)
+))
+WHERE rr.snapshot->'ingredients' @> jsonb_build_array(jsonb_build_object('item_id', sqlc.arg(source_id)::uuid));
+
+-- name: MoveRecipeRevisionTags :exec
+-- Swaps the merged tag for the target in every stored snapshot, dropping
+-- the duplicate when a snapshot already had both.
+UPDATE recipe_revisions rr
+SET snapshot = jsonb_set(rr.snapshot, '{tag_ids}', (
+  SELECT jsonb_agg(DISTINCT t.tag_id ORDER BY t.tag_id)
+  FROM (
+    SELECT
+      CASE
+        WHEN e.tag_id = to_jsonb(sqlc.arg(source_id)::uuid) THEN to_jsonb(sqlc.arg(target_id)::uuid)
+        ELSE e.tag_id
+      END AS tag_id
+    FROM jsonb_array_elements(rr.snapshot->'tag_ids') AS e(tag_id)
+  ) t
+))
+WHERE rr.snapshot->'tag_ids' @> jsonb_build_array(sqlc.arg(source_id)::uuid);
+
+-- name: MoveRecipeRevisionRecipeBooks :exec
+UPDATE recipe_revisions
+SET snapshot = jsonb_set(snapshot, '{recipe_book_id}', to_jsonb(sqlc.arg(target_id)::uuid))
+WHERE snapshot->'recipe_book_id' = to_jsonb(sqlc.arg(source_id)::uuid);
@@ -0,0 +1,27 @@
+-- name: RefreshRecipeSearchDocument :exec
+-- Recipes using this one as a sub-recipe index its title, so they are
+-- refreshed too.
+INSERT INTO recipe_search_documents (recipe_id, document, updated_at)
+SELECT affected.recipe_id, recipe_search_document(affected.recipe_id), now()
+FROM (
+  SELECT sqlc.arg(recipe_id)::uuid AS recipe_id
+  UNION
+  SELECT ri.recipe_id
+  FROM recipe_ingredients ri
+  WHERE ri.sub_recipe_id = sqlc.arg(recipe_id)::uuid
+) affected
+ON CONFLICT (recipe_id) DO UPDATE
+SET document = EXCLUDED.document,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: RefreshRecipeSearchDocumentsByItemID :exec
+INSERT INTO recipe_search_documents (recipe_id, document, updated_at)
+SELECT affected.recipe_id, recipe_search_document(affected.recipe_id), now()
+FROM (
+  SELECT DISTINCT ri.recipe_id
+  FROM recipe_ingredients ri
+  WHERE ri.item_id = sqlc.arg(item_id)::uuid
+) affected
+ON CONFLICT (recipe_id) DO UPDATE
+SET document = EXCLUDED.document,
+    updated_at = EXCLUDED.updated_at;
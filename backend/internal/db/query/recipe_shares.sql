@@ -0,0 +1,27 @@
+-- name: CreateRecipeShare :one
+INSERT INTO recipe_shares (recipe_id, token_hash, expires_at, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListRecipeSharesByRecipeID :many
+SELECT *
+FROM recipe_shares
+WHERE recipe_id = $1 AND created_by = $2
+ORDER BY created_at DESC;
+
+-- name: DeleteRecipeShare :execrows
+DELETE FROM recipe_shares
+WHERE recipe_id = $1 AND id = $2 AND created_by = $3;
+
+-- name: DeleteRecipeSharesByRecipeID :execrows
+DELETE FROM recipe_shares
+WHERE recipe_id = $1 AND created_by = $2;
+
+-- name: GetSharedRecipeIDByTokenHash :one
+-- Expired shares and shares of deleted recipes resolve to no rows.
+SELECT s.recipe_id
+FROM recipe_shares s
+JOIN recipes r ON r.id = s.recipe_id
+WHERE s.token_hash = $1
+  AND r.deleted_at IS NULL
+  AND (s.expires_at IS NULL OR s.expires_at > now());
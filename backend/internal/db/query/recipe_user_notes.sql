@@ -0,0 +1,16 @@
+-- name: GetRecipeUserNote :one
+SELECT *
+FROM recipe_user_notes
+WHERE user_id = $1 AND recipe_id = $2;
+
+-- name: UpsertRecipeUserNote :one
+INSERT INTO recipe_user_notes (user_id, recipe_id, note)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, recipe_id) DO UPDATE
+SET note = EXCLUDED.note,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteRecipeUserNote :execrows
+DELETE FROM recipe_user_notes
+WHERE user_id = $1 AND recipe_id = $2;
@@ -7,11 +7,12 @@ INSERT INTO recipes (
   source_url,
   notes,
   recipe_book_id,
+  parent_recipe_id,
   deleted_at,
   created_by,
   updated_by
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, NULL, $8, $9
+  $1, $2, $3, $4, $5, $6, $7, $8, NULL, $9, $10
 )
 RETURNING *;
 
@@ -25,6 +26,7 @@ SELECT
   ri.id,
   ri.recipe_id,
   ri.position,
+  ri.group_name,
   ri.quantity,
   ri.quantity_text,
   ri.unit,
@@ -36,6 +38,10 @@ SELECT
   a.sort_group AS aisle_sort_group,
   a.sort_order AS aisle_sort_order,
   a.numeric_value AS aisle_numeric_value,
+  ri.sub_recipe_id,
+  sr.title AS sub_recipe_title,
+  sr.servings AS sub_recipe_servings,
+  sr.deleted_at IS NOT NULL AS sub_recipe_deleted,
   ri.prep,
   ri.notes,
   ri.original_text,
@@ -44,8 +50,9 @@ SELECT
   ri.updated_at,
   ri.updated_by
 FROM recipe_ingredients ri
-JOIN items i ON i.id = ri.item_id
+LEFT JOIN items i ON i.id = ri.item_id
 LEFT JOIN grocery_aisles a ON a.id = i.aisle_id
+LEFT JOIN recipes sr ON sr.id = ri.sub_recipe_id
 WHERE ri.recipe_id = $1
 ORDER BY ri.position ASC;
 
@@ -68,28 +75,50 @@ ORDER BY t.name ASC;
 INSERT INTO recipe_ingredients (
   recipe_id,
   position,
+  group_name,
   quantity,
   quantity_text,
   unit,
   item_id,
+  sub_recipe_id,
   prep,
   notes,
   original_text,
   created_by,
   updated_by
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 );
 
+-- name: SubRecipeTreeContains :one
+WITH RECURSIVE tree AS (
+  SELECT sqlc.arg(sub_recipe_id)::uuid AS recipe_id
+  UNION
+  SELECT ri.sub_recipe_id
+  FROM recipe_ingredients ri
+  JOIN tree t ON t.recipe_id = ri.recipe_id
+  WHERE ri.sub_recipe_id IS NOT NULL
+)
+SELECT EXISTS (
+  SELECT 1
+  FROM tree
+  WHERE recipe_id = sqlc.arg(recipe_id)::uuid
+) AS contains_recipe;
+
 -- name: CreateRecipeStep :exec
 INSERT INTO recipe_steps (
   recipe_id,
   step_number,
+  group_name,
   instruction,
+  duration_seconds,
+  duration_max_seconds,
+  temperature,
+  temperature_unit,
   created_by,
   updated_by
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 );
 
 -- name: CreateRecipeTag :exec
@@ -108,32 +137,164 @@ FROM tags
 WHERE id = ANY($1::uuid[]);
 
 -- name: ListRecipes :many
+WITH search AS (
+  SELECT websearch_to_tsquery('english', sqlc.arg(q)::text) AS query
+),
+matches AS (
+  SELECT
+    r.id,
+    r.title,
+    r.servings,
+    r.prep_time_minutes,
+    r.total_time_minutes,
+    r.source_url,
+    r.notes,
+    r.recipe_book_id,
+    r.deleted_at,
+    r.created_at,
+    r.updated_at,
+    c.last_cooked_at,
+    c.times_cooked,
+    c.avg_rating,
+    CASE
+      WHEN sqlc.arg(q)::text = '' THEN 0
+      ELSE ts_rank(COALESCE(d.document, ''::tsvector), s.query) + word_similarity(sqlc.arg(q)::text, r.title)
+    END::float8 AS rank
+  FROM recipes r
+  CROSS JOIN search s
+  LEFT JOIN recipe_search_documents d ON d.recipe_id = r.id
+  CROSS JOIN LATERAL (
+    SELECT
+      max(ce.cooked_at)::timestamptz AS last_cooked_at,
+      count(*)::int AS times_cooked,
+      avg(ce.rating) AS avg_rating
+    FROM recipe_cook_events ce
+    WHERE ce.recipe_id = r.id
+  ) c
+  WHERE
+    (
+      sqlc.arg(q)::text = ''
+      OR r.title ILIKE ('%' || sqlc.arg(q)::text || '%')
+      OR d.document @@ s.query
+      OR (sqlc.arg(fuzzy)::boolean AND sqlc.arg(q)::text <% r.title)
+    )
+    AND (sqlc.arg(book_id)::uuid IS NULL OR r.recipe_book_id = sqlc.arg(book_id)::uuid)
+    AND (
+      cardinality(sqlc.arg(tag_ids)::uuid[]) = 0
+      OR (sqlc.arg(tag_match_all)::boolean AND NOT EXISTS (
+        SELECT 1
+        FROM unnest(sqlc.arg(tag_ids)::uuid[]) AS wanted(tag_id)
+        WHERE NOT EXISTS (
+          SELECT 1
+          FROM recipe_tags rt
+          WHERE rt.recipe_id = r.id AND rt.tag_id = wanted.tag_id
+        )
+      ))
+      OR (NOT sqlc.arg(tag_match_all)::boolean AND EXISTS (
+        SELECT 1
+        FROM recipe_tags rt
+        WHERE rt.recipe_id = r.id AND rt.tag_id = ANY(sqlc.arg(tag_ids)::uuid[])
+      ))
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM unnest(sqlc.arg(with_item_ids)::uuid[]) AS wanted(item_id)
+      WHERE NOT EXISTS (
+        SELECT 1
+        FROM recipe_ingredients ri
+        WHERE ri.recipe_id = r.id AND ri.item_id = wanted.item_id
+      )
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM recipe_ingredients ri
+      WHERE ri.recipe_id = r.id AND ri.item_id = ANY(sqlc.arg(without_item_ids)::uuid[])
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM recipe_item_attributes(r.id) AS a(attribute)
+      WHERE a.attribute = ANY(sqlc.arg(exclude_attributes)::text[])
+    )
+    AND (
+      cardinality(sqlc.arg(exclude_attributes)::text[]) = 0
+      OR NOT EXISTS (SELECT 1 FROM recipe_unclassified_items(r.id))
+    )
+    AND (sqlc.narg(max_total_time)::int IS NULL OR r.total_time_minutes <= sqlc.narg(max_total_time)::int)
+    AND (sqlc.narg(max_prep_time)::int IS NULL OR r.prep_time_minutes <= sqlc.narg(max_prep_time)::int)
+    AND (sqlc.narg(servings_min)::int IS NULL OR r.servings >= sqlc.narg(servings_min)::int)
+    AND (sqlc.narg(servings_max)::int IS NULL OR r.servings <= sqlc.narg(servings_max)::int)
+    AND (sqlc.arg(include_deleted)::boolean OR r.deleted_at IS NULL)
+    AND (
+      NOT sqlc.arg(favorites_only)::boolean
+      OR EXISTS (
+        SELECT 1
+        FROM recipe_favorites f
+        WHERE f.recipe_id = r.id AND f.user_id = sqlc.arg(user_id)::uuid
+      )
+    )
+)
 SELECT
-  r.id,
-  r.title,
-  r.servings,
-  r.prep_time_minutes,
-  r.total_time_minutes,
-  r.source_url,
-  r.notes,
-  r.recipe_book_id,
-  r.deleted_at,
-  r.updated_at
-FROM recipes r
-WHERE
-  (sqlc.arg(q)::text = '' OR r.title ILIKE ('%' || sqlc.arg(q)::text || '%'))
-  AND (sqlc.arg(book_id)::uuid IS NULL OR r.recipe_book_id = sqlc.arg(book_id)::uuid)
-  AND (sqlc.arg(tag_id)::uuid IS NULL OR EXISTS (
+  m.id,
+  m.title,
+  m.servings,
+  m.prep_time_minutes,
+  m.total_time_minutes,
+  m.source_url,
+  m.notes,
+  m.recipe_book_id,
+  m.deleted_at,
+  m.created_at,
+  m.updated_at,
+  m.last_cooked_at,
+  m.times_cooked,
+  m.avg_rating,
+  EXISTS (
     SELECT 1
-    FROM recipe_tags rt
-    WHERE rt.recipe_id = r.id AND rt.tag_id = sqlc.arg(tag_id)::uuid
-  ))
-  AND (sqlc.arg(include_deleted)::boolean OR r.deleted_at IS NULL)
-  AND (
-    sqlc.arg(cursor_updated_at)::timestamptz IS NULL
-    OR (r.updated_at, r.id) < (sqlc.arg(cursor_updated_at)::timestamptz, sqlc.arg(cursor_id)::uuid)
-  )
-ORDER BY r.updated_at DESC, r.id DESC
+    FROM recipe_favorites f
+    WHERE f.recipe_id = m.id AND f.user_id = sqlc.arg(user_id)::uuid
+  ) AS is_favorite,
+  m.rank
+FROM matches m
+WHERE
+  NOT sqlc.arg(has_cursor)::boolean
+  OR CASE sqlc.arg(sort_key)::text
+    WHEN 'relevance' THEN
+      (m.rank, m.updated_at, m.id) < (sqlc.arg(cursor_rank)::float8, sqlc.arg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid)
+    WHEN 'title' THEN
+      (sqlc.arg(sort_desc)::boolean AND (lower(m.title), m.id) < (lower(sqlc.arg(cursor_text)::text), sqlc.arg(cursor_id)::uuid))
+      OR (NOT sqlc.arg(sort_desc)::boolean AND (lower(m.title), m.id) > (lower(sqlc.arg(cursor_text)::text), sqlc.arg(cursor_id)::uuid))
+    WHEN 'total_time' THEN
+      (sqlc.arg(sort_desc)::boolean AND (m.total_time_minutes, m.id) < (sqlc.arg(cursor_int)::int, sqlc.arg(cursor_id)::uuid))
+      OR (NOT sqlc.arg(sort_desc)::boolean AND (m.total_time_minutes, m.id) > (sqlc.arg(cursor_int)::int, sqlc.arg(cursor_id)::uuid))
+    WHEN 'prep_time' THEN
+      (sqlc.arg(sort_desc)::boolean AND (m.prep_time_minutes, m.id) < (sqlc.arg(cursor_int)::int, sqlc.arg(cursor_id)::uuid))
+      OR (NOT sqlc.arg(sort_desc)::boolean AND (m.prep_time_minutes, m.id) > (sqlc.arg(cursor_int)::int, sqlc.arg(cursor_id)::uuid))
+    WHEN 'created_at' THEN
+      (sqlc.arg(sort_desc)::boolean AND (m.created_at, m.id) < (sqlc.arg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid))
+      OR (NOT sqlc.arg(sort_desc)::boolean AND (m.created_at, m.id) > (sqlc.arg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid))
+    WHEN 'last_cooked' THEN
+      (sqlc.arg(sort_desc)::boolean AND (COALESCE(m.last_cooked_at, '-infinity'), m.id) < (COALESCE(sqlc.arg(cursor_time)::timestamptz, '-infinity'), sqlc.arg(cursor_id)::uuid))
+      OR (NOT sqlc.arg(sort_desc)::boolean AND (COALESCE(m.last_cooked_at, '-infinity'), m.id) > (COALESCE(sqlc.arg(cursor_time)::timestamptz, '-infinity'), sqlc.arg(cursor_id)::uuid))
+    ELSE
+      (sqlc.arg(sort_desc)::boolean AND (m.updated_at, m.id) < (sqlc.arg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid))
+      OR (NOT sqlc.arg(sort_desc)::boolean AND (m.updated_at, m.id) > (sqlc.arg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid))
+  END
+ORDER BY
+  CASE WHEN sqlc.arg(sort_key)::text = 'relevance' THEN m.rank END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'title' AND NOT sqlc.arg(sort_desc)::boolean THEN lower(m.title) END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'title' AND sqlc.arg(sort_desc)::boolean THEN lower(m.title) END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'total_time' AND NOT sqlc.arg(sort_desc)::boolean THEN m.total_time_minutes END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'total_time' AND sqlc.arg(sort_desc)::boolean THEN m.total_time_minutes END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'prep_time' AND NOT sqlc.arg(sort_desc)::boolean THEN m.prep_time_minutes END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'prep_time' AND sqlc.arg(sort_desc)::boolean THEN m.prep_time_minutes END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at' AND NOT sqlc.arg(sort_desc)::boolean THEN m.created_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at' AND sqlc.arg(sort_desc)::boolean THEN m.created_at END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'last_cooked' AND NOT sqlc.arg(sort_desc)::boolean THEN COALESCE(m.last_cooked_at, '-infinity') END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'last_cooked' AND sqlc.arg(sort_desc)::boolean THEN COALESCE(m.last_cooked_at, '-infinity') END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'updated_at' AND NOT sqlc.arg(sort_desc)::boolean THEN m.updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text IN ('updated_at', 'relevance') AND sqlc.arg(sort_desc)::boolean THEN m.updated_at END DESC,
+  CASE WHEN NOT sqlc.arg(sort_desc)::boolean THEN m.id END ASC,
+  CASE WHEN sqlc.arg(sort_desc)::boolean THEN m.id END DESC
 LIMIT sqlc.arg(page_limit);
 
 -- name: ListRecipeTagsByRecipeIDs :many
@@ -1,4 +1,7 @@
 -- name: ListShoppingListItemsByListID :many
+-- Lines are ordered by the list's store layout when it has a store, else by
+-- the default layout; items not placed in the store's aisles use their default
+-- aisle.
 SELECT
   sli.id,
   sli.shopping_list_id,
@@ -10,7 +13,7 @@ SELECT
   sli.purchased_at,
   i.name AS item_name,
   i.store_url AS item_store_url,
-  i.aisle_id AS item_aisle_id,
+  a.id AS item_aisle_id,
   a.name AS aisle_name,
   a.sort_group AS aisle_sort_group,
   a.sort_order AS aisle_sort_order,
@@ -18,7 +21,8 @@ SELECT
 FROM shopping_list_items sli
 JOIN shopping_lists sl ON sl.id = sli.shopping_list_id
 JOIN items i ON i.id = sli.item_id
-LEFT JOIN grocery_aisles a ON a.id = i.aisle_id
+LEFT JOIN store_item_aisles sia ON sia.store_id = sl.store_id AND sia.item_id = i.id
+LEFT JOIN grocery_aisles a ON a.id = COALESCE(sia.aisle_id, i.aisle_id)
 WHERE sli.shopping_list_id = sqlc.arg(shopping_list_id)
   AND sl.created_by = sqlc.arg(user_id)
 ORDER BY
@@ -79,7 +83,8 @@ SET is_purchased = sqlc.arg(is_purchased),
     updated_by = sqlc.arg(updated_by)
 FROM shopping_lists sl
 JOIN items i ON i.id = sli.item_id
-LEFT JOIN grocery_aisles a ON a.id = i.aisle_id
+LEFT JOIN store_item_aisles sia ON sia.store_id = sl.store_id AND sia.item_id = i.id
+LEFT JOIN grocery_aisles a ON a.id = COALESCE(sia.aisle_id, i.aisle_id)
 WHERE sli.id = sqlc.arg(id)
   AND sli.shopping_list_id = sqlc.arg(shopping_list_id)
   AND sli.shopping_list_id = sl.id
@@ -95,12 +100,23 @@ RETURNING
   sli.purchased_at,
   i.name AS item_name,
   i.store_url AS item_store_url,
-  i.aisle_id AS item_aisle_id,
+  a.id AS item_aisle_id,
   a.name AS aisle_name,
   a.sort_group AS aisle_sort_group,
   a.sort_order AS aisle_sort_order,
   a.numeric_value AS aisle_numeric_value;
 
+-- name: GetShoppingListItemForUpdate :one
+-- Locks a list item so its purchase state can be changed and the pantry
+-- restocked at most once.
+SELECT sli.*
+FROM shopping_list_items sli
+JOIN shopping_lists sl ON sl.id = sli.shopping_list_id
+WHERE sli.id = sqlc.arg(id)
+  AND sli.shopping_list_id = sqlc.arg(shopping_list_id)
+  AND sl.created_by = sqlc.arg(user_id)
+FOR UPDATE OF sli;
+
 -- name: DeleteShoppingListItemByID :execrows
 DELETE FROM shopping_list_items sli
 USING shopping_lists sl
@@ -110,27 +126,114 @@ WHERE sli.id = sqlc.arg(id)
   AND sl.created_by = sqlc.arg(user_id);
 
 -- name: ListRecipeIngredientsByRecipeIDs :many
+-- Sub-recipe lines are expanded recursively; factor is the fraction of the
+-- sub-recipe's batch that one batch of the root recipe uses. Deleted
+-- sub-recipes contribute nothing.
+WITH RECURSIVE expanded AS (
+  SELECT
+    ri.recipe_id AS root_recipe_id,
+    ri.item_id,
+    ri.sub_recipe_id,
+    ri.quantity,
+    ri.quantity_text,
+    ri.unit,
+    1::float8 AS factor,
+    ARRAY[ri.recipe_id] AS path,
+    ARRAY[ri.position] AS sort_path
+  FROM recipe_ingredients ri
+  JOIN recipes r ON r.id = ri.recipe_id
+  WHERE ri.recipe_id = ANY(sqlc.arg(recipe_ids)::uuid[])
+    AND r.deleted_at IS NULL
+  UNION ALL
+  SELECT
+    e.root_recipe_id,
+    ri.item_id,
+    ri.sub_recipe_id,
+    ri.quantity,
+    ri.quantity_text,
+    ri.unit,
+    e.factor * COALESCE(e.quantity::float8, sr.servings::float8) / sr.servings::float8,
+    e.path || ri.recipe_id,
+    e.sort_path || ri.position
+  FROM expanded e
+  JOIN recipes sr ON sr.id = e.sub_recipe_id
+  JOIN recipe_ingredients ri ON ri.recipe_id = sr.id
+  WHERE NOT ri.recipe_id = ANY(e.path)
+    AND sr.deleted_at IS NULL
+)
 SELECT
-  ri.item_id,
-  ri.quantity,
-  ri.quantity_text,
-  ri.unit
-FROM recipe_ingredients ri
-JOIN recipes r ON r.id = ri.recipe_id
-WHERE ri.recipe_id = ANY(sqlc.arg(recipe_ids)::uuid[])
-  AND r.deleted_at IS NULL
-ORDER BY ri.recipe_id ASC, ri.position ASC;
+  e.root_recipe_id AS recipe_id,
+  e.item_id,
+  e.quantity,
+  e.quantity_text,
+  e.unit,
+  e.factor,
+  r.servings AS recipe_servings
+FROM expanded e
+JOIN recipes r ON r.id = e.root_recipe_id
+WHERE e.item_id IS NOT NULL
+ORDER BY e.root_recipe_id ASC, e.sort_path ASC;
 
 -- name: ListRecipeIngredientsByMealPlanDate :many
+-- Sub-recipe lines are expanded recursively, as in ListRecipeIngredientsByRecipeIDs.
+WITH RECURSIVE expanded AS (
+  SELECT
+    ri.item_id,
+    ri.sub_recipe_id,
+    ri.quantity,
+    ri.quantity_text,
+    ri.unit,
+    1::float8 AS factor,
+    ARRAY[ri.recipe_id] AS path,
+    ARRAY[ri.position] AS sort_path
+  FROM meal_plan_entries mpe
+  JOIN recipes r ON r.id = mpe.recipe_id
+  JOIN recipe_ingredients ri ON ri.recipe_id = r.id
+  WHERE mpe.user_id = sqlc.arg(user_id)
+    AND mpe.plan_date = sqlc.arg(plan_date)
+    AND r.deleted_at IS NULL
+  UNION ALL
+  SELECT
+    ri.item_id,
+    ri.sub_recipe_id,
+    ri.quantity,
+    ri.quantity_text,
+    ri.unit,
+    e.factor * COALESCE(e.quantity::float8, sr.servings::float8) / sr.servings::float8,
+    e.path || ri.recipe_id,
+    e.sort_path || ri.position
+  FROM expanded e
+  JOIN recipes sr ON sr.id = e.sub_recipe_id
+  JOIN recipe_ingredients ri ON ri.recipe_id = sr.id
+  WHERE NOT ri.recipe_id = ANY(e.path)
+    AND sr.deleted_at IS NULL
+)
 SELECT
-  ri.item_id,
-  ri.quantity,
-  ri.quantity_text,
-  ri.unit
-FROM meal_plan_entries mpe
-JOIN recipes r ON r.id = mpe.recipe_id
-JOIN recipe_ingredients ri ON ri.recipe_id = r.id
-WHERE mpe.user_id = sqlc.arg(user_id)
-  AND mpe.plan_date = sqlc.arg(plan_date)
-  AND r.deleted_at IS NULL
-ORDER BY mpe.plan_date ASC, ri.position ASC;
+  e.item_id,
+  e.quantity,
+  e.quantity_text,
+  e.unit,
+  e.factor
+FROM expanded e
+WHERE e.item_id IS NOT NULL
+ORDER BY e.sort_path ASC;
+
+-- name: ListShoppingListPantryReservations :many
+-- Lists the pantry stock already set against a list for the given items.
+SELECT r.item_id, r.unit, r.quantity
+FROM shopping_list_pantry_reservations r
+JOIN shopping_lists sl ON sl.id = r.shopping_list_id
+WHERE r.shopping_list_id = sqlc.arg(shopping_list_id)
+  AND sl.created_by = sqlc.arg(user_id)
+  AND r.item_id = ANY(sqlc.arg(item_ids)::uuid[]);
+
+-- name: AddShoppingListPantryReservation :execrows
+-- Sets pantry stock against a list, adding to any earlier reservation for the
+-- same item and unit.
+INSERT INTO shopping_list_pantry_reservations (shopping_list_id, item_id, unit, quantity)
+SELECT sl.id, sqlc.arg(item_id), sqlc.arg(unit), sqlc.arg(quantity)
+FROM shopping_lists sl
+WHERE sl.id = sqlc.arg(shopping_list_id)
+  AND sl.created_by = sqlc.arg(user_id)
+ON CONFLICT (shopping_list_id, item_id, unit) DO UPDATE
+SET quantity = shopping_list_pantry_reservations.quantity + EXCLUDED.quantity;
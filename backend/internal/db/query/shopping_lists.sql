@@ -4,6 +4,7 @@ SELECT
   list_date,
   name,
   notes,
+  store_id,
   created_at,
   updated_at
 FROM shopping_lists
@@ -17,16 +18,18 @@ INSERT INTO shopping_lists (
   list_date,
   name,
   notes,
+  store_id,
   created_by,
   updated_by
 ) VALUES (
   sqlc.arg(list_date),
   sqlc.arg(name),
   sqlc.arg(notes),
+  sqlc.arg(store_id),
   sqlc.arg(created_by),
   sqlc.arg(updated_by)
 )
-RETURNING id, list_date, name, notes, created_at, updated_at;
+RETURNING id, list_date, name, notes, store_id, created_at, updated_at;
 
 -- name: GetShoppingListByID :one
 SELECT
@@ -34,6 +37,7 @@ SELECT
   list_date,
   name,
   notes,
+  store_id,
   created_at,
   updated_at
 FROM shopping_lists
@@ -41,15 +45,18 @@ WHERE id = sqlc.arg(id)
   AND created_by = sqlc.arg(user_id);
 
 -- name: UpdateShoppingListByID :one
+-- The store is only changed when set_store is true, so clients that don't
+-- send one keep the list's current store.
 UPDATE shopping_lists
 SET list_date = sqlc.arg(list_date),
     name = sqlc.arg(name),
     notes = sqlc.arg(notes),
+    store_id = CASE WHEN sqlc.arg(set_store)::boolean THEN sqlc.narg(store_id)::uuid ELSE store_id END,
     updated_at = now(),
     updated_by = sqlc.arg(updated_by)
 WHERE id = sqlc.arg(id)
   AND created_by = sqlc.arg(user_id)
-RETURNING id, list_date, name, notes, created_at, updated_at;
+RETURNING id, list_date, name, notes, store_id, created_at, updated_at;
 
 -- name: DeleteShoppingListByID :execrows
 DELETE FROM shopping_lists
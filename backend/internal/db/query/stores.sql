@@ -0,0 +1,73 @@
+-- name: ListStores :many
+SELECT *
+FROM stores
+ORDER BY name ASC;
+
+-- name: GetStoreByID :one
+SELECT *
+FROM stores
+WHERE id = $1;
+
+-- name: CreateStore :one
+INSERT INTO stores (
+  name,
+  created_by,
+  updated_by
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: UpdateStoreByID :one
+UPDATE stores
+SET name = $2, updated_at = now(), updated_by = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteStoreByID :execrows
+DELETE FROM stores
+WHERE id = $1;
+
+-- name: ListStoreItemAisles :many
+SELECT
+  sia.item_id,
+  i.name AS item_name,
+  a.id AS aisle_id,
+  a.name AS aisle_name,
+  a.sort_group AS aisle_sort_group,
+  a.sort_order AS aisle_sort_order,
+  a.numeric_value AS aisle_numeric_value
+FROM store_item_aisles sia
+JOIN items i ON i.id = sia.item_id
+JOIN grocery_aisles a ON a.id = sia.aisle_id
+WHERE sia.store_id = $1
+ORDER BY
+  a.sort_group ASC,
+  a.sort_order ASC,
+  COALESCE(a.numeric_value, 0) ASC,
+  a.name ASC,
+  i.name ASC;
+
+-- name: UpsertStoreItemAisle :one
+INSERT INTO store_item_aisles (
+  store_id,
+  item_id,
+  aisle_id,
+  created_by,
+  updated_by
+) VALUES (
+  sqlc.arg(store_id),
+  sqlc.arg(item_id),
+  sqlc.arg(aisle_id),
+  sqlc.arg(user_id),
+  sqlc.arg(user_id)
+)
+ON CONFLICT (store_id, item_id) DO UPDATE
+SET aisle_id = EXCLUDED.aisle_id,
+  updated_at = now(),
+  updated_by = EXCLUDED.updated_by
+RETURNING *;
+
+-- name: DeleteStoreItemAisle :execrows
+DELETE FROM store_item_aisles
+WHERE store_id = $1 AND item_id = $2;
@@ -23,3 +23,23 @@ RETURNING *;
 DELETE FROM tags
 WHERE id = $1;
 
+
+-- name: GetTagByID :one
+SELECT *
+FROM tags
+WHERE id = $1;
+
+-- name: ListRecipeIDsByTagID :many
+SELECT recipe_id
+FROM recipe_tags
+WHERE tag_id = $1
+ORDER BY recipe_id;
+
+-- name: MoveRecipeTagsToTag :execrows
+-- Gives the source tag's recipes the target tag, skipping recipes that
+-- already have it. Deleting the source tag removes the old rows.
+INSERT INTO recipe_tags (recipe_id, tag_id, created_by, updated_by)
+SELECT rt.recipe_id, sqlc.arg(target_id)::uuid, sqlc.arg(user_id)::uuid, sqlc.arg(user_id)::uuid
+FROM recipe_tags rt
+WHERE rt.tag_id = sqlc.arg(source_id)::uuid
+ON CONFLICT (recipe_id, tag_id) DO NOTHING;
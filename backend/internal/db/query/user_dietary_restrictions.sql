@@ -0,0 +1,20 @@
+-- name: GetUserDietaryRestrictions :one
+SELECT *
+FROM user_dietary_restrictions
+WHERE user_id = $1;
+
+-- name: UpsertUserDietaryRestrictions :one
+INSERT INTO user_dietary_restrictions (
+  user_id,
+  diets,
+  allergens,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (user_id) DO UPDATE
+SET diets = EXCLUDED.diets,
+    allergens = EXCLUDED.allergens,
+    updated_at = now(),
+    updated_by = EXCLUDED.updated_by
+RETURNING *;
@@ -238,3 +238,654 @@ CREATE INDEX recipe_ingredients_item_id_idx ON recipe_ingredients (item_id);
 
 ALTER TABLE recipe_ingredients
 	DROP COLUMN item;
+
+-- +goose StatementBegin
+CREATE FUNCTION recipe_search_document(target_recipe_id uuid) RETURNS tsvector
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT
+		setweight(to_tsvector('english', r.title), 'A')
+		|| setweight(to_tsvector('english', COALESCE((
+			SELECT string_agg(i.name::text, ' ' ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '')), 'B')
+		|| setweight(to_tsvector('english', COALESCE(r.notes, '')), 'C')
+		|| setweight(to_tsvector('english', COALESCE((
+			SELECT string_agg(rs.instruction, ' ' ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '')), 'D')
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+CREATE TABLE recipe_search_documents (
+	recipe_id uuid PRIMARY KEY REFERENCES recipes (id) ON DELETE CASCADE,
+	document tsvector NOT NULL,
+	updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX recipe_search_documents_document_idx ON recipe_search_documents USING gin (document);
+
+INSERT INTO recipe_search_documents (recipe_id, document)
+SELECT r.id, recipe_search_document(r.id)
+FROM recipes r;
+
+-- +goose StatementBegin
+CREATE FUNCTION recipe_snapshot(target_recipe_id uuid) RETURNS jsonb
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT jsonb_build_object(
+		'title', r.title,
+		'servings', r.servings,
+		'prep_time_minutes', r.prep_time_minutes,
+		'total_time_minutes', r.total_time_minutes,
+		'source_url', r.source_url,
+		'notes', r.notes,
+		'recipe_book_id', r.recipe_book_id,
+		'tag_ids', COALESCE((
+			SELECT jsonb_agg(rt.tag_id ORDER BY rt.tag_id)
+			FROM recipe_tags rt
+			WHERE rt.recipe_id = r.id
+		), '[]'::jsonb),
+		'ingredients', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'position', ri.position,
+				'quantity', ri.quantity,
+				'quantity_text', ri.quantity_text,
+				'unit', ri.unit,
+				'item_id', ri.item_id,
+				'item_name', i.name,
+				'prep', ri.prep,
+				'notes', ri.notes,
+				'original_text', ri.original_text
+			) ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '[]'::jsonb),
+		'steps', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'step_number', rs.step_number,
+				'instruction', rs.instruction
+			) ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '[]'::jsonb)
+	)
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+CREATE TABLE recipe_revisions (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	revision_number int NOT NULL CONSTRAINT recipe_revisions_revision_number_positive_chk CHECK (revision_number > 0),
+	snapshot jsonb NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	CONSTRAINT recipe_revisions_recipe_id_revision_number_unique UNIQUE (recipe_id, revision_number)
+);
+
+-- Existing recipes start their history at their current state.
+INSERT INTO recipe_revisions (recipe_id, revision_number, snapshot, created_at, created_by)
+SELECT r.id, 1, recipe_snapshot(r.id), r.updated_at, r.updated_by
+FROM recipes r;
+
+CREATE TABLE recipe_images (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	-- Steps are rewritten on every recipe update, so images point at a step
+	-- number rather than a step row.
+	step_number int CONSTRAINT recipe_images_step_number_positive_chk CHECK (step_number > 0),
+	content_type text NOT NULL,
+	byte_size bigint NOT NULL CONSTRAINT recipe_images_byte_size_positive_chk CHECK (byte_size > 0),
+	width int NOT NULL,
+	height int NOT NULL,
+	blob_key text NOT NULL,
+	thumbnail_key text NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id)
+);
+
+CREATE INDEX recipe_images_recipe_id_idx ON recipe_images (recipe_id);
+
+CREATE TABLE item_nutrition (
+	item_id uuid PRIMARY KEY REFERENCES items (id) ON DELETE CASCADE,
+	-- Nutrient values describe reference_quantity of reference_unit, e.g. 100 g.
+	reference_quantity numeric NOT NULL CONSTRAINT item_nutrition_reference_quantity_positive_chk CHECK (reference_quantity > 0),
+	reference_unit text NOT NULL,
+	calories numeric NOT NULL CONSTRAINT item_nutrition_calories_nonnegative_chk CHECK (calories >= 0),
+	protein_g numeric NOT NULL CONSTRAINT item_nutrition_protein_g_nonnegative_chk CHECK (protein_g >= 0),
+	fat_g numeric NOT NULL CONSTRAINT item_nutrition_fat_g_nonnegative_chk CHECK (fat_g >= 0),
+	carbs_g numeric NOT NULL CONSTRAINT item_nutrition_carbs_g_nonnegative_chk CHECK (carbs_g >= 0),
+	fiber_g numeric NOT NULL CONSTRAINT item_nutrition_fiber_g_nonnegative_chk CHECK (fiber_g >= 0),
+	sodium_mg numeric NOT NULL CONSTRAINT item_nutrition_sodium_mg_nonnegative_chk CHECK (sodium_mg >= 0),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id)
+);
+
+CREATE TABLE recipe_cook_events (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	cooked_at timestamptz NOT NULL DEFAULT now(),
+	servings int CONSTRAINT recipe_cook_events_servings_positive_chk CHECK (servings > 0),
+	rating int CONSTRAINT recipe_cook_events_rating_range_chk CHECK (rating BETWEEN 1 AND 5),
+	notes text,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id)
+);
+
+CREATE INDEX recipe_cook_events_recipe_id_cooked_at_idx ON recipe_cook_events (recipe_id, cooked_at DESC);
+
+CREATE TABLE recipe_favorites (
+	user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (user_id, recipe_id)
+);
+
+CREATE INDEX recipe_favorites_recipe_id_idx ON recipe_favorites (recipe_id);
+
+CREATE TABLE recipe_user_notes (
+	user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	note text NOT NULL CONSTRAINT recipe_user_notes_note_not_blank_chk CHECK (btrim(note) <> ''),
+	created_at timestamptz NOT NULL DEFAULT now(),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (user_id, recipe_id)
+);
+
+ALTER TABLE recipe_ingredients
+	ALTER COLUMN item_id DROP NOT NULL;
+
+ALTER TABLE recipe_ingredients
+	ADD COLUMN sub_recipe_id uuid REFERENCES recipes (id);
+
+-- A line is either a shopping item or another recipe, never both.
+ALTER TABLE recipe_ingredients
+	ADD CONSTRAINT recipe_ingredients_item_or_sub_recipe_chk CHECK ((item_id IS NULL) <> (sub_recipe_id IS NULL));
+
+ALTER TABLE recipe_ingredients
+	ADD CONSTRAINT recipe_ingredients_sub_recipe_not_self_chk CHECK (sub_recipe_id <> recipe_id);
+
+CREATE INDEX recipe_ingredients_sub_recipe_id_idx ON recipe_ingredients (sub_recipe_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_snapshot(target_recipe_id uuid) RETURNS jsonb
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT jsonb_build_object(
+		'title', r.title,
+		'servings', r.servings,
+		'prep_time_minutes', r.prep_time_minutes,
+		'total_time_minutes', r.total_time_minutes,
+		'source_url', r.source_url,
+		'notes', r.notes,
+		'recipe_book_id', r.recipe_book_id,
+		'tag_ids', COALESCE((
+			SELECT jsonb_agg(rt.tag_id ORDER BY rt.tag_id)
+			FROM recipe_tags rt
+			WHERE rt.recipe_id = r.id
+		), '[]'::jsonb),
+		'ingredients', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'position', ri.position,
+				'quantity', ri.quantity,
+				'quantity_text', ri.quantity_text,
+				'unit', ri.unit,
+				'item_id', ri.item_id,
+				'item_name', i.name,
+				'sub_recipe_id', ri.sub_recipe_id,
+				'prep', ri.prep,
+				'notes', ri.notes,
+				'original_text', ri.original_text
+			) ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			LEFT JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '[]'::jsonb),
+		'steps', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'step_number', rs.step_number,
+				'instruction', rs.instruction
+			) ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '[]'::jsonb)
+	)
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE recipe_ingredients
+	ADD COLUMN group_name text NULL CONSTRAINT recipe_ingredients_group_name_not_blank_chk CHECK (btrim(group_name) <> '');
+
+ALTER TABLE recipe_steps
+	ADD COLUMN group_name text NULL CONSTRAINT recipe_steps_group_name_not_blank_chk CHECK (btrim(group_name) <> '');
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_snapshot(target_recipe_id uuid) RETURNS jsonb
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT jsonb_build_object(
+		'title', r.title,
+		'servings', r.servings,
+		'prep_time_minutes', r.prep_time_minutes,
+		'total_time_minutes', r.total_time_minutes,
+		'source_url', r.source_url,
+		'notes', r.notes,
+		'recipe_book_id', r.recipe_book_id,
+		'tag_ids', COALESCE((
+			SELECT jsonb_agg(rt.tag_id ORDER BY rt.tag_id)
+			FROM recipe_tags rt
+			WHERE rt.recipe_id = r.id
+		), '[]'::jsonb),
+		'ingredients', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'position', ri.position,
+				'quantity', ri.quantity,
+				'quantity_text', ri.quantity_text,
+				'unit', ri.unit,
+				'item_id', ri.item_id,
+				'item_name', i.name,
+				'sub_recipe_id', ri.sub_recipe_id,
+				'group_name', ri.group_name,
+				'prep', ri.prep,
+				'notes', ri.notes,
+				'original_text', ri.original_text
+			) ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			LEFT JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '[]'::jsonb),
+		'steps', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'step_number', rs.step_number,
+				'instruction', rs.instruction,
+				'group_name', rs.group_name
+			) ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '[]'::jsonb)
+	)
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE recipe_steps
+	ADD COLUMN duration_seconds integer NULL CONSTRAINT recipe_steps_duration_seconds_chk CHECK (duration_seconds > 0),
+	ADD COLUMN duration_max_seconds integer NULL,
+	ADD COLUMN temperature integer NULL,
+	ADD COLUMN temperature_unit text NULL CONSTRAINT recipe_steps_temperature_unit_chk CHECK (temperature_unit IN ('F', 'C')),
+	ADD CONSTRAINT recipe_steps_duration_range_chk CHECK (
+		duration_max_seconds IS NULL
+		OR (duration_seconds IS NOT NULL AND duration_max_seconds >= duration_seconds)
+	),
+	ADD CONSTRAINT recipe_steps_temperature_pair_chk CHECK ((temperature IS NULL) = (temperature_unit IS NULL));
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_snapshot(target_recipe_id uuid) RETURNS jsonb
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT jsonb_build_object(
+		'title', r.title,
+		'servings', r.servings,
+		'prep_time_minutes', r.prep_time_minutes,
+		'total_time_minutes', r.total_time_minutes,
+		'source_url', r.source_url,
+		'notes', r.notes,
+		'recipe_book_id', r.recipe_book_id,
+		'tag_ids', COALESCE((
+			SELECT jsonb_agg(rt.tag_id ORDER BY rt.tag_id)
+			FROM recipe_tags rt
+			WHERE rt.recipe_id = r.id
+		), '[]'::jsonb),
+		'ingredients', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'position', ri.position,
+				'quantity', ri.quantity,
+				'quantity_text', ri.quantity_text,
+				'unit', ri.unit,
+				'item_id', ri.item_id,
+				'item_name', i.name,
+				'sub_recipe_id', ri.sub_recipe_id,
+				'group_name', ri.group_name,
+				'prep', ri.prep,
+				'notes', ri.notes,
+				'original_text', ri.original_text
+			) ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			LEFT JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '[]'::jsonb),
+		'steps', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'step_number', rs.step_number,
+				'instruction', rs.instruction,
+				'group_name', rs.group_name,
+				'duration_seconds', rs.duration_seconds,
+				'duration_max_seconds', rs.duration_max_seconds,
+				'temperature', rs.temperature,
+				'temperature_unit', rs.temperature_unit
+			) ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '[]'::jsonb)
+	)
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+CREATE TABLE item_prices (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	-- price is what quantity of unit cost, e.g. 3.49 for 1 lb.
+	price numeric NOT NULL CONSTRAINT item_prices_price_nonnegative_chk CHECK (price >= 0),
+	quantity numeric NOT NULL CONSTRAINT item_prices_quantity_positive_chk CHECK (quantity > 0),
+	unit text NOT NULL,
+	store text CONSTRAINT item_prices_store_not_blank_chk CHECK (store IS NULL OR btrim(store) <> ''),
+	priced_on date NOT NULL DEFAULT current_date,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id)
+);
+
+CREATE INDEX item_prices_item_id_priced_on_idx ON item_prices (item_id, priced_on DESC, created_at DESC);
+
+-- recipe_shares grants read-only access to one recipe to anyone holding the
+-- token. Only the sha256 hash of the token is stored.
+CREATE TABLE recipe_shares (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	token_hash text NOT NULL,
+	expires_at timestamptz NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	CONSTRAINT recipe_shares_token_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX recipe_shares_recipe_id_idx ON recipe_shares (recipe_id);
+
+-- parent_recipe_id records the recipe a clone or variant was copied from.
+ALTER TABLE recipes
+	ADD COLUMN parent_recipe_id uuid NULL REFERENCES recipes (id) ON DELETE SET NULL;
+
+ALTER TABLE recipes
+	ADD CONSTRAINT recipes_parent_not_self_chk CHECK (parent_recipe_id <> id);
+
+CREATE INDEX recipes_parent_recipe_id_idx ON recipes (parent_recipe_id);
+
+-- item_attributes records what an item contains for allergen and diet
+-- purposes. An item with no rows is treated as containing none of them.
+CREATE TABLE item_attributes (
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	attribute text NOT NULL CONSTRAINT item_attributes_attribute_chk CHECK (
+		attribute IN ('meat', 'fish', 'shellfish', 'dairy', 'eggs', 'honey', 'gluten', 'nuts', 'peanuts', 'soy', 'sesame')
+	),
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	PRIMARY KEY (item_id, attribute)
+);
+
+-- user_dietary_restrictions holds the diets a user follows and the allergens
+-- they avoid. Values are validated by the API against the same vocabulary
+-- used for item attributes.
+CREATE TABLE user_dietary_restrictions (
+	user_id uuid PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+	diets text[] NOT NULL DEFAULT '{}',
+	allergens text[] NOT NULL DEFAULT '{}',
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id)
+);
+
+-- recipe_item_attributes returns the attributes of every item used by a
+-- recipe, including items reached through sub-recipes.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_item_attributes(target_recipe_id uuid) RETURNS SETOF text
+LANGUAGE sql
+STABLE
+AS $$
+	WITH RECURSIVE tree AS (
+		SELECT target_recipe_id AS recipe_id
+		UNION
+		SELECT ri.sub_recipe_id
+		FROM recipe_ingredients ri
+		JOIN tree t ON t.recipe_id = ri.recipe_id
+		WHERE ri.sub_recipe_id IS NOT NULL
+	)
+	SELECT DISTINCT ia.attribute
+	FROM tree t
+	JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+	JOIN item_attributes ia ON ia.item_id = ri.item_id
+$$;
+-- +goose StatementEnd
+
+-- pantry_items records what the household already has at home. Rows for the
+-- same item, location, unit, and best-before date are one stock entry, so
+-- restocking adds to the existing quantity.
+CREATE TABLE pantry_items (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	quantity numeric NULL CONSTRAINT pantry_items_quantity_positive_chk CHECK (quantity IS NULL OR quantity > 0),
+	unit text NULL,
+	location text NOT NULL DEFAULT 'pantry' CONSTRAINT pantry_items_location_chk CHECK (location IN ('fridge', 'freezer', 'pantry')),
+	best_before date NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id),
+	CONSTRAINT pantry_items_stock_unique UNIQUE NULLS NOT DISTINCT (item_id, location, unit, best_before)
+);
+
+CREATE TABLE item_aliases (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	-- alias is another name the item goes by, e.g. "scallions" for "green onions".
+	alias citext NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	CONSTRAINT item_aliases_alias_unique UNIQUE (alias)
+);
+
+CREATE INDEX item_aliases_item_id_idx ON item_aliases (item_id);
+CREATE INDEX item_aliases_alias_trgm_idx ON item_aliases USING gin (alias gin_trgm_ops);
+
+CREATE TABLE stores (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	name citext NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id),
+	CONSTRAINT stores_name_unique UNIQUE (name)
+);
+
+-- Aisles without a store make up the default layout that items.aisle_id
+-- points into. Each store has its own aisles, named independently.
+ALTER TABLE grocery_aisles
+	ADD COLUMN store_id uuid NULL REFERENCES stores (id) ON DELETE CASCADE;
+
+ALTER TABLE grocery_aisles
+	DROP CONSTRAINT grocery_aisles_name_unique;
+
+ALTER TABLE grocery_aisles
+	ADD CONSTRAINT grocery_aisles_store_name_unique UNIQUE NULLS NOT DISTINCT (store_id, name);
+
+ALTER TABLE grocery_aisles
+	ADD CONSTRAINT grocery_aisles_id_store_unique UNIQUE (id, store_id);
+
+-- store_item_aisles places an item in one of a store's aisles. The composite
+-- key keeps the aisle within the same store.
+CREATE TABLE store_item_aisles (
+	store_id uuid NOT NULL REFERENCES stores (id) ON DELETE CASCADE,
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	aisle_id uuid NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id),
+	PRIMARY KEY (store_id, item_id),
+	CONSTRAINT store_item_aisles_aisle_fkey FOREIGN KEY (aisle_id, store_id) REFERENCES grocery_aisles (id, store_id) ON DELETE CASCADE
+);
+
+CREATE INDEX store_item_aisles_item_id_idx ON store_item_aisles (item_id);
+
+-- A list assigned to a store is grouped and ordered by that store's aisles.
+ALTER TABLE shopping_lists
+	ADD COLUMN store_id uuid NULL REFERENCES stores (id) ON DELETE SET NULL;
+
+-- Sub-recipe lines have no item, so index the sub-recipe's title in their place.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_search_document(target_recipe_id uuid) RETURNS tsvector
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT
+		setweight(to_tsvector('english', r.title), 'A')
+		|| setweight(to_tsvector('english', COALESCE((
+			SELECT string_agg(COALESCE(i.name::text, sr.title), ' ' ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			LEFT JOIN items i ON i.id = ri.item_id
+			LEFT JOIN recipes sr ON sr.id = ri.sub_recipe_id
+			WHERE ri.recipe_id = r.id
+		), '')), 'B')
+		|| setweight(to_tsvector('english', COALESCE(r.notes, '')), 'C')
+		|| setweight(to_tsvector('english', COALESCE((
+			SELECT string_agg(rs.instruction, ' ' ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '')), 'D')
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+UPDATE recipe_search_documents d
+SET document = recipe_search_document(d.recipe_id),
+	updated_at = now()
+WHERE EXISTS (
+	SELECT 1
+	FROM recipe_ingredients ri
+	WHERE ri.recipe_id = d.recipe_id
+		AND ri.sub_recipe_id IS NOT NULL
+);
+
+-- item_attribute_classifications marks items whose attributes have been set,
+-- so an item that contains none of them can be told apart from one nobody
+-- has looked at. Recipe labels only make claims when every item is classified.
+CREATE TABLE item_attribute_classifications (
+	item_id uuid PRIMARY KEY REFERENCES items (id) ON DELETE CASCADE,
+	classified_at timestamptz NOT NULL DEFAULT now(),
+	classified_by uuid NOT NULL REFERENCES users (id)
+);
+
+-- Items that already have attributes were classified when they were set.
+INSERT INTO item_attribute_classifications (item_id, classified_at, classified_by)
+SELECT DISTINCT ON (ia.item_id) ia.item_id, ia.created_at, ia.created_by
+FROM item_attributes ia
+ORDER BY ia.item_id, ia.created_at;
+
+-- recipe_unclassified_items returns the items used by a recipe, including
+-- items reached through sub-recipes, whose attributes are unknown.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_unclassified_items(target_recipe_id uuid)
+RETURNS TABLE (item_id uuid, item_name text)
+LANGUAGE sql
+STABLE
+AS $$
+	WITH RECURSIVE tree AS (
+		SELECT target_recipe_id AS recipe_id
+		UNION
+		SELECT ri.sub_recipe_id
+		FROM recipe_ingredients ri
+		JOIN tree t ON t.recipe_id = ri.recipe_id
+		WHERE ri.sub_recipe_id IS NOT NULL
+	)
+	SELECT DISTINCT i.id, i.name::text
+	FROM tree t
+	JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+	JOIN items i ON i.id = ri.item_id
+	WHERE NOT EXISTS (
+		SELECT 1
+		FROM item_attribute_classifications c
+		WHERE c.item_id = i.id
+	)
+$$;
+-- +goose StatementEnd
+
+-- Deleted sub-recipes are left off shopping lists, so their items no longer
+-- count toward a recipe's dietary attributes or unknowns either.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_item_attributes(target_recipe_id uuid) RETURNS SETOF text
+LANGUAGE sql
+STABLE
+AS $$
+	WITH RECURSIVE tree AS (
+		SELECT target_recipe_id AS recipe_id
+		UNION
+		SELECT sr.id
+		FROM recipe_ingredients ri
+		JOIN tree t ON t.recipe_id = ri.recipe_id
+		JOIN recipes sr ON sr.id = ri.sub_recipe_id
+		WHERE sr.deleted_at IS NULL
+	)
+	SELECT DISTINCT ia.attribute
+	FROM tree t
+	JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+	JOIN item_attributes ia ON ia.item_id = ri.item_id
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_unclassified_items(target_recipe_id uuid)
+RETURNS TABLE (item_id uuid, item_name text)
+LANGUAGE sql
+STABLE
+AS $$
+	WITH RECURSIVE tree AS (
+		SELECT target_recipe_id AS recipe_id
+		UNION
+		SELECT sr.id
+		FROM recipe_ingredients ri
+		JOIN tree t ON t.recipe_id = ri.recipe_id
+		JOIN recipes sr ON sr.id = ri.sub_recipe_id
+		WHERE sr.deleted_at IS NULL
+	)
+	SELECT DISTINCT i.id, i.name::text
+	FROM tree t
+	JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+	JOIN items i ON i.id = ri.item_id
+	WHERE NOT EXISTS (
+		SELECT 1
+		FROM item_attribute_classifications c
+		WHERE c.item_id = i.id
+	)
+$$;
+-- +goose StatementEnd
+
+-- shopping_list_pantry_reservations records pantry stock already set against
+-- a list when recipes were added with exclude_pantry, in the unit of the need
+-- it covered. Later additions to the same list only use the stock left over.
+CREATE TABLE shopping_list_pantry_reservations (
+	shopping_list_id uuid NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	unit text NOT NULL,
+	quantity numeric NOT NULL CHECK (quantity > 0),
+	PRIMARY KEY (shopping_list_id, item_id, unit)
+);
+
+CREATE INDEX shopping_list_pantry_reservations_item_id_idx ON shopping_list_pantry_reservations (item_id);
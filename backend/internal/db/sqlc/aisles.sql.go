@@ -17,12 +17,13 @@ INSERT INTO grocery_aisles (
   sort_group,
   sort_order,
   numeric_value,
+  store_id,
   created_by,
   updated_by
 ) VALUES (
-  $1, $2, $3, $4, $5, $6
+  $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, name, sort_group, sort_order, numeric_value, created_at, created_by, updated_at, updated_by
+RETURNING id, name, sort_group, sort_order, numeric_value, created_at, created_by, updated_at, updated_by, store_id
 `
 
 type CreateGroceryAisleParams struct {
@@ -30,6 +31,7 @@ type CreateGroceryAisleParams struct {
 	SortGroup    int32       `json:"sort_group"`
 	SortOrder    int32       `json:"sort_order"`
 	NumericValue pgtype.Int4 `json:"numeric_value"`
+	StoreID      pgtype.UUID `json:"store_id"`
 	CreatedBy    pgtype.UUID `json:"created_by"`
 	UpdatedBy    pgtype.UUID `json:"updated_by"`
 }
@@ -40,6 +42,7 @@ func (q *Queries) CreateGroceryAisle(ctx context.Context, arg CreateGroceryAisle
 		arg.SortGroup,
 		arg.SortOrder,
 		arg.NumericValue,
+		arg.StoreID,
 		arg.CreatedBy,
 		arg.UpdatedBy,
 	)
@@ -54,6 +57,7 @@ func (q *Queries) CreateGroceryAisle(ctx context.Context, arg CreateGroceryAisle
 		&i.CreatedBy,
 		&i.UpdatedAt,
 		&i.UpdatedBy,
+		&i.StoreID,
 	)
 	return i, err
 }
@@ -61,10 +65,18 @@ func (q *Queries) CreateGroceryAisle(ctx context.Context, arg CreateGroceryAisle
 const deleteGroceryAisleByID = `-- name: DeleteGroceryAisleByID :execrows
 DELETE FROM grocery_aisles
 WHERE id = $1
+  AND store_id IS NOT DISTINCT FROM $2::uuid
 `
 
-func (q *Queries) DeleteGroceryAisleByID(ctx context.Context, id pgtype.UUID) (int64, error) {
-	result, err := q.db.Exec(ctx, deleteGroceryAisleByID, id)
+type DeleteGroceryAisleByIDParams struct {
+	ID      pgtype.UUID `json:"id"`
+	StoreID pgtype.UUID `json:"store_id"`
+}
+
+// Deletes an aisle in the given store's layout, or the default layout when
+// store_id is null.
+func (q *Queries) DeleteGroceryAisleByID(ctx context.Context, arg DeleteGroceryAisleByIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteGroceryAisleByID, arg.ID, arg.StoreID)
 	if err != nil {
 		return 0, err
 	}
@@ -81,7 +93,8 @@ SELECT
   created_at,
   created_by,
   updated_at,
-  updated_by
+  updated_by,
+  store_id
 FROM grocery_aisles
 WHERE id = $1
 `
@@ -99,6 +112,7 @@ func (q *Queries) GetGroceryAisleByID(ctx context.Context, id pgtype.UUID) (Groc
 		&i.CreatedBy,
 		&i.UpdatedAt,
 		&i.UpdatedBy,
+		&i.StoreID,
 	)
 	return i, err
 }
@@ -113,11 +127,14 @@ SELECT
   created_at,
   created_by,
   updated_at,
-  updated_by
+  updated_by,
+  store_id
 FROM grocery_aisles
+WHERE store_id IS NULL
 ORDER BY sort_group ASC, sort_order ASC, name ASC
 `
 
+// Lists the default layout; store aisles are listed by ListGroceryAislesByStoreID.
 func (q *Queries) ListGroceryAisles(ctx context.Context) ([]GroceryAisle, error) {
 	rows, err := q.db.Query(ctx, listGroceryAisles)
 	if err != nil {
@@ -137,6 +154,55 @@ func (q *Queries) ListGroceryAisles(ctx context.Context) ([]GroceryAisle, error)
 			&i.CreatedBy,
 			&i.UpdatedAt,
 			&i.UpdatedBy,
+			&i.StoreID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGroceryAislesByStoreID = `-- name: ListGroceryAislesByStoreID :many
+SELECT
+  id,
+  name,
+  sort_group,
+  sort_order,
+  numeric_value,
+  created_at,
+  created_by,
+  updated_at,
+  updated_by,
+  store_id
+FROM grocery_aisles
+WHERE store_id = $1
+ORDER BY sort_group ASC, sort_order ASC, name ASC
+`
+
+func (q *Queries) ListGroceryAislesByStoreID(ctx context.Context, storeID pgtype.UUID) ([]GroceryAisle, error) {
+	rows, err := q.db.Query(ctx, listGroceryAislesByStoreID, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GroceryAisle{}
+	for rows.Next() {
+		var i GroceryAisle
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.SortGroup,
+			&i.SortOrder,
+			&i.NumericValue,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdatedAt,
+			&i.UpdatedBy,
+			&i.StoreID,
 		); err != nil {
 			return nil, err
 		}
@@ -157,7 +223,8 @@ SET name = $2,
     updated_at = now(),
     updated_by = $6
 WHERE id = $1
-RETURNING id, name, sort_group, sort_order, numeric_value, created_at, created_by, updated_at, updated_by
+  AND store_id IS NOT DISTINCT FROM $7::uuid
+RETURNING id, name, sort_group, sort_order, numeric_value, created_at, created_by, updated_at, updated_by, store_id
 `
 
 type UpdateGroceryAisleByIDParams struct {
@@ -167,8 +234,11 @@ type UpdateGroceryAisleByIDParams struct {
 	SortOrder    int32       `json:"sort_order"`
 	NumericValue pgtype.Int4 `json:"numeric_value"`
 	UpdatedBy    pgtype.UUID `json:"updated_by"`
+	StoreID      pgtype.UUID `json:"store_id"`
 }
 
+// Updates an aisle in the given store's layout, or the default layout when
+// store_id is null.
 func (q *Queries) UpdateGroceryAisleByID(ctx context.Context, arg UpdateGroceryAisleByIDParams) (GroceryAisle, error) {
 	row := q.db.QueryRow(ctx, updateGroceryAisleByID,
 		arg.ID,
@@ -177,6 +247,7 @@ func (q *Queries) UpdateGroceryAisleByID(ctx context.Context, arg UpdateGroceryA
 		arg.SortOrder,
 		arg.NumericValue,
 		arg.UpdatedBy,
+		arg.StoreID,
 	)
 	var i GroceryAisle
 	err := row.Scan(
@@ -189,6 +260,7 @@ func (q *Queries) UpdateGroceryAisleByID(ctx context.Context, arg UpdateGroceryA
 		&i.CreatedBy,
 		&i.UpdatedAt,
 		&i.UpdatedBy,
+		&i.StoreID,
 	)
 	return i, err
 }
@@ -18,6 +18,7 @@ type GroceryAisle struct {
 	CreatedBy    pgtype.UUID        `json:"created_by"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 	UpdatedBy    pgtype.UUID        `json:"updated_by"`
+	StoreID      pgtype.UUID        `json:"store_id"`
 }
 
 type Item struct {
@@ -31,6 +32,53 @@ type Item struct {
 	UpdatedBy pgtype.UUID        `json:"updated_by"`
 }
 
+type ItemAlias struct {
+	ID        pgtype.UUID        `json:"id"`
+	ItemID    pgtype.UUID        `json:"item_id"`
+	Alias     string             `json:"alias"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+}
+
+type ItemAttribute struct {
+	ItemID    pgtype.UUID        `json:"item_id"`
+	Attribute string             `json:"attribute"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+}
+
+type ItemAttributeClassification struct {
+	ItemID       pgtype.UUID        `json:"item_id"`
+	ClassifiedAt pgtype.Timestamptz `json:"classified_at"`
+	ClassifiedBy pgtype.UUID        `json:"classified_by"`
+}
+
+type ItemNutrition struct {
+	ItemID            pgtype.UUID        `json:"item_id"`
+	ReferenceQuantity pgtype.Numeric     `json:"reference_quantity"`
+	ReferenceUnit     string             `json:"reference_unit"`
+	Calories          pgtype.Numeric     `json:"calories"`
+	ProteinG          pgtype.Numeric     `json:"protein_g"`
+	FatG              pgtype.Numeric     `json:"fat_g"`
+	CarbsG            pgtype.Numeric     `json:"carbs_g"`
+	FiberG            pgtype.Numeric     `json:"fiber_g"`
+	SodiumMg          pgtype.Numeric     `json:"sodium_mg"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	UpdatedBy         pgtype.UUID        `json:"updated_by"`
+}
+
+type ItemPrice struct {
+	ID        pgtype.UUID        `json:"id"`
+	ItemID    pgtype.UUID        `json:"item_id"`
+	Price     pgtype.Numeric     `json:"price"`
+	Quantity  pgtype.Numeric     `json:"quantity"`
+	Unit      string             `json:"unit"`
+	Store     pgtype.Text        `json:"store"`
+	PricedOn  pgtype.Date        `json:"priced_on"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+}
+
 type MealPlanEntry struct {
 	ID        pgtype.UUID        `json:"id"`
 	UserID    pgtype.UUID        `json:"user_id"`
@@ -42,6 +90,19 @@ type MealPlanEntry struct {
 	UpdatedBy pgtype.UUID        `json:"updated_by"`
 }
 
+type PantryItem struct {
+	ID         pgtype.UUID        `json:"id"`
+	ItemID     pgtype.UUID        `json:"item_id"`
+	Quantity   pgtype.Numeric     `json:"quantity"`
+	Unit       pgtype.Text        `json:"unit"`
+	Location   string             `json:"location"`
+	BestBefore pgtype.Date        `json:"best_before"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	CreatedBy  pgtype.UUID        `json:"created_by"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	UpdatedBy  pgtype.UUID        `json:"updated_by"`
+}
+
 type PersonalAccessToken struct {
 	ID         pgtype.UUID        `json:"id"`
 	UserID     pgtype.UUID        `json:"user_id"`
@@ -69,6 +130,7 @@ type Recipe struct {
 	CreatedBy        pgtype.UUID        `json:"created_by"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 	UpdatedBy        pgtype.UUID        `json:"updated_by"`
+	ParentRecipeID   pgtype.UUID        `json:"parent_recipe_id"`
 }
 
 type RecipeBook struct {
@@ -80,6 +142,39 @@ type RecipeBook struct {
 	UpdatedBy pgtype.UUID        `json:"updated_by"`
 }
 
+type RecipeCookEvent struct {
+	ID        pgtype.UUID        `json:"id"`
+	RecipeID  pgtype.UUID        `json:"recipe_id"`
+	CookedAt  pgtype.Timestamptz `json:"cooked_at"`
+	Servings  pgtype.Int4        `json:"servings"`
+	Rating    pgtype.Int4        `json:"rating"`
+	Notes     pgtype.Text        `json:"notes"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	UpdatedBy pgtype.UUID        `json:"updated_by"`
+}
+
+type RecipeFavorite struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	RecipeID  pgtype.UUID        `json:"recipe_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type RecipeImage struct {
+	ID           pgtype.UUID        `json:"id"`
+	RecipeID     pgtype.UUID        `json:"recipe_id"`
+	StepNumber   pgtype.Int4        `json:"step_number"`
+	ContentType  string             `json:"content_type"`
+	ByteSize     int64              `json:"byte_size"`
+	Width        int32              `json:"width"`
+	Height       int32              `json:"height"`
+	BlobKey      string             `json:"blob_key"`
+	ThumbnailKey string             `json:"thumbnail_key"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	CreatedBy    pgtype.UUID        `json:"created_by"`
+}
+
 type RecipeIngredient struct {
 	ID           pgtype.UUID        `json:"id"`
 	RecipeID     pgtype.UUID        `json:"recipe_id"`
@@ -95,17 +190,48 @@ type RecipeIngredient struct {
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 	UpdatedBy    pgtype.UUID        `json:"updated_by"`
 	ItemID       pgtype.UUID        `json:"item_id"`
+	SubRecipeID  pgtype.UUID        `json:"sub_recipe_id"`
+	GroupName    pgtype.Text        `json:"group_name"`
+}
+
+type RecipeRevision struct {
+	ID             pgtype.UUID        `json:"id"`
+	RecipeID       pgtype.UUID        `json:"recipe_id"`
+	RevisionNumber int32              `json:"revision_number"`
+	Snapshot       []byte             `json:"snapshot"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	CreatedBy      pgtype.UUID        `json:"created_by"`
+}
+
+type RecipeSearchDocument struct {
+	RecipeID  pgtype.UUID        `json:"recipe_id"`
+	Document  interface{}        `json:"document"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type RecipeShare struct {
+	ID        pgtype.UUID        `json:"id"`
+	RecipeID  pgtype.UUID        `json:"recipe_id"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
 }
 
 type RecipeStep struct {
-	ID          pgtype.UUID        `json:"id"`
-	RecipeID    pgtype.UUID        `json:"recipe_id"`
-	StepNumber  int32              `json:"step_number"`
-	Instruction string             `json:"instruction"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	CreatedBy   pgtype.UUID        `json:"created_by"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	UpdatedBy   pgtype.UUID        `json:"updated_by"`
+	ID                 pgtype.UUID        `json:"id"`
+	RecipeID           pgtype.UUID        `json:"recipe_id"`
+	StepNumber         int32              `json:"step_number"`
+	Instruction        string             `json:"instruction"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	CreatedBy          pgtype.UUID        `json:"created_by"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	UpdatedBy          pgtype.UUID        `json:"updated_by"`
+	GroupName          pgtype.Text        `json:"group_name"`
+	DurationSeconds    pgtype.Int4        `json:"duration_seconds"`
+	DurationMaxSeconds pgtype.Int4        `json:"duration_max_seconds"`
+	Temperature        pgtype.Int4        `json:"temperature"`
+	TemperatureUnit    pgtype.Text        `json:"temperature_unit"`
 }
 
 type RecipeTag struct {
@@ -117,6 +243,14 @@ type RecipeTag struct {
 	UpdatedBy pgtype.UUID        `json:"updated_by"`
 }
 
+type RecipeUserNote struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	RecipeID  pgtype.UUID        `json:"recipe_id"`
+	Note      string             `json:"note"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Session struct {
 	ID         pgtype.UUID        `json:"id"`
 	UserID     pgtype.UUID        `json:"user_id"`
@@ -138,6 +272,7 @@ type ShoppingList struct {
 	CreatedBy pgtype.UUID        `json:"created_by"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 	UpdatedBy pgtype.UUID        `json:"updated_by"`
+	StoreID   pgtype.UUID        `json:"store_id"`
 }
 
 type ShoppingListItem struct {
@@ -155,6 +290,32 @@ type ShoppingListItem struct {
 	UpdatedBy      pgtype.UUID        `json:"updated_by"`
 }
 
+type ShoppingListPantryReservation struct {
+	ShoppingListID pgtype.UUID    `json:"shopping_list_id"`
+	ItemID         pgtype.UUID    `json:"item_id"`
+	Unit           string         `json:"unit"`
+	Quantity       pgtype.Numeric `json:"quantity"`
+}
+
+type Store struct {
+	ID        pgtype.UUID        `json:"id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	UpdatedBy pgtype.UUID        `json:"updated_by"`
+}
+
+type StoreItemAisle struct {
+	StoreID   pgtype.UUID        `json:"store_id"`
+	ItemID    pgtype.UUID        `json:"item_id"`
+	AisleID   pgtype.UUID        `json:"aisle_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	UpdatedBy pgtype.UUID        `json:"updated_by"`
+}
+
 type Tag struct {
 	ID        pgtype.UUID        `json:"id"`
 	Name      string             `json:"name"`
@@ -175,3 +336,11 @@ type User struct {
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 	UpdatedBy    pgtype.UUID        `json:"updated_by"`
 }
+
+type UserDietaryRestriction struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Diets     []string           `json:"diets"`
+	Allergens []string           `json:"allergens"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	UpdatedBy pgtype.UUID        `json:"updated_by"`
+}
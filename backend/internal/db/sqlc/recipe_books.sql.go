@@ -55,6 +55,26 @@ func (q *Queries) DeleteRecipeBookByID(ctx context.Context, id pgtype.UUID) (int
 	return result.RowsAffected(), nil
 }
 
+const getRecipeBookByID = `-- name: GetRecipeBookByID :one
+SELECT id, name, created_at, created_by, updated_at, updated_by
+FROM recipe_books
+WHERE id = $1
+`
+
+func (q *Queries) GetRecipeBookByID(ctx context.Context, id pgtype.UUID) (RecipeBook, error) {
+	row := q.db.QueryRow(ctx, getRecipeBookByID, id)
+	var i RecipeBook
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdatedAt,
+		&i.UpdatedBy,
+	)
+	return i, err
+}
+
 const listRecipeBooks = `-- name: ListRecipeBooks :many
 SELECT id, name, created_at, created_by, updated_at, updated_by
 FROM recipe_books
@@ -88,6 +108,56 @@ func (q *Queries) ListRecipeBooks(ctx context.Context) ([]RecipeBook, error) {
 	return items, nil
 }
 
+const listRecipeIDsByRecipeBookID = `-- name: ListRecipeIDsByRecipeBookID :many
+SELECT id
+FROM recipes
+WHERE recipe_book_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListRecipeIDsByRecipeBookID(ctx context.Context, recipeBookID pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listRecipeIDsByRecipeBookID, recipeBookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveRecipesToRecipeBook = `-- name: MoveRecipesToRecipeBook :execrows
+UPDATE recipes
+SET recipe_book_id = $1::uuid,
+    updated_at = now(),
+    updated_by = $2::uuid
+WHERE recipe_book_id = $3::uuid
+`
+
+type MoveRecipesToRecipeBookParams struct {
+	TargetID pgtype.UUID `json:"target_id"`
+	UserID   pgtype.UUID `json:"user_id"`
+	SourceID pgtype.UUID `json:"source_id"`
+}
+
+// Soft-deleted recipes move too so the source book can be deleted.
+func (q *Queries) MoveRecipesToRecipeBook(ctx context.Context, arg MoveRecipesToRecipeBookParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveRecipesToRecipeBook, arg.TargetID, arg.UserID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateRecipeBookByID = `-- name: UpdateRecipeBookByID :one
 UPDATE recipe_books
 SET name = $2, updated_at = now(), updated_by = $3
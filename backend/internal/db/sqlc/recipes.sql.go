@@ -33,13 +33,14 @@ INSERT INTO recipes (
   source_url,
   notes,
   recipe_book_id,
+  parent_recipe_id,
   deleted_at,
   created_by,
   updated_by
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, NULL, $8, $9
+  $1, $2, $3, $4, $5, $6, $7, $8, NULL, $9, $10
 )
-RETURNING id, title, servings, prep_time_minutes, total_time_minutes, source_url, notes, recipe_book_id, deleted_at, created_at, created_by, updated_at, updated_by
+RETURNING id, title, servings, prep_time_minutes, total_time_minutes, source_url, notes, recipe_book_id, deleted_at, created_at, created_by, updated_at, updated_by, parent_recipe_id
 `
 
 type CreateRecipeParams struct {
@@ -50,6 +51,7 @@ type CreateRecipeParams struct {
 	SourceUrl        pgtype.Text `json:"source_url"`
 	Notes            pgtype.Text `json:"notes"`
 	RecipeBookID     pgtype.UUID `json:"recipe_book_id"`
+	ParentRecipeID   pgtype.UUID `json:"parent_recipe_id"`
 	CreatedBy        pgtype.UUID `json:"created_by"`
 	UpdatedBy        pgtype.UUID `json:"updated_by"`
 }
@@ -63,6 +65,7 @@ func (q *Queries) CreateRecipe(ctx context.Context, arg CreateRecipeParams) (Rec
 		arg.SourceUrl,
 		arg.Notes,
 		arg.RecipeBookID,
+		arg.ParentRecipeID,
 		arg.CreatedBy,
 		arg.UpdatedBy,
 	)
@@ -81,6 +84,7 @@ func (q *Queries) CreateRecipe(ctx context.Context, arg CreateRecipeParams) (Rec
 		&i.CreatedBy,
 		&i.UpdatedAt,
 		&i.UpdatedBy,
+		&i.ParentRecipeID,
 	)
 	return i, err
 }
@@ -89,27 +93,31 @@ const createRecipeIngredient = `-- name: CreateRecipeIngredient :exec
 INSERT INTO recipe_ingredients (
   recipe_id,
   position,
+  group_name,
   quantity,
   quantity_text,
   unit,
   item_id,
+  sub_recipe_id,
   prep,
   notes,
   original_text,
   created_by,
   updated_by
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 )
 `
 
 type CreateRecipeIngredientParams struct {
 	RecipeID     pgtype.UUID    `json:"recipe_id"`
 	Position     int32          `json:"position"`
+	GroupName    pgtype.Text    `json:"group_name"`
 	Quantity     pgtype.Numeric `json:"quantity"`
 	QuantityText pgtype.Text    `json:"quantity_text"`
 	Unit         pgtype.Text    `json:"unit"`
 	ItemID       pgtype.UUID    `json:"item_id"`
+	SubRecipeID  pgtype.UUID    `json:"sub_recipe_id"`
 	Prep         pgtype.Text    `json:"prep"`
 	Notes        pgtype.Text    `json:"notes"`
 	OriginalText pgtype.Text    `json:"original_text"`
@@ -121,10 +129,12 @@ func (q *Queries) CreateRecipeIngredient(ctx context.Context, arg CreateRecipeIn
 	_, err := q.db.Exec(ctx, createRecipeIngredient,
 		arg.RecipeID,
 		arg.Position,
+		arg.GroupName,
 		arg.Quantity,
 		arg.QuantityText,
 		arg.Unit,
 		arg.ItemID,
+		arg.SubRecipeID,
 		arg.Prep,
 		arg.Notes,
 		arg.OriginalText,
@@ -138,27 +148,42 @@ const createRecipeStep = `-- name: CreateRecipeStep :exec
 INSERT INTO recipe_steps (
   recipe_id,
   step_number,
+  group_name,
   instruction,
+  duration_seconds,
+  duration_max_seconds,
+  temperature,
+  temperature_unit,
   created_by,
   updated_by
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 )
 `
 
 type CreateRecipeStepParams struct {
-	RecipeID    pgtype.UUID `json:"recipe_id"`
-	StepNumber  int32       `json:"step_number"`
-	Instruction string      `json:"instruction"`
-	CreatedBy   pgtype.UUID `json:"created_by"`
-	UpdatedBy   pgtype.UUID `json:"updated_by"`
+	RecipeID           pgtype.UUID `json:"recipe_id"`
+	StepNumber         int32       `json:"step_number"`
+	GroupName          pgtype.Text `json:"group_name"`
+	Instruction        string      `json:"instruction"`
+	DurationSeconds    pgtype.Int4 `json:"duration_seconds"`
+	DurationMaxSeconds pgtype.Int4 `json:"duration_max_seconds"`
+	Temperature        pgtype.Int4 `json:"temperature"`
+	TemperatureUnit    pgtype.Text `json:"temperature_unit"`
+	CreatedBy          pgtype.UUID `json:"created_by"`
+	UpdatedBy          pgtype.UUID `json:"updated_by"`
 }
 
 func (q *Queries) CreateRecipeStep(ctx context.Context, arg CreateRecipeStepParams) error {
 	_, err := q.db.Exec(ctx, createRecipeStep,
 		arg.RecipeID,
 		arg.StepNumber,
+		arg.GroupName,
 		arg.Instruction,
+		arg.DurationSeconds,
+		arg.DurationMaxSeconds,
+		arg.Temperature,
+		arg.TemperatureUnit,
 		arg.CreatedBy,
 		arg.UpdatedBy,
 	)
@@ -224,7 +249,7 @@ func (q *Queries) DeleteRecipeTagsByRecipeID(ctx context.Context, recipeID pgtyp
 }
 
 const getRecipeByID = `-- name: GetRecipeByID :one
-SELECT id, title, servings, prep_time_minutes, total_time_minutes, source_url, notes, recipe_book_id, deleted_at, created_at, created_by, updated_at, updated_by
+SELECT id, title, servings, prep_time_minutes, total_time_minutes, source_url, notes, recipe_book_id, deleted_at, created_at, created_by, updated_at, updated_by, parent_recipe_id
 FROM recipes
 WHERE id = $1
 `
@@ -246,6 +271,7 @@ func (q *Queries) GetRecipeByID(ctx context.Context, id pgtype.UUID) (Recipe, er
 		&i.CreatedBy,
 		&i.UpdatedAt,
 		&i.UpdatedBy,
+		&i.ParentRecipeID,
 	)
 	return i, err
 }
@@ -268,6 +294,7 @@ SELECT
   ri.id,
   ri.recipe_id,
   ri.position,
+  ri.group_name,
   ri.quantity,
   ri.quantity_text,
   ri.unit,
@@ -279,6 +306,10 @@ SELECT
   a.sort_group AS aisle_sort_group,
   a.sort_order AS aisle_sort_order,
   a.numeric_value AS aisle_numeric_value,
+  ri.sub_recipe_id,
+  sr.title AS sub_recipe_title,
+  sr.servings AS sub_recipe_servings,
+  sr.deleted_at IS NOT NULL AS sub_recipe_deleted,
   ri.prep,
   ri.notes,
   ri.original_text,
@@ -287,8 +318,9 @@ SELECT
   ri.updated_at,
   ri.updated_by
 FROM recipe_ingredients ri
-JOIN items i ON i.id = ri.item_id
+LEFT JOIN items i ON i.id = ri.item_id
 LEFT JOIN grocery_aisles a ON a.id = i.aisle_id
+LEFT JOIN recipes sr ON sr.id = ri.sub_recipe_id
 WHERE ri.recipe_id = $1
 ORDER BY ri.position ASC
 `
@@ -297,17 +329,22 @@ type ListRecipeIngredientsByRecipeIDRow struct {
 	ID                pgtype.UUID        `json:"id"`
 	RecipeID          pgtype.UUID        `json:"recipe_id"`
 	Position          int32              `json:"position"`
+	GroupName         pgtype.Text        `json:"group_name"`
 	Quantity          pgtype.Numeric     `json:"quantity"`
 	QuantityText      pgtype.Text        `json:"quantity_text"`
 	Unit              pgtype.Text        `json:"unit"`
 	ItemID            pgtype.UUID        `json:"item_id"`
-	ItemName          string             `json:"item_name"`
+	ItemName          pgtype.Text        `json:"item_name"`
 	ItemStoreUrl      pgtype.Text        `json:"item_store_url"`
 	ItemAisleID       pgtype.UUID        `json:"item_aisle_id"`
 	AisleName         pgtype.Text        `json:"aisle_name"`
 	AisleSortGroup    pgtype.Int4        `json:"aisle_sort_group"`
 	AisleSortOrder    pgtype.Int4        `json:"aisle_sort_order"`
 	AisleNumericValue pgtype.Int4        `json:"aisle_numeric_value"`
+	SubRecipeID       pgtype.UUID        `json:"sub_recipe_id"`
+	SubRecipeTitle    pgtype.Text        `json:"sub_recipe_title"`
+	SubRecipeServings pgtype.Int4        `json:"sub_recipe_servings"`
+	SubRecipeDeleted  bool               `json:"sub_recipe_deleted"`
 	Prep              pgtype.Text        `json:"prep"`
 	Notes             pgtype.Text        `json:"notes"`
 	OriginalText      pgtype.Text        `json:"original_text"`
@@ -330,6 +367,7 @@ func (q *Queries) ListRecipeIngredientsByRecipeID(ctx context.Context, recipeID
 			&i.ID,
 			&i.RecipeID,
 			&i.Position,
+			&i.GroupName,
 			&i.Quantity,
 			&i.QuantityText,
 			&i.Unit,
@@ -341,6 +379,10 @@ func (q *Queries) ListRecipeIngredientsByRecipeID(ctx context.Context, recipeID
 			&i.AisleSortGroup,
 			&i.AisleSortOrder,
 			&i.AisleNumericValue,
+			&i.SubRecipeID,
+			&i.SubRecipeTitle,
+			&i.SubRecipeServings,
+			&i.SubRecipeDeleted,
 			&i.Prep,
 			&i.Notes,
 			&i.OriginalText,
@@ -360,7 +402,7 @@ func (q *Queries) ListRecipeIngredientsByRecipeID(ctx context.Context, recipeID
 }
 
 const listRecipeStepsByRecipeID = `-- name: ListRecipeStepsByRecipeID :many
-SELECT id, recipe_id, step_number, instruction, created_at, created_by, updated_at, updated_by
+SELECT id, recipe_id, step_number, instruction, created_at, created_by, updated_at, updated_by, group_name, duration_seconds, duration_max_seconds, temperature, temperature_unit
 FROM recipe_steps
 WHERE recipe_id = $1
 ORDER BY step_number ASC
@@ -384,6 +426,11 @@ func (q *Queries) ListRecipeStepsByRecipeID(ctx context.Context, recipeID pgtype
 			&i.CreatedBy,
 			&i.UpdatedAt,
 			&i.UpdatedBy,
+			&i.GroupName,
+			&i.DurationSeconds,
+			&i.DurationMaxSeconds,
+			&i.Temperature,
+			&i.TemperatureUnit,
 		); err != nil {
 			return nil, err
 		}
@@ -468,43 +515,192 @@ func (q *Queries) ListRecipeTagsByRecipeIDs(ctx context.Context, dollar_1 []pgty
 }
 
 const listRecipes = `-- name: ListRecipes :many
+WITH search AS (
+  SELECT websearch_to_tsquery('english', $1::text) AS query
+),
+matches AS (
+  SELECT
+    r.id,
+    r.title,
+    r.servings,
+    r.prep_time_minutes,
+    r.total_time_minutes,
+    r.source_url,
+    r.notes,
+    r.recipe_book_id,
+    r.deleted_at,
+    r.created_at,
+    r.updated_at,
+    c.last_cooked_at,
+    c.times_cooked,
+    c.avg_rating,
+    CASE
+      WHEN $1::text = '' THEN 0
+      ELSE ts_rank(COALESCE(d.document, ''::tsvector), s.query) + word_similarity($1::text, r.title)
+    END::float8 AS rank
+  FROM recipes r
+  CROSS JOIN search s
+  LEFT JOIN recipe_search_documents d ON d.recipe_id = r.id
+  CROSS JOIN LATERAL (
+    SELECT
+      max(ce.cooked_at)::timestamptz AS last_cooked_at,
+      count(*)::int AS times_cooked,
+      avg(ce.rating) AS avg_rating
+    FROM recipe_cook_events ce
+    WHERE ce.recipe_id = r.id
+  ) c
+  WHERE
+    (
+      $1::text = ''
+      OR r.title ILIKE ('%' || $1::text || '%')
+      OR d.document @@ s.query
+      OR ($2::boolean AND $1::text <% r.title)
+    )
+    AND ($3::uuid IS NULL OR r.recipe_book_id = $3::uuid)
+    AND (
+      cardinality($4::uuid[]) = 0
+      OR ($5::boolean AND NOT EXISTS (
+        SELECT 1
+        FROM unnest($4::uuid[]) AS wanted(tag_id)
+        WHERE NOT EXISTS (
+          SELECT 1
+          FROM recipe_tags rt
+          WHERE rt.recipe_id = r.id AND rt.tag_id = wanted.tag_id
+        )
+      ))
+      OR (NOT $5::boolean AND EXISTS (
+        SELECT 1
+        FROM recipe_tags rt
+        WHERE rt.recipe_id = r.id AND rt.tag_id = ANY($4::uuid[])
+      ))
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM unnest($6::uuid[]) AS wanted(item_id)
+      WHERE NOT EXISTS (
+        SELECT 1
+        FROM recipe_ingredients ri
+        WHERE ri.recipe_id = r.id AND ri.item_id = wanted.item_id
+      )
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM recipe_ingredients ri
+      WHERE ri.recipe_id = r.id AND ri.item_id = ANY($7::uuid[])
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM recipe_item_attributes(r.id) AS a(attribute)
+      WHERE a.attribute = ANY($8::text[])
+    )
+    AND (
+      cardinality($8::text[]) = 0
+      OR NOT EXISTS (SELECT 1 FROM recipe_unclassified_items(r.id))
+    )
+    AND ($9::int IS NULL OR r.total_time_minutes <= $9::int)
+    AND ($10::int IS NULL OR r.prep_time_minutes <= $10::int)
+    AND ($11::int IS NULL OR r.servings >= $11::int)
+    AND ($12::int IS NULL OR r.servings <= $12::int)
+    AND ($13::boolean OR r.deleted_at IS NULL)
+    AND (
+      NOT $14::boolean
+      OR EXISTS (
+        SELECT 1
+        FROM recipe_favorites f
+        WHERE f.recipe_id = r.id AND f.user_id = $15::uuid
+      )
+    )
+)
 SELECT
-  r.id,
-  r.title,
-  r.servings,
-  r.prep_time_minutes,
-  r.total_time_minutes,
-  r.source_url,
-  r.notes,
-  r.recipe_book_id,
-  r.deleted_at,
-  r.updated_at
-FROM recipes r
-WHERE
-  ($1::text = '' OR r.title ILIKE ('%' || $1::text || '%'))
-  AND ($2::uuid IS NULL OR r.recipe_book_id = $2::uuid)
-  AND ($3::uuid IS NULL OR EXISTS (
+  m.id,
+  m.title,
+  m.servings,
+  m.prep_time_minutes,
+  m.total_time_minutes,
+  m.source_url,
+  m.notes,
+  m.recipe_book_id,
+  m.deleted_at,
+  m.created_at,
+  m.updated_at,
+  m.last_cooked_at,
+  m.times_cooked,
+  m.avg_rating,
+  EXISTS (
     SELECT 1
-    FROM recipe_tags rt
-    WHERE rt.recipe_id = r.id AND rt.tag_id = $3::uuid
-  ))
-  AND ($4::boolean OR r.deleted_at IS NULL)
-  AND (
-    $5::timestamptz IS NULL
-    OR (r.updated_at, r.id) < ($5::timestamptz, $6::uuid)
-  )
-ORDER BY r.updated_at DESC, r.id DESC
-LIMIT $7
+    FROM recipe_favorites f
+    WHERE f.recipe_id = m.id AND f.user_id = $15::uuid
+  ) AS is_favorite,
+  m.rank
+FROM matches m
+WHERE
+  NOT $16::boolean
+  OR CASE $17::text
+    WHEN 'relevance' THEN
+      (m.rank, m.updated_at, m.id) < ($18::float8, $19::timestamptz, $20::uuid)
+    WHEN 'title' THEN
+      ($21::boolean AND (lower(m.title), m.id) < (lower($22::text), $20::uuid))
+      OR (NOT $21::boolean AND (lower(m.title), m.id) > (lower($22::text), $20::uuid))
+    WHEN 'total_time' THEN
+      ($21::boolean AND (m.total_time_minutes, m.id) < ($23::int, $20::uuid))
+      OR (NOT $21::boolean AND (m.total_time_minutes, m.id) > ($23::int, $20::uuid))
+    WHEN 'prep_time' THEN
+      ($21::boolean AND (m.prep_time_minutes, m.id) < ($23::int, $20::uuid))
+      OR (NOT $21::boolean AND (m.prep_time_minutes, m.id) > ($23::int, $20::uuid))
+    WHEN 'created_at' THEN
+      ($21::boolean AND (m.created_at, m.id) < ($19::timestamptz, $20::uuid))
+      OR (NOT $21::boolean AND (m.created_at, m.id) > ($19::timestamptz, $20::uuid))
+    WHEN 'last_cooked' THEN
+      ($21::boolean AND (COALESCE(m.last_cooked_at, '-infinity'), m.id) < (COALESCE($19::timestamptz, '-infinity'), $20::uuid))
+      OR (NOT $21::boolean AND (COALESCE(m.last_cooked_at, '-infinity'), m.id) > (COALESCE($19::timestamptz, '-infinity'), $20::uuid))
+    ELSE
+      ($21::boolean AND (m.updated_at, m.id) < ($19::timestamptz, $20::uuid))
+      OR (NOT $21::boolean AND (m.updated_at, m.id) > ($19::timestamptz, $20::uuid))
+  END
+ORDER BY
+  CASE WHEN $17::text = 'relevance' THEN m.rank END DESC,
+  CASE WHEN $17::text = 'title' AND NOT $21::boolean THEN lower(m.title) END ASC,
+  CASE WHEN $17::text = 'title' AND $21::boolean THEN lower(m.title) END DESC,
+  CASE WHEN $17::text = 'total_time' AND NOT $21::boolean THEN m.total_time_minutes END ASC,
+  CASE WHEN $17::text = 'total_time' AND $21::boolean THEN m.total_time_minutes END DESC,
+  CASE WHEN $17::text = 'prep_time' AND NOT $21::boolean THEN m.prep_time_minutes END ASC,
+  CASE WHEN $17::text = 'prep_time' AND $21::boolean THEN m.prep_time_minutes END DESC,
+  CASE WHEN $17::text = 'created_at' AND NOT $21::boolean THEN m.created_at END ASC,
+  CASE WHEN $17::text = 'created_at' AND $21::boolean THEN m.created_at END DESC,
+  CASE WHEN $17::text = 'last_cooked' AND NOT $21::boolean THEN COALESCE(m.last_cooked_at, '-infinity') END ASC,
+  CASE WHEN $17::text = 'last_cooked' AND $21::boolean THEN COALESCE(m.last_cooked_at, '-infinity') END DESC,
+  CASE WHEN $17::text = 'updated_at' AND NOT $21::boolean THEN m.updated_at END ASC,
+  CASE WHEN $17::text IN ('updated_at', 'relevance') AND $21::boolean THEN m.updated_at END DESC,
+  CASE WHEN NOT $21::boolean THEN m.id END ASC,
+  CASE WHEN $21::boolean THEN m.id END DESC
+LIMIT $24
 `
 
 type ListRecipesParams struct {
-	Q               string             `json:"q"`
-	BookID          pgtype.UUID        `json:"book_id"`
-	TagID           pgtype.UUID        `json:"tag_id"`
-	IncludeDeleted  bool               `json:"include_deleted"`
-	CursorUpdatedAt pgtype.Timestamptz `json:"cursor_updated_at"`
-	CursorID        pgtype.UUID        `json:"cursor_id"`
-	PageLimit       int32              `json:"page_limit"`
+	Q                 string             `json:"q"`
+	Fuzzy             bool               `json:"fuzzy"`
+	BookID            pgtype.UUID        `json:"book_id"`
+	TagIds            []pgtype.UUID      `json:"tag_ids"`
+	TagMatchAll       bool               `json:"tag_match_all"`
+	WithItemIds       []pgtype.UUID      `json:"with_item_ids"`
+	WithoutItemIds    []pgtype.UUID      `json:"without_item_ids"`
+	ExcludeAttributes []string           `json:"exclude_attributes"`
+	MaxTotalTime      pgtype.Int4        `json:"max_total_time"`
+	MaxPrepTime       pgtype.Int4        `json:"max_prep_time"`
+	ServingsMin       pgtype.Int4        `json:"servings_min"`
+	ServingsMax       pgtype.Int4        `json:"servings_max"`
+	IncludeDeleted    bool               `json:"include_deleted"`
+	FavoritesOnly     bool               `json:"favorites_only"`
+	UserID            pgtype.UUID        `json:"user_id"`
+	HasCursor         bool               `json:"has_cursor"`
+	SortKey           string             `json:"sort_key"`
+	CursorRank        float64            `json:"cursor_rank"`
+	CursorTime        pgtype.Timestamptz `json:"cursor_time"`
+	CursorID          pgtype.UUID        `json:"cursor_id"`
+	SortDesc          bool               `json:"sort_desc"`
+	CursorText        string             `json:"cursor_text"`
+	CursorInt         int32              `json:"cursor_int"`
+	PageLimit         int32              `json:"page_limit"`
 }
 
 type ListRecipesRow struct {
@@ -517,17 +713,40 @@ type ListRecipesRow struct {
 	Notes            pgtype.Text        `json:"notes"`
 	RecipeBookID     pgtype.UUID        `json:"recipe_book_id"`
 	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	LastCookedAt     pgtype.Timestamptz `json:"last_cooked_at"`
+	TimesCooked      int32              `json:"times_cooked"`
+	AvgRating        pgtype.Numeric     `json:"avg_rating"`
+	IsFavorite       bool               `json:"is_favorite"`
+	Rank             float64            `json:"rank"`
 }
 
 func (q *Queries) ListRecipes(ctx context.Context, arg ListRecipesParams) ([]ListRecipesRow, error) {
 	rows, err := q.db.Query(ctx, listRecipes,
 		arg.Q,
+		arg.Fuzzy,
 		arg.BookID,
-		arg.TagID,
+		arg.TagIds,
+		arg.TagMatchAll,
+		arg.WithItemIds,
+		arg.WithoutItemIds,
+		arg.ExcludeAttributes,
+		arg.MaxTotalTime,
+		arg.MaxPrepTime,
+		arg.ServingsMin,
+		arg.ServingsMax,
 		arg.IncludeDeleted,
-		arg.CursorUpdatedAt,
+		arg.FavoritesOnly,
+		arg.UserID,
+		arg.HasCursor,
+		arg.SortKey,
+		arg.CursorRank,
+		arg.CursorTime,
 		arg.CursorID,
+		arg.SortDesc,
+		arg.CursorText,
+		arg.CursorInt,
 		arg.PageLimit,
 	)
 	if err != nil {
@@ -547,7 +766,13 @@ func (q *Queries) ListRecipes(ctx context.Context, arg ListRecipesParams) ([]Lis
 			&i.Notes,
 			&i.RecipeBookID,
 			&i.DeletedAt,
+			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.LastCookedAt,
+			&i.TimesCooked,
+			&i.AvgRating,
+			&i.IsFavorite,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -601,6 +826,34 @@ func (q *Queries) SoftDeleteRecipeByID(ctx context.Context, arg SoftDeleteRecipe
 	return result.RowsAffected(), nil
 }
 
+const subRecipeTreeContains = `-- name: SubRecipeTreeContains :one
+WITH RECURSIVE tree AS (
+  SELECT $1::uuid AS recipe_id
+  UNION
+  SELECT ri.sub_recipe_id
+  FROM recipe_ingredients ri
+  JOIN tree t ON t.recipe_id = ri.recipe_id
+  WHERE ri.sub_recipe_id IS NOT NULL
+)
+SELECT EXISTS (
+  SELECT 1
+  FROM tree
+  WHERE recipe_id = $2::uuid
+) AS contains_recipe
+`
+
+type SubRecipeTreeContainsParams struct {
+	SubRecipeID pgtype.UUID `json:"sub_recipe_id"`
+	RecipeID    pgtype.UUID `json:"recipe_id"`
+}
+
+func (q *Queries) SubRecipeTreeContains(ctx context.Context, arg SubRecipeTreeContainsParams) (bool, error) {
+	row := q.db.QueryRow(ctx, subRecipeTreeContains, arg.SubRecipeID, arg.RecipeID)
+	var contains_recipe bool
+	err := row.Scan(&contains_recipe)
+	return contains_recipe, err
+}
+
 const updateRecipeByID = `-- name: UpdateRecipeByID :one
 UPDATE recipes
 SET title = $2,
@@ -613,7 +866,7 @@ SET title = $2,
     updated_at = now(),
     updated_by = $9
 WHERE id = $1
-RETURNING id, title, servings, prep_time_minutes, total_time_minutes, source_url, notes, recipe_book_id, deleted_at, created_at, created_by, updated_at, updated_by
+RETURNING id, title, servings, prep_time_minutes, total_time_minutes, source_url, notes, recipe_book_id, deleted_at, created_at, created_by, updated_at, updated_by, parent_recipe_id
 `
 
 type UpdateRecipeByIDParams struct {
@@ -655,6 +908,7 @@ func (q *Queries) UpdateRecipeByID(ctx context.Context, arg UpdateRecipeByIDPara
 		&i.CreatedBy,
 		&i.UpdatedAt,
 		&i.UpdatedBy,
+		&i.ParentRecipeID,
 	)
 	return i, err
 }
@@ -11,6 +11,40 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addShoppingListPantryReservation = `-- name: AddShoppingListPantryReservation :execrows
+INSERT INTO shopping_list_pantry_reservations (shopping_list_id, item_id, unit, quantity)
+SELECT sl.id, $1, $2, $3
+FROM shopping_lists sl
+WHERE sl.id = $4
+  AND sl.created_by = $5
+ON CONFLICT (shopping_list_id, item_id, unit) DO UPDATE
+SET quantity = shopping_list_pantry_reservations.quantity + EXCLUDED.quantity
+`
+
+type AddShoppingListPantryReservationParams struct {
+	ItemID         pgtype.UUID    `json:"item_id"`
+	Unit           string         `json:"unit"`
+	Quantity       pgtype.Numeric `json:"quantity"`
+	ShoppingListID pgtype.UUID    `json:"shopping_list_id"`
+	UserID         pgtype.UUID    `json:"user_id"`
+}
+
+// Sets pantry stock against a list, adding to any earlier reservation for the
+// same item and unit.
+func (q *Queries) AddShoppingListPantryReservation(ctx context.Context, arg AddShoppingListPantryReservationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addShoppingListPantryReservation,
+		arg.ItemID,
+		arg.Unit,
+		arg.Quantity,
+		arg.ShoppingListID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteShoppingListItemByID = `-- name: DeleteShoppingListItemByID :execrows
 DELETE FROM shopping_list_items sli
 USING shopping_lists sl
@@ -34,19 +68,86 @@ func (q *Queries) DeleteShoppingListItemByID(ctx context.Context, arg DeleteShop
 	return result.RowsAffected(), nil
 }
 
+const getShoppingListItemForUpdate = `-- name: GetShoppingListItemForUpdate :one
+SELECT sli.id, sli.shopping_list_id, sli.item_id, sli.unit, sli.quantity, sli.quantity_text, sli.is_purchased, sli.purchased_at, sli.created_at, sli.created_by, sli.updated_at, sli.updated_by
+FROM shopping_list_items sli
+JOIN shopping_lists sl ON sl.id = sli.shopping_list_id
+WHERE sli.id = $1
+  AND sli.shopping_list_id = $2
+  AND sl.created_by = $3
+FOR UPDATE OF sli
+`
+
+type GetShoppingListItemForUpdateParams struct {
+	ID             pgtype.UUID `json:"id"`
+	ShoppingListID pgtype.UUID `json:"shopping_list_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+}
+
+// Locks a list item so its purchase state can be changed and the pantry
+// restocked at most once.
+func (q *Queries) GetShoppingListItemForUpdate(ctx context.Context, arg GetShoppingListItemForUpdateParams) (ShoppingListItem, error) {
+	row := q.db.QueryRow(ctx, getShoppingListItemForUpdate, arg.ID, arg.ShoppingListID, arg.UserID)
+	var i ShoppingListItem
+	err := row.Scan(
+		&i.ID,
+		&i.ShoppingListID,
+		&i.ItemID,
+		&i.Unit,
+		&i.Quantity,
+		&i.QuantityText,
+		&i.IsPurchased,
+		&i.PurchasedAt,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdatedAt,
+		&i.UpdatedBy,
+	)
+	return i, err
+}
+
 const listRecipeIngredientsByMealPlanDate = `-- name: ListRecipeIngredientsByMealPlanDate :many
+WITH RECURSIVE expanded AS (
+  SELECT
+    ri.item_id,
+    ri.sub_recipe_id,
+    ri.quantity,
+    ri.quantity_text,
+    ri.unit,
+    1::float8 AS factor,
+    ARRAY[ri.recipe_id] AS path,
+    ARRAY[ri.position] AS sort_path
+  FROM meal_plan_entries mpe
+  JOIN recipes r ON r.id = mpe.recipe_id
+  JOIN recipe_ingredients ri ON ri.recipe_id = r.id
+  WHERE mpe.user_id = $1
+    AND mpe.plan_date = $2
+    AND r.deleted_at IS NULL
+  UNION ALL
+  SELECT
+    ri.item_id,
+    ri.sub_recipe_id,
+    ri.quantity,
+    ri.quantity_text,
+    ri.unit,
+    e.factor * COALESCE(e.quantity::float8, sr.servings::float8) / sr.servings::float8,
+    e.path || ri.recipe_id,
+    e.sort_path || ri.position
+  FROM expanded e
+  JOIN recipes sr ON sr.id = e.sub_recipe_id
+  JOIN recipe_ingredients ri ON ri.recipe_id = sr.id
+  WHERE NOT ri.recipe_id = ANY(e.path)
+    AND sr.deleted_at IS NULL
+)
 SELECT
-  ri.item_id,
-  ri.quantity,
-  ri.quantity_text,
-  ri.unit
-FROM meal_plan_entries mpe
-JOIN recipes r ON r.id = mpe.recipe_id
-JOIN recipe_ingredients ri ON ri.recipe_id = r.id
-WHERE mpe.user_id = $1
-  AND mpe.plan_date = $2
-  AND r.deleted_at IS NULL
-ORDER BY mpe.plan_date ASC, ri.position ASC
+  e.item_id,
+  e.quantity,
+  e.quantity_text,
+  e.unit,
+  e.factor
+FROM expanded e
+WHERE e.item_id IS NOT NULL
+ORDER BY e.sort_path ASC
 `
 
 type ListRecipeIngredientsByMealPlanDateParams struct {
@@ -59,8 +160,10 @@ type ListRecipeIngredientsByMealPlanDateRow struct {
 	Quantity     pgtype.Numeric `json:"quantity"`
 	QuantityText pgtype.Text    `json:"quantity_text"`
 	Unit         pgtype.Text    `json:"unit"`
+	Factor       float64        `json:"factor"`
 }
 
+// Sub-recipe lines are expanded recursively, as in ListRecipeIngredientsByRecipeIDs.
 func (q *Queries) ListRecipeIngredientsByMealPlanDate(ctx context.Context, arg ListRecipeIngredientsByMealPlanDateParams) ([]ListRecipeIngredientsByMealPlanDateRow, error) {
 	rows, err := q.db.Query(ctx, listRecipeIngredientsByMealPlanDate, arg.UserID, arg.PlanDate)
 	if err != nil {
@@ -75,6 +178,7 @@ func (q *Queries) ListRecipeIngredientsByMealPlanDate(ctx context.Context, arg L
 			&i.Quantity,
 			&i.QuantityText,
 			&i.Unit,
+			&i.Factor,
 		); err != nil {
 			return nil, err
 		}
@@ -87,25 +191,65 @@ func (q *Queries) ListRecipeIngredientsByMealPlanDate(ctx context.Context, arg L
 }
 
 const listRecipeIngredientsByRecipeIDs = `-- name: ListRecipeIngredientsByRecipeIDs :many
+WITH RECURSIVE expanded AS (
+  SELECT
+    ri.recipe_id AS root_recipe_id,
+    ri.item_id,
+    ri.sub_recipe_id,
+    ri.quantity,
+    ri.quantity_text,
+    ri.unit,
+    1::float8 AS factor,
+    ARRAY[ri.recipe_id] AS path,
+    ARRAY[ri.position] AS sort_path
+  FROM recipe_ingredients ri
+  JOIN recipes r ON r.id = ri.recipe_id
+  WHERE ri.recipe_id = ANY($1::uuid[])
+    AND r.deleted_at IS NULL
+  UNION ALL
+  SELECT
+    e.root_recipe_id,
+    ri.item_id,
+    ri.sub_recipe_id,
+    ri.quantity,
+    ri.quantity_text,
+    ri.unit,
+    e.factor * COALESCE(e.quantity::float8, sr.servings::float8) / sr.servings::float8,
+    e.path || ri.recipe_id,
+    e.sort_path || ri.position
+  FROM expanded e
+  JOIN recipes sr ON sr.id = e.sub_recipe_id
+  JOIN recipe_ingredients ri ON ri.recipe_id = sr.id
+  WHERE NOT ri.recipe_id = ANY(e.path)
+    AND sr.deleted_at IS NULL
+)
 SELECT
-  ri.item_id,
-  ri.quantity,
-  ri.quantity_text,
-  ri.unit
-FROM recipe_ingredients ri
-JOIN recipes r ON r.id = ri.recipe_id
-WHERE ri.recipe_id = ANY($1::uuid[])
-  AND r.deleted_at IS NULL
-ORDER BY ri.recipe_id ASC, ri.position ASC
+  e.root_recipe_id AS recipe_id,
+  e.item_id,
+  e.quantity,
+  e.quantity_text,
+  e.unit,
+  e.factor,
+  r.servings AS recipe_servings
+FROM expanded e
+JOIN recipes r ON r.id = e.root_recipe_id
+WHERE e.item_id IS NOT NULL
+ORDER BY e.root_recipe_id ASC, e.sort_path ASC
 `
 
 type ListRecipeIngredientsByRecipeIDsRow struct {
-	ItemID       pgtype.UUID    `json:"item_id"`
-	Quantity     pgtype.Numeric `json:"quantity"`
-	QuantityText pgtype.Text    `json:"quantity_text"`
-	Unit         pgtype.Text    `json:"unit"`
+	RecipeID       pgtype.UUID    `json:"recipe_id"`
+	ItemID         pgtype.UUID    `json:"item_id"`
+	Quantity       pgtype.Numeric `json:"quantity"`
+	QuantityText   pgtype.Text    `json:"quantity_text"`
+	Unit           pgtype.Text    `json:"unit"`
+	Factor         float64        `json:"factor"`
+	RecipeServings int32          `json:"recipe_servings"`
 }
 
+// Sub-recipe lines are expanded recursively; factor is the fraction of the
+// sub-recipe's batch that one batch of the root recipe uses. Deleted
+// sub-recipes contribute nothing.
 func (q *Queries) ListRecipeIngredientsByRecipeIDs(ctx context.Context, recipeIds []pgtype.UUID) ([]ListRecipeIngredientsByRecipeIDsRow, error) {
 	rows, err := q.db.Query(ctx, listRecipeIngredientsByRecipeIDs, recipeIds)
 	if err != nil {
@@ -116,10 +260,13 @@ func (q *Queries) ListRecipeIngredientsByRecipeIDs(ctx context.Context, recipeId
 	for rows.Next() {
 		var i ListRecipeIngredientsByRecipeIDsRow
 		if err := rows.Scan(
+			&i.RecipeID,
 			&i.ItemID,
 			&i.Quantity,
 			&i.QuantityText,
 			&i.Unit,
+			&i.Factor,
+			&i.RecipeServings,
 		); err != nil {
 			return nil, err
 		}
@@ -143,7 +290,7 @@ SELECT
   sli.purchased_at,
   i.name AS item_name,
   i.store_url AS item_store_url,
-  i.aisle_id AS item_aisle_id,
+  a.id AS item_aisle_id,
   a.name AS aisle_name,
   a.sort_group AS aisle_sort_group,
   a.sort_order AS aisle_sort_order,
@@ -151,7 +298,8 @@ SELECT
 FROM shopping_list_items sli
 JOIN shopping_lists sl ON sl.id = sli.shopping_list_id
 JOIN items i ON i.id = sli.item_id
-LEFT JOIN grocery_aisles a ON a.id = i.aisle_id
+LEFT JOIN store_item_aisles sia ON sia.store_id = sl.store_id AND sia.item_id = i.id
+LEFT JOIN grocery_aisles a ON a.id = COALESCE(sia.aisle_id, i.aisle_id)
 WHERE sli.shopping_list_id = $1
   AND sl.created_by = $2
 ORDER BY
@@ -185,6 +333,9 @@ type ListShoppingListItemsByListIDRow struct {
 	AisleNumericValue pgtype.Int4        `json:"aisle_numeric_value"`
 }
 
+// Lines are ordered by the list's store layout when it has a store, else by
+// the default layout; items not placed in the store's aisles use their default
+// aisle.
 func (q *Queries) ListShoppingListItemsByListID(ctx context.Context, arg ListShoppingListItemsByListIDParams) ([]ListShoppingListItemsByListIDRow, error) {
 	rows, err := q.db.Query(ctx, listShoppingListItemsByListID, arg.ShoppingListID, arg.UserID)
 	if err != nil {
@@ -221,6 +372,48 @@ func (q *Queries) ListShoppingListItemsByListID(ctx context.Context, arg ListSho
 	return items, nil
 }
 
+const listShoppingListPantryReservations = `-- name: ListShoppingListPantryReservations :many
+SELECT r.item_id, r.unit, r.quantity
+FROM shopping_list_pantry_reservations r
+JOIN shopping_lists sl ON sl.id = r.shopping_list_id
+WHERE r.shopping_list_id = $1
+  AND sl.created_by = $2
+  AND r.item_id = ANY($3::uuid[])
+`
+
+type ListShoppingListPantryReservationsParams struct {
+	ShoppingListID pgtype.UUID   `json:"shopping_list_id"`
+	UserID         pgtype.UUID   `json:"user_id"`
+	ItemIds        []pgtype.UUID `json:"item_ids"`
+}
+
+type ListShoppingListPantryReservationsRow struct {
+	ItemID   pgtype.UUID    `json:"item_id"`
+	Unit     string         `json:"unit"`
+	Quantity pgtype.Numeric `json:"quantity"`
+}
+
+// Lists the pantry stock already set against a list for the given items.
+func (q *Queries) ListShoppingListPantryReservations(ctx context.Context, arg ListShoppingListPantryReservationsParams) ([]ListShoppingListPantryReservationsRow, error) {
+	rows, err := q.db.Query(ctx, listShoppingListPantryReservations, arg.ShoppingListID, arg.UserID, arg.ItemIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListShoppingListPantryReservationsRow{}
+	for rows.Next() {
+		var i ListShoppingListPantryReservationsRow
+		if err := rows.Scan(&i.ItemID, &i.Unit, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateShoppingListItemPurchased = `-- name: UpdateShoppingListItemPurchased :one
 UPDATE shopping_list_items AS sli
 SET is_purchased = $1,
@@ -229,7 +422,8 @@ SET is_purchased = $1,
     updated_by = $2
 FROM shopping_lists sl
 JOIN items i ON i.id = sli.item_id
-LEFT JOIN grocery_aisles a ON a.id = i.aisle_id
+LEFT JOIN store_item_aisles sia ON sia.store_id = sl.store_id AND sia.item_id = i.id
+LEFT JOIN grocery_aisles a ON a.id = COALESCE(sia.aisle_id, i.aisle_id)
 WHERE sli.id = $3
   AND sli.shopping_list_id = $4
   AND sli.shopping_list_id = sl.id
@@ -245,7 +439,7 @@ RETURNING
   sli.purchased_at,
   i.name AS item_name,
   i.store_url AS item_store_url,
-  i.aisle_id AS item_aisle_id,
+  a.id AS item_aisle_id,
   a.name AS aisle_name,
   a.sort_group AS aisle_sort_group,
   a.sort_order AS aisle_sort_order,
@@ -16,6 +16,7 @@ INSERT INTO shopping_lists (
   list_date,
   name,
   notes,
+  store_id,
   created_by,
   updated_by
 ) VALUES (
@@ -23,15 +24,17 @@ INSERT INTO shopping_lists (
   $2,
   $3,
   $4,
-  $5
+  $5,
+  $6
 )
-RETURNING id, list_date, name, notes, created_at, updated_at
+RETURNING id, list_date, name, notes, store_id, created_at, updated_at
 `
 
 type CreateShoppingListParams struct {
 	ListDate  pgtype.Date `json:"list_date"`
 	Name      string      `json:"name"`
 	Notes     pgtype.Text `json:"notes"`
+	StoreID   pgtype.UUID `json:"store_id"`
 	CreatedBy pgtype.UUID `json:"created_by"`
 	UpdatedBy pgtype.UUID `json:"updated_by"`
 }
@@ -41,6 +44,7 @@ type CreateShoppingListRow struct {
 	ListDate  pgtype.Date        `json:"list_date"`
 	Name      string             `json:"name"`
 	Notes     pgtype.Text        `json:"notes"`
+	StoreID   pgtype.UUID        `json:"store_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
@@ -50,6 +54,7 @@ func (q *Queries) CreateShoppingList(ctx context.Context, arg CreateShoppingList
 		arg.ListDate,
 		arg.Name,
 		arg.Notes,
+		arg.StoreID,
 		arg.CreatedBy,
 		arg.UpdatedBy,
 	)
@@ -59,6 +64,7 @@ func (q *Queries) CreateShoppingList(ctx context.Context, arg CreateShoppingList
 		&i.ListDate,
 		&i.Name,
 		&i.Notes,
+		&i.StoreID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -90,6 +96,7 @@ SELECT
   list_date,
   name,
   notes,
+  store_id,
   created_at,
   updated_at
 FROM shopping_lists
@@ -107,6 +114,7 @@ type GetShoppingListByIDRow struct {
 	ListDate  pgtype.Date        `json:"list_date"`
 	Name      string             `json:"name"`
 	Notes     pgtype.Text        `json:"notes"`
+	StoreID   pgtype.UUID        `json:"store_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
@@ -119,6 +127,7 @@ func (q *Queries) GetShoppingListByID(ctx context.Context, arg GetShoppingListBy
 		&i.ListDate,
 		&i.Name,
 		&i.Notes,
+		&i.StoreID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -131,6 +140,7 @@ SELECT
   list_date,
   name,
   notes,
+  store_id,
   created_at,
   updated_at
 FROM shopping_lists
@@ -151,6 +161,7 @@ type ListShoppingListsByDateRangeRow struct {
 	ListDate  pgtype.Date        `json:"list_date"`
 	Name      string             `json:"name"`
 	Notes     pgtype.Text        `json:"notes"`
+	StoreID   pgtype.UUID        `json:"store_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
@@ -169,6 +180,7 @@ func (q *Queries) ListShoppingListsByDateRange(ctx context.Context, arg ListShop
 			&i.ListDate,
 			&i.Name,
 			&i.Notes,
+			&i.StoreID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -187,17 +199,20 @@ UPDATE shopping_lists
 SET list_date = $1,
     name = $2,
     notes = $3,
+    store_id = CASE WHEN $4::boolean THEN $5::uuid ELSE store_id END,
     updated_at = now(),
-    updated_by = $4
-WHERE id = $5
-  AND created_by = $6
-RETURNING id, list_date, name, notes, created_at, updated_at
+    updated_by = $6
+WHERE id = $7
+  AND created_by = $8
+RETURNING id, list_date, name, notes, store_id, created_at, updated_at
 `
 
 type UpdateShoppingListByIDParams struct {
 	ListDate  pgtype.Date `json:"list_date"`
 	Name      string      `json:"name"`
 	Notes     pgtype.Text `json:"notes"`
+	SetStore  bool        `json:"set_store"`
+	StoreID   pgtype.UUID `json:"store_id"`
 	UpdatedBy pgtype.UUID `json:"updated_by"`
 	ID        pgtype.UUID `json:"id"`
 	UserID    pgtype.UUID `json:"user_id"`
@@ -208,15 +223,20 @@ type UpdateShoppingListByIDRow struct {
 	ListDate  pgtype.Date        `json:"list_date"`
 	Name      string             `json:"name"`
 	Notes     pgtype.Text        `json:"notes"`
+	StoreID   pgtype.UUID        `json:"store_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+// The store is only changed when set_store is true, so clients that don't
+// send one keep the list's current store.
 func (q *Queries) UpdateShoppingListByID(ctx context.Context, arg UpdateShoppingListByIDParams) (UpdateShoppingListByIDRow, error) {
 	row := q.db.QueryRow(ctx, updateShoppingListByID,
 		arg.ListDate,
 		arg.Name,
 		arg.Notes,
+		arg.SetStore,
+		arg.StoreID,
 		arg.UpdatedBy,
 		arg.ID,
 		arg.UserID,
@@ -227,6 +247,7 @@ func (q *Queries) UpdateShoppingListByID(ctx context.Context, arg UpdateShopping
 		&i.ListDate,
 		&i.Name,
 		&i.Notes,
+		&i.StoreID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
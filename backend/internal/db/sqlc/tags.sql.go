@@ -55,6 +55,53 @@ func (q *Queries) DeleteTagByID(ctx context.Context, id pgtype.UUID) (int64, err
 	return result.RowsAffected(), nil
 }
 
+const getTagByID = `-- name: GetTagByID :one
+SELECT id, name, created_at, created_by, updated_at, updated_by
+FROM tags
+WHERE id = $1
+`
+
+func (q *Queries) GetTagByID(ctx context.Context, id pgtype.UUID) (Tag, error) {
+	row := q.db.QueryRow(ctx, getTagByID, id)
+	var i Tag
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdatedAt,
+		&i.UpdatedBy,
+	)
+	return i, err
+}
+
+const listRecipeIDsByTagID = `-- name: ListRecipeIDsByTagID :many
+SELECT recipe_id
+FROM recipe_tags
+WHERE tag_id = $1
+ORDER BY recipe_id
+`
+
+func (q *Queries) ListRecipeIDsByTagID(ctx context.Context, tagID pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listRecipeIDsByTagID, tagID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var recipe_id pgtype.UUID
+		if err := rows.Scan(&recipe_id); err != nil {
+			return nil, err
+		}
+		items = append(items, recipe_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTags = `-- name: ListTags :many
 SELECT id, name, created_at, created_by, updated_at, updated_by
 FROM tags
@@ -88,6 +135,30 @@ func (q *Queries) ListTags(ctx context.Context) ([]Tag, error) {
 	return items, nil
 }
 
+const moveRecipeTagsToTag = `-- name: MoveRecipeTagsToTag :execrows
+INSERT INTO recipe_tags (recipe_id, tag_id, created_by, updated_by)
+SELECT rt.recipe_id, $1::uuid, $2::uuid, $2::uuid
+FROM recipe_tags rt
+WHERE rt.tag_id = $3::uuid
+ON CONFLICT (recipe_id, tag_id) DO NOTHING
+`
+
+type MoveRecipeTagsToTagParams struct {
+	TargetID pgtype.UUID `json:"target_id"`
+	UserID   pgtype.UUID `json:"user_id"`
+	SourceID pgtype.UUID `json:"source_id"`
+}
+
+// Gives the source tag's recipes the target tag, skipping recipes that
+// already have it. Deleting the source tag removes the old rows.
+func (q *Queries) MoveRecipeTagsToTag(ctx context.Context, arg MoveRecipeTagsToTagParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveRecipeTagsToTag, arg.TargetID, arg.UserID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateTagByID = `-- name: UpdateTagByID :one
 UPDATE tags
 SET name = $2, updated_at = now(), updated_by = $3
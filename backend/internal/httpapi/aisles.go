@@ -41,7 +41,8 @@ func (a *App) handleAislesList(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// handleAislesGet returns a grocery aisle by id.
+// handleAislesGet returns a default-layout aisle by id. Store aisles are
+// reached through /stores/{id}/aisles and are not found here.
 func (a *App) handleAislesGet(w http.ResponseWriter, r *http.Request) error {
 	if _, ok := authInfoFromRequest(r); !ok {
 		return errUnauthorized("unauthorized")
@@ -59,6 +60,9 @@ func (a *App) handleAislesGet(w http.ResponseWriter, r *http.Request) error {
 		}
 		return errInternal(err)
 	}
+	if row.StoreID.Valid {
+		return errNotFound()
+	}
 
 	if err := response.WriteJSON(w, http.StatusOK, aisleResponseFromRow(row)); err != nil {
 		a.logger.Warn("write failed", "err", err, "path", "/api/v1/aisles/{id}")
@@ -105,7 +109,7 @@ func (a *App) handleAislesCreate(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// handleAislesUpdate updates a grocery aisle.
+// handleAislesUpdate updates a default-layout aisle.
 func (a *App) handleAislesUpdate(w http.ResponseWriter, r *http.Request) error {
 	info, ok := authInfoFromRequest(r)
 	if !ok {
@@ -152,7 +156,7 @@ func (a *App) handleAislesUpdate(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// handleAislesDelete deletes a grocery aisle.
+// handleAislesDelete deletes a default-layout aisle.
 func (a *App) handleAislesDelete(w http.ResponseWriter, r *http.Request) error {
 	if _, ok := authInfoFromRequest(r); !ok {
 		return errUnauthorized("unauthorized")
@@ -163,7 +167,9 @@ func (a *App) handleAislesDelete(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	affected, err := a.queries.DeleteGroceryAisleByID(r.Context(), pgtype.UUID{Bytes: id, Valid: true})
+	affected, err := a.queries.DeleteGroceryAisleByID(r.Context(), sqlc.DeleteGroceryAisleByIDParams{
+		ID: pgtype.UUID{Bytes: id, Valid: true},
+	})
 	if err != nil {
 		return errInternal(err)
 	}
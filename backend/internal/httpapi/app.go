@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/saiaj/cooking_app/backend/internal/blobstore"
 	"github.com/saiaj/cooking_app/backend/internal/config"
 	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
 )
@@ -30,6 +31,9 @@ type App struct {
 
 	maxJSONBodyBytes int64
 	strictJSON       bool
+
+	blobs               blobstore.Store
+	maxImageUploadBytes int64
 }
 
 // New wires the API app (router + DB pool).
@@ -53,6 +57,11 @@ func New(ctx context.Context, logger *slog.Logger, cfg config.Config) (*App, err
 		return nil, err
 	}
 
+	maxImageUploadBytes := cfg.MaxImageUploadBytes
+	if maxImageUploadBytes <= 0 {
+		maxImageUploadBytes = defaultMaxImageUploadBytes
+	}
+
 	app := &App{
 		logger:              logger,
 		pool:                pool,
@@ -66,6 +75,15 @@ func New(ctx context.Context, logger *slog.Logger, cfg config.Config) (*App, err
 		tokenCreateLimiter:  newRateLimiter(cfg.TokenCreateRateLimitPerMin, cfg.TokenCreateRateLimitBurst),
 		maxJSONBodyBytes:    cfg.MaxJSONBodyBytes,
 		strictJSON:          cfg.StrictJSON,
+		maxImageUploadBytes: maxImageUploadBytes,
+	}
+	if cfg.BlobStoreDir != "" {
+		blobs, err := blobstore.NewLocal(cfg.BlobStoreDir)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+		app.blobs = blobs
 	}
 	app.mux = routes(app)
 
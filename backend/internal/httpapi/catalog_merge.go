@@ -0,0 +1,374 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+// itemMergeResponse reports what moved from the merged item onto the kept
+// one. Combined rows were added onto an existing row of the kept item rather
+// than repointed.
+type itemMergeResponse struct {
+	Item                      itemResponse `json:"item"`
+	MergedItemID              string       `json:"merged_item_id"`
+	RecipeIngredientsMoved    int64        `json:"recipe_ingredients_moved"`
+	ShoppingListItemsMoved    int64        `json:"shopping_list_items_moved"`
+	ShoppingListItemsCombined int64        `json:"shopping_list_items_combined"`
+	PantryItemsMoved          int64        `json:"pantry_items_moved"`
+	PantryItemsCombined       int64        `json:"pantry_items_combined"`
+}
+
+type tagMergeResponse struct {
+	Tag          tagResponse `json:"tag"`
+	MergedTagID  string      `json:"merged_tag_id"`
+	RecipesMoved int64       `json:"recipes_moved"`
+}
+
+type recipeBookMergeResponse struct {
+	RecipeBook         recipeBookResponse `json:"recipe_book"`
+	MergedRecipeBookID string             `json:"merged_recipe_book_id"`
+	RecipesMoved       int64              `json:"recipes_moved"`
+}
+
+// handleItemsMerge folds the item in the path into target: every recipe
+// ingredient, shopping list line, pantry entry, price, alias, and store
+// placement moves to target, lines that collide with one of target's are
+// added onto it, and the merged item's name becomes an alias of target.
+// Every recipe that used the merged item gets a revision, and earlier
+// revisions are repointed at target so they can still be reverted to.
+func (a *App) handleItemsMerge(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	sourceID, targetID, err := parseMergeIDs(r, "cannot merge an item into itself")
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
+	var resp itemMergeResponse
+	err = a.withinTx(ctx, func(q *sqlc.Queries) error {
+		source, err := q.GetItemByID(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		target, err := q.GetItemByID(ctx, targetID)
+		if err != nil {
+			return err
+		}
+
+		resp.ShoppingListItemsCombined, err = q.MergeShoppingListItemQuantities(ctx, sqlc.MergeShoppingListItemQuantitiesParams{
+			UserID:   userID,
+			TargetID: targetID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.DeleteMergedShoppingListItems(ctx, sqlc.DeleteMergedShoppingListItemsParams{
+			TargetID: targetID,
+			SourceID: sourceID,
+		}); err != nil {
+			return err
+		}
+		resp.ShoppingListItemsMoved, err = q.MoveShoppingListItems(ctx, sqlc.MoveShoppingListItemsParams{
+			TargetID: targetID,
+			UserID:   userID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+
+		resp.PantryItemsCombined, err = q.MergePantryItemQuantities(ctx, sqlc.MergePantryItemQuantitiesParams{
+			UserID:   userID,
+			TargetID: targetID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.DeleteMergedPantryItems(ctx, sqlc.DeleteMergedPantryItemsParams{
+			TargetID: targetID,
+			SourceID: sourceID,
+		}); err != nil {
+			return err
+		}
+		resp.PantryItemsMoved, err = q.MovePantryItems(ctx, sqlc.MovePantryItemsParams{
+			TargetID: targetID,
+			UserID:   userID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+
+		recipeIDs, err := q.ListRecipeIDsByItemID(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		resp.RecipeIngredientsMoved, err = q.MoveRecipeIngredientItems(ctx, sqlc.MoveRecipeIngredientItemsParams{
+			TargetID: targetID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.MoveRecipeRevisionItems(ctx, sqlc.MoveRecipeRevisionItemsParams{SourceID: sourceID, TargetID: targetID}); err != nil {
+			return err
+		}
+		if err := q.MoveShoppingListPantryReservations(ctx, sqlc.MoveShoppingListPantryReservationsParams{SourceID: sourceID, TargetID: targetID}); err != nil {
+			return err
+		}
+		if err := q.MoveItemPrices(ctx, sqlc.MoveItemPricesParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return err
+		}
+		if err := q.MoveItemNutrition(ctx, sqlc.MoveItemNutritionParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return err
+		}
+		if err := q.MoveItemAttributes(ctx, sqlc.MoveItemAttributesParams{
+			TargetID: targetID,
+			UserID:   userID,
+			SourceID: sourceID,
+		}); err != nil {
+			return err
+		}
+		if err := q.MoveItemAliases(ctx, sqlc.MoveItemAliasesParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return err
+		}
+		if err := q.MoveStoreItemAisles(ctx, sqlc.MoveStoreItemAislesParams{
+			TargetID: targetID,
+			UserID:   userID,
+			SourceID: sourceID,
+		}); err != nil {
+			return err
+		}
+
+		if _, err := q.DeleteItemByID(ctx, sourceID); err != nil {
+			return err
+		}
+		if err := q.EnsureItemAlias(ctx, sqlc.EnsureItemAliasParams{
+			ItemID:    targetID,
+			Alias:     source.Name,
+			CreatedBy: userID,
+		}); err != nil {
+			return err
+		}
+		if resp.RecipeIngredientsMoved > 0 {
+			// Item names are part of each recipe's search document.
+			if err := q.RefreshRecipeSearchDocumentsByItemID(ctx, targetID); err != nil {
+				return err
+			}
+		}
+		if err := q.CreateRecipeRevisions(ctx, sqlc.CreateRecipeRevisionsParams{
+			CreatedBy: userID,
+			RecipeIds: recipeIDs,
+		}); err != nil {
+			return err
+		}
+
+		resp.Item = itemResponseFromGetRow(target)
+		resp.MergedItemID = uuidString(sourceID)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/items/{id}/merge-into/{target}")
+	}
+	return nil
+}
+
+// handleTagsMerge gives every recipe tagged with the tag in the path the
+// target tag instead, then deletes the merged tag. Each of those recipes
+// gets a revision, and earlier revisions are repointed at target.
+func (a *App) handleTagsMerge(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	sourceID, targetID, err := parseMergeIDs(r, "cannot merge a tag into itself")
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
+	var resp tagMergeResponse
+	err = a.withinTx(ctx, func(q *sqlc.Queries) error {
+		if _, err := q.GetTagByID(ctx, sourceID); err != nil {
+			return err
+		}
+		target, err := q.GetTagByID(ctx, targetID)
+		if err != nil {
+			return err
+		}
+
+		recipeIDs, err := q.ListRecipeIDsByTagID(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		resp.RecipesMoved, err = q.MoveRecipeTagsToTag(ctx, sqlc.MoveRecipeTagsToTagParams{
+			TargetID: targetID,
+			UserID:   userID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.MoveRecipeRevisionTags(ctx, sqlc.MoveRecipeRevisionTagsParams{SourceID: sourceID, TargetID: targetID}); err != nil {
+			return err
+		}
+		if _, err := q.DeleteTagByID(ctx, sourceID); err != nil {
+			return err
+		}
+		if err := q.CreateRecipeRevisions(ctx, sqlc.CreateRecipeRevisionsParams{
+			CreatedBy: userID,
+			RecipeIds: recipeIDs,
+		}); err != nil {
+			return err
+		}
+
+		resp.Tag = tagResponse{
+			ID:        uuidString(target.ID),
+			Name:      target.Name,
+			CreatedAt: timeString(target.CreatedAt),
+		}
+		resp.MergedTagID = uuidString(sourceID)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/tags/{id}/merge-into/{target}")
+	}
+	return nil
+}
+
+// handleRecipeBooksMerge moves every recipe in the book in the path to the
+// target book, then deletes the merged book. Each moved recipe gets a
+// revision, and earlier revisions are repointed at target.
+func (a *App) handleRecipeBooksMerge(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	sourceID, targetID, err := parseMergeIDs(r, "cannot merge a recipe book into itself")
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
+	var resp recipeBookMergeResponse
+	err = a.withinTx(ctx, func(q *sqlc.Queries) error {
+		if _, err := q.GetRecipeBookByID(ctx, sourceID); err != nil {
+			return err
+		}
+		target, err := q.GetRecipeBookByID(ctx, targetID)
+		if err != nil {
+			return err
+		}
+
+		recipeIDs, err := q.ListRecipeIDsByRecipeBookID(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		resp.RecipesMoved, err = q.MoveRecipesToRecipeBook(ctx, sqlc.MoveRecipesToRecipeBookParams{
+			TargetID: targetID,
+			UserID:   userID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.MoveRecipeRevisionRecipeBooks(ctx, sqlc.MoveRecipeRevisionRecipeBooksParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return err
+		}
+		if _, err := q.DeleteRecipeBookByID(ctx, sourceID); err != nil {
+			return err
+		}
+		if err := q.CreateRecipeRevisions(ctx, sqlc.CreateRecipeRevisionsParams{
+			CreatedBy: userID,
+			RecipeIds: recipeIDs,
+		}); err != nil {
+			return err
+		}
+
+		resp.RecipeBook = recipeBookResponse{
+			ID:        uuidString(target.ID),
+			Name:      target.Name,
+			CreatedAt: timeString(target.CreatedAt),
+		}
+		resp.MergedRecipeBookID = uuidString(sourceID)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipe-books/{id}/merge-into/{target}")
+	}
+	return nil
+}
+
+// parseMergeIDs reads the {id} being merged away and the {target} it merges
+// into, which must differ.
+func parseMergeIDs(r *http.Request, selfMessage string) (pgtype.UUID, pgtype.UUID, error) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+	target, err := parseUUIDParam(r, "target")
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+	if id == target {
+		return pgtype.UUID{}, pgtype.UUID{}, errValidationField("target", selfMessage)
+	}
+	return pgtype.UUID{Bytes: id, Valid: true}, pgtype.UUID{Bytes: target, Valid: true}, nil
+}
+
+// withinTx runs fn with queries bound to a transaction that commits when fn
+// returns nil.
+func (a *App) withinTx(ctx context.Context, fn func(q *sqlc.Queries) error) error {
+	tx, err := a.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
+			a.logger.Warn("rollback failed", "err", rollbackErr)
+		}
+	}()
+
+	if err := fn(a.queries.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,229 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type itemMergeResponse struct {
+	Item                      itemResponse `json:"item"`
+	MergedItemID              string       `json:"merged_item_id"`
+	RecipeIngredientsMoved    int64        `json:"recipe_ingredients_moved"`
+	ShoppingListItemsMoved    int64        `json:"shopping_list_items_moved"`
+	ShoppingListItemsCombined int64        `json:"shopping_list_items_combined"`
+	PantryItemsMoved          int64        `json:"pantry_items_moved"`
+	PantryItemsCombined       int64        `json:"pantry_items_combined"`
+}
+
+type namedResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCatalog_MergeItemsTagsAndBooks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, err := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); err != nil {
+		t.Fatalf("bootstrap user: %v", err)
+	}
+
+	app, err := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   testSessionCookieName,
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if err != nil {
+		t.Fatalf("new app: %v", err)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookie jar: %v", err)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	create := func(path, name string) string {
+		t.Helper()
+		var out namedResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+path, `{"name":"`+name+`"}`, http.StatusOK, &out)
+		return out.ID
+	}
+
+	scallionID := create("/api/v1/items", "Scallion")
+	onionID := create("/api/v1/items", "Green onions")
+	quickID := create("/api/v1/tags", "quick")
+	fastID := create("/api/v1/tags", "fast")
+	oldBookID := create("/api/v1/recipe-books", "Old binder")
+	bookID := create("/api/v1/recipe-books", "Family")
+
+	var recipe recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"Fried rice",
+  "servings":2,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":"`+oldBookID+`",
+  "tag_ids":["`+quickID+`","`+fastID+`"],
+  "ingredients":[{"position":1,"item_id":"`+scallionID+`","quantity":2}],
+  "steps":[{"step_number":1,"instruction":"Fry."}]
+}`, http.StatusCreated, &recipe)
+
+	var list testShoppingListResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists", `{"list_date":"2025-02-09","name":"Shop","notes":null}`, http.StatusCreated, &list)
+	listURL := server.URL + "/api/v1/shopping-lists/" + list.ID
+	doRevisionsRequest(t, client, csrf, http.MethodPost, listURL+"/items",
+		`{"items":[{"item_id":"`+scallionID+`","quantity":2,"unit":"bunch"},{"item_id":"`+onionID+`","quantity":1,"unit":"bunch"},{"item_id":"`+scallionID+`","quantity":3},{"item_id":"`+onionID+`","quantity":4}]}`, http.StatusOK, nil)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/pantry-items", `{"item_id":"`+scallionID+`","quantity":1,"location":"fridge"}`, http.StatusCreated, nil)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/pantry-items", `{"item_id":"`+onionID+`","quantity":2,"location":"fridge"}`, http.StatusCreated, nil)
+
+	t.Run("rejects self and missing targets", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/items/"+onionID+"/merge-into/"+onionID, "", http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/items/"+scallionID+"/merge-into/"+uuid.NewString(), "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/tags/"+uuid.NewString()+"/merge-into/"+quickID, "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipe-books/"+bookID+"/merge-into/not-a-uuid", "", http.StatusBadRequest, nil)
+	})
+
+	t.Run("merge item", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/items/"+scallionID+"/attributes", `{"attributes":["gluten"]}`, http.StatusOK, nil)
+
+		var got itemMergeResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/items/"+scallionID+"/merge-into/"+onionID, "", http.StatusOK, &got)
+		if got.Item.ID != onionID || got.MergedItemID != scallionID || got.RecipeIngredientsMoved != 1 {
+			t.Fatalf("merge=%+v", got)
+		}
+		if got.ShoppingListItemsCombined != 2 || got.ShoppingListItemsMoved != 0 || got.PantryItemsCombined != 1 || got.PantryItemsMoved != 0 {
+			t.Fatalf("merge counts=%+v", got)
+		}
+
+		var detail testShoppingListDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, listURL, "", http.StatusOK, &detail)
+		if len(detail.Items) != 2 {
+			t.Fatalf("list items=%+v, want bunches and unitless lines each combined", detail.Items)
+		}
+		for _, item := range detail.Items {
+			if item.Item.ID != onionID {
+				t.Fatalf("list item=%+v, want it on green onions", item)
+			}
+			if item.Unit != nil && *item.Unit == "bunch" && (item.Quantity == nil || *item.Quantity != 3) {
+				t.Fatalf("bunch line=%+v, want 3", item)
+			}
+			if item.Unit == nil && (item.Quantity == nil || *item.Quantity != 7) {
+				t.Fatalf("unitless line=%+v, want 7", item)
+			}
+		}
+
+		var revisions []recipeRevisionSummary
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+recipe.ID+"/revisions", "", http.StatusOK, &revisions)
+		if len(revisions) != 2 {
+			t.Fatalf("revisions=%+v, want one for the item merge", revisions)
+		}
+
+		var fridge []pantryItemResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/pantry-items?location=fridge", "", http.StatusOK, &fridge)
+		if len(fridge) != 1 || fridge[0].ItemID != onionID || *fridge[0].Quantity != 3 {
+			t.Fatalf("fridge=%+v, want 3 green onions", fridge)
+		}
+
+		var aliases []itemAliasResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/items/"+onionID+"/aliases", "", http.StatusOK, &aliases)
+		if len(aliases) != 1 || aliases[0].Alias != "Scallion" {
+			t.Fatalf("aliases=%+v, want the merged name", aliases)
+		}
+
+		var attributes itemAttributesResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/items/"+onionID+"/attributes", "", http.StatusOK, &attributes)
+		if attributes.Classified || len(attributes.Attributes) != 1 || attributes.Attributes[0] != "gluten" {
+			t.Fatalf("attributes=%+v, want the merged attribute and still unclassified", attributes)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/items/"+scallionID, "", http.StatusNotFound, nil)
+	})
+
+	t.Run("merge tag and book", func(t *testing.T) {
+		var tag struct {
+			Tag          namedResponse `json:"tag"`
+			MergedTagID  string        `json:"merged_tag_id"`
+			RecipesMoved int64         `json:"recipes_moved"`
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/tags/"+fastID+"/merge-into/"+quickID, "", http.StatusOK, &tag)
+		if tag.Tag.ID != quickID || tag.MergedTagID != fastID || tag.RecipesMoved != 0 {
+			t.Fatalf("tag merge=%+v, want the recipe already tagged quick", tag)
+		}
+
+		var book struct {
+			RecipeBook         namedResponse `json:"recipe_book"`
+			MergedRecipeBookID string        `json:"merged_recipe_book_id"`
+			RecipesMoved       int64         `json:"recipes_moved"`
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipe-books/"+oldBookID+"/merge-into/"+bookID, "", http.StatusOK, &book)
+		if book.RecipeBook.ID != bookID || book.RecipesMoved != 1 {
+			t.Fatalf("book merge=%+v", book)
+		}
+
+		var got recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+recipe.ID, "", http.StatusOK, &got)
+		if got.RecipeBookID == nil || *got.RecipeBookID != bookID {
+			t.Fatalf("recipe book=%v, want %s", got.RecipeBookID, bookID)
+		}
+		if len(got.Tags) != 1 || got.Tags[0].ID != quickID {
+			t.Fatalf("tags=%+v, want only quick", got.Tags)
+		}
+		if len(got.Ingredients) != 1 || got.Ingredients[0].Item.ID != onionID {
+			t.Fatalf("ingredients=%+v, want green onions", got.Ingredients)
+		}
+
+		var revisions []recipeRevisionSummary
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+recipe.ID+"/revisions", "", http.StatusOK, &revisions)
+		if len(revisions) != 4 {
+			t.Fatalf("revisions=%+v, want one each for the item, tag, and book merges", revisions)
+		}
+	})
+
+	t.Run("revert to a revision from before the merges", func(t *testing.T) {
+		var got recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+recipe.ID+"/revisions/1/revert", "", http.StatusOK, &got)
+		if got.RecipeBookID == nil || *got.RecipeBookID != bookID {
+			t.Fatalf("recipe book=%v, want %s", got.RecipeBookID, bookID)
+		}
+		if len(got.Tags) != 1 || got.Tags[0].ID != quickID {
+			t.Fatalf("tags=%+v, want only quick", got.Tags)
+		}
+		if len(got.Ingredients) != 1 || got.Ingredients[0].Item.ID != onionID {
+			t.Fatalf("ingredients=%+v, want green onions", got.Ingredients)
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package httpapi
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// itemAttributes is the vocabulary of things an item can contain. It must
+// match item_attributes_attribute_chk.
+var itemAttributes = []string{
+	"dairy",
+	"eggs",
+	"fish",
+	"gluten",
+	"honey",
+	"meat",
+	"nuts",
+	"peanuts",
+	"sesame",
+	"shellfish",
+	"soy",
+}
+
+// dietaryAllergens are the item attributes reported as allergens. meat and
+// honey only matter to diets.
+var dietaryAllergens = []string{
+	"dairy",
+	"eggs",
+	"fish",
+	"gluten",
+	"nuts",
+	"peanuts",
+	"sesame",
+	"shellfish",
+	"soy",
+}
+
+// dietExclusions maps each supported diet to the item attributes it rules out.
+var dietExclusions = map[string][]string{
+	"vegetarian":  {"fish", "meat", "shellfish"},
+	"vegan":       {"dairy", "eggs", "fish", "honey", "meat", "shellfish"},
+	"pescatarian": {"meat"},
+	"gluten-free": {"gluten"},
+	"dairy-free":  {"dairy"},
+	"nut-free":    {"nuts", "peanuts"},
+}
+
+// dietNames lists the supported diets in response order.
+var dietNames = []string{
+	"dairy-free",
+	"gluten-free",
+	"nut-free",
+	"pescatarian",
+	"vegan",
+	"vegetarian",
+}
+
+// recipeDietaryResponse derives a recipe's labels from the attributes of its
+// items. Items whose attributes were never set are unknown rather than free
+// of everything: they are listed in UnclassifiedItems, and while any remain
+// no diet is claimed.
+type recipeDietaryResponse struct {
+	Allergens         []string              `json:"allergens"`
+	Diets             []string              `json:"diets"`
+	UnclassifiedItems []dietaryItemResponse `json:"unclassified_items"`
+}
+
+type dietaryItemResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// recipeDietaryItems is what a recipe's items say about allergens and diets.
+type recipeDietaryItems struct {
+	attributes   []string
+	unclassified []dietaryItemResponse
+}
+
+// dietaryLabelsFromItems reports the allergens the items are known to
+// contain and, when no item is unclassified, the diets none of them rule out.
+func dietaryLabelsFromItems(items recipeDietaryItems) recipeDietaryResponse {
+	labels := recipeDietaryResponse{
+		Allergens:         []string{},
+		Diets:             []string{},
+		UnclassifiedItems: []dietaryItemResponse{},
+	}
+	for _, allergen := range dietaryAllergens {
+		if slices.Contains(items.attributes, allergen) {
+			labels.Allergens = append(labels.Allergens, allergen)
+		}
+	}
+	if len(items.unclassified) > 0 {
+		labels.UnclassifiedItems = append(labels.UnclassifiedItems, items.unclassified...)
+		return labels
+	}
+	for _, diet := range dietNames {
+		if len(dietConflicts(diet, items.attributes)) == 0 {
+			labels.Diets = append(labels.Diets, diet)
+		}
+	}
+	return labels
+}
+
+// dietConflicts returns the attributes in attrs that diet rules out.
+func dietConflicts(diet string, attrs []string) []string {
+	var conflicts []string
+	for _, excluded := range dietExclusions[diet] {
+		if slices.Contains(attrs, excluded) {
+			conflicts = append(conflicts, excluded)
+		}
+	}
+	return conflicts
+}
+
+const (
+	dietaryConflictDiet     = "diet"
+	dietaryConflictAllergen = "allergen"
+	dietaryConflictUnknown  = "unknown"
+)
+
+// dietaryConflict explains why a recipe does not fit a declared restriction,
+// or, for kind unknown, which unclassified items keep it from being checked.
+type dietaryConflict struct {
+	Kind        string                `json:"kind"`
+	Restriction string                `json:"restriction"`
+	Attributes  []string              `json:"attributes"`
+	Items       []dietaryItemResponse `json:"items"`
+	Message     string                `json:"message"`
+}
+
+// dietaryConflicts checks recipe attributes against declared diets and
+// allergens, in diet-then-allergen order. A restriction the known attributes
+// do not break is reported as unknown while any item is unclassified.
+func dietaryConflicts(diets, allergens []string, items recipeDietaryItems) []dietaryConflict {
+	conflicts := []dietaryConflict{}
+	var unknown []string
+	for _, diet := range diets {
+		if found := dietConflicts(diet, items.attributes); len(found) > 0 {
+			conflicts = append(conflicts, dietaryConflict{
+				Kind:        dietaryConflictDiet,
+				Restriction: diet,
+				Attributes:  found,
+				Items:       []dietaryItemResponse{},
+				Message:     "recipe is not " + diet + ": contains " + strings.Join(found, ", "),
+			})
+		} else {
+			unknown = append(unknown, diet)
+		}
+	}
+	for _, allergen := range allergens {
+		if slices.Contains(items.attributes, allergen) {
+			conflicts = append(conflicts, dietaryConflict{
+				Kind:        dietaryConflictAllergen,
+				Restriction: allergen,
+				Attributes:  []string{allergen},
+				Items:       []dietaryItemResponse{},
+				Message:     "recipe contains " + allergen,
+			})
+		} else {
+			unknown = append(unknown, allergen)
+		}
+	}
+	if len(items.unclassified) == 0 {
+		return conflicts
+	}
+	names := make([]string, 0, len(items.unclassified))
+	for _, item := range items.unclassified {
+		names = append(names, item.Name)
+	}
+	for _, restriction := range unknown {
+		conflicts = append(conflicts, dietaryConflict{
+			Kind:        dietaryConflictUnknown,
+			Restriction: restriction,
+			Attributes:  []string{},
+			Items:       items.unclassified,
+			Message:     "cannot check " + restriction + ": no allergen data for " + strings.Join(names, ", "),
+		})
+	}
+	return conflicts
+}
+
+// normalizeDietaryValues trims, lowercases, de-duplicates, and sorts values,
+// reporting the first one not in vocabulary.
+func normalizeDietaryValues(values, vocabulary []string) ([]string, string, bool) {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if !slices.Contains(vocabulary, v) {
+			return nil, v, false
+		}
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	slices.Sort(out)
+	return out, "", true
+}
+
+// loadRecipeDietaryItems returns the item attributes and unclassified items
+// of each recipe, keyed by recipe id.
+func (a *App) loadRecipeDietaryItems(ctx context.Context, recipeIDs []pgtype.UUID) (map[string]recipeDietaryItems, error) {
+	rows, err := a.queries.ListRecipeAttributesByRecipeIDs(ctx, recipeIDs)
+	if err != nil {
+		return nil, err
+	}
+	unclassifiedRows, err := a.queries.ListRecipeUnclassifiedItemsByRecipeIDs(ctx, recipeIDs)
+	if err != nil {
+		return nil, err
+	}
+	byRecipeID := make(map[string]recipeDietaryItems, len(recipeIDs))
+	for _, row := range rows {
+		id := uuidString(row.RecipeID)
+		items := byRecipeID[id]
+		items.attributes = append(items.attributes, row.Attribute)
+		byRecipeID[id] = items
+	}
+	for _, row := range unclassifiedRows {
+		id := uuidString(row.RecipeID)
+		items := byRecipeID[id]
+		items.unclassified = append(items.unclassified, dietaryItemResponse{
+			ID:   uuidString(row.ItemID),
+			Name: row.ItemName,
+		})
+		byRecipeID[id] = items
+	}
+	return byRecipeID, nil
+}
@@ -0,0 +1,108 @@
+package httpapi
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDietaryLabelsFromAttributes(t *testing.T) {
+	t.Parallel()
+
+	got := dietaryLabelsFromItems(recipeDietaryItems{attributes: []string{"nuts", "dairy", "honey"}})
+	if want := []string{"dairy", "nuts"}; !slices.Equal(got.Allergens, want) {
+		t.Fatalf("allergens=%v, want %v", got.Allergens, want)
+	}
+	if want := []string{"gluten-free", "pescatarian", "vegetarian"}; !slices.Equal(got.Diets, want) {
+		t.Fatalf("diets=%v, want %v", got.Diets, want)
+	}
+	if got.UnclassifiedItems == nil || len(got.UnclassifiedItems) != 0 {
+		t.Fatalf("unclassified=%v, want empty list", got.UnclassifiedItems)
+	}
+
+	none := dietaryLabelsFromItems(recipeDietaryItems{})
+	if len(none.Allergens) != 0 || !slices.Equal(none.Diets, dietNames) {
+		t.Fatalf("labels without attributes=%+v, want every diet and no allergens", none)
+	}
+
+	stock := dietaryItemResponse{ID: "item-stock", Name: "stock cube"}
+	unknown := dietaryLabelsFromItems(recipeDietaryItems{attributes: []string{"nuts"}, unclassified: []dietaryItemResponse{stock}})
+	if !slices.Equal(unknown.Allergens, []string{"nuts"}) || len(unknown.Diets) != 0 {
+		t.Fatalf("labels with an unclassified item=%+v, want nuts and no diets", unknown)
+	}
+	if !slices.Equal(unknown.UnclassifiedItems, []dietaryItemResponse{stock}) {
+		t.Fatalf("unclassified=%v, want %v", unknown.UnclassifiedItems, stock)
+	}
+}
+
+func TestDietaryConflicts(t *testing.T) {
+	t.Parallel()
+
+	got := dietaryConflicts([]string{"vegan", "gluten-free"}, []string{"nuts", "soy"}, recipeDietaryItems{attributes: []string{"eggs", "dairy", "nuts"}})
+	if len(got) != 2 {
+		t.Fatalf("conflicts=%+v, want vegan and nuts", got)
+	}
+	if got[0].Kind != dietaryConflictDiet || got[0].Restriction != "vegan" || !slices.Equal(got[0].Attributes, []string{"dairy", "eggs"}) {
+		t.Fatalf("diet conflict=%+v", got[0])
+	}
+	if got[0].Message != "recipe is not vegan: contains dairy, eggs" {
+		t.Fatalf("message=%q", got[0].Message)
+	}
+	if got[1].Kind != dietaryConflictAllergen || got[1].Restriction != "nuts" {
+		t.Fatalf("allergen conflict=%+v", got[1])
+	}
+
+	if none := dietaryConflicts([]string{"vegan"}, nil, recipeDietaryItems{attributes: []string{"gluten"}}); none == nil || len(none) != 0 {
+		t.Fatalf("conflicts=%v, want empty non-nil slice", none)
+	}
+
+	stock := dietaryItemResponse{ID: "item-stock", Name: "stock cube"}
+	unknown := dietaryConflicts([]string{"vegan"}, []string{"nuts", "soy"}, recipeDietaryItems{
+		attributes:   []string{"nuts"},
+		unclassified: []dietaryItemResponse{stock},
+	})
+	if len(unknown) != 3 || unknown[0].Kind != dietaryConflictAllergen || unknown[0].Restriction != "nuts" {
+		t.Fatalf("conflicts=%+v, want nuts then vegan and soy unknown", unknown)
+	}
+	for i, restriction := range []string{"vegan", "soy"} {
+		warning := unknown[i+1]
+		if warning.Kind != dietaryConflictUnknown || warning.Restriction != restriction || !slices.Equal(warning.Items, []dietaryItemResponse{stock}) {
+			t.Fatalf("unknown warning=%+v, want %s blocked by %v", warning, restriction, stock)
+		}
+	}
+	if unknown[1].Message != "cannot check vegan: no allergen data for stock cube" {
+		t.Fatalf("message=%q", unknown[1].Message)
+	}
+}
+
+func TestNormalizeDietaryValues(t *testing.T) {
+	t.Parallel()
+
+	got, _, ok := normalizeDietaryValues([]string{" Nuts", "dairy", "", "nuts"}, itemAttributes)
+	if !ok || !slices.Equal(got, []string{"dairy", "nuts"}) {
+		t.Fatalf("normalized=%v ok=%v", got, ok)
+	}
+
+	if _, invalid, ok := normalizeDietaryValues([]string{"dairy", "Tofu"}, itemAttributes); ok || invalid != "tofu" {
+		t.Fatalf("invalid=%q ok=%v, want tofu rejected", invalid, ok)
+	}
+}
+
+func TestDietVocabularyIsConsistent(t *testing.T) {
+	t.Parallel()
+
+	if len(dietNames) != len(dietExclusions) {
+		t.Fatalf("dietNames=%v does not match dietExclusions", dietNames)
+	}
+	for _, diet := range dietNames {
+		for _, attr := range dietExclusions[diet] {
+			if !slices.Contains(itemAttributes, attr) {
+				t.Fatalf("diet %s excludes unknown attribute %s", diet, attr)
+			}
+		}
+	}
+	for _, allergen := range dietaryAllergens {
+		if !slices.Contains(itemAttributes, allergen) {
+			t.Fatalf("allergen %s is not an item attribute", allergen)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package httpapi
 
-import "net/http"
+import (
+	"net/http"
+	"strings"
+)
 
 type handlerFunc func(http.ResponseWriter, *http.Request) error
 
@@ -29,9 +32,19 @@ func (a *App) writeError(w http.ResponseWriter, r *http.Request, err error) {
 	a.writeProblem(w, http.StatusInternalServerError, "internal_error", "internal error", nil)
 }
 
+// sharedRecipePathPrefixes are paths whose last segment is a share token.
+var sharedRecipePathPrefixes = []string{"/shared/recipes/", "/api/v1/shared/recipes/"}
+
+// safePath returns the request path for logging, with share tokens redacted
+// because they grant access on their own.
 func safePath(r *http.Request) string {
 	if r == nil || r.URL == nil {
 		return ""
 	}
+	for _, prefix := range sharedRecipePathPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return prefix + "{token}"
+		}
+	}
 	return r.URL.Path
 }
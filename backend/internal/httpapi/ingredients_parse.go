@@ -0,0 +1,92 @@
+package httpapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+	"github.com/saiaj/cooking_app/backend/internal/ingredients"
+)
+
+const maxParseIngredientLines = 200
+
+type parseIngredientsRequest struct {
+	Lines []string `json:"lines"`
+}
+
+type parsedIngredientResponse struct {
+	Quantity     *float64 `json:"quantity"`
+	QuantityText *string  `json:"quantity_text"`
+	Unit         *string  `json:"unit"`
+	ItemName     *string  `json:"item_name"`
+	Prep         *string  `json:"prep"`
+	Notes        *string  `json:"notes"`
+	OriginalText string   `json:"original_text"`
+}
+
+type parseIngredientsResponse struct {
+	Items []parsedIngredientResponse `json:"items"`
+}
+
+func (a *App) handleIngredientsParse(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	var req parseIngredientsRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+	if len(req.Lines) == 0 {
+		return errValidationField("lines", "at least one line is required")
+	}
+	if len(req.Lines) > maxParseIngredientLines {
+		return errValidationField("lines", fmt.Sprintf("at most %d lines are allowed", maxParseIngredientLines))
+	}
+
+	resp := parseIngredientsResponse{Items: make([]parsedIngredientResponse, 0, len(req.Lines))}
+	for _, text := range req.Lines {
+		line := ingredients.Parse(text)
+		resp.Items = append(resp.Items, parsedIngredientResponse{
+			Quantity:     line.Quantity,
+			QuantityText: optionalString(line.QuantityText),
+			Unit:         optionalString(line.Unit),
+			ItemName:     optionalString(line.Item),
+			Prep:         optionalString(line.Prep),
+			Notes:        optionalString(line.Notes),
+			OriginalText: line.OriginalText,
+		})
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/ingredients/parse")
+	}
+	return nil
+}
+
+// fillParsedIngredients completes ingredients that were sent with only
+// original_text by parsing the line. Fields the client did set are kept.
+func fillParsedIngredients(req *createRecipeRequest) {
+	for i := range req.Ingredients {
+		ing := &req.Ingredients[i]
+		if trimPtr(ing.ItemID) != nil || trimPtr(ing.ItemName) != nil || trimPtr(ing.SubRecipeID) != nil || trimPtr(ing.OriginalText) == nil {
+			continue
+		}
+
+		line := ingredients.Parse(*ing.OriginalText)
+		ing.ItemName = optionalString(line.Item)
+		if ing.Quantity == nil && ing.QuantityText == nil {
+			ing.Quantity = line.Quantity
+			ing.QuantityText = optionalString(line.QuantityText)
+		}
+		if ing.Unit == nil {
+			ing.Unit = optionalString(line.Unit)
+		}
+		if ing.Prep == nil {
+			ing.Prep = optionalString(line.Prep)
+		}
+		if ing.Notes == nil {
+			ing.Notes = optionalString(line.Notes)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package httpapi
+
+import (
+	"testing"
+)
+
+func TestFillParsedIngredientsFromOriginalText(t *testing.T) {
+	t.Parallel()
+
+	req := createRecipeRequest{
+		Title:    "Soup",
+		Servings: 2,
+		TagIDs:   []string{},
+		Ingredients: []recipeIngredientRequest{
+			{Position: 1, OriginalText: stringPtr("1 1/2 cups finely chopped onion, divided")},
+			{Position: 2, OriginalText: stringPtr("2 carrots"), Notes: stringPtr("from the garden")},
+			{Position: 3, ItemName: stringPtr("Salt"), OriginalText: stringPtr("1 tsp kosher salt")},
+		},
+		Steps: []recipeStepRequest{{StepNumber: 1, Instruction: "Cook."}},
+	}
+
+	fillParsedIngredients(&req)
+	if errs := validateCreateRecipeRequest(req); len(errs) > 0 {
+		t.Fatalf("unexpected validation errors: %+v", errs)
+	}
+
+	onion := req.Ingredients[0]
+	if onion.Quantity == nil || *onion.Quantity != 1.5 || *onion.QuantityText != "1 1/2" || *onion.Unit != "cup" {
+		t.Fatalf("onion quantity=%v text=%v unit=%v", onion.Quantity, onion.QuantityText, onion.Unit)
+	}
+	if *onion.ItemName != "onion" || *onion.Prep != "finely chopped" || *onion.Notes != "divided" {
+		t.Fatalf("onion item=%q prep=%q notes=%q", *onion.ItemName, *onion.Prep, *onion.Notes)
+	}
+
+	carrots := req.Ingredients[1]
+	if *carrots.ItemName != "carrots" || *carrots.Notes != "from the garden" {
+		t.Fatalf("carrots item=%q notes=%q, want client notes kept", *carrots.ItemName, *carrots.Notes)
+	}
+
+	salt := req.Ingredients[2]
+	if *salt.ItemName != "Salt" || salt.Quantity != nil || salt.Unit != nil {
+		t.Fatalf("salt=%+v, want ingredient with item_name left alone", salt)
+	}
+}
+
+func TestFillParsedIngredientsLeavesUnparseableLinesInvalid(t *testing.T) {
+	t.Parallel()
+
+	req := createRecipeRequest{
+		Title:       "Soup",
+		Servings:    2,
+		Ingredients: []recipeIngredientRequest{{Position: 1, OriginalText: stringPtr("   ")}},
+		Steps:       []recipeStepRequest{{StepNumber: 1, Instruction: "Cook."}},
+	}
+
+	fillParsedIngredients(&req)
+	errs := validateCreateRecipeRequest(req)
+	if len(errs) != 1 || errs[0].Field != "ingredients[0].item_id" {
+		t.Fatalf("errs=%+v, want item_id required", errs)
+	}
+}
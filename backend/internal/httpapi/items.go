@@ -1,6 +1,7 @@
 package httpapi
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
@@ -106,10 +107,16 @@ func (a *App) handleItemsCreate(w http.ResponseWriter, r *http.Request) error {
 		return errValidationField("name", "name is required")
 	}
 
+	if err := a.checkNameNotAlias(r.Context(), req.Name, pgtype.UUID{}); err != nil {
+		return err
+	}
 	aisleID, err := uuidPtrToPG(req.AisleID)
 	if err != nil {
 		return errValidationField("aisle_id", "invalid id")
 	}
+	if err := a.checkDefaultAisle(r.Context(), aisleID); err != nil {
+		return err
+	}
 	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
 	created, err := a.queries.CreateItem(r.Context(), sqlc.CreateItemParams{
 		Name:      req.Name,
@@ -161,10 +168,16 @@ func (a *App) handleItemsUpdate(w http.ResponseWriter, r *http.Request) error {
 		return errValidationField("name", "name is required")
 	}
 
+	if err := a.checkNameNotAlias(r.Context(), req.Name, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return err
+	}
 	aisleID, err := uuidPtrToPG(req.AisleID)
 	if err != nil {
 		return errValidationField("aisle_id", "invalid id")
 	}
+	if err := a.checkDefaultAisle(r.Context(), aisleID); err != nil {
+		return err
+	}
 	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
 	updated, err := a.queries.UpdateItemByID(r.Context(), sqlc.UpdateItemByIDParams{
 		ID:        pgtype.UUID{Bytes: id, Valid: true},
@@ -186,6 +199,10 @@ func (a *App) handleItemsUpdate(w http.ResponseWriter, r *http.Request) error {
 		}
 		return errInternal(err)
 	}
+	// Item names are part of each recipe's search document.
+	if err := a.queries.RefreshRecipeSearchDocumentsByItemID(r.Context(), updated.ID); err != nil {
+		return errInternal(err)
+	}
 
 	row, err := a.queries.GetItemByID(r.Context(), updated.ID)
 	if err != nil {
@@ -221,6 +238,42 @@ func (a *App) handleItemsDelete(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// checkNameNotAlias rejects an item name that is already an alias of another
+// item, so every name resolves to one item. itemID is the item being renamed,
+// which may take one of its own aliases as its name.
+func (a *App) checkNameNotAlias(ctx context.Context, name string, itemID pgtype.UUID) error {
+	alias, err := a.queries.GetItemByAlias(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return errInternal(err)
+	}
+	if alias.ID == itemID {
+		return nil
+	}
+	return errValidationField("name", "name is an alias of "+alias.Name)
+}
+
+// checkDefaultAisle rejects store aisles as an item's aisle. Items sit in the
+// default layout; store placements are set per store.
+func (a *App) checkDefaultAisle(ctx context.Context, aisleID pgtype.UUID) error {
+	if !aisleID.Valid {
+		return nil
+	}
+	aisle, err := a.queries.GetGroceryAisleByID(ctx, aisleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errValidationField("aisle_id", "aisle does not exist")
+		}
+		return errInternal(err)
+	}
+	if aisle.StoreID.Valid {
+		return errValidationField("aisle_id", "aisle belongs to a store layout")
+	}
+	return nil
+}
+
 // itemResponseFromListRow maps a list row into an item response.
 func itemResponseFromListRow(row sqlc.ListItemsRow) itemResponse {
 	return buildItemResponse(
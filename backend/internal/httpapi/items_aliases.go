@@ -0,0 +1,152 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+// itemAliasRequest adds another name an item goes by.
+type itemAliasRequest struct {
+	Alias string `json:"alias"`
+}
+
+type itemAliasResponse struct {
+	ID        string `json:"id"`
+	ItemID    string `json:"item_id"`
+	Alias     string `json:"alias"`
+	CreatedAt string `json:"created_at"`
+	CreatedBy string `json:"created_by"`
+}
+
+// handleItemAliasesList returns an item's aliases in alphabetical order.
+func (a *App) handleItemAliasesList(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	itemID := pgtype.UUID{Bytes: id, Valid: true}
+	if _, getErr := a.queries.GetItemByID(ctx, itemID); getErr != nil {
+		if errors.Is(getErr, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(getErr)
+	}
+	rows, err := a.queries.ListItemAliasesByItemID(ctx, itemID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	resp := make([]itemAliasResponse, 0, len(rows))
+	for _, row := range rows {
+		resp = append(resp, itemAliasResponseFromRow(row))
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/items/{id}/aliases")
+	}
+	return nil
+}
+
+// handleItemAliasesCreate adds an alias to an item. An alias may not repeat
+// another alias or any item's name, so every name resolves to one item.
+func (a *App) handleItemAliasesCreate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req itemAliasRequest
+	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
+		return decodeErr
+	}
+	req.Alias = strings.TrimSpace(req.Alias)
+	if req.Alias == "" {
+		return errValidationField("alias", "alias is required")
+	}
+
+	ctx := r.Context()
+	if _, getErr := a.queries.GetItemByName(ctx, req.Alias); getErr == nil {
+		return errValidationField("alias", "an item already has this name")
+	} else if !errors.Is(getErr, pgx.ErrNoRows) {
+		return errInternal(getErr)
+	}
+
+	row, err := a.queries.CreateItemAlias(ctx, sqlc.CreateItemAliasParams{
+		ItemID:    pgtype.UUID{Bytes: id, Valid: true},
+		Alias:     req.Alias,
+		CreatedBy: pgtype.UUID{Bytes: info.UserID, Valid: true},
+	})
+	if err != nil {
+		if isPGUniqueViolation(err) {
+			return errValidationField("alias", "alias already exists")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusCreated, itemAliasResponseFromRow(row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/items/{id}/aliases")
+	}
+	return nil
+}
+
+// handleItemAliasesDelete removes a single alias.
+func (a *App) handleItemAliasesDelete(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	aliasID, err := parseUUIDParam(r, "alias_id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeleteItemAlias(r.Context(), sqlc.DeleteItemAliasParams{
+		ItemID: pgtype.UUID{Bytes: id, Valid: true},
+		ID:     pgtype.UUID{Bytes: aliasID, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+	if affected == 0 {
+		return errNotFound()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func itemAliasResponseFromRow(row sqlc.ItemAlias) itemAliasResponse {
+	return itemAliasResponse{
+		ID:        uuidString(row.ID),
+		ItemID:    uuidString(row.ItemID),
+		Alias:     row.Alias,
+		CreatedAt: timeString(row.CreatedAt),
+		CreatedBy: uuidString(row.CreatedBy),
+	}
+}
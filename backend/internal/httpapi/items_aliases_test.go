@@ -0,0 +1,153 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type itemAliasResponse struct {
+	ID     string `json:"id"`
+	ItemID string `json:"item_id"`
+	Alias  string `json:"alias"`
+}
+
+type recipeSaveResponse struct {
+	recipeDetailResponse
+	IngredientMatches []struct {
+		Position      int      `json:"position"`
+		RequestedName string   `json:"requested_name"`
+		ItemID        string   `json:"item_id"`
+		ItemName      string   `json:"item_name"`
+		Match         string   `json:"match"`
+		Similarity    *float64 `json:"similarity"`
+	} `json:"ingredient_matches"`
+}
+
+func TestItems_AliasesAndFuzzyResolution(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, err := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); err != nil {
+		t.Fatalf("bootstrap user: %v", err)
+	}
+
+	app, err := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   testSessionCookieName,
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if err != nil {
+		t.Fatalf("new app: %v", err)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookie jar: %v", err)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	recipePayload := func(title, ingredients string) string {
+		return `{
+  "title":"` + title + `",
+  "servings":2,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[` + ingredients + `],
+  "steps":[{"step_number":1,"instruction":"Cook."}]
+}`
+	}
+
+	var base recipeSaveResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+		recipePayload("Base", `{"position":1,"item_name":"Green onions"},{"position":2,"item_name":"Tomato"},{"position":3,"item_name":"All-purpose flour"}`), http.StatusCreated, &base)
+	if len(base.IngredientMatches) != 0 {
+		t.Fatalf("matches=%+v, want none for new items", base.IngredientMatches)
+	}
+	onionID := base.Ingredients[0].Item.ID
+	aliasesURL := server.URL + "/api/v1/items/" + onionID + "/aliases"
+
+	var scallions itemAliasResponse
+	t.Run("manage aliases", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPost, aliasesURL, `{"alias":"  "}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, aliasesURL, `{"alias":"tomato"}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/items/"+uuid.NewString()+"/aliases", `{"alias":"spring onions"}`, http.StatusNotFound, nil)
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, aliasesURL, `{"alias":"Scallions"}`, http.StatusCreated, &scallions)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, aliasesURL, `{"alias":"spring onions"}`, http.StatusCreated, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, aliasesURL, `{"alias":"SCALLIONS"}`, http.StatusBadRequest, nil)
+
+		var aliases []itemAliasResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, aliasesURL, "", http.StatusOK, &aliases)
+		if len(aliases) != 2 || aliases[0].Alias != "Scallions" || aliases[1].Alias != "spring onions" {
+			t.Fatalf("aliases=%+v", aliases)
+		}
+	})
+
+	t.Run("item names cannot reuse aliases", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/items", `{"name":"scallions"}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/items/"+base.Ingredients[1].Item.ID, `{"name":"Spring Onions"}`, http.StatusBadRequest, nil)
+	})
+
+	t.Run("recipe save reports fuzzy matches", func(t *testing.T) {
+		var got recipeSaveResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+			recipePayload("Salsa", `{"position":1,"item_name":"scallions"},{"position":2,"item_name":"Tomatoes"},{"position":3,"item_name":"all purpose flour"},{"position":4,"item_name":"Red onion"}`), http.StatusCreated, &got)
+		if len(got.IngredientMatches) != 3 {
+			t.Fatalf("matches=%+v, want alias, plural, and similar", got.IngredientMatches)
+		}
+		alias, plural, similar := got.IngredientMatches[0], got.IngredientMatches[1], got.IngredientMatches[2]
+		if alias.Match != "alias" || alias.ItemID != onionID || alias.Position != 1 {
+			t.Fatalf("alias match=%+v", alias)
+		}
+		if plural.Match != "plural" || plural.ItemName != "Tomato" || plural.RequestedName != "Tomatoes" {
+			t.Fatalf("plural match=%+v", plural)
+		}
+		if similar.Match != "similar" || similar.ItemName != "All-purpose flour" || similar.Similarity == nil || *similar.Similarity < 0.7 {
+			t.Fatalf("similar match=%+v", similar)
+		}
+		if got.Ingredients[3].Item.Name != "Red onion" {
+			t.Fatalf("red onion resolved to %+v, want a new item", got.Ingredients[3].Item)
+		}
+	})
+
+	t.Run("delete alias", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, aliasesURL+"/"+scallions.ID, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, aliasesURL+"/"+scallions.ID, "", http.StatusNotFound, nil)
+	})
+}
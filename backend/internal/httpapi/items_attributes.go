@@ -0,0 +1,108 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+// itemAttributesRequest replaces an item's attributes. An empty list clears
+// them; omitting the list is an error so a typo cannot wipe existing data.
+type itemAttributesRequest struct {
+	Attributes []string `json:"attributes"`
+}
+
+// itemAttributesResponse reports Classified false for an item whose
+// attributes were never set, as opposed to set to an empty list.
+type itemAttributesResponse struct {
+	ItemID     string   `json:"item_id"`
+	Attributes []string `json:"attributes"`
+	Classified bool     `json:"classified"`
+}
+
+// handleItemAttributesGet returns the allergen and diet attributes of an item.
+func (a *App) handleItemAttributesGet(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	itemID := pgtype.UUID{Bytes: id, Valid: true}
+	if _, err := a.queries.GetItemByID(r.Context(), itemID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	attrs, err := a.queries.ListItemAttributes(r.Context(), itemID)
+	if err != nil {
+		return errInternal(err)
+	}
+	classified, err := a.queries.IsItemClassified(r.Context(), itemID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	resp := itemAttributesResponse{ItemID: id.String(), Attributes: attrs, Classified: classified}
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/items/{id}/attributes")
+	}
+	return nil
+}
+
+// handleItemAttributesPut replaces the allergen and diet attributes of an item.
+func (a *App) handleItemAttributesPut(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req itemAttributesRequest
+	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
+		return decodeErr
+	}
+	if req.Attributes == nil {
+		return errValidationField("attributes", "required")
+	}
+	attrs, invalid, ok := normalizeDietaryValues(req.Attributes, itemAttributes)
+	if !ok {
+		return errValidationField("attributes", "unknown attribute "+invalid)
+	}
+
+	ctx := r.Context()
+	itemID := pgtype.UUID{Bytes: id, Valid: true}
+	if _, err := a.queries.GetItemByID(ctx, itemID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := a.queries.ReplaceItemAttributes(ctx, sqlc.ReplaceItemAttributesParams{
+		ItemID:     itemID,
+		Attributes: attrs,
+		CreatedBy:  pgtype.UUID{Bytes: info.UserID, Valid: true},
+	}); err != nil {
+		return errInternal(err)
+	}
+
+	resp := itemAttributesResponse{ItemID: id.String(), Attributes: attrs, Classified: true}
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/items/{id}/attributes")
+	}
+	return nil
+}
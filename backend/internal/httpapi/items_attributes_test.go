@@ -0,0 +1,238 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type itemAttributesResponse struct {
+	ItemID     string   `json:"item_id"`
+	Attributes []string `json:"attributes"`
+	Classified bool     `json:"classified"`
+}
+
+type userDietaryRestrictionsResponse struct {
+	UserID    string   `json:"user_id"`
+	Diets     []string `json:"diets"`
+	Allergens []string `json:"allergens"`
+}
+
+type mealPlanCreateResponse struct {
+	Date     string `json:"date"`
+	Warnings []struct {
+		Kind        string          `json:"kind"`
+		Restriction string          `json:"restriction"`
+		Attributes  []string        `json:"attributes"`
+		Items       []namedResponse `json:"items"`
+	} `json:"warnings"`
+}
+
+func TestItems_AttributesAndDietaryLabels(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	user, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	})
+	if userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+	userID := uuid.UUID(user.ID.Bytes).String()
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	createRecipe := func(title, ingredients string) recipeDetailResponse {
+		t.Helper()
+		var recipe recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"`+title+`",
+  "servings":2,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[`+ingredients+`],
+  "steps":[{"step_number":1,"instruction":"Combine."}]
+}`, http.StatusCreated, &recipe)
+		return recipe
+	}
+
+	pesto := createRecipe("Pesto", `{"position":1,"quantity":50,"unit":"g","item_name":"Pine nuts"},{"position":2,"quantity":30,"unit":"g","item_name":"Parmesan"}`)
+	pasta := createRecipe("Pesto pasta", `{"position":1,"quantity":1,"sub_recipe_id":"`+pesto.ID+`"},{"position":2,"quantity":200,"unit":"g","item_name":"Spaghetti"}`)
+	rice := createRecipe("Plain rice", `{"position":1,"quantity":200,"unit":"g","item_name":"Rice"}`)
+	broth := createRecipe("Veggie broth", `{"position":1,"quantity":1,"unit":"cube","item_name":"Stock cube"}`)
+	if len(pasta.Diets) != 0 || len(pasta.Allergens) != 0 || len(pasta.Unclassified) != 3 {
+		t.Fatalf("labels before attributes: diets=%v allergens=%v unclassified=%v", pasta.Diets, pasta.Allergens, pasta.Unclassified)
+	}
+
+	attributesURL := func(itemID string) string {
+		return server.URL + "/api/v1/items/" + itemID + "/attributes"
+	}
+	pineNutsID := pesto.Ingredients[0].Item.ID
+	parmesanID := pesto.Ingredients[1].Item.ID
+	spaghettiID := pasta.Ingredients[1].Item.ID
+	riceID := rice.Ingredients[0].Item.ID
+	stockCubeID := broth.Ingredients[0].Item.ID
+
+	t.Run("attributes validate and replace", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPut, attributesURL(pineNutsID), `{"attributes":["tofu"]}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, attributesURL(pineNutsID), `{}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, attributesURL(uuid.NewString()), `{"attributes":[]}`, http.StatusNotFound, nil)
+
+		var got itemAttributesResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, attributesURL(pineNutsID), "", http.StatusOK, &got)
+		if got.Classified || len(got.Attributes) != 0 {
+			t.Fatalf("attributes before set=%+v, want unclassified", got)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPut, attributesURL(pineNutsID), `{"attributes":["Nuts","gluten"]}`, http.StatusOK, &got)
+		if !slices.Equal(got.Attributes, []string{"gluten", "nuts"}) {
+			t.Fatalf("attributes=%v", got.Attributes)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPut, attributesURL(pineNutsID), `{"attributes":["nuts"]}`, http.StatusOK, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodGet, attributesURL(pineNutsID), "", http.StatusOK, &got)
+		if !slices.Equal(got.Attributes, []string{"nuts"}) {
+			t.Fatalf("attributes after replace=%v", got.Attributes)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodPut, attributesURL(parmesanID), `{"attributes":["dairy"]}`, http.StatusOK, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, attributesURL(spaghettiID), `{"attributes":["gluten"]}`, http.StatusOK, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, attributesURL(riceID), `{"attributes":[]}`, http.StatusOK, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodGet, attributesURL(riceID), "", http.StatusOK, &got)
+		if !got.Classified || len(got.Attributes) != 0 {
+			t.Fatalf("rice attributes=%+v, want classified with none", got)
+		}
+	})
+
+	t.Run("recipe labels include sub-recipes", func(t *testing.T) {
+		var detail recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+pasta.ID, "", http.StatusOK, &detail)
+		if !slices.Equal(detail.Allergens, []string{"dairy", "gluten", "nuts"}) {
+			t.Fatalf("allergens=%v", detail.Allergens)
+		}
+		if !slices.Equal(detail.Diets, []string{"pescatarian", "vegetarian"}) || len(detail.Unclassified) != 0 {
+			t.Fatalf("diets=%v unclassified=%v", detail.Diets, detail.Unclassified)
+		}
+	})
+
+	t.Run("unclassified items make labels unknown", func(t *testing.T) {
+		var detail recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+broth.ID, "", http.StatusOK, &detail)
+		if len(detail.Diets) != 0 || len(detail.Unclassified) != 1 || detail.Unclassified[0].ID != stockCubeID {
+			t.Fatalf("diets=%v unclassified=%v, want no diets and the stock cube", detail.Diets, detail.Unclassified)
+		}
+	})
+
+	t.Run("list filters by diet and allergen", func(t *testing.T) {
+		titles := func(query string) []string {
+			t.Helper()
+			var list recipesListResponse
+			doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes?sort=title&"+query, "", http.StatusOK, &list)
+			out := make([]string, 0, len(list.Items))
+			for _, item := range list.Items {
+				out = append(out, item.Title)
+			}
+			return out
+		}
+
+		if got := titles("diet=vegetarian"); !slices.Equal(got, []string{"Pesto", "Pesto pasta", "Plain rice"}) {
+			t.Fatalf("vegetarian=%v", got)
+		}
+		if got := titles("diet=vegan"); !slices.Equal(got, []string{"Plain rice"}) {
+			t.Fatalf("vegan=%v", got)
+		}
+		if got := titles("exclude_allergen=gluten"); !slices.Equal(got, []string{"Pesto", "Plain rice"}) {
+			t.Fatalf("exclude gluten=%v", got)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes?diet=keto", "", http.StatusBadRequest, nil)
+	})
+
+	t.Run("meal plan warns about restrictions", func(t *testing.T) {
+		restrictionsURL := server.URL + "/api/v1/users/" + userID + "/dietary-restrictions"
+		var restrictions userDietaryRestrictionsResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, restrictionsURL, "", http.StatusOK, &restrictions)
+		if len(restrictions.Diets) != 0 || len(restrictions.Allergens) != 0 {
+			t.Fatalf("restrictions=%+v, want none declared", restrictions)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPut, restrictionsURL, `{"diets":["carnivore"]}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, restrictionsURL, `{"diets":["vegan"],"allergens":["nuts"]}`, http.StatusOK, &restrictions)
+		if !slices.Equal(restrictions.Diets, []string{"vegan"}) || !slices.Equal(restrictions.Allergens, []string{"nuts"}) {
+			t.Fatalf("restrictions=%+v", restrictions)
+		}
+
+		var planned mealPlanCreateResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/meal-plans",
+			`{"date":"2025-03-01","recipe_id":"`+pasta.ID+`"}`, http.StatusOK, &planned)
+		if len(planned.Warnings) != 2 {
+			t.Fatalf("warnings=%+v, want vegan and nuts", planned.Warnings)
+		}
+		if planned.Warnings[0].Restriction != "vegan" || !slices.Equal(planned.Warnings[0].Attributes, []string{"dairy"}) {
+			t.Fatalf("diet warning=%+v", planned.Warnings[0])
+		}
+		if planned.Warnings[1].Kind != "allergen" || planned.Warnings[1].Restriction != "nuts" {
+			t.Fatalf("allergen warning=%+v", planned.Warnings[1])
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/meal-plans",
+			`{"date":"2025-03-02","recipe_id":"`+rice.ID+`"}`, http.StatusOK, &planned)
+		if planned.Warnings == nil || len(planned.Warnings) != 0 {
+			t.Fatalf("warnings=%v, want empty list", planned.Warnings)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/meal-plans",
+			`{"date":"2025-03-03","recipe_id":"`+broth.ID+`"}`, http.StatusOK, &planned)
+		if len(planned.Warnings) != 2 {
+			t.Fatalf("warnings=%+v, want vegan and nuts unknown", planned.Warnings)
+		}
+		for _, warning := range planned.Warnings {
+			if warning.Kind != "unknown" || len(warning.Items) != 1 || warning.Items[0].ID != stockCubeID {
+				t.Fatalf("warning=%+v, want unknown because of the stock cube", warning)
+			}
+		}
+	})
+}
@@ -0,0 +1,446 @@
+package httpapi
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+	"github.com/saiaj/cooking_app/backend/internal/units"
+)
+
+// nutritionFacts holds the tracked nutrients for some amount of food.
+type nutritionFacts struct {
+	Calories float64 `json:"calories"`
+	ProteinG float64 `json:"protein_g"`
+	FatG     float64 `json:"fat_g"`
+	CarbsG   float64 `json:"carbs_g"`
+	FiberG   float64 `json:"fiber_g"`
+	SodiumMg float64 `json:"sodium_mg"`
+}
+
+// itemNutritionRequest captures nutrition facts for ReferenceQuantity of
+// ReferenceUnit of an item. Nutrients are pointers so omitted values can be
+// reported instead of read as zero.
+type itemNutritionRequest struct {
+	ReferenceQuantity float64  `json:"reference_quantity"`
+	ReferenceUnit     string   `json:"reference_unit"`
+	Calories          *float64 `json:"calories"`
+	ProteinG          *float64 `json:"protein_g"`
+	FatG              *float64 `json:"fat_g"`
+	CarbsG            *float64 `json:"carbs_g"`
+	FiberG            *float64 `json:"fiber_g"`
+	SodiumMg          *float64 `json:"sodium_mg"`
+}
+
+type itemNutritionResponse struct {
+	ItemID            string  `json:"item_id"`
+	ReferenceQuantity float64 `json:"reference_quantity"`
+	ReferenceUnit     string  `json:"reference_unit"`
+	nutritionFacts
+	UpdatedAt string `json:"updated_at"`
+	UpdatedBy string `json:"updated_by"`
+}
+
+type itemNutritionImportSkip struct {
+	Line   int    `json:"line"`
+	Item   string `json:"item"`
+	Reason string `json:"reason"`
+}
+
+type itemNutritionImportResponse struct {
+	Imported int                       `json:"imported"`
+	Skipped  []itemNutritionImportSkip `json:"skipped"`
+}
+
+// itemNutritionCSVRow is a validated row from a nutrition CSV import.
+type itemNutritionCSVRow struct {
+	line int
+	item string
+	req  itemNutritionRequest
+}
+
+var itemNutritionCSVColumns = []string{
+	"item",
+	"reference_quantity",
+	"reference_unit",
+	"calories",
+	"protein_g",
+	"fat_g",
+	"carbs_g",
+	"fiber_g",
+	"sodium_mg",
+}
+
+// handleItemNutritionGet returns the nutrition facts stored for an item.
+func (a *App) handleItemNutritionGet(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	row, err := a.queries.GetItemNutrition(r.Context(), pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+	resp, err := itemNutritionResponseFromRow(row)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/items/{id}/nutrition")
+	}
+	return nil
+}
+
+// handleItemNutritionPut creates or replaces the nutrition facts for an item.
+func (a *App) handleItemNutritionPut(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req itemNutritionRequest
+	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
+		return decodeErr
+	}
+	req.ReferenceUnit = normalizeReferenceUnit(req.ReferenceUnit)
+	if errs := validateItemNutritionRequest("", req); len(errs) > 0 {
+		return errValidation(errs)
+	}
+
+	params, err := upsertItemNutritionParams(pgtype.UUID{Bytes: id, Valid: true}, info.UserID, req)
+	if err != nil {
+		return errInternal(err)
+	}
+	row, err := a.queries.UpsertItemNutrition(r.Context(), params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+	resp, err := itemNutritionResponseFromRow(row)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/items/{id}/nutrition")
+	}
+	return nil
+}
+
+// handleItemNutritionDelete removes the nutrition facts for an item.
+func (a *App) handleItemNutritionDelete(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeleteItemNutrition(r.Context(), pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return errInternal(err)
+	}
+	if affected == 0 {
+		return errNotFound()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// handleItemNutritionImport bulk-loads nutrition facts from a CSV body with a
+// header row naming itemNutritionCSVColumns. Items are matched by name; rows
+// for unknown items are skipped and reported. Any invalid row rejects the
+// whole import.
+func (a *App) handleItemNutritionImport(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+	if r.Body == nil {
+		return errBadRequest("invalid CSV")
+	}
+	if a.maxJSONBodyBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, a.maxJSONBodyBytes)
+	}
+
+	rows, err := parseItemNutritionCSV(r.Body)
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	tx, err := a.pool.Begin(ctx)
+	if err != nil {
+		return errInternal(err)
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
+			a.logger.Warn("rollback failed", "err", rollbackErr)
+		}
+	}()
+
+	queries := a.queries.WithTx(tx)
+	resp := itemNutritionImportResponse{Skipped: []itemNutritionImportSkip{}}
+	for _, row := range rows {
+		item, err := queries.GetItemByName(ctx, row.item)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				resp.Skipped = append(resp.Skipped, itemNutritionImportSkip{Line: row.line, Item: row.item, Reason: "item not found"})
+				continue
+			}
+			return errInternal(err)
+		}
+		params, err := upsertItemNutritionParams(item.ID, info.UserID, row.req)
+		if err != nil {
+			return errInternal(err)
+		}
+		if _, err := queries.UpsertItemNutrition(ctx, params); err != nil {
+			return errInternal(err)
+		}
+		resp.Imported++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/items/nutrition/import")
+	}
+	return nil
+}
+
+// parseItemNutritionCSV reads and validates every row before anything is
+// written so a bad file leaves the database untouched.
+func parseItemNutritionCSV(body io.Reader) ([]itemNutritionCSVRow, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, csvReadError(err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, column := range itemNutritionCSVColumns {
+		if _, ok := index[column]; !ok {
+			return nil, errValidationField("header", fmt.Sprintf("missing column %q", column))
+		}
+	}
+
+	var (
+		rows []itemNutritionCSVRow
+		errs []response.FieldError
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, csvReadError(err)
+		}
+		line, _ := reader.FieldPos(0)
+		prefix := fmt.Sprintf("lines[%d].", line)
+
+		field := func(name string) string {
+			return strings.TrimSpace(record[index[name]])
+		}
+		number := func(name string) *float64 {
+			raw := field(name)
+			if raw == "" {
+				return nil
+			}
+			value, parseErr := strconv.ParseFloat(raw, 64)
+			if parseErr != nil {
+				errs = append(errs, response.FieldError{Field: prefix + name, Message: name + " must be a number"})
+				return nil
+			}
+			return &value
+		}
+
+		row := itemNutritionCSVRow{line: line, item: field("item")}
+		if row.item == "" {
+			errs = append(errs, response.FieldError{Field: prefix + "item", Message: "item is required"})
+		}
+		row.req = itemNutritionRequest{
+			ReferenceUnit: normalizeReferenceUnit(field("reference_unit")),
+			Calories:      number("calories"),
+			ProteinG:      number("protein_g"),
+			FatG:          number("fat_g"),
+			CarbsG:        number("carbs_g"),
+			FiberG:        number("fiber_g"),
+			SodiumMg:      number("sodium_mg"),
+		}
+		if quantity := number("reference_quantity"); quantity != nil {
+			row.req.ReferenceQuantity = *quantity
+		}
+		errs = append(errs, validateItemNutritionRequest(prefix, row.req)...)
+		rows = append(rows, row)
+	}
+
+	if len(errs) > 0 {
+		return nil, errValidation(errs)
+	}
+	if len(rows) == 0 {
+		return nil, errValidationField("body", "at least one row is required")
+	}
+	return rows, nil
+}
+
+func csvReadError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return errRequestTooLarge()
+	}
+	if errors.Is(err, io.EOF) {
+		return errBadRequest("CSV body is empty")
+	}
+	return errBadRequest("invalid CSV")
+}
+
+// validateItemNutritionRequest checks a nutrition payload; prefix is prepended
+// to field names so CSV errors can point at a line.
+func validateItemNutritionRequest(prefix string, req itemNutritionRequest) []response.FieldError {
+	var errs []response.FieldError
+	switch {
+	case !isFiniteNumber(req.ReferenceQuantity):
+		errs = append(errs, response.FieldError{Field: prefix + "reference_quantity", Message: "reference_quantity must be a finite number"})
+	case req.ReferenceQuantity <= 0:
+		errs = append(errs, response.FieldError{Field: prefix + "reference_quantity", Message: "reference_quantity must be > 0"})
+	}
+	if req.ReferenceUnit == "" {
+		errs = append(errs, response.FieldError{Field: prefix + "reference_unit", Message: "reference_unit is required"})
+	}
+	nutrients := []struct {
+		name  string
+		value *float64
+	}{
+		{"calories", req.Calories},
+		{"protein_g", req.ProteinG},
+		{"fat_g", req.FatG},
+		{"carbs_g", req.CarbsG},
+		{"fiber_g", req.FiberG},
+		{"sodium_mg", req.SodiumMg},
+	}
+	for _, n := range nutrients {
+		switch {
+		case n.value == nil:
+			errs = append(errs, response.FieldError{Field: prefix + n.name, Message: n.name + " is required"})
+		case !isFiniteNumber(*n.value):
+			errs = append(errs, response.FieldError{Field: prefix + n.name, Message: n.name + " must be a finite number"})
+		case *n.value < 0:
+			errs = append(errs, response.FieldError{Field: prefix + n.name, Message: n.name + " must be >= 0"})
+		}
+	}
+	return errs
+}
+
+// isFiniteNumber reports whether value is neither NaN nor infinite; such
+// values parse but cannot be stored or encoded back as JSON.
+func isFiniteNumber(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// normalizeReferenceUnit stores known units under their canonical name so
+// recipe quantities convert against them; other units are kept as written.
+// Nutrition and price references both use it.
+func normalizeReferenceUnit(unit string) string {
+	unit = strings.TrimSpace(unit)
+	if known, ok := units.Lookup(unit); ok {
+		return known.Name
+	}
+	return unit
+}
+
+func upsertItemNutritionParams(itemID pgtype.UUID, userID uuid.UUID, req itemNutritionRequest) (sqlc.UpsertItemNutritionParams, error) {
+	params := sqlc.UpsertItemNutritionParams{
+		ItemID:        itemID,
+		ReferenceUnit: req.ReferenceUnit,
+		UpdatedBy:     pgtype.UUID{Bytes: userID, Valid: true},
+	}
+	values := []struct {
+		src *float64
+		dst *pgtype.Numeric
+	}{
+		{&req.ReferenceQuantity, &params.ReferenceQuantity},
+		{req.Calories, &params.Calories},
+		{req.ProteinG, &params.ProteinG},
+		{req.FatG, &params.FatG},
+		{req.CarbsG, &params.CarbsG},
+		{req.FiberG, &params.FiberG},
+		{req.SodiumMg, &params.SodiumMg},
+	}
+	for _, v := range values {
+		n, err := numericPtrFromFloat64(v.src)
+		if err != nil {
+			return sqlc.UpsertItemNutritionParams{}, err
+		}
+		*v.dst = n
+	}
+	return params, nil
+}
+
+func itemNutritionResponseFromRow(row sqlc.ItemNutrition) (itemNutritionResponse, error) {
+	resp := itemNutritionResponse{
+		ItemID:        uuidString(row.ItemID),
+		ReferenceUnit: row.ReferenceUnit,
+		UpdatedAt:     timeString(row.UpdatedAt),
+		UpdatedBy:     uuidString(row.UpdatedBy),
+	}
+	values := []struct {
+		src pgtype.Numeric
+		dst *float64
+	}{
+		{row.ReferenceQuantity, &resp.ReferenceQuantity},
+		{row.Calories, &resp.Calories},
+		{row.ProteinG, &resp.ProteinG},
+		{row.FatG, &resp.FatG},
+		{row.CarbsG, &resp.CarbsG},
+		{row.FiberG, &resp.FiberG},
+		{row.SodiumMg, &resp.SodiumMg},
+	}
+	for _, v := range values {
+		f, err := float64PtrFromNumeric(v.src)
+		if err != nil {
+			return itemNutritionResponse{}, err
+		}
+		if f != nil {
+			*v.dst = *f
+		}
+	}
+	return resp, nil
+}
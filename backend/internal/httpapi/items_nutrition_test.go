@@ -0,0 +1,203 @@
+package httpapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type nutritionFactsResponse struct {
+	Calories float64 `json:"calories"`
+	ProteinG float64 `json:"protein_g"`
+	FatG     float64 `json:"fat_g"`
+	CarbsG   float64 `json:"carbs_g"`
+	FiberG   float64 `json:"fiber_g"`
+	SodiumMg float64 `json:"sodium_mg"`
+}
+
+type itemNutritionResponse struct {
+	nutritionFactsResponse
+	ItemID            string  `json:"item_id"`
+	ReferenceQuantity float64 `json:"reference_quantity"`
+	ReferenceUnit     string  `json:"reference_unit"`
+}
+
+type recipeNutritionResponse struct {
+	PerServing nutritionFactsResponse `json:"per_serving"`
+	Complete   bool                   `json:"complete"`
+	Uncounted  []struct {
+		IngredientID string `json:"ingredient_id"`
+		ItemName     string `json:"item_name"`
+		Reason       string `json:"reason"`
+	} `json:"uncounted"`
+}
+
+type itemNutritionImportResponse struct {
+	Imported int `json:"imported"`
+	Skipped  []struct {
+		Line   int    `json:"line"`
+		Item   string `json:"item"`
+		Reason string `json:"reason"`
+	} `json:"skipped"`
+}
+
+func TestItems_Nutrition(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	var created recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"Pancakes",
+  "servings":2,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[
+    {"position":1,"quantity":200,"unit":"g","item_name":"Flour"},
+    {"position":2,"quantity":2,"item_name":"Egg"},
+    {"position":3,"quantity_text":"a splash","item_name":"Milk"}
+  ],
+  "steps":[{"step_number":1,"instruction":"Whisk and fry."}]
+}`, http.StatusCreated, &created)
+	if created.Nutrition.Complete || len(created.Nutrition.Uncounted) != 3 {
+		t.Fatalf("nutrition=%+v, want every ingredient uncounted", created.Nutrition)
+	}
+	flourID := created.Ingredients[0].Item.ID
+	flourURL := server.URL + "/api/v1/items/" + flourID + "/nutrition"
+
+	t.Run("get before set is not found", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodGet, flourURL, "", http.StatusNotFound, nil)
+	})
+
+	t.Run("put validates and stores nutrition", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPut, flourURL, `{
+  "reference_quantity":0,
+  "reference_unit":"g",
+  "calories":-1
+}`, http.StatusBadRequest, nil)
+
+		var out itemNutritionResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPut, flourURL, `{
+  "reference_quantity":100,
+  "reference_unit":"grams",
+  "calories":364,
+  "protein_g":10,
+  "fat_g":1,
+  "carbs_g":76,
+  "fiber_g":2.7,
+  "sodium_mg":2
+}`, http.StatusOK, &out)
+		if out.ItemID != flourID || out.ReferenceUnit != "g" || out.Calories != 364 {
+			t.Fatalf("nutrition=%+v", out)
+		}
+
+		var got itemNutritionResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, flourURL, "", http.StatusOK, &got)
+		if got != out {
+			t.Fatalf("got=%+v, want %+v", got, out)
+		}
+	})
+
+	t.Run("csv import matches items by name", func(t *testing.T) {
+		body := "item,reference_quantity,reference_unit,calories,protein_g,fat_g,carbs_g,fiber_g,sodium_mg\n" +
+			"Egg,1,piece,72,6.3,4.8,0.4,0,71\n" +
+			"Dragon Fruit,100,g,60,1.2,0,13,3,0\n"
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/items/nutrition/import", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("new import request: %v", err)
+		}
+		req.Header.Set("Content-Type", "text/csv")
+		req.Header.Set("X-CSRF-Token", csrf)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("import: %v", err)
+		}
+		defer func() {
+			if closeErr := resp.Body.Close(); closeErr != nil {
+				t.Errorf("close import body: %v", closeErr)
+			}
+		}()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("import status=%d, want 200", resp.StatusCode)
+		}
+		var out itemNutritionImportResponse
+		if decodeErr := json.NewDecoder(resp.Body).Decode(&out); decodeErr != nil {
+			t.Fatalf("decode import: %v", decodeErr)
+		}
+		if out.Imported != 1 || len(out.Skipped) != 1 || out.Skipped[0].Line != 3 {
+			t.Fatalf("import=%+v", out)
+		}
+	})
+
+	t.Run("recipe detail reports per-serving nutrition", func(t *testing.T) {
+		var detail recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+created.ID, "", http.StatusOK, &detail)
+		// (2*364 + 2*72) / 2 servings.
+		if detail.Nutrition.PerServing.Calories != 436 {
+			t.Fatalf("calories=%v, want 436", detail.Nutrition.PerServing.Calories)
+		}
+		if detail.Nutrition.Complete || len(detail.Nutrition.Uncounted) != 1 || detail.Nutrition.Uncounted[0].Reason != "missing_nutrition" {
+			t.Fatalf("nutrition=%+v, want milk uncounted", detail.Nutrition)
+		}
+	})
+
+	t.Run("delete removes nutrition", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, flourURL, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, flourURL, "", http.StatusNotFound, nil)
+	})
+}
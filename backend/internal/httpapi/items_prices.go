@@ -0,0 +1,241 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+const (
+	maxItemPriceStoreLength = 100
+	unitPricePrecision      = 10000
+)
+
+// itemPriceRequest records that Quantity of Unit cost Price, optionally at a
+// store. PricedOn defaults to today.
+type itemPriceRequest struct {
+	Price    *float64 `json:"price"`
+	Quantity float64  `json:"quantity"`
+	Unit     string   `json:"unit"`
+	Store    *string  `json:"store"`
+	PricedOn *string  `json:"priced_on"`
+}
+
+// itemPriceResponse is one entry in an item's price history. UnitPrice is the
+// price of a single Unit so entries for different package sizes compare.
+type itemPriceResponse struct {
+	ID        string  `json:"id"`
+	ItemID    string  `json:"item_id"`
+	Price     float64 `json:"price"`
+	Quantity  float64 `json:"quantity"`
+	Unit      string  `json:"unit"`
+	UnitPrice float64 `json:"unit_price"`
+	Store     *string `json:"store"`
+	PricedOn  string  `json:"priced_on"`
+	CreatedAt string  `json:"created_at"`
+	CreatedBy string  `json:"created_by"`
+}
+
+// handleItemPricesList returns an item's price history, newest first.
+func (a *App) handleItemPricesList(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	itemID := pgtype.UUID{Bytes: id, Valid: true}
+	if _, getErr := a.queries.GetItemByID(ctx, itemID); getErr != nil {
+		if errors.Is(getErr, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(getErr)
+	}
+	rows, err := a.queries.ListItemPricesByItemID(ctx, itemID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	resp := make([]itemPriceResponse, 0, len(rows))
+	for _, row := range rows {
+		price, buildErr := itemPriceResponseFromRow(row)
+		if buildErr != nil {
+			return errInternal(buildErr)
+		}
+		resp = append(resp, price)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/items/{id}/prices")
+	}
+	return nil
+}
+
+// handleItemPricesCreate adds a price record to an item's history.
+func (a *App) handleItemPricesCreate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req itemPriceRequest
+	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
+		return decodeErr
+	}
+	req.Unit = normalizeReferenceUnit(req.Unit)
+	if errs := validateItemPriceRequest(req); len(errs) > 0 {
+		return errValidation(errs)
+	}
+
+	params, err := createItemPriceParams(pgtype.UUID{Bytes: id, Valid: true}, info.UserID, req)
+	if err != nil {
+		return err
+	}
+	row, err := a.queries.CreateItemPrice(r.Context(), params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+	resp, err := itemPriceResponseFromRow(row)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusCreated, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/items/{id}/prices")
+	}
+	return nil
+}
+
+// handleItemPricesDelete removes a single price record.
+func (a *App) handleItemPricesDelete(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	priceID, err := parseUUIDParam(r, "price_id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeleteItemPrice(r.Context(), sqlc.DeleteItemPriceParams{
+		ItemID: pgtype.UUID{Bytes: id, Valid: true},
+		ID:     pgtype.UUID{Bytes: priceID, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+	if affected == 0 {
+		return errNotFound()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func validateItemPriceRequest(req itemPriceRequest) []response.FieldError {
+	var errs []response.FieldError
+	switch {
+	case req.Price == nil:
+		errs = append(errs, response.FieldError{Field: "price", Message: "price is required"})
+	case *req.Price < 0:
+		errs = append(errs, response.FieldError{Field: "price", Message: "price must be >= 0"})
+	}
+	if req.Quantity <= 0 {
+		errs = append(errs, response.FieldError{Field: "quantity", Message: "quantity must be > 0"})
+	}
+	if req.Unit == "" {
+		errs = append(errs, response.FieldError{Field: "unit", Message: "unit is required"})
+	}
+	if req.Store != nil && len([]rune(strings.TrimSpace(*req.Store))) > maxItemPriceStoreLength {
+		errs = append(errs, response.FieldError{
+			Field:   "store",
+			Message: fmt.Sprintf("store must be at most %d characters", maxItemPriceStoreLength),
+		})
+	}
+	return errs
+}
+
+func createItemPriceParams(itemID pgtype.UUID, userID uuid.UUID, req itemPriceRequest) (sqlc.CreateItemPriceParams, error) {
+	params := sqlc.CreateItemPriceParams{
+		ItemID:    itemID,
+		Unit:      req.Unit,
+		Store:     textPtrToPG(req.Store),
+		CreatedBy: pgtype.UUID{Bytes: userID, Valid: true},
+	}
+	if req.PricedOn != nil {
+		pricedOn, err := parseMealPlanDate("priced_on", *req.PricedOn)
+		if err != nil {
+			return sqlc.CreateItemPriceParams{}, err
+		}
+		params.PricedOn = pricedOn
+	}
+	price, err := numericPtrFromFloat64(req.Price)
+	if err != nil {
+		return sqlc.CreateItemPriceParams{}, errInternal(err)
+	}
+	quantity, err := numericPtrFromFloat64(&req.Quantity)
+	if err != nil {
+		return sqlc.CreateItemPriceParams{}, errInternal(err)
+	}
+	params.Price = price
+	params.Quantity = quantity
+	return params, nil
+}
+
+func itemPriceResponseFromRow(row sqlc.ItemPrice) (itemPriceResponse, error) {
+	resp := itemPriceResponse{
+		ID:        uuidString(row.ID),
+		ItemID:    uuidString(row.ItemID),
+		Unit:      row.Unit,
+		Store:     textStringPtr(row.Store),
+		PricedOn:  mealPlanDateString(row.PricedOn),
+		CreatedAt: timeString(row.CreatedAt),
+		CreatedBy: uuidString(row.CreatedBy),
+	}
+	values := []struct {
+		src pgtype.Numeric
+		dst *float64
+	}{
+		{row.Price, &resp.Price},
+		{row.Quantity, &resp.Quantity},
+	}
+	for _, v := range values {
+		f, err := float64PtrFromNumeric(v.src)
+		if err != nil {
+			return itemPriceResponse{}, err
+		}
+		if f != nil {
+			*v.dst = *f
+		}
+	}
+	if resp.Quantity > 0 {
+		resp.UnitPrice = math.Round(resp.Price/resp.Quantity*unitPricePrecision) / unitPricePrecision
+	}
+	return resp, nil
+}
@@ -0,0 +1,187 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type itemPriceResponse struct {
+	ID        string  `json:"id"`
+	ItemID    string  `json:"item_id"`
+	Price     float64 `json:"price"`
+	Quantity  float64 `json:"quantity"`
+	Unit      string  `json:"unit"`
+	UnitPrice float64 `json:"unit_price"`
+	Store     *string `json:"store"`
+	PricedOn  string  `json:"priced_on"`
+}
+
+type costGapResponse struct {
+	IngredientID string `json:"ingredient_id"`
+	ItemID       string `json:"item_id"`
+	ItemName     string `json:"item_name"`
+	Reason       string `json:"reason"`
+}
+
+type recipeCostResponse struct {
+	Total      float64           `json:"total"`
+	PerServing float64           `json:"per_serving"`
+	Complete   bool              `json:"complete"`
+	Uncounted  []costGapResponse `json:"uncounted"`
+}
+
+type shoppingListCostResponse struct {
+	Total     float64           `json:"total"`
+	Complete  bool              `json:"complete"`
+	Uncounted []costGapResponse `json:"uncounted"`
+}
+
+func TestItems_Prices(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	var recipe recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"Rice bowl",
+  "servings":4,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[
+    {"position":1,"quantity":500,"unit":"g","item_name":"Rice"},
+    {"position":2,"quantity":4,"item_name":"Egg"},
+    {"position":3,"quantity_text":"to taste","item_name":"Soy sauce"}
+  ],
+  "steps":[{"step_number":1,"instruction":"Cook and top."}]
+}`, http.StatusCreated, &recipe)
+	if recipe.Cost.Complete || recipe.Cost.Total != 0 || len(recipe.Cost.Uncounted) != 3 {
+		t.Fatalf("cost=%+v, want every ingredient unpriced", recipe.Cost)
+	}
+	riceID := recipe.Ingredients[0].Item.ID
+	eggID := recipe.Ingredients[1].Item.ID
+	ricePricesURL := server.URL + "/api/v1/items/" + riceID + "/prices"
+
+	t.Run("create validates and records history", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPost, ricePricesURL,
+			`{"price":-1,"quantity":0,"unit":""}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost,
+			server.URL+"/api/v1/items/00000000-0000-0000-0000-000000000000/prices",
+			`{"price":1,"quantity":1,"unit":"kg"}`, http.StatusNotFound, nil)
+
+		var older itemPriceResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, ricePricesURL,
+			`{"price":4,"quantity":2,"unit":"kilograms","store":"Corner Shop","priced_on":"2025-01-05"}`, http.StatusCreated, &older)
+		if older.Unit != "kg" || older.UnitPrice != 2 || older.Store == nil || *older.Store != "Corner Shop" {
+			t.Fatalf("price=%+v", older)
+		}
+		var newer itemPriceResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, ricePricesURL,
+			`{"price":5,"quantity":2,"unit":"kg","priced_on":"2025-03-01"}`, http.StatusCreated, &newer)
+
+		var history []itemPriceResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, ricePricesURL, "", http.StatusOK, &history)
+		if len(history) != 2 || history[0].ID != newer.ID || history[1].ID != older.ID {
+			t.Fatalf("history=%+v, want newest first", history)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/items/"+eggID+"/prices",
+			`{"price":3,"quantity":12,"unit":"piece"}`, http.StatusCreated, nil)
+	})
+
+	t.Run("recipe detail estimates cost from latest prices", func(t *testing.T) {
+		var detail recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+recipe.ID, "", http.StatusOK, &detail)
+		// 0.5 kg rice at 2.50/kg plus 4 eggs at 0.25 each.
+		if detail.Cost.Total != 2.25 || detail.Cost.PerServing != 0.56 {
+			t.Fatalf("cost=%+v, want 2.25 total", detail.Cost)
+		}
+		if detail.Cost.Complete || len(detail.Cost.Uncounted) != 1 || detail.Cost.Uncounted[0].Reason != "missing_quantity" {
+			t.Fatalf("cost=%+v, want soy sauce uncounted", detail.Cost)
+		}
+
+		var scaled recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+recipe.ID+"?servings=8", "", http.StatusOK, &scaled)
+		if scaled.Cost.Total != 4.5 || scaled.Cost.PerServing != detail.Cost.PerServing {
+			t.Fatalf("scaled cost=%+v, want 4.5 total", scaled.Cost)
+		}
+	})
+
+	t.Run("shopping list detail totals item costs", func(t *testing.T) {
+		var list testShoppingListResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists",
+			`{"list_date":"2025-03-02","name":"Bowls","notes":null}`, http.StatusCreated, &list)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists/"+list.ID+"/items/from-recipes",
+			`{"recipe_ids":["`+recipe.ID+`"]}`, http.StatusOK, nil)
+
+		var detail testShoppingListDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/shopping-lists/"+list.ID, "", http.StatusOK, &detail)
+		if detail.Cost.Total != 2.25 || detail.Cost.Complete {
+			t.Fatalf("cost=%+v, want 2.25 and incomplete", detail.Cost)
+		}
+		rice := findListItem(detail.Items, "Rice")
+		if rice == nil || rice.EstimatedCost == nil || *rice.EstimatedCost != 1.25 {
+			t.Fatalf("rice=%+v, want 1.25 estimate", rice)
+		}
+	})
+
+	t.Run("delete removes a price", func(t *testing.T) {
+		var history []itemPriceResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, ricePricesURL, "", http.StatusOK, &history)
+		priceURL := ricePricesURL + "/" + history[0].ID
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, priceURL, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, priceURL, "", http.StatusNotFound, nil)
+	})
+}
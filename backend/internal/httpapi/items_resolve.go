@@ -0,0 +1,180 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+)
+
+// ingredientItemMinSimilarity is the trigram similarity an existing item name
+// or alias needs before an unknown ingredient name is folded into that item.
+// It keeps "all purpose flour" with "all-purpose flour" but "red onion" apart
+// from "onion".
+const ingredientItemMinSimilarity = 0.7
+
+// ingredientItemSimilarityPrecision rounds reported similarity to three
+// decimal places.
+const ingredientItemSimilarityPrecision = 1000
+
+// Ways an ingredient name can resolve to an item with a different name.
+const (
+	itemMatchAlias   = "alias"
+	itemMatchPlural  = "plural"
+	itemMatchSimilar = "similar"
+)
+
+// ingredientItemMatch records an ingredient name that resolved to an existing
+// item other than by its exact name. Similarity is set for similar matches.
+type ingredientItemMatch struct {
+	Position   int
+	Name       string
+	ItemID     pgtype.UUID
+	ItemName   string
+	Kind       string
+	Similarity *float64
+}
+
+// recipeIngredientMatchResponse tells the client which ingredient names were
+// folded into an existing item so a wrong guess can be corrected.
+type recipeIngredientMatchResponse struct {
+	Position      int      `json:"position"`
+	RequestedName string   `json:"requested_name"`
+	ItemID        string   `json:"item_id"`
+	ItemName      string   `json:"item_name"`
+	Match         string   `json:"match"`
+	Similarity    *float64 `json:"similarity"`
+}
+
+// recipeSaveResponse is the recipe detail returned by create, update, and
+// import, plus the ingredient names that were matched fuzzily.
+type recipeSaveResponse struct {
+	recipeDetailResponse
+	IngredientMatches []recipeIngredientMatchResponse `json:"ingredient_matches"`
+}
+
+func newRecipeSaveResponse(detail recipeDetailResponse, matches []ingredientItemMatch) recipeSaveResponse {
+	resp := recipeSaveResponse{
+		recipeDetailResponse: detail,
+		IngredientMatches:    make([]recipeIngredientMatchResponse, 0, len(matches)),
+	}
+	for _, match := range matches {
+		resp.IngredientMatches = append(resp.IngredientMatches, recipeIngredientMatchResponse{
+			Position:      match.Position,
+			RequestedName: match.Name,
+			ItemID:        uuidString(match.ItemID),
+			ItemName:      match.ItemName,
+			Match:         match.Kind,
+			Similarity:    match.Similarity,
+		})
+	}
+	return resp
+}
+
+// findExistingItem looks for the item an ingredient name refers to when no
+// item has that exact name. It tries aliases, then singular and plural forms
+// of the name against item names and aliases, then the closest item name or
+// alias by trigram similarity. ok is false when nothing qualifies.
+func findExistingItem(ctx context.Context, q recipeWorkflowQueries, name string) (ingredientItemMatch, bool, error) {
+	match := ingredientItemMatch{Name: name}
+
+	alias, err := q.GetItemByAlias(ctx, name)
+	switch {
+	case err == nil:
+		match.ItemID, match.ItemName, match.Kind = alias.ID, alias.Name, itemMatchAlias
+		return match, true, nil
+	case !errors.Is(err, pgx.ErrNoRows):
+		return ingredientItemMatch{}, false, err
+	}
+
+	for _, variant := range itemNameVariants(name) {
+		item, itemErr := q.GetItemByName(ctx, variant)
+		switch {
+		case itemErr == nil:
+			match.ItemID, match.ItemName, match.Kind = item.ID, item.Name, itemMatchPlural
+			return match, true, nil
+		case !errors.Is(itemErr, pgx.ErrNoRows):
+			return ingredientItemMatch{}, false, itemErr
+		}
+		alias, aliasErr := q.GetItemByAlias(ctx, variant)
+		switch {
+		case aliasErr == nil:
+			match.ItemID, match.ItemName, match.Kind = alias.ID, alias.Name, itemMatchPlural
+			return match, true, nil
+		case !errors.Is(aliasErr, pgx.ErrNoRows):
+			return ingredientItemMatch{}, false, aliasErr
+		}
+	}
+
+	similar, err := q.FindSimilarItem(ctx, sqlc.FindSimilarItemParams{
+		Name:          name,
+		MinSimilarity: ingredientItemMinSimilarity,
+	})
+	switch {
+	case err == nil:
+		score := math.Round(similar.Similarity*ingredientItemSimilarityPrecision) / ingredientItemSimilarityPrecision
+		match.ItemID, match.ItemName, match.Kind, match.Similarity = similar.ID, similar.Name, itemMatchSimilar, &score
+		return match, true, nil
+	case !errors.Is(err, pgx.ErrNoRows):
+		return ingredientItemMatch{}, false, err
+	}
+	return ingredientItemMatch{}, false, nil
+}
+
+// itemNameVariants returns the other singular and plural spellings of name,
+// lowercased, by inflecting its last word. Only regular English endings are
+// handled; irregular nouns are what aliases are for.
+func itemNameVariants(name string) []string {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	var variants []string
+	add := func(variant string) {
+		if variant != lower && !slices.Contains(variants, variant) {
+			variants = append(variants, variant)
+		}
+	}
+
+	if strings.HasSuffix(lower, "s") && !strings.HasSuffix(lower, "ss") && len(lower) > 3 {
+		stem := lower[:len(lower)-1]
+		switch {
+		case strings.HasSuffix(lower, "ies"):
+			add(lower[:len(lower)-3] + "y")
+		case strings.HasSuffix(lower, "ves"):
+			add(lower[:len(lower)-3] + "f")
+		}
+		if trimmed := strings.TrimSuffix(stem, "e"); trimmed != stem && hasAnySuffix(trimmed, "s", "x", "z", "ch", "sh", "o") {
+			add(trimmed)
+		}
+		add(stem)
+		return variants
+	}
+	if strings.HasSuffix(lower, "s") || lower == "" {
+		return variants
+	}
+
+	switch {
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		add(lower[:len(lower)-1] + "ies")
+	case hasAnySuffix(lower, "x", "z", "ch", "sh"):
+		add(lower + "es")
+	case strings.HasSuffix(lower, "o"):
+		add(lower + "es")
+		add(lower + "s")
+	default:
+		add(lower + "s")
+	}
+	return variants
+}
+
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package httpapi
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+)
+
+func TestItemNameVariants(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "Tomatoes", want: []string{"tomato", "tomatoe"}},
+		{name: "tomato", want: []string{"tomatoes", "tomatos"}},
+		{name: "Green Onions", want: []string{"green onion"}},
+		{name: "green onion", want: []string{"green onions"}},
+		{name: "berries", want: []string{"berry", "berrie"}},
+		{name: "berry", want: []string{"berries"}},
+		{name: "bay leaves", want: []string{"bay leaf", "bay leave"}},
+		{name: "peach", want: []string{"peaches"}},
+		{name: "peaches", want: []string{"peach", "peache"}},
+		{name: "watercress", want: nil},
+		{name: "gas", want: nil},
+	}
+	for _, tt := range tests {
+		if got := itemNameVariants(tt.name); !slices.Equal(got, tt.want) {
+			t.Errorf("itemNameVariants(%q)=%q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResolveIngredientItemID_Matches(t *testing.T) {
+	t.Parallel()
+
+	actorID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	onionID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	tomatoID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	flourID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	createdID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	items := map[string]sqlc.Item{
+		"green onions":      {ID: onionID, Name: "Green onions"},
+		"tomato":            {ID: tomatoID, Name: "Tomato"},
+		"all-purpose flour": {ID: flourID, Name: "All-purpose flour"},
+	}
+	aliases := map[string]sqlc.GetItemByAliasRow{
+		"scallions": {ID: onionID, Name: "Green onions"},
+	}
+	var created []string
+	q := fakeRecipeWorkflowQueries{
+		getItemByName: func(ctx context.Context, name string) (sqlc.Item, error) {
+			if item, ok := items[strings.ToLower(name)]; ok {
+				return item, nil
+			}
+			return sqlc.Item{}, pgx.ErrNoRows
+		},
+		getItemByAlias: func(ctx context.Context, alias string) (sqlc.GetItemByAliasRow, error) {
+			if item, ok := aliases[strings.ToLower(alias)]; ok {
+				return item, nil
+			}
+			return sqlc.GetItemByAliasRow{}, pgx.ErrNoRows
+		},
+		findSimilarItem: func(ctx context.Context, arg sqlc.FindSimilarItemParams) (sqlc.FindSimilarItemRow, error) {
+			if arg.MinSimilarity != ingredientItemMinSimilarity {
+				t.Fatalf("min similarity=%v", arg.MinSimilarity)
+			}
+			if arg.Name == "all purpose flour" {
+				return sqlc.FindSimilarItemRow{ID: flourID, Name: "All-purpose flour", Similarity: 0.85714}, nil
+			}
+			return sqlc.FindSimilarItemRow{}, pgx.ErrNoRows
+		},
+		createItem: func(ctx context.Context, arg sqlc.CreateItemParams) (sqlc.Item, error) {
+			created = append(created, arg.Name)
+			return sqlc.Item{ID: createdID, Name: arg.Name}, nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		wantID     pgtype.UUID
+		wantKind   string
+		similarity float64
+	}{
+		{name: "Green Onions", wantID: onionID},
+		{name: "Scallions", wantID: onionID, wantKind: itemMatchAlias},
+		{name: "scallion", wantID: onionID, wantKind: itemMatchPlural},
+		{name: "Tomatoes", wantID: tomatoID, wantKind: itemMatchPlural},
+		{name: "all purpose flour", wantID: flourID, wantKind: itemMatchSimilar, similarity: 0.857},
+		{name: "Saffron", wantID: createdID},
+	}
+	for i, tt := range tests {
+		ingredient := recipeIngredientRequest{Position: i + 1, ItemName: stringPtr(tt.name)}
+		id, match, err := resolveIngredientItemID(context.Background(), q, actorID, ingredient, i)
+		if err != nil {
+			t.Fatalf("%s: resolve: %v", tt.name, err)
+		}
+		if id != tt.wantID {
+			t.Fatalf("%s: id=%v, want %v", tt.name, id, tt.wantID)
+		}
+		if tt.wantKind == "" {
+			if match != nil {
+				t.Fatalf("%s: match=%+v, want none", tt.name, match)
+			}
+			continue
+		}
+		if match == nil || match.Kind != tt.wantKind || match.Position != i+1 || match.Name != tt.name {
+			t.Fatalf("%s: match=%+v, want %s at position %d", tt.name, match, tt.wantKind, i+1)
+		}
+		if tt.similarity != 0 && (match.Similarity == nil || *match.Similarity != tt.similarity) {
+			t.Fatalf("%s: similarity=%v, want %v", tt.name, match.Similarity, tt.similarity)
+		}
+	}
+	if !slices.Equal(created, []string{"Saffron"}) {
+		t.Fatalf("created=%q, want only Saffron", created)
+	}
+}
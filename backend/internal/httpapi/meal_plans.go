@@ -1,6 +1,7 @@
 package httpapi
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strings"
@@ -26,6 +27,13 @@ type mealPlanEntryResponse struct {
 	Recipe mealPlanRecipeResponse `json:"recipe"`
 }
 
+// mealPlanCreateResponse adds warnings for any of the planning user's dietary
+// restrictions the recipe conflicts with. Conflicts never block planning.
+type mealPlanCreateResponse struct {
+	mealPlanEntryResponse
+	Warnings []dietaryConflict `json:"warnings"`
+}
+
 type mealPlanListResponse struct {
 	Items []mealPlanEntryResponse `json:"items"`
 }
@@ -145,12 +153,20 @@ func (a *App) handleMealPlansCreate(w http.ResponseWriter, r *http.Request) erro
 		return errInternal(err)
 	}
 
-	resp := mealPlanEntryResponse{
-		Date: mealPlanDateString(row.PlanDate),
-		Recipe: mealPlanRecipeResponse{
-			ID:    uuidString(row.RecipeID),
-			Title: row.Title,
+	warnings, err := a.mealPlanDietaryWarnings(r.Context(), pgtype.UUID{Bytes: info.UserID, Valid: true}, row.RecipeID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	resp := mealPlanCreateResponse{
+		mealPlanEntryResponse: mealPlanEntryResponse{
+			Date: mealPlanDateString(row.PlanDate),
+			Recipe: mealPlanRecipeResponse{
+				ID:    uuidString(row.RecipeID),
+				Title: row.Title,
+			},
 		},
+		Warnings: warnings,
 	}
 	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
 		a.logger.Warn("write failed", "err", err, "path", "/api/v1/meal-plans")
@@ -158,6 +174,23 @@ func (a *App) handleMealPlansCreate(w http.ResponseWriter, r *http.Request) erro
 	return nil
 }
 
+// mealPlanDietaryWarnings reports how a recipe conflicts with a user's
+// declared diets and allergens.
+func (a *App) mealPlanDietaryWarnings(ctx context.Context, userID, recipeID pgtype.UUID) ([]dietaryConflict, error) {
+	restrictions, err := a.loadUserDietaryRestrictions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(restrictions.Diets) == 0 && len(restrictions.Allergens) == 0 {
+		return []dietaryConflict{}, nil
+	}
+	dietaryByRecipeID, err := a.loadRecipeDietaryItems(ctx, []pgtype.UUID{recipeID})
+	if err != nil {
+		return nil, err
+	}
+	return dietaryConflicts(restrictions.Diets, restrictions.Allergens, dietaryByRecipeID[uuidString(recipeID)]), nil
+}
+
 // handleMealPlansDelete deletes a meal plan entry for the authenticated user.
 func (a *App) handleMealPlansDelete(w http.ResponseWriter, r *http.Request) error {
 	info, ok := authInfoFromRequest(r)
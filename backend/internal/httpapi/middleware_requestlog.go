@@ -30,7 +30,7 @@ func (a *App) requestLogger(next http.Handler) http.Handler {
 			"request_id", middleware.GetReqID(r.Context()),
 			"remote_ip", remoteIP,
 			"method", r.Method,
-			"path", r.URL.Path,
+			"path", safePath(r),
 			"status", status,
 			"duration_ms", time.Since(start).Milliseconds(),
 		}
@@ -0,0 +1,485 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+// defaultPantryLocation is used when stock is added without a location.
+const defaultPantryLocation = "pantry"
+
+// pantryLocations must match pantry_items_location_chk.
+var pantryLocations = []string{"fridge", "freezer", "pantry"}
+
+// pantryItemRequest adds stock to the pantry. Stock matching an existing entry
+// (same item, location, unit, and best-before date) is added to it.
+type pantryItemRequest struct {
+	ItemID     string   `json:"item_id"`
+	Quantity   *float64 `json:"quantity"`
+	Unit       *string  `json:"unit"`
+	Location   *string  `json:"location"`
+	BestBefore *string  `json:"best_before"`
+}
+
+// pantryItemUpdateRequest replaces an entry's stock details. The item itself
+// cannot change; delete the entry and add a new one instead.
+type pantryItemUpdateRequest struct {
+	Quantity   *float64 `json:"quantity"`
+	Unit       *string  `json:"unit"`
+	Location   *string  `json:"location"`
+	BestBefore *string  `json:"best_before"`
+}
+
+// pantryItemResponse is one pantry entry. A null quantity means the amount on
+// hand is unknown.
+type pantryItemResponse struct {
+	ID         string   `json:"id"`
+	ItemID     string   `json:"item_id"`
+	ItemName   string   `json:"item_name"`
+	Quantity   *float64 `json:"quantity"`
+	Unit       *string  `json:"unit"`
+	Location   string   `json:"location"`
+	BestBefore *string  `json:"best_before"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+// pantryStockFields holds validated pantry entry values ready for SQL.
+type pantryStockFields struct {
+	quantity   pgtype.Numeric
+	unit       pgtype.Text
+	location   string
+	bestBefore pgtype.Date
+}
+
+// handlePantryItemsList returns pantry entries, optionally for one location.
+func (a *App) handlePantryItemsList(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	var location pgtype.Text
+	if raw := r.URL.Query().Get("location"); raw != "" {
+		normalized, err := normalizePantryLocation(&raw)
+		if err != nil {
+			return err
+		}
+		location = pgtype.Text{String: normalized, Valid: true}
+	}
+
+	rows, err := a.queries.ListPantryItems(r.Context(), location)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	resp := make([]pantryItemResponse, 0, len(rows))
+	for _, row := range rows {
+		item, buildErr := pantryItemResponseFromListRow(row)
+		if buildErr != nil {
+			return errInternal(buildErr)
+		}
+		resp = append(resp, item)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/pantry-items")
+	}
+	return nil
+}
+
+// handlePantryItemsCreate adds stock to the pantry.
+func (a *App) handlePantryItemsCreate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	var req pantryItemRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+
+	itemID, err := parseShoppingListItemID(req.ItemID)
+	if err != nil {
+		return err
+	}
+	fields, err := validatePantryStock(req.Quantity, req.Unit, req.Location, req.BestBefore)
+	if err != nil {
+		return err
+	}
+
+	actor := pgtype.UUID{Bytes: info.UserID, Valid: true}
+	row, err := a.queries.UpsertPantryItem(r.Context(), sqlc.UpsertPantryItemParams{
+		ItemID:     itemID,
+		Quantity:   fields.quantity,
+		Unit:       fields.unit,
+		Location:   fields.location,
+		BestBefore: fields.bestBefore,
+		CreatedBy:  actor,
+		UpdatedBy:  actor,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errValidationField("item_id", "item does not exist")
+		}
+		return errInternal(err)
+	}
+
+	resp, err := pantryItemResponseFromUpsertRow(row)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusCreated, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/pantry-items")
+	}
+	return nil
+}
+
+// handlePantryItemsUpdate replaces the quantity, unit, location, and
+// best-before date of a pantry entry.
+func (a *App) handlePantryItemsUpdate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req pantryItemUpdateRequest
+	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
+		return decodeErr
+	}
+	fields, err := validatePantryStock(req.Quantity, req.Unit, req.Location, req.BestBefore)
+	if err != nil {
+		return err
+	}
+
+	row, err := a.queries.UpdatePantryItem(r.Context(), sqlc.UpdatePantryItemParams{
+		Quantity:   fields.quantity,
+		Unit:       fields.unit,
+		Location:   fields.location,
+		BestBefore: fields.bestBefore,
+		UpdatedBy:  pgtype.UUID{Bytes: info.UserID, Valid: true},
+		ID:         pgtype.UUID{Bytes: id, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		if isPGUniqueViolation(err) {
+			return errConflict("pantry already has this item with the same location, unit, and best-before date")
+		}
+		return errInternal(err)
+	}
+
+	resp, err := pantryItemResponseFromUpdateRow(row)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/pantry-items/{id}")
+	}
+	return nil
+}
+
+// handlePantryItemsDelete removes a pantry entry.
+func (a *App) handlePantryItemsDelete(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeletePantryItem(r.Context(), pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return errInternal(err)
+	}
+	if affected == 0 {
+		return errNotFound()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// validatePantryStock checks pantry entry values and normalizes the unit and
+// location. Location defaults to the pantry.
+func validatePantryStock(quantity *float64, unit, location, bestBefore *string) (pantryStockFields, error) {
+	var errs []response.FieldError
+	if quantity != nil && (*quantity <= 0 || math.IsNaN(*quantity) || math.IsInf(*quantity, 0)) {
+		errs = append(errs, response.FieldError{Field: "quantity", Message: "quantity must be > 0"})
+	}
+	fields := pantryStockFields{}
+	normalized, err := normalizePantryLocation(location)
+	if err != nil {
+		errs = append(errs, response.FieldError{Field: "location", Message: "location must be one of " + strings.Join(pantryLocations, ", ")})
+	}
+	fields.location = normalized
+	if bestBefore != nil && strings.TrimSpace(*bestBefore) != "" {
+		date, dateErr := parseMealPlanDate("best_before", *bestBefore)
+		if dateErr != nil {
+			errs = append(errs, response.FieldError{Field: "best_before", Message: "invalid date"})
+		}
+		fields.bestBefore = date
+	}
+	if len(errs) > 0 {
+		return pantryStockFields{}, errValidation(errs)
+	}
+
+	numeric, err := numericPtrFromFloat64(quantity)
+	if err != nil {
+		return pantryStockFields{}, errValidationField("quantity", "invalid quantity")
+	}
+	fields.quantity = numeric
+	fields.unit = textPtrToPG(pantryUnit(unit))
+	return fields, nil
+}
+
+// normalizePantryLocation lowercases a location and checks it against
+// pantryLocations. A missing or blank location means the pantry.
+func normalizePantryLocation(location *string) (string, error) {
+	if location == nil || strings.TrimSpace(*location) == "" {
+		return defaultPantryLocation, nil
+	}
+	normalized := strings.ToLower(strings.TrimSpace(*location))
+	if !slices.Contains(pantryLocations, normalized) {
+		return "", errValidationField("location", "location must be one of "+strings.Join(pantryLocations, ", "))
+	}
+	return normalized, nil
+}
+
+// pantryUnit trims a unit and maps known units to their canonical name so
+// restocked and hand-entered stock land on the same entry.
+func pantryUnit(unit *string) *string {
+	trimmed := trimPtr(unit)
+	if trimmed == nil {
+		return nil
+	}
+	normalized := normalizeReferenceUnit(*trimmed)
+	return &normalized
+}
+
+// restockPantryFromListItem adds a purchased shopping list item to the pantry.
+func restockPantryFromListItem(ctx context.Context, queries *sqlc.Queries, item sqlc.ShoppingListItem, location string, userID uuid.UUID) (pantryItemResponse, error) {
+	quantity, err := float64PtrFromNumeric(item.Quantity)
+	if err != nil {
+		return pantryItemResponse{}, errInternal(err)
+	}
+	if quantity != nil && *quantity <= 0 {
+		quantity = nil
+	}
+	numeric, err := numericPtrFromFloat64(quantity)
+	if err != nil {
+		return pantryItemResponse{}, errInternal(err)
+	}
+
+	actor := pgtype.UUID{Bytes: userID, Valid: true}
+	row, err := queries.UpsertPantryItem(ctx, sqlc.UpsertPantryItemParams{
+		ItemID:    item.ItemID,
+		Quantity:  numeric,
+		Unit:      textPtrToPG(pantryUnit(textStringPtr(item.Unit))),
+		Location:  location,
+		CreatedBy: actor,
+		UpdatedBy: actor,
+	})
+	if err != nil {
+		return pantryItemResponse{}, errInternal(err)
+	}
+	resp, err := pantryItemResponseFromUpsertRow(row)
+	if err != nil {
+		return pantryItemResponse{}, errInternal(err)
+	}
+	return resp, nil
+}
+
+// excludePantryStock loads pantry stock for the given list items and
+// subtracts it from them. Stock already set against the list by earlier
+// additions is not counted again; the stock used now is returned so it can
+// be recorded with the new lines.
+func (a *App) excludePantryStock(ctx context.Context, listID pgtype.UUID, userID uuid.UUID, items []normalizedShoppingListItem) ([]normalizedShoppingListItem, []pantryReservation, error) {
+	if len(items) == 0 {
+		return items, nil, nil
+	}
+	itemIDs := make([]pgtype.UUID, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.itemID)
+	}
+	stock, err := a.queries.ListPantryItemsByItemIDs(ctx, itemIDs)
+	if err != nil {
+		return nil, nil, errInternal(err)
+	}
+	rows, err := a.queries.ListShoppingListPantryReservations(ctx, sqlc.ListShoppingListPantryReservationsParams{
+		ShoppingListID: listID,
+		UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+		ItemIds:        itemIDs,
+	})
+	if err != nil {
+		return nil, nil, errInternal(err)
+	}
+	reserved := make([]pantryReservation, 0, len(rows))
+	for _, row := range rows {
+		quantity, err := float64PtrFromNumeric(row.Quantity)
+		if err != nil {
+			return nil, nil, errInternal(err)
+		}
+		if quantity == nil {
+			continue
+		}
+		reserved = append(reserved, pantryReservation{itemID: row.ItemID, unit: row.Unit, quantity: *quantity})
+	}
+	return subtractPantryStock(items, stock, reserved)
+}
+
+// pantryStock is the part of a pantry entry not yet set against a list line.
+type pantryStock struct {
+	quantity *float64
+	unit     *string
+}
+
+// pantryReservation is pantry stock set against a list, in the unit of the
+// need it covered.
+type pantryReservation struct {
+	itemID   pgtype.UUID
+	unit     string
+	quantity float64
+}
+
+// subtractPantryStock lowers each needed quantity by the stock on hand in a
+// compatible unit and drops lines the pantry fully covers. Stock in reserved
+// is taken first, since the list already relies on it. Lines without a
+// numeric quantity ("to taste") are dropped when the item is stocked at all;
+// stock without a quantity never reduces a numeric need. The stock used for
+// items is returned as new reservations.
+func subtractPantryStock(items []normalizedShoppingListItem, stock []sqlc.PantryItem, reserved []pantryReservation) ([]normalizedShoppingListItem, []pantryReservation, error) {
+	onHand := make(map[string][]*pantryStock, len(stock))
+	for _, row := range stock {
+		quantity, err := float64PtrFromNumeric(row.Quantity)
+		if err != nil {
+			return nil, nil, errInternal(err)
+		}
+		id := uuidString(row.ItemID)
+		onHand[id] = append(onHand[id], &pantryStock{quantity: quantity, unit: textStringPtr(row.Unit)})
+	}
+	for _, reservation := range reserved {
+		takePantryStock(onHand[uuidString(reservation.itemID)], reservation.quantity, reservation.unit)
+	}
+
+	out := make([]normalizedShoppingListItem, 0, len(items))
+	var used []pantryReservation
+	for _, item := range items {
+		entries, ok := onHand[uuidString(item.itemID)]
+		if !ok {
+			out = append(out, item)
+			continue
+		}
+		if item.quantity == nil {
+			continue
+		}
+
+		needUnit := "piece"
+		if item.unit != nil {
+			needUnit = *item.unit
+		}
+		remaining := takePantryStock(entries, *item.quantity, needUnit)
+		remaining = math.Round(remaining*quantityScalePrecision) / quantityScalePrecision
+		taken := math.Round((*item.quantity-math.Max(remaining, 0))*quantityScalePrecision) / quantityScalePrecision
+		if taken > 0 {
+			used = append(used, pantryReservation{itemID: item.itemID, unit: needUnit, quantity: taken})
+		}
+		if remaining <= 0 {
+			continue
+		}
+		if remaining != *item.quantity {
+			item.quantity = &remaining
+			item.quantityText = nil
+		}
+		out = append(out, item)
+	}
+	return out, used, nil
+}
+
+// takePantryStock sets stock in a compatible unit against a need and returns
+// the part of the need left uncovered.
+func takePantryStock(entries []*pantryStock, need float64, needUnit string) float64 {
+	remaining := need
+	for _, entry := range entries {
+		if remaining <= 0 {
+			break
+		}
+		if entry.quantity == nil || *entry.quantity <= 0 {
+			continue
+		}
+		available, compatible := referenceFactor(*entry.quantity, entry.unit, 1, needUnit)
+		if !compatible || available <= 0 {
+			continue
+		}
+		used := math.Min(available, remaining)
+		remaining -= used
+		*entry.quantity -= *entry.quantity * used / available
+	}
+	return remaining
+}
+
+func pantryItemResponseFromBase(
+	id pgtype.UUID,
+	itemID pgtype.UUID,
+	itemName string,
+	quantity pgtype.Numeric,
+	unit pgtype.Text,
+	location string,
+	bestBefore pgtype.Date,
+	createdAt pgtype.Timestamptz,
+	updatedAt pgtype.Timestamptz,
+) (pantryItemResponse, error) {
+	amount, err := float64PtrFromNumeric(quantity)
+	if err != nil {
+		return pantryItemResponse{}, err
+	}
+	resp := pantryItemResponse{
+		ID:        uuidString(id),
+		ItemID:    uuidString(itemID),
+		ItemName:  itemName,
+		Quantity:  amount,
+		Unit:      textStringPtr(unit),
+		Location:  location,
+		CreatedAt: timeString(createdAt),
+		UpdatedAt: timeString(updatedAt),
+	}
+	if bestBefore.Valid {
+		date := mealPlanDateString(bestBefore)
+		resp.BestBefore = &date
+	}
+	return resp, nil
+}
+
+func pantryItemResponseFromListRow(row sqlc.ListPantryItemsRow) (pantryItemResponse, error) {
+	return pantryItemResponseFromBase(row.ID, row.ItemID, row.ItemName, row.Quantity, row.Unit, row.Location, row.BestBefore, row.CreatedAt, row.UpdatedAt)
+}
+
+func pantryItemResponseFromUpsertRow(row sqlc.UpsertPantryItemRow) (pantryItemResponse, error) {
+	return pantryItemResponseFromBase(row.ID, row.ItemID, row.ItemName, row.Quantity, row.Unit, row.Location, row.BestBefore, row.CreatedAt, row.UpdatedAt)
+}
+
+func pantryItemResponseFromUpdateRow(row sqlc.UpdatePantryItemRow) (pantryItemResponse, error) {
+	return pantryItemResponseFromBase(row.ID, row.ItemID, row.ItemName, row.Quantity, row.Unit, row.Location, row.BestBefore, row.CreatedAt, row.UpdatedAt)
+}
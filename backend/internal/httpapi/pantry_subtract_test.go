@@ -0,0 +1,155 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+)
+
+func TestSubtractPantryStock(t *testing.T) {
+	t.Parallel()
+
+	flour := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	milk := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	salt := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	eggs := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	rice := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	items := []normalizedShoppingListItem{
+		testListItem(flour, 500, "g"),
+		testListItem(milk, 2, "cup"),
+		{itemID: salt, quantityText: stringPtr("to taste")},
+		testListItem(eggs, 6, ""),
+		testListItem(rice, 1, "kg"),
+	}
+	stock := []sqlc.PantryItem{
+		testPantryItem(t, flour, 1, "kg"),
+		testPantryItem(t, milk, 250, "ml"),
+		{ItemID: salt},
+		testPantryItem(t, eggs, 2, ""),
+		{ItemID: rice},
+	}
+
+	got, _, err := subtractPantryStock(items, stock, nil)
+	if err != nil {
+		t.Fatalf("subtract: %v", err)
+	}
+	byItem := map[string]normalizedShoppingListItem{}
+	for _, item := range got {
+		byItem[uuidString(item.itemID)] = item
+	}
+
+	if _, ok := byItem[uuidString(flour)]; ok {
+		t.Fatalf("flour is fully stocked and should be dropped")
+	}
+	if _, ok := byItem[uuidString(salt)]; ok {
+		t.Fatalf("salt without a quantity is stocked and should be dropped")
+	}
+	if q := byItem[uuidString(milk)].quantity; q == nil || *q != 0.9433 {
+		t.Fatalf("milk quantity=%v, want 0.9433 cup", q)
+	}
+	if q := byItem[uuidString(eggs)].quantity; q == nil || *q != 4 {
+		t.Fatalf("eggs quantity=%v, want 4", q)
+	}
+	if q := byItem[uuidString(rice)].quantity; q == nil || *q != 1 {
+		t.Fatalf("rice quantity=%v, want 1 (unknown stock amount is not subtracted)", q)
+	}
+}
+
+func TestSubtractPantryStock_StockIsUsedOnce(t *testing.T) {
+	t.Parallel()
+
+	onions := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	items := []normalizedShoppingListItem{
+		testListItem(onions, 2, ""),
+		testListItem(onions, 3, "piece"),
+	}
+	stock := []sqlc.PantryItem{testPantryItem(t, onions, 4, "piece")}
+
+	got, _, err := subtractPantryStock(items, stock, nil)
+	if err != nil {
+		t.Fatalf("subtract: %v", err)
+	}
+	if len(got) != 1 || got[0].quantity == nil || *got[0].quantity != 1 {
+		t.Fatalf("items=%+v, want one line of 1 onion", got)
+	}
+}
+
+func TestSubtractPantryStock_ReservedStockIsNotCountedAgain(t *testing.T) {
+	t.Parallel()
+
+	eggs := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	stock := []sqlc.PantryItem{testPantryItem(t, eggs, 3, "")}
+
+	// An earlier recipe on the list already relies on 2 of the 3 eggs.
+	reserved := []pantryReservation{{itemID: eggs, unit: "piece", quantity: 2}}
+	got, used, err := subtractPantryStock([]normalizedShoppingListItem{testListItem(eggs, 3, "")}, stock, reserved)
+	if err != nil {
+		t.Fatalf("subtract: %v", err)
+	}
+	if len(got) != 1 || *got[0].quantity != 2 {
+		t.Fatalf("items=%+v, want 2 eggs", got)
+	}
+	if len(used) != 1 || used[0].unit != "piece" || used[0].quantity != 1 {
+		t.Fatalf("used=%+v, want the last egg reserved", used)
+	}
+}
+
+func TestSubtractPantryStock_IncompatibleUnitsAreKept(t *testing.T) {
+	t.Parallel()
+
+	butter := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	items := []normalizedShoppingListItem{testListItem(butter, 2, "tbsp")}
+	stock := []sqlc.PantryItem{testPantryItem(t, butter, 250, "g")}
+
+	got, _, err := subtractPantryStock(items, stock, nil)
+	if err != nil {
+		t.Fatalf("subtract: %v", err)
+	}
+	if len(got) != 1 || *got[0].quantity != 2 {
+		t.Fatalf("items=%+v, want butter unchanged", got)
+	}
+}
+
+func TestValidatePantryStock(t *testing.T) {
+	t.Parallel()
+
+	zero := 0.0
+	fields, err := validatePantryStock(nil, stringPtr(" Grams "), stringPtr("Fridge"), stringPtr("2025-03-01"))
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if fields.unit.String != "g" || fields.location != "fridge" || !fields.bestBefore.Valid {
+		t.Fatalf("fields=%+v", fields)
+	}
+	if fields, err = validatePantryStock(nil, nil, nil, nil); err != nil || fields.location != defaultPantryLocation {
+		t.Fatalf("default location=%q err=%v", fields.location, err)
+	}
+	if _, err = validatePantryStock(&zero, nil, stringPtr("cellar"), stringPtr("soon")); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func testListItem(itemID pgtype.UUID, quantity float64, unit string) normalizedShoppingListItem {
+	item := normalizedShoppingListItem{itemID: itemID, quantity: &quantity}
+	if unit != "" {
+		item.unit = &unit
+	}
+	return item
+}
+
+func testPantryItem(t *testing.T, itemID pgtype.UUID, quantity float64, unit string) sqlc.PantryItem {
+	t.Helper()
+
+	n, err := numericPtrFromFloat64(&quantity)
+	if err != nil {
+		t.Fatalf("numeric: %v", err)
+	}
+	return sqlc.PantryItem{
+		ItemID:   itemID,
+		Quantity: n,
+		Unit:     pgtype.Text{String: unit, Valid: unit != ""},
+	}
+}
@@ -0,0 +1,213 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type pantryItemResponse struct {
+	ID         string   `json:"id"`
+	ItemID     string   `json:"item_id"`
+	ItemName   string   `json:"item_name"`
+	Quantity   *float64 `json:"quantity"`
+	Unit       *string  `json:"unit"`
+	Location   string   `json:"location"`
+	BestBefore *string  `json:"best_before"`
+}
+
+type shoppingListItemPurchaseResponse struct {
+	testShoppingListItem
+	PantryItem *pantryItemResponse `json:"pantry_item"`
+}
+
+func TestPantry_StockShoppingAndRestock(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, err := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); err != nil {
+		t.Fatalf("bootstrap user: %v", err)
+	}
+
+	app, err := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   testSessionCookieName,
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if err != nil {
+		t.Fatalf("new app: %v", err)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookie jar: %v", err)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	var recipe recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"Pancakes",
+  "servings":4,
+  "prep_time_minutes":5,
+  "total_time_minutes":20,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[
+    {"position":1,"quantity":500,"unit":"g","item_name":"Flour"},
+    {"position":2,"quantity":2,"unit":"cup","item_name":"Milk"},
+    {"position":3,"quantity":3,"item_name":"Eggs"},
+    {"position":4,"quantity_text":"to taste","item_name":"Salt"}
+  ],
+  "steps":[{"step_number":1,"instruction":"Whisk and fry."}]
+}`, http.StatusCreated, &recipe)
+	flourID := recipe.Ingredients[0].Item.ID
+	milkID := recipe.Ingredients[1].Item.ID
+	eggsID := recipe.Ingredients[2].Item.ID
+	saltID := recipe.Ingredients[3].Item.ID
+
+	pantryURL := server.URL + "/api/v1/pantry-items"
+	var flourStock pantryItemResponse
+
+	t.Run("pantry entries validate and merge", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPost, pantryURL, `{"item_id":"`+flourID+`","location":"cellar"}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, pantryURL, `{"item_id":"`+flourID+`","quantity":0}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, pantryURL, `{"item_id":"`+uuid.NewString()+`"}`, http.StatusBadRequest, nil)
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, pantryURL, `{"item_id":"`+flourID+`","quantity":0.5,"unit":"kg"}`, http.StatusCreated, &flourStock)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, pantryURL, `{"item_id":"`+flourID+`","quantity":0.5,"unit":"kilograms"}`, http.StatusCreated, &flourStock)
+		if flourStock.Quantity == nil || *flourStock.Quantity != 1 || flourStock.Location != "pantry" {
+			t.Fatalf("flour stock=%+v, want 1 kg merged in pantry", flourStock)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPost, pantryURL, `{"item_id":"`+milkID+`","quantity":250,"unit":"ml","location":"fridge","best_before":"2025-03-01"}`, http.StatusCreated, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, pantryURL, `{"item_id":"`+saltID+`"}`, http.StatusCreated, nil)
+
+		var fridge []pantryItemResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, pantryURL+"?location=fridge", "", http.StatusOK, &fridge)
+		if len(fridge) != 1 || fridge[0].ItemName != "Milk" || fridge[0].BestBefore == nil || *fridge[0].BestBefore != "2025-03-01" {
+			t.Fatalf("fridge=%+v", fridge)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodGet, pantryURL+"?location=attic", "", http.StatusBadRequest, nil)
+	})
+
+	listURL := func() string {
+		t.Helper()
+		var list testShoppingListResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists", `{"list_date":"2025-02-09","name":"Shop","notes":null}`, http.StatusCreated, &list)
+		return server.URL + "/api/v1/shopping-lists/" + list.ID + "/items"
+	}
+
+	t.Run("exclude_pantry subtracts stock", func(t *testing.T) {
+		var items []testShoppingListItem
+		doRevisionsRequest(t, client, csrf, http.MethodPost, listURL()+"/from-recipes", `{"recipe_ids":["`+recipe.ID+`"]}`, http.StatusOK, &items)
+		if len(items) != 4 {
+			t.Fatalf("items=%d, want all 4 without exclude_pantry", len(items))
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, listURL()+"/from-recipes", `{"recipe_ids":["`+recipe.ID+`"],"exclude_pantry":true}`, http.StatusOK, &items)
+		if findListItem(items, "Flour") != nil || findListItem(items, "Salt") != nil {
+			t.Fatalf("items=%+v, flour and salt are stocked", items)
+		}
+		milk := findListItem(items, "Milk")
+		if milk == nil || milk.Quantity == nil || *milk.Quantity != 0.9433 {
+			t.Fatalf("milk=%+v, want 0.9433 cup", milk)
+		}
+		if eggs := findListItem(items, "Eggs"); eggs == nil || *eggs.Quantity != 3 {
+			t.Fatalf("eggs=%+v, want 3", eggs)
+		}
+	})
+
+	t.Run("exclude_pantry counts stock once per list", func(t *testing.T) {
+		url := listURL() + "/from-recipes"
+		body := `{"recipe_ids":["` + recipe.ID + `"],"exclude_pantry":true}`
+		var items []testShoppingListItem
+		doRevisionsRequest(t, client, csrf, http.MethodPost, url, body, http.StatusOK, &items)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, url, body, http.StatusOK, &items)
+
+		// 1 kg of flour covers both 500 g batches; the milk stock only the first.
+		if findListItem(items, "Flour") != nil {
+			t.Fatalf("items=%+v, flour covers two batches", items)
+		}
+		if milk := findListItem(items, "Milk"); milk == nil || milk.Quantity == nil || *milk.Quantity != 2.9433 {
+			t.Fatalf("milk=%+v, want 2.9433 cup", milk)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, url, body, http.StatusOK, &items)
+		if flour := findListItem(items, "Flour"); flour == nil || flour.Quantity == nil || *flour.Quantity != 500 {
+			t.Fatalf("flour=%+v, want 500 g once the stock is used up", flour)
+		}
+		if milk := findListItem(items, "Milk"); milk == nil || *milk.Quantity != 4.9433 {
+			t.Fatalf("milk=%+v, want 4.9433 cup", milk)
+		}
+	})
+
+	t.Run("purchase restocks the pantry once", func(t *testing.T) {
+		url := listURL()
+		var items []testShoppingListItem
+		doRevisionsRequest(t, client, csrf, http.MethodPost, url, `{"items":[{"item_id":"`+eggsID+`","quantity":12}]}`, http.StatusOK, &items)
+		itemURL := url + "/" + items[0].ID
+
+		doRevisionsRequest(t, client, csrf, http.MethodPatch, itemURL, `{"is_purchased":false,"restock_pantry":true}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPatch, itemURL, `{"is_purchased":true,"restock_pantry":true,"pantry_location":"garage"}`, http.StatusBadRequest, nil)
+
+		var purchased shoppingListItemPurchaseResponse
+		body := `{"is_purchased":true,"restock_pantry":true,"pantry_location":"fridge"}`
+		doRevisionsRequest(t, client, csrf, http.MethodPatch, itemURL, body, http.StatusOK, &purchased)
+		if !purchased.IsPurchased || purchased.PantryItem == nil || *purchased.PantryItem.Quantity != 12 || purchased.PantryItem.Location != "fridge" {
+			t.Fatalf("purchase=%+v", purchased)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPatch, itemURL, body, http.StatusOK, &purchased)
+		if purchased.PantryItem != nil {
+			t.Fatalf("repeat purchase restocked again: %+v", purchased.PantryItem)
+		}
+
+		var fridge []pantryItemResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, pantryURL+"?location=fridge", "", http.StatusOK, &fridge)
+		if len(fridge) != 2 || fridge[0].ItemName != "Eggs" || *fridge[0].Quantity != 12 {
+			t.Fatalf("fridge=%+v, want eggs restocked once", fridge)
+		}
+	})
+
+	t.Run("update and delete entries", func(t *testing.T) {
+		var updated pantryItemResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPut, pantryURL+"/"+flourStock.ID, `{"quantity":200,"unit":"g","location":"pantry","best_before":"2026-01-01"}`, http.StatusOK, &updated)
+		if *updated.Quantity != 200 || *updated.Unit != "g" || *updated.BestBefore != "2026-01-01" {
+			t.Fatalf("updated=%+v", updated)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPut, pantryURL+"/"+uuid.NewString(), `{"quantity":1}`, http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, pantryURL+"/"+flourStock.ID, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, pantryURL+"/"+flourStock.ID, "", http.StatusNotFound, nil)
+	})
+}
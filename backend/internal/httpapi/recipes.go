@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
 	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
 )
 
@@ -17,41 +19,74 @@ type recipeTagResponse struct {
 	Name string `json:"name"`
 }
 
+// recipeIngredientResponse is one ingredient line. Exactly one of Item and
+// SubRecipe is set.
 type recipeIngredientResponse struct {
-	ID           string       `json:"id"`
-	Position     int          `json:"position"`
-	Quantity     *float64     `json:"quantity"`
-	QuantityText *string      `json:"quantity_text"`
-	Unit         *string      `json:"unit"`
-	Item         itemResponse `json:"item"`
-	Prep         *string      `json:"prep"`
-	Notes        *string      `json:"notes"`
-	OriginalText *string      `json:"original_text"`
+	ID           string                   `json:"id"`
+	Position     int                      `json:"position"`
+	GroupName    *string                  `json:"group_name"`
+	Quantity     *float64                 `json:"quantity"`
+	QuantityText *string                  `json:"quantity_text"`
+	Unit         *string                  `json:"unit"`
+	Item         *itemResponse            `json:"item"`
+	SubRecipe    *recipeSubRecipeResponse `json:"sub_recipe"`
+	Prep         *string                  `json:"prep"`
+	Notes        *string                  `json:"notes"`
+	OriginalText *string                  `json:"original_text"`
 }
 
+// recipeSubRecipeResponse is a recipe used as an ingredient, with its own
+// ingredients for one full batch.
+type recipeSubRecipeResponse struct {
+	ID          string                     `json:"id"`
+	Title       string                     `json:"title"`
+	Servings    int32                      `json:"servings"`
+	Deleted     bool                       `json:"deleted"`
+	Ingredients []recipeIngredientResponse `json:"ingredients"`
+}
+
+// maxSubRecipeDepth bounds how deeply recipe detail expands nested
+// sub-recipes. Writes already reject cycles; this only guards the read path.
+const maxSubRecipeDepth = 8
+
 type recipeStepResponse struct {
-	ID          string `json:"id"`
-	StepNumber  int    `json:"step_number"`
-	Instruction string `json:"instruction"`
+	ID                 string  `json:"id"`
+	StepNumber         int     `json:"step_number"`
+	GroupName          *string `json:"group_name"`
+	Instruction        string  `json:"instruction"`
+	DurationSeconds    *int32  `json:"duration_seconds"`
+	DurationMaxSeconds *int32  `json:"duration_max_seconds"`
+	Temperature        *int32  `json:"temperature"`
+	TemperatureUnit    *string `json:"temperature_unit"`
 }
 
 type recipeDetailResponse struct {
-	ID               string                     `json:"id"`
-	Title            string                     `json:"title"`
-	Servings         int32                      `json:"servings"`
-	PrepTimeMinutes  int32                      `json:"prep_time_minutes"`
-	TotalTimeMinutes int32                      `json:"total_time_minutes"`
-	SourceURL        *string                    `json:"source_url"`
-	Notes            *string                    `json:"notes"`
-	RecipeBookID     *string                    `json:"recipe_book_id"`
-	Tags             []recipeTagResponse        `json:"tags"`
-	Ingredients      []recipeIngredientResponse `json:"ingredients"`
-	Steps            []recipeStepResponse       `json:"steps"`
-	CreatedAt        string                     `json:"created_at"`
-	CreatedBy        string                     `json:"created_by"`
-	UpdatedAt        string                     `json:"updated_at"`
-	UpdatedBy        string                     `json:"updated_by"`
-	DeletedAt        *string                    `json:"deleted_at"`
+	ID                 string                     `json:"id"`
+	Title              string                     `json:"title"`
+	Servings           int32                      `json:"servings"`
+	ScaledFromServings *int32                     `json:"scaled_from_servings"`
+	PrepTimeMinutes    int32                      `json:"prep_time_minutes"`
+	TotalTimeMinutes   int32                      `json:"total_time_minutes"`
+	SourceURL          *string                    `json:"source_url"`
+	Notes              *string                    `json:"notes"`
+	RecipeBookID       *string                    `json:"recipe_book_id"`
+	ParentRecipe       *recipeLineageResponse     `json:"parent_recipe"`
+	Variants           []recipeLineageResponse    `json:"variants"`
+	Tags               []recipeTagResponse        `json:"tags"`
+	Ingredients        []recipeIngredientResponse `json:"ingredients"`
+	Steps              []recipeStepResponse       `json:"steps"`
+	Images             []recipeImageResponse      `json:"images"`
+	Nutrition          recipeNutritionResponse    `json:"nutrition"`
+	Cost               recipeCostResponse         `json:"cost"`
+	IsFavorite         bool                       `json:"is_favorite"`
+	PersonalNote       *string                    `json:"personal_note"`
+	CreatedAt          string                     `json:"created_at"`
+	CreatedBy          string                     `json:"created_by"`
+	UpdatedAt          string                     `json:"updated_at"`
+	UpdatedBy          string                     `json:"updated_by"`
+	DeletedAt          *string                    `json:"deleted_at"`
+	recipeCookStatsResponse
+	recipeDietaryResponse
 }
 
 func (a *App) handleRecipesCreate(w http.ResponseWriter, r *http.Request) error {
@@ -65,6 +100,7 @@ func (a *App) handleRecipesCreate(w http.ResponseWriter, r *http.Request) error
 		return err
 	}
 
+	fillParsedIngredients(&req)
 	if errs := validateCreateRecipeRequest(req); len(errs) > 0 {
 		return errValidation(errs)
 	}
@@ -72,24 +108,25 @@ func (a *App) handleRecipesCreate(w http.ResponseWriter, r *http.Request) error
 	ctx := r.Context()
 	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
 
-	recipeID, err := createRecipeUsecase(ctx, a.recipeWorkflows(), userID, req)
+	recipeID, matches, err := createRecipeUsecase(ctx, a.recipeWorkflows(), userID, req)
 	if err != nil {
 		return mapRecipeUsecaseError(err)
 	}
 
-	detail, err := a.loadRecipeDetail(ctx, recipeID)
+	detail, err := a.loadRecipeDetail(ctx, recipeID, userID)
 	if err != nil {
 		return errInternal(err)
 	}
 
-	if err := response.WriteJSON(w, http.StatusCreated, detail); err != nil {
+	if err := response.WriteJSON(w, http.StatusCreated, newRecipeSaveResponse(detail, matches)); err != nil {
 		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes")
 	}
 	return nil
 }
 
 func (a *App) handleRecipesGet(w http.ResponseWriter, r *http.Request) error {
-	if _, ok := authInfoFromRequest(r); !ok {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
 		return errUnauthorized("unauthorized")
 	}
 
@@ -98,13 +135,19 @@ func (a *App) handleRecipesGet(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	detail, err := a.loadRecipeDetail(r.Context(), pgtype.UUID{Bytes: id, Valid: true})
+	servings, err := parseServingsQuery(r)
+	if err != nil {
+		return err
+	}
+
+	detail, err := a.loadRecipeDetail(r.Context(), pgtype.UUID{Bytes: id, Valid: true}, pgtype.UUID{Bytes: info.UserID, Valid: true})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return errNotFound()
 		}
 		return errInternal(err)
 	}
+	scaleRecipeDetail(&detail, servings)
 
 	if err := response.WriteJSON(w, http.StatusOK, detail); err != nil {
 		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}")
@@ -112,13 +155,15 @@ func (a *App) handleRecipesGet(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-func (a *App) loadRecipeDetail(ctx context.Context, id pgtype.UUID) (recipeDetailResponse, error) {
+// loadRecipeDetail assembles a recipe's detail response. userID selects the
+// caller's favorite flag and personal note.
+func (a *App) loadRecipeDetail(ctx context.Context, id, userID pgtype.UUID) (recipeDetailResponse, error) {
 	row, err := a.queries.GetRecipeByID(ctx, id)
 	if err != nil {
 		return recipeDetailResponse{}, err
 	}
 
-	ingredients, err := a.queries.ListRecipeIngredientsByRecipeID(ctx, id)
+	outIngredients, err := a.loadRecipeIngredients(ctx, id, 0)
 	if err != nil {
 		return recipeDetailResponse{}, err
 	}
@@ -130,50 +175,66 @@ func (a *App) loadRecipeDetail(ctx context.Context, id pgtype.UUID) (recipeDetai
 	if err != nil {
 		return recipeDetailResponse{}, err
 	}
-
-	outIngredients := make([]recipeIngredientResponse, 0, len(ingredients))
-	for _, ing := range ingredients {
-		var quantity *float64
-		if ing.Quantity.Valid {
-			f8, err := ing.Quantity.Float64Value()
-			if err != nil {
-				return recipeDetailResponse{}, err
-			}
-			if f8.Valid {
-				q := f8.Float64
-				quantity = &q
-			}
-		}
-		outIngredients = append(outIngredients, recipeIngredientResponse{
-			ID:           uuidString(ing.ID),
-			Position:     int(ing.Position),
-			Quantity:     quantity,
-			QuantityText: textStringPtr(ing.QuantityText),
-			Unit:         textStringPtr(ing.Unit),
-			Item: itemResponse{
-				ID:       uuidString(ing.ItemID),
-				Name:     ing.ItemName,
-				StoreURL: textStringPtr(ing.ItemStoreUrl),
-				Aisle: buildAisleResponse(
-					ing.ItemAisleID,
-					ing.AisleName,
-					ing.AisleSortGroup,
-					ing.AisleSortOrder,
-					ing.AisleNumericValue,
-				),
-			},
-			Prep:         textStringPtr(ing.Prep),
-			Notes:        textStringPtr(ing.Notes),
-			OriginalText: textStringPtr(ing.OriginalText),
-		})
+	images, err := a.queries.ListRecipeImagesByRecipeID(ctx, id)
+	if err != nil {
+		return recipeDetailResponse{}, err
+	}
+	nutritionRows, err := a.queries.ListItemNutritionByRecipeID(ctx, id)
+	if err != nil {
+		return recipeDetailResponse{}, err
+	}
+	nutritionRefs, err := itemNutritionReferences(nutritionRows)
+	if err != nil {
+		return recipeDetailResponse{}, err
+	}
+	priceRows, err := a.queries.ListLatestItemPricesByRecipeID(ctx, id)
+	if err != nil {
+		return recipeDetailResponse{}, err
+	}
+	priceRefs, err := itemPriceReferences(priceRows)
+	if err != nil {
+		return recipeDetailResponse{}, err
+	}
+	statsRow, err := a.queries.GetRecipeCookStats(ctx, id)
+	if err != nil {
+		return recipeDetailResponse{}, err
+	}
+	cookStats, err := recipeCookStats(statsRow.LastCookedAt, statsRow.TimesCooked, statsRow.AvgRating)
+	if err != nil {
+		return recipeDetailResponse{}, err
+	}
+	isFavorite, err := a.queries.IsRecipeFavorite(ctx, sqlc.IsRecipeFavoriteParams{UserID: userID, RecipeID: id})
+	if err != nil {
+		return recipeDetailResponse{}, err
+	}
+	parent, variants, err := a.loadRecipeLineage(ctx, row)
+	if err != nil {
+		return recipeDetailResponse{}, err
+	}
+	dietaryByRecipeID, err := a.loadRecipeDietaryItems(ctx, []pgtype.UUID{id})
+	if err != nil {
+		return recipeDetailResponse{}, err
+	}
+	var personalNote *string
+	noteRow, err := a.queries.GetRecipeUserNote(ctx, sqlc.GetRecipeUserNoteParams{UserID: userID, RecipeID: id})
+	switch {
+	case err == nil:
+		personalNote = &noteRow.Note
+	case !errors.Is(err, pgx.ErrNoRows):
+		return recipeDetailResponse{}, err
 	}
 
 	outSteps := make([]recipeStepResponse, 0, len(steps))
 	for _, s := range steps {
 		outSteps = append(outSteps, recipeStepResponse{
-			ID:          uuidString(s.ID),
-			StepNumber:  int(s.StepNumber),
-			Instruction: s.Instruction,
+			ID:                 uuidString(s.ID),
+			StepNumber:         int(s.StepNumber),
+			GroupName:          textStringPtr(s.GroupName),
+			Instruction:        s.Instruction,
+			DurationSeconds:    int32PtrFromPG(s.DurationSeconds),
+			DurationMaxSeconds: int32PtrFromPG(s.DurationMaxSeconds),
+			Temperature:        int32PtrFromPG(s.Temperature),
+			TemperatureUnit:    textStringPtr(s.TemperatureUnit),
 		})
 	}
 
@@ -191,17 +252,94 @@ func (a *App) loadRecipeDetail(ctx context.Context, id pgtype.UUID) (recipeDetai
 		SourceURL:        textStringPtr(row.SourceUrl),
 		Notes:            textStringPtr(row.Notes),
 		RecipeBookID:     uuidStringPtr(row.RecipeBookID),
+		ParentRecipe:     parent,
+		Variants:         variants,
 		Tags:             outTags,
 		Ingredients:      outIngredients,
 		Steps:            outSteps,
+		Images:           recipeImageResponses(images),
+		Nutrition:        computeRecipeNutrition(uuidString(row.ID), row.Servings, outIngredients, nutritionRefs),
+		Cost:             computeRecipeCost(uuidString(row.ID), row.Servings, outIngredients, priceRefs),
+		IsFavorite:       isFavorite,
+		PersonalNote:     personalNote,
 		CreatedAt:        timeString(row.CreatedAt),
 		CreatedBy:        uuidString(row.CreatedBy),
 		UpdatedAt:        timeString(row.UpdatedAt),
 		UpdatedBy:        uuidString(row.UpdatedBy),
 		DeletedAt:        timeStringPtr(row.DeletedAt),
+
+		recipeCookStatsResponse: cookStats,
+		recipeDietaryResponse:   dietaryLabelsFromItems(dietaryByRecipeID[uuidString(id)]),
 	}, nil
 }
 
+// loadRecipeIngredients loads a recipe's ingredient lines, expanding
+// sub-recipes into their own ingredients.
+func (a *App) loadRecipeIngredients(ctx context.Context, id pgtype.UUID, depth int) ([]recipeIngredientResponse, error) {
+	ingredients, err := a.queries.ListRecipeIngredientsByRecipeID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]recipeIngredientResponse, 0, len(ingredients))
+	for _, ing := range ingredients {
+		var quantity *float64
+		if ing.Quantity.Valid {
+			f8, err := ing.Quantity.Float64Value()
+			if err != nil {
+				return nil, err
+			}
+			if f8.Valid {
+				q := f8.Float64
+				quantity = &q
+			}
+		}
+		resp := recipeIngredientResponse{
+			ID:           uuidString(ing.ID),
+			Position:     int(ing.Position),
+			GroupName:    textStringPtr(ing.GroupName),
+			Quantity:     quantity,
+			QuantityText: textStringPtr(ing.QuantityText),
+			Unit:         textStringPtr(ing.Unit),
+			Prep:         textStringPtr(ing.Prep),
+			Notes:        textStringPtr(ing.Notes),
+			OriginalText: textStringPtr(ing.OriginalText),
+		}
+		if ing.SubRecipeID.Valid {
+			sub := &recipeSubRecipeResponse{
+				ID:          uuidString(ing.SubRecipeID),
+				Title:       ing.SubRecipeTitle.String,
+				Servings:    ing.SubRecipeServings.Int32,
+				Deleted:     ing.SubRecipeDeleted,
+				Ingredients: []recipeIngredientResponse{},
+			}
+			// Deleted sub-recipes are not expanded, matching the shopping list.
+			if !sub.Deleted && depth < maxSubRecipeDepth {
+				sub.Ingredients, err = a.loadRecipeIngredients(ctx, ing.SubRecipeID, depth+1)
+				if err != nil {
+					return nil, err
+				}
+			}
+			resp.SubRecipe = sub
+		} else {
+			resp.Item = &itemResponse{
+				ID:       uuidString(ing.ItemID),
+				Name:     ing.ItemName.String,
+				StoreURL: textStringPtr(ing.ItemStoreUrl),
+				Aisle: buildAisleResponse(
+					ing.ItemAisleID,
+					ing.AisleName,
+					ing.AisleSortGroup,
+					ing.AisleSortOrder,
+					ing.AisleNumericValue,
+				),
+			}
+		}
+		out = append(out, resp)
+	}
+	return out, nil
+}
+
 func textPtrToPG(value *string) pgtype.Text {
 	if value == nil {
 		return pgtype.Text{}
@@ -213,6 +351,20 @@ func textPtrToPG(value *string) pgtype.Text {
 	return pgtype.Text{String: trimmed, Valid: true}
 }
 
+func int32PtrToPG(value *int32) pgtype.Int4 {
+	if value == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *value, Valid: true}
+}
+
+// temperatureUnitToPG stores a step temperature unit as "F" or "C".
+func temperatureUnitToPG(value *string) pgtype.Text {
+	unit := textPtrToPG(value)
+	unit.String = strings.ToUpper(unit.String)
+	return unit
+}
+
 func textStringPtr(v pgtype.Text) *string {
 	if !v.Valid {
 		return nil
@@ -233,8 +385,9 @@ func numericPtrFromFloat64(v *float64) (pgtype.Numeric, error) {
 	if v == nil {
 		return pgtype.Numeric{}, nil
 	}
+	// Numeric.Scan only accepts text, so format the float first.
 	var n pgtype.Numeric
-	if err := n.Scan(*v); err != nil {
+	if err := n.Scan(strconv.FormatFloat(*v, 'f', -1, 64)); err != nil {
 		return pgtype.Numeric{}, err
 	}
 	return n, nil
@@ -0,0 +1,326 @@
+package httpapi
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+const (
+	maxCookRating     = 5
+	maxCookNotesRunes = 2000
+)
+
+// recipeCookStatsResponse summarizes a recipe's cook log. It is embedded in
+// recipe list items and recipe detail.
+type recipeCookStatsResponse struct {
+	LastCookedAt *string  `json:"last_cooked_at"`
+	TimesCooked  int32    `json:"times_cooked"`
+	AvgRating    *float64 `json:"avg_rating"`
+}
+
+type recipeCookEventRequest struct {
+	CookedAt *string `json:"cooked_at"`
+	Servings *int32  `json:"servings"`
+	Rating   *int32  `json:"rating"`
+	Notes    *string `json:"notes"`
+}
+
+type recipeCookEventResponse struct {
+	ID        string  `json:"id"`
+	RecipeID  string  `json:"recipe_id"`
+	CookedAt  string  `json:"cooked_at"`
+	Servings  *int32  `json:"servings"`
+	Rating    *int32  `json:"rating"`
+	Notes     *string `json:"notes"`
+	CreatedAt string  `json:"created_at"`
+	CreatedBy string  `json:"created_by"`
+	UpdatedAt string  `json:"updated_at"`
+	UpdatedBy string  `json:"updated_by"`
+}
+
+// recipeCookEventInput is a validated cook event request. A zero cookedAt
+// means "now" on create and "unchanged" on update.
+type recipeCookEventInput struct {
+	cookedAt pgtype.Timestamptz
+	servings pgtype.Int4
+	rating   pgtype.Int4
+	notes    pgtype.Text
+}
+
+func (a *App) handleRecipeCooksList(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	recipeID := pgtype.UUID{Bytes: id, Valid: true}
+
+	if _, err := a.queries.GetRecipeDeletedAtByID(r.Context(), recipeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	rows, err := a.queries.ListRecipeCookEventsByRecipeID(r.Context(), recipeID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	out := make([]recipeCookEventResponse, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, recipeCookEventResponseFromRow(row))
+	}
+	if err := response.WriteJSON(w, http.StatusOK, out); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/cooks")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeCooksCreate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req recipeCookEventRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+	input, err := parseRecipeCookEventRequest(req)
+	if err != nil {
+		return err
+	}
+
+	row, err := a.queries.CreateRecipeCookEvent(r.Context(), sqlc.CreateRecipeCookEventParams{
+		RecipeID: pgtype.UUID{Bytes: id, Valid: true},
+		CookedAt: input.cookedAt,
+		Servings: input.servings,
+		Rating:   input.rating,
+		Notes:    input.notes,
+		UserID:   pgtype.UUID{Bytes: info.UserID, Valid: true},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusCreated, recipeCookEventResponseFromRow(row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/cooks")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeCooksGet(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	cookID, err := parseUUIDParam(r, "cook_id")
+	if err != nil {
+		return err
+	}
+
+	row, err := a.queries.GetRecipeCookEvent(r.Context(), sqlc.GetRecipeCookEventParams{
+		RecipeID: pgtype.UUID{Bytes: id, Valid: true},
+		ID:       pgtype.UUID{Bytes: cookID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, recipeCookEventResponseFromRow(row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/cooks/{cook_id}")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeCooksUpdate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	cookID, err := parseUUIDParam(r, "cook_id")
+	if err != nil {
+		return err
+	}
+
+	var req recipeCookEventRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+	input, err := parseRecipeCookEventRequest(req)
+	if err != nil {
+		return err
+	}
+
+	row, err := a.queries.UpdateRecipeCookEvent(r.Context(), sqlc.UpdateRecipeCookEventParams{
+		CookedAt:  input.cookedAt,
+		Servings:  input.servings,
+		Rating:    input.rating,
+		Notes:     input.notes,
+		UpdatedBy: pgtype.UUID{Bytes: info.UserID, Valid: true},
+		RecipeID:  pgtype.UUID{Bytes: id, Valid: true},
+		ID:        pgtype.UUID{Bytes: cookID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, recipeCookEventResponseFromRow(row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/cooks/{cook_id}")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeCooksDelete(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	cookID, err := parseUUIDParam(r, "cook_id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeleteRecipeCookEvent(r.Context(), sqlc.DeleteRecipeCookEventParams{
+		RecipeID: pgtype.UUID{Bytes: id, Valid: true},
+		ID:       pgtype.UUID{Bytes: cookID, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+	if affected == 0 {
+		return errNotFound()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// parseRecipeCookEventRequest validates a cook event. cooked_at is optional
+// RFC3339 and may not be in the future; rating is 1-5.
+func parseRecipeCookEventRequest(req recipeCookEventRequest) (recipeCookEventInput, error) {
+	var (
+		input recipeCookEventInput
+		errs  []response.FieldError
+	)
+
+	if req.CookedAt != nil && strings.TrimSpace(*req.CookedAt) != "" {
+		parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(*req.CookedAt))
+		switch {
+		case err != nil:
+			errs = append(errs, response.FieldError{Field: "cooked_at", Message: "cooked_at must be RFC3339"})
+		case parsed.After(time.Now().Add(time.Minute)):
+			errs = append(errs, response.FieldError{Field: "cooked_at", Message: "cooked_at cannot be in the future"})
+		default:
+			input.cookedAt = pgtype.Timestamptz{Time: parsed.UTC(), Valid: true}
+		}
+	}
+
+	if req.Servings != nil {
+		if *req.Servings <= 0 {
+			errs = append(errs, response.FieldError{Field: "servings", Message: "servings must be positive"})
+		} else {
+			input.servings = pgtype.Int4{Int32: *req.Servings, Valid: true}
+		}
+	}
+
+	if req.Rating != nil {
+		if *req.Rating < 1 || *req.Rating > maxCookRating {
+			errs = append(errs, response.FieldError{Field: "rating", Message: "rating must be between 1 and 5"})
+		} else {
+			input.rating = pgtype.Int4{Int32: *req.Rating, Valid: true}
+		}
+	}
+
+	if req.Notes != nil && len([]rune(strings.TrimSpace(*req.Notes))) > maxCookNotesRunes {
+		errs = append(errs, response.FieldError{Field: "notes", Message: "notes is too long"})
+	}
+	input.notes = textPtrToPG(req.Notes)
+
+	if len(errs) > 0 {
+		return recipeCookEventInput{}, errValidation(errs)
+	}
+	return input, nil
+}
+
+func recipeCookEventResponseFromRow(row sqlc.RecipeCookEvent) recipeCookEventResponse {
+	return recipeCookEventResponse{
+		ID:        uuidString(row.ID),
+		RecipeID:  uuidString(row.RecipeID),
+		CookedAt:  timeString(row.CookedAt),
+		Servings:  int32PtrFromPG(row.Servings),
+		Rating:    int32PtrFromPG(row.Rating),
+		Notes:     textStringPtr(row.Notes),
+		CreatedAt: timeString(row.CreatedAt),
+		CreatedBy: uuidString(row.CreatedBy),
+		UpdatedAt: timeString(row.UpdatedAt),
+		UpdatedBy: uuidString(row.UpdatedBy),
+	}
+}
+
+// recipeCookStats builds the cook log summary; the average rating is rounded
+// to one decimal and is null until a cook event has a rating.
+func recipeCookStats(lastCookedAt pgtype.Timestamptz, timesCooked int32, avgRating pgtype.Numeric) (recipeCookStatsResponse, error) {
+	avg, err := float64PtrFromNumeric(avgRating)
+	if err != nil {
+		return recipeCookStatsResponse{}, err
+	}
+	if avg != nil {
+		rounded := math.Round(*avg*10) / 10
+		avg = &rounded
+	}
+	return recipeCookStatsResponse{
+		LastCookedAt: timeStringPtr(lastCookedAt),
+		TimesCooked:  timesCooked,
+		AvgRating:    avg,
+	}, nil
+}
+
+func int32PtrFromPG(v pgtype.Int4) *int32 {
+	if !v.Valid {
+		return nil
+	}
+	n := v.Int32
+	return &n
+}
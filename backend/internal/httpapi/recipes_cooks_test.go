@@ -0,0 +1,164 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type recipeCookEventResponse struct {
+	ID       string  `json:"id"`
+	RecipeID string  `json:"recipe_id"`
+	CookedAt string  `json:"cooked_at"`
+	Servings *int    `json:"servings"`
+	Rating   *int    `json:"rating"`
+	Notes    *string `json:"notes"`
+}
+
+func TestRecipes_Cooks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	createRecipe := func(title string) recipeDetailResponse {
+		var out recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"`+title+`",
+  "servings":4,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[],
+  "steps":[{"step_number":1,"instruction":"Cook."}]
+}`, http.StatusCreated, &out)
+		return out
+	}
+	soup := createRecipe(recipeTitleChickenSoup)
+	stew := createRecipe(recipeTitleBeefStew)
+	if soup.TimesCooked != 0 || soup.LastCookedAt != nil || soup.AvgRating != nil {
+		t.Fatalf("new recipe stats=%d/%v/%v, want empty", soup.TimesCooked, soup.LastCookedAt, soup.AvgRating)
+	}
+	cooksURL := server.URL + "/api/v1/recipes/" + soup.ID + "/cooks"
+
+	var first recipeCookEventResponse
+	t.Run("log cooks", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPost, cooksURL, `{"rating":9}`, http.StatusBadRequest, nil)
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, cooksURL, `{
+  "cooked_at":"2025-06-01T18:30:00Z",
+  "servings":4,
+  "rating":4,
+  "notes":"Needed more salt."
+}`, http.StatusCreated, &first)
+		if first.RecipeID != soup.ID || first.Rating == nil || *first.Rating != 4 || first.Notes == nil {
+			t.Fatalf("cook=%+v", first)
+		}
+
+		var second recipeCookEventResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, cooksURL, `{"rating":5}`, http.StatusCreated, &second)
+		if second.Servings != nil || second.CookedAt == "" {
+			t.Fatalf("cook=%+v, want defaulted cooked_at", second)
+		}
+
+		var out []recipeCookEventResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, cooksURL, "", http.StatusOK, &out)
+		if len(out) != 2 || out[0].ID != second.ID || out[1].ID != first.ID {
+			t.Fatalf("cooks=%+v, want newest first", out)
+		}
+	})
+
+	t.Run("detail and list report cook stats", func(t *testing.T) {
+		var detail recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+soup.ID, "", http.StatusOK, &detail)
+		if detail.TimesCooked != 2 || detail.AvgRating == nil || *detail.AvgRating != 4.5 || detail.LastCookedAt == nil {
+			t.Fatalf("stats=%d/%v/%v", detail.TimesCooked, detail.LastCookedAt, detail.AvgRating)
+		}
+
+		q := url.Values{}
+		q.Set("sort", "-last_cooked")
+		out := getRecipesList(t, client, server.URL, q)
+		if len(out.Items) != 2 || out.Items[0].ID != soup.ID || out.Items[1].ID != stew.ID {
+			t.Fatalf("items=%v, want most recently cooked first", out.Items)
+		}
+		if out.Items[0].TimesCooked != 2 || out.Items[1].TimesCooked != 0 || out.Items[1].LastCookedAt != nil {
+			t.Fatalf("items=%+v", out.Items)
+		}
+
+		q.Set("sort", "last_cooked")
+		q.Set("limit", "1")
+		page1 := getRecipesList(t, client, server.URL, q)
+		if len(page1.Items) != 1 || page1.Items[0].ID != stew.ID || page1.NextCursor == nil {
+			t.Fatalf("page1=%+v, want never-cooked first", page1)
+		}
+		q.Set("cursor", *page1.NextCursor)
+		page2 := getRecipesList(t, client, server.URL, q)
+		if len(page2.Items) != 1 || page2.Items[0].ID != soup.ID {
+			t.Fatalf("page2=%+v, want %s", page2.Items, recipeTitleChickenSoup)
+		}
+	})
+
+	t.Run("update and delete a cook", func(t *testing.T) {
+		var updated recipeCookEventResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPut, cooksURL+"/"+first.ID, `{"rating":2,"notes":null}`, http.StatusOK, &updated)
+		if updated.Rating == nil || *updated.Rating != 2 || updated.Notes != nil || updated.CookedAt != first.CookedAt {
+			t.Fatalf("updated=%+v, want rating 2 with cooked_at kept", updated)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, cooksURL+"/"+first.ID, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodGet, cooksURL+"/"+first.ID, "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, server.URL+"/api/v1/recipes/"+stew.ID+"/cooks/"+first.ID, "", http.StatusNotFound, nil)
+	})
+}
@@ -0,0 +1,93 @@
+package httpapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+func TestParseRecipeCookEventRequest(t *testing.T) {
+	t.Parallel()
+
+	servings, rating := int32(4), int32(5)
+	input, err := parseRecipeCookEventRequest(recipeCookEventRequest{
+		CookedAt: stringPtr("2025-06-01T18:30:00-04:00"),
+		Servings: &servings,
+		Rating:   &rating,
+		Notes:    stringPtr("  Needed more salt.  "),
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !input.cookedAt.Valid || !input.cookedAt.Time.Equal(time.Date(2025, 6, 1, 22, 30, 0, 0, time.UTC)) {
+		t.Fatalf("cooked_at=%v", input.cookedAt)
+	}
+	if input.servings.Int32 != 4 || input.rating.Int32 != 5 || input.notes.String != "Needed more salt." {
+		t.Fatalf("input=%+v", input)
+	}
+
+	empty, err := parseRecipeCookEventRequest(recipeCookEventRequest{})
+	if err != nil {
+		t.Fatalf("parse empty: %v", err)
+	}
+	if empty.cookedAt.Valid || empty.servings.Valid || empty.rating.Valid || empty.notes.Valid {
+		t.Fatalf("empty=%+v, want all null", empty)
+	}
+}
+
+func TestParseRecipeCookEventRequestRejectsInvalid(t *testing.T) {
+	t.Parallel()
+
+	zero, six := int32(0), int32(6)
+	future := time.Now().Add(48 * time.Hour).Format(time.RFC3339)
+	_, err := parseRecipeCookEventRequest(recipeCookEventRequest{
+		CookedAt: &future,
+		Servings: &zero,
+		Rating:   &six,
+	})
+	apiErr, ok := asAPIError(err)
+	if !ok || apiErr.kind != apiErrorValidation {
+		t.Fatalf("err=%v, want validation error", err)
+	}
+	details, _ := apiErr.details.([]response.FieldError)
+	fields := map[string]bool{}
+	for _, d := range details {
+		fields[d.Field] = true
+	}
+	for _, want := range []string{"cooked_at", "servings", "rating"} {
+		if !fields[want] {
+			t.Fatalf("details=%+v, want %s error", details, want)
+		}
+	}
+
+	if _, err := parseRecipeCookEventRequest(recipeCookEventRequest{CookedAt: stringPtr("last tuesday")}); err == nil {
+		t.Fatalf("expected error for non-RFC3339 cooked_at")
+	}
+}
+
+func TestRecipeCookStatsRoundsAverage(t *testing.T) {
+	t.Parallel()
+
+	var avg pgtype.Numeric
+	if err := avg.Scan("4.3333333333333333"); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	cooked := pgtype.Timestamptz{Time: time.Date(2025, 6, 1, 22, 30, 0, 0, time.UTC), Valid: true}
+	stats, err := recipeCookStats(cooked, 3, avg)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.AvgRating == nil || *stats.AvgRating != 4.3 || stats.TimesCooked != 3 || stats.LastCookedAt == nil {
+		t.Fatalf("stats=%+v", stats)
+	}
+
+	never, err := recipeCookStats(pgtype.Timestamptz{}, 0, pgtype.Numeric{})
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if never.AvgRating != nil || never.LastCookedAt != nil || never.TimesCooked != 0 {
+		t.Fatalf("never=%+v, want empty stats", never)
+	}
+}
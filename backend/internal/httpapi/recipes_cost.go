@@ -0,0 +1,152 @@
+package httpapi
+
+import (
+	"math"
+
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+)
+
+// Reasons an ingredient or list item is left out of a cost estimate.
+const (
+	costGapMissingPrice    = "missing_price"
+	costGapMissingQuantity = "missing_quantity"
+	costGapUnitMismatch    = "unit_mismatch"
+	costGapSubRecipe       = "sub_recipe"
+)
+
+const costPrecision = 100
+
+type recipeCostGapResponse struct {
+	IngredientID string `json:"ingredient_id"`
+	ItemName     string `json:"item_name"`
+	Reason       string `json:"reason"`
+}
+
+// recipeCostResponse estimates what a recipe costs from each item's latest
+// price. Like nutrition, Total only sums the ingredients that could be
+// priced; Complete is false when Uncounted lists any.
+type recipeCostResponse struct {
+	Total      float64                 `json:"total"`
+	PerServing float64                 `json:"per_serving"`
+	Complete   bool                    `json:"complete"`
+	Uncounted  []recipeCostGapResponse `json:"uncounted"`
+}
+
+type shoppingListCostGapResponse struct {
+	ItemID   string `json:"item_id"`
+	ItemName string `json:"item_name"`
+	Reason   string `json:"reason"`
+}
+
+// shoppingListCostResponse totals the estimated cost of every item on a list.
+type shoppingListCostResponse struct {
+	Total     float64                       `json:"total"`
+	Complete  bool                          `json:"complete"`
+	Uncounted []shoppingListCostGapResponse `json:"uncounted"`
+}
+
+// itemPriceReference is an item's latest price for a package amount.
+type itemPriceReference struct {
+	quantity float64
+	unit     string
+	price    float64
+}
+
+func itemPriceReferences(rows []sqlc.ItemPrice) (map[string]itemPriceReference, error) {
+	refs := make(map[string]itemPriceReference, len(rows))
+	for _, row := range rows {
+		resp, err := itemPriceResponseFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		refs[resp.ItemID] = itemPriceReference{
+			quantity: resp.Quantity,
+			unit:     resp.Unit,
+			price:    resp.Price,
+		}
+	}
+	return refs, nil
+}
+
+// estimateCost prices quantity of unit against an item's reference. It
+// returns the gap reason when the amount cannot be priced.
+func estimateCost(quantity *float64, unit *string, ref itemPriceReference, priced bool) (float64, string) {
+	switch {
+	case !priced:
+		return 0, costGapMissingPrice
+	case quantity == nil:
+		return 0, costGapMissingQuantity
+	}
+	factor, ok := referenceFactor(*quantity, unit, ref.quantity, ref.unit)
+	if !ok {
+		return 0, costGapUnitMismatch
+	}
+	return ref.price * factor, ""
+}
+
+// computeRecipeCost sums ingredient costs, including those of sub-recipes,
+// and divides by servings. Sub-recipes are expanded as for nutrition.
+func computeRecipeCost(recipeID string, servings int32, ingredients []recipeIngredientResponse, refs map[string]itemPriceReference) recipeCostResponse {
+	out := recipeCostResponse{Uncounted: []recipeCostGapResponse{}}
+	lines, unexpanded := expandRecipeIngredients(recipeID, ingredients)
+	var total float64
+	for _, line := range lines {
+		ing := line.ingredient
+		ref, ok := refs[ing.Item.ID]
+		cost, reason := estimateCost(ing.Quantity, ing.Unit, ref, ok)
+		if reason != "" {
+			out.Uncounted = append(out.Uncounted, recipeCostGapResponse{
+				IngredientID: ing.ID,
+				ItemName:     ing.Item.Name,
+				Reason:       reason,
+			})
+			continue
+		}
+		total += cost * line.factor
+	}
+	for _, ing := range unexpanded {
+		out.Uncounted = append(out.Uncounted, recipeCostGapResponse{
+			IngredientID: ing.ID,
+			ItemName:     ing.SubRecipe.Title,
+			Reason:       costGapSubRecipe,
+		})
+	}
+
+	out.Total = roundCost(total)
+	if servings > 0 {
+		out.PerServing = roundCost(total / float64(servings))
+	}
+	out.Complete = len(out.Uncounted) == 0
+	return out
+}
+
+// estimateShoppingListCost sets EstimatedCost on each list item that can be
+// priced and returns the list total.
+func estimateShoppingListCost(items []shoppingListItemResponse, refs map[string]itemPriceReference) shoppingListCostResponse {
+	out := shoppingListCostResponse{Uncounted: []shoppingListCostGapResponse{}}
+	var total float64
+	for i := range items {
+		item := &items[i]
+		ref, ok := refs[item.Item.ID]
+		cost, reason := estimateCost(item.Quantity, item.Unit, ref, ok)
+		if reason != "" {
+			out.Uncounted = append(out.Uncounted, shoppingListCostGapResponse{
+				ItemID:   item.Item.ID,
+				ItemName: item.Item.Name,
+				Reason:   reason,
+			})
+			continue
+		}
+		rounded := roundCost(cost)
+		item.EstimatedCost = &rounded
+		total += cost
+	}
+
+	out.Total = roundCost(total)
+	out.Complete = len(out.Uncounted) == 0
+	return out
+}
+
+func roundCost(v float64) float64 {
+	return math.Round(v*costPrecision) / costPrecision
+}
@@ -0,0 +1,107 @@
+package httpapi
+
+import (
+	"testing"
+)
+
+func TestComputeRecipeCost(t *testing.T) {
+	t.Parallel()
+
+	qty := func(v float64) *float64 { return &v }
+	refs := map[string]itemPriceReference{
+		"flour":  {quantity: 2, unit: "kg", price: 3.5},
+		"egg":    {quantity: 12, unit: "piece", price: 4.2},
+		"butter": {quantity: 1, unit: "stick", price: 0.9},
+	}
+	ingredients := []recipeIngredientResponse{
+		{ID: "ing-flour", Quantity: qty(500), Unit: stringPtr("g"), Item: &itemResponse{ID: "flour", Name: "Flour"}},
+		{ID: "ing-egg", Quantity: qty(3), Item: &itemResponse{ID: "egg", Name: "Egg"}},
+		{ID: "ing-butter", Quantity: qty(2), Unit: stringPtr("Stick"), Item: &itemResponse{ID: "butter", Name: "Butter"}},
+		{ID: "ing-egg-wash", QuantityText: stringPtr("a little"), Item: &itemResponse{ID: "egg", Name: "Egg"}},
+		{ID: "ing-flour-cup", Quantity: qty(1), Unit: stringPtr("cup"), Item: &itemResponse{ID: "flour", Name: "Flour"}},
+		{ID: "ing-salt", Quantity: qty(1), Unit: stringPtr("tsp"), Item: &itemResponse{ID: "salt", Name: "Salt"}},
+		// Half of a two-serving dough made with 1 kg of flour.
+		{ID: "ing-dough", Quantity: qty(1), SubRecipe: &recipeSubRecipeResponse{ID: "dough", Title: "Pizza dough", Servings: 2, Ingredients: []recipeIngredientResponse{
+			{ID: "ing-dough-flour", Quantity: qty(1), Unit: stringPtr("kg"), Item: &itemResponse{ID: "flour", Name: "Flour"}},
+			{ID: "ing-dough-yeast", Quantity: qty(7), Unit: stringPtr("g"), Item: &itemResponse{ID: "yeast", Name: "Yeast"}},
+		}}},
+		{ID: "ing-self", Quantity: qty(1), SubRecipe: &recipeSubRecipeResponse{ID: "pie", Title: "Pie", Servings: 4}},
+		// A deleted sub-recipe is a gap, as it is left off shopping lists.
+		{ID: "ing-glaze", Quantity: qty(1), SubRecipe: &recipeSubRecipeResponse{ID: "glaze", Title: "Glaze", Servings: 1, Deleted: true, Ingredients: []recipeIngredientResponse{
+			{ID: "ing-glaze-butter", Quantity: qty(1), Unit: stringPtr("stick"), Item: &itemResponse{ID: "butter", Name: "Butter"}},
+		}}},
+	}
+
+	got := computeRecipeCost("pie", 4, ingredients, refs)
+
+	// 0.875 flour + 1.05 eggs + 1.80 butter + 0.875 flour in the dough.
+	if got.Total != 4.6 || got.PerServing != 1.15 {
+		t.Fatalf("cost=%+v, want total 4.60 and 1.15 per serving", got)
+	}
+	if got.Complete {
+		t.Fatalf("complete=true, want false")
+	}
+	wantGaps := map[string]string{
+		"ing-egg-wash":    costGapMissingQuantity,
+		"ing-flour-cup":   costGapUnitMismatch,
+		"ing-salt":        costGapMissingPrice,
+		"ing-dough-yeast": costGapMissingPrice,
+		"ing-self":        costGapSubRecipe,
+		"ing-glaze":       costGapSubRecipe,
+	}
+	if len(got.Uncounted) != len(wantGaps) {
+		t.Fatalf("uncounted=%+v", got.Uncounted)
+	}
+	for _, gap := range got.Uncounted {
+		if wantGaps[gap.IngredientID] != gap.Reason {
+			t.Fatalf("gap %s reason=%q, want %q", gap.IngredientID, gap.Reason, wantGaps[gap.IngredientID])
+		}
+	}
+}
+
+func TestEstimateShoppingListCost(t *testing.T) {
+	t.Parallel()
+
+	qty := func(v float64) *float64 { return &v }
+	items := []shoppingListItemResponse{
+		{ID: "line-milk", Quantity: qty(2), Unit: stringPtr("qt"), Item: itemResponse{ID: "milk", Name: "Milk"}},
+		{ID: "line-basil", QuantityText: stringPtr("1 bunch"), Item: itemResponse{ID: "basil", Name: "Basil"}},
+		{ID: "line-rice", Quantity: qty(1), Unit: stringPtr("lb"), Item: itemResponse{ID: "rice", Name: "Rice"}},
+	}
+	refs := map[string]itemPriceReference{
+		"milk":  {quantity: 1, unit: "gal", price: 4.4},
+		"basil": {quantity: 1, unit: "piece", price: 2},
+	}
+
+	got := estimateShoppingListCost(items, refs)
+
+	if got.Total != 2.2 || got.Complete {
+		t.Fatalf("cost=%+v, want 2.2 and incomplete", got)
+	}
+	if items[0].EstimatedCost == nil || *items[0].EstimatedCost != 2.2 {
+		t.Fatalf("milk estimated cost=%v, want 2.2", items[0].EstimatedCost)
+	}
+	if items[1].EstimatedCost != nil || items[2].EstimatedCost != nil {
+		t.Fatalf("unpriced items should have no estimate: %+v", items)
+	}
+	if len(got.Uncounted) != 2 || got.Uncounted[0].Reason != costGapMissingQuantity || got.Uncounted[1].Reason != costGapMissingPrice {
+		t.Fatalf("uncounted=%+v", got.Uncounted)
+	}
+}
+
+func TestValidateItemPriceRequest(t *testing.T) {
+	t.Parallel()
+
+	price := 3.99
+	negative := -1.0
+	if errs := validateItemPriceRequest(itemPriceRequest{Price: &price, Quantity: 1, Unit: "lb"}); len(errs) != 0 {
+		t.Fatalf("valid request errs=%+v", errs)
+	}
+	errs := validateItemPriceRequest(itemPriceRequest{Price: &negative, Quantity: 0, Unit: ""})
+	if len(errs) != 3 {
+		t.Fatalf("errs=%+v, want price, quantity, and unit", errs)
+	}
+	if errs := validateItemPriceRequest(itemPriceRequest{Quantity: 1, Unit: "lb"}); len(errs) != 1 || errs[0].Field != "price" {
+		t.Fatalf("missing price errs=%+v", errs)
+	}
+}
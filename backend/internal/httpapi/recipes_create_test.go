@@ -211,38 +211,67 @@ type itemResponse struct {
 
 // recipeIngredientResponse mirrors the ingredient payload for recipe responses.
 type recipeIngredientResponse struct {
-	ID           string       `json:"id"`
-	Position     int          `json:"position"`
-	Quantity     *float64     `json:"quantity"`
-	QuantityText *string      `json:"quantity_text"`
-	Unit         *string      `json:"unit"`
-	Item         itemResponse `json:"item"`
-	Prep         *string      `json:"prep"`
-	Notes        *string      `json:"notes"`
-	OriginalText *string      `json:"original_text"`
+	ID           string                   `json:"id"`
+	Position     int                      `json:"position"`
+	GroupName    *string                  `json:"group_name"`
+	Quantity     *float64                 `json:"quantity"`
+	QuantityText *string                  `json:"quantity_text"`
+	Unit         *string                  `json:"unit"`
+	Item         *itemResponse            `json:"item"`
+	SubRecipe    *recipeSubRecipeResponse `json:"sub_recipe"`
+	Prep         *string                  `json:"prep"`
+	Notes        *string                  `json:"notes"`
+	OriginalText *string                  `json:"original_text"`
+}
+
+type recipeSubRecipeResponse struct {
+	ID          string                     `json:"id"`
+	Title       string                     `json:"title"`
+	Servings    int                        `json:"servings"`
+	Deleted     bool                       `json:"deleted"`
+	Ingredients []recipeIngredientResponse `json:"ingredients"`
 }
 
 type recipeStepResponse struct {
-	ID          string `json:"id"`
-	StepNumber  int    `json:"step_number"`
-	Instruction string `json:"instruction"`
+	ID                 string  `json:"id"`
+	StepNumber         int     `json:"step_number"`
+	GroupName          *string `json:"group_name"`
+	Instruction        string  `json:"instruction"`
+	DurationSeconds    *int    `json:"duration_seconds"`
+	DurationMaxSeconds *int    `json:"duration_max_seconds"`
+	Temperature        *int    `json:"temperature"`
+	TemperatureUnit    *string `json:"temperature_unit"`
 }
 
 type recipeDetailResponse struct {
 	ID               string                     `json:"id"`
 	Title            string                     `json:"title"`
 	Servings         int                        `json:"servings"`
+	ScaledFrom       *int                       `json:"scaled_from_servings"`
 	PrepTimeMinutes  int                        `json:"prep_time_minutes"`
 	TotalTimeMinutes int                        `json:"total_time_minutes"`
 	SourceURL        *string                    `json:"source_url"`
 	Notes            *string                    `json:"notes"`
 	RecipeBookID     *string                    `json:"recipe_book_id"`
+	ParentRecipe     *recipeLineageResponse     `json:"parent_recipe"`
+	Variants         []recipeLineageResponse    `json:"variants"`
 	Tags             []recipeTagResponse        `json:"tags"`
 	Ingredients      []recipeIngredientResponse `json:"ingredients"`
 	Steps            []recipeStepResponse       `json:"steps"`
+	Images           []recipeImageResponse      `json:"images"`
+	Nutrition        recipeNutritionResponse    `json:"nutrition"`
+	Cost             recipeCostResponse         `json:"cost"`
+	IsFavorite       bool                       `json:"is_favorite"`
+	PersonalNote     *string                    `json:"personal_note"`
 	CreatedAt        string                     `json:"created_at"`
 	CreatedBy        string                     `json:"created_by"`
 	UpdatedAt        string                     `json:"updated_at"`
 	UpdatedBy        string                     `json:"updated_by"`
 	DeletedAt        *string                    `json:"deleted_at"`
+	LastCookedAt     *string                    `json:"last_cooked_at"`
+	TimesCooked      int                        `json:"times_cooked"`
+	AvgRating        *float64                   `json:"avg_rating"`
+	Allergens        []string                   `json:"allergens"`
+	Diets            []string                   `json:"diets"`
+	Unclassified     []namedResponse            `json:"unclassified_items"`
 }
@@ -74,11 +74,11 @@ func TestRecipes_GetDetail(t *testing.T) {
 	}
 
 	var qty1 pgtype.Numeric
-	if scanErr := qty1.Scan(1.0); scanErr != nil {
+	if scanErr := qty1.Scan("1"); scanErr != nil {
 		t.Fatalf("scan numeric: %v", scanErr)
 	}
 	var qty2 pgtype.Numeric
-	if scanErr := qty2.Scan(0.5); scanErr != nil {
+	if scanErr := qty2.Scan("0.5"); scanErr != nil {
 		t.Fatalf("scan numeric: %v", scanErr)
 	}
 
@@ -227,6 +227,51 @@ func TestRecipes_GetDetail(t *testing.T) {
 	if len(got.Steps) != 2 || got.Steps[0].StepNumber != 1 || got.Steps[0].Instruction != "Boil the chicken." {
 		t.Fatalf("steps=%v, want ordered by step_number", got.Steps)
 	}
+	if got.ScaledFrom != nil {
+		t.Fatalf("scaled_from_servings=%v, want nil", *got.ScaledFrom)
+	}
+
+	resp, err = client.Get(server.URL + "/api/v1/recipes/" + recipeID + "?servings=8")
+	if err != nil {
+		t.Fatalf("get scaled recipe: %v", err)
+	}
+	scaledBody := resp.Body
+	t.Cleanup(func() {
+		if closeErr := scaledBody.Close(); closeErr != nil {
+			t.Errorf("close scaled body: %v", closeErr)
+		}
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("scaled status=%d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var scaled recipeDetailResponse
+	if decodeErr := json.NewDecoder(scaledBody).Decode(&scaled); decodeErr != nil {
+		t.Fatalf("decode scaled: %v", decodeErr)
+	}
+	if scaled.Servings != 8 || scaled.ScaledFrom == nil || *scaled.ScaledFrom != 4 {
+		t.Fatalf("servings=%d scaled_from=%v, want 8 from 4", scaled.Servings, scaled.ScaledFrom)
+	}
+	if len(scaled.Ingredients) != 2 || scaled.Ingredients[0].Quantity == nil || *scaled.Ingredients[0].Quantity != 2 {
+		t.Fatalf("ingredients=%v, want chicken doubled to 2", scaled.Ingredients)
+	}
+	if scaled.Ingredients[1].Quantity == nil || *scaled.Ingredients[1].Quantity != 1 {
+		t.Fatalf("ingredients=%v, want carrot doubled to 1", scaled.Ingredients)
+	}
+
+	resp, err = client.Get(server.URL + "/api/v1/recipes/" + recipeID + "?servings=0")
+	if err != nil {
+		t.Fatalf("get invalid servings: %v", err)
+	}
+	invalidBody := resp.Body
+	t.Cleanup(func() {
+		if closeErr := invalidBody.Close(); closeErr != nil {
+			t.Errorf("close invalid body: %v", closeErr)
+		}
+	})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("invalid servings status=%d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
 }
 
 func TestRecipes_GetDetail_InvalidID(t *testing.T) {
@@ -0,0 +1,166 @@
+package httpapi
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+const (
+	defaultDuplicateMinScore = 0.5
+	defaultDuplicateLimit    = 20
+	maxDuplicateLimit        = 100
+
+	// duplicateTitleWeight is the share of the score taken by title
+	// similarity; ingredient overlap makes up the rest.
+	duplicateTitleWeight = 0.6
+	// duplicateScorePrecision rounds scores to three decimal places.
+	duplicateScorePrecision = 1000
+)
+
+type recipeDuplicateRecipeResponse struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type recipeDuplicateResponse struct {
+	Recipe            recipeDuplicateRecipeResponse `json:"recipe"`
+	Duplicate         recipeDuplicateRecipeResponse `json:"duplicate"`
+	Score             float64                       `json:"score"`
+	TitleSimilarity   float64                       `json:"title_similarity"`
+	IngredientOverlap float64                       `json:"ingredient_overlap"`
+}
+
+func (a *App) handleRecipesDuplicates(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	qp := r.URL.Query()
+	var recipeID pgtype.UUID
+	if v := strings.TrimSpace(qp.Get("recipe_id")); v != "" {
+		parsed, err := uuid.Parse(v)
+		if err != nil {
+			return errValidationField("recipe_id", "invalid id")
+		}
+		recipeID = pgtype.UUID{Bytes: parsed, Valid: true}
+	}
+
+	minScore := defaultDuplicateMinScore
+	if v := strings.TrimSpace(qp.Get("min_score")); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || math.IsNaN(parsed) || parsed < 0 || parsed > 1 {
+			return errValidationField("min_score", "min_score must be between 0 and 1")
+		}
+		minScore = parsed
+	}
+
+	limit := defaultDuplicateLimit
+	if v := strings.TrimSpace(qp.Get("limit")); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			return errValidationField("limit", "invalid limit")
+		}
+		if parsed > maxDuplicateLimit {
+			parsed = maxDuplicateLimit
+		}
+		limit = parsed
+	}
+
+	ctx := r.Context()
+	if recipeID.Valid {
+		deletedAt, err := a.queries.GetRecipeDeletedAtByID(ctx, recipeID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errNotFound()
+			}
+			return errInternal(err)
+		}
+		if deletedAt.Valid {
+			return errNotFound()
+		}
+	}
+
+	rows, err := a.queries.ListRecipeDuplicateCandidates(ctx, sqlc.ListRecipeDuplicateCandidatesParams{
+		RecipeID:    recipeID,
+		TitleWeight: duplicateTitleWeight,
+		MinScore:    minScore,
+		RowLimit:    int32(limit),
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+
+	resp := rankRecipeDuplicates(rows, minScore, limit)
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/duplicates")
+	}
+	return nil
+}
+
+// rankRecipeDuplicates scores candidate pairs, drops those below minScore,
+// and returns at most limit pairs, best first. The query already filters and
+// orders with the same score; this shapes the response and rounds it.
+func rankRecipeDuplicates(rows []sqlc.ListRecipeDuplicateCandidatesRow, minScore float64, limit int) []recipeDuplicateResponse {
+	out := []recipeDuplicateResponse{}
+	for _, row := range rows {
+		overlap := ingredientOverlap(row.SharedItems, row.RecipeItems, row.OtherItems)
+		score := duplicateScore(row.TitleSimilarity, overlap, row.RecipeItems > 0 && row.OtherItems > 0)
+		if score < minScore {
+			continue
+		}
+		out = append(out, recipeDuplicateResponse{
+			Recipe:            recipeDuplicateRecipeResponse{ID: uuidString(row.RecipeID), Title: row.RecipeTitle},
+			Duplicate:         recipeDuplicateRecipeResponse{ID: uuidString(row.OtherID), Title: row.OtherTitle},
+			Score:             score,
+			TitleSimilarity:   roundDuplicateScore(row.TitleSimilarity),
+			IngredientOverlap: roundDuplicateScore(overlap),
+		})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		if out[i].Recipe.Title != out[j].Recipe.Title {
+			return out[i].Recipe.Title < out[j].Recipe.Title
+		}
+		return out[i].Duplicate.Title < out[j].Duplicate.Title
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// ingredientOverlap is the Jaccard index of two recipes' ingredient item sets.
+func ingredientOverlap(shared, a, b int32) float64 {
+	union := a + b - shared
+	if union <= 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// duplicateScore blends title similarity with ingredient overlap. When either
+// recipe has no linked items the overlap says nothing, so the title alone
+// decides.
+func duplicateScore(titleSimilarity, overlap float64, hasItems bool) float64 {
+	if !hasItems {
+		return roundDuplicateScore(titleSimilarity)
+	}
+	return roundDuplicateScore(duplicateTitleWeight*titleSimilarity + (1-duplicateTitleWeight)*overlap)
+}
+
+func roundDuplicateScore(v float64) float64 {
+	return math.Round(v*duplicateScorePrecision) / duplicateScorePrecision
+}
@@ -0,0 +1,55 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+)
+
+func TestRankRecipeDuplicates(t *testing.T) {
+	t.Parallel()
+
+	candidate := func(title, other string, similarity float64, shared, items, otherItems int32) sqlc.ListRecipeDuplicateCandidatesRow {
+		return sqlc.ListRecipeDuplicateCandidatesRow{
+			RecipeID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			RecipeTitle:     title,
+			OtherID:         pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			OtherTitle:      other,
+			TitleSimilarity: similarity,
+			SharedItems:     shared,
+			RecipeItems:     items,
+			OtherItems:      otherItems,
+		}
+	}
+
+	rows := []sqlc.ListRecipeDuplicateCandidatesRow{
+		// Same ingredients, reworded title: 0.6*0.4 + 0.4*1.
+		candidate("Beef Chili", "Mom's Chili", 0.4, 5, 5, 5),
+		// Near-identical titles, no linked items: the title decides.
+		candidate("Pancakes", "Pancakes!", 0.9, 0, 0, 3),
+		// Shares only salt: well below the threshold.
+		candidate("Pancakes", "Beef Chili", 0.05, 1, 3, 5),
+		// 0.6*0.8 + 0.4*(2/4) = 0.68.
+		candidate("Tomato Soup", "Tomato Soup (quick)", 0.8, 2, 3, 3),
+	}
+
+	got := rankRecipeDuplicates(rows, 0.5, 10)
+	if len(got) != 3 {
+		t.Fatalf("got %d pairs, want 3: %+v", len(got), got)
+	}
+	wantScores := []float64{0.9, 0.68, 0.64}
+	for i, want := range wantScores {
+		if got[i].Score != want {
+			t.Fatalf("pair %d score=%v, want %v (%+v)", i, got[i].Score, want, got[i])
+		}
+	}
+	if got[1].IngredientOverlap != 0.5 || got[2].IngredientOverlap != 1 {
+		t.Fatalf("overlaps=%v,%v want 0.5,1", got[1].IngredientOverlap, got[2].IngredientOverlap)
+	}
+
+	if limited := rankRecipeDuplicates(rows, 0, 2); len(limited) != 2 || limited[0].Score != 0.9 {
+		t.Fatalf("limited=%+v, want top 2", limited)
+	}
+}
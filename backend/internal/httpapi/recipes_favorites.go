@@ -0,0 +1,73 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+)
+
+// handleRecipeFavoritePut marks a recipe as one of the caller's favorites.
+// Favorites are per user, so list and detail report is_favorite for the
+// caller only. Repeating the request is a no-op.
+func (a *App) handleRecipeFavoritePut(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	recipeID := pgtype.UUID{Bytes: id, Valid: true}
+
+	if _, err := a.queries.GetRecipeDeletedAtByID(r.Context(), recipeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := a.queries.AddRecipeFavorite(r.Context(), sqlc.AddRecipeFavoriteParams{
+		UserID:   pgtype.UUID{Bytes: info.UserID, Valid: true},
+		RecipeID: recipeID,
+	}); err != nil {
+		return errInternal(err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (a *App) handleRecipeFavoriteDelete(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	recipeID := pgtype.UUID{Bytes: id, Valid: true}
+
+	if _, err := a.queries.GetRecipeDeletedAtByID(r.Context(), recipeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := a.queries.DeleteRecipeFavorite(r.Context(), sqlc.DeleteRecipeFavoriteParams{
+		UserID:   pgtype.UUID{Bytes: info.UserID, Valid: true},
+		RecipeID: recipeID,
+	}); err != nil {
+		return errInternal(err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
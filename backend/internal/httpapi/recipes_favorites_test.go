@@ -0,0 +1,161 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type recipePersonalNoteResponse struct {
+	RecipeID string `json:"recipe_id"`
+	Note     string `json:"note"`
+}
+
+func TestRecipes_FavoritesAndPersonalNotes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	newClient := func() *http.Client {
+		jar, jarErr := cookiejar.New(nil)
+		if jarErr != nil {
+			t.Fatalf("cookie jar: %v", jarErr)
+		}
+		return &http.Client{Jar: jar}
+	}
+
+	joe := newClient()
+	joeCSRF := loginAndGetCSRFToken(t, joe, server.URL)
+	doRevisionsRequest(t, joe, joeCSRF, http.MethodPost, server.URL+"/api/v1/users", `{"username":"shannon","password":"pw2"}`, http.StatusCreated, nil)
+	shannon := newClient()
+	shannonCSRF := loginAsAndGetCSRFToken(t, shannon, server.URL, `{"username":"shannon","password":"pw2"}`)
+
+	createRecipe := func(title string) recipeDetailResponse {
+		var out recipeDetailResponse
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"`+title+`",
+  "servings":4,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[],
+  "steps":[{"step_number":1,"instruction":"Cook."}]
+}`, http.StatusCreated, &out)
+		return out
+	}
+	soup := createRecipe(recipeTitleChickenSoup)
+	stew := createRecipe(recipeTitleBeefStew)
+	recipeURL := server.URL + "/api/v1/recipes/" + soup.ID
+
+	t.Run("favorites are per user", func(t *testing.T) {
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodPut, recipeURL+"/favorite", "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodPut, recipeURL+"/favorite", "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodPut, server.URL+"/api/v1/recipes/00000000-0000-0000-0000-000000000000/favorite", "", http.StatusNotFound, nil)
+
+		q := url.Values{}
+		q.Set("favorites", "true")
+		joeFavorites := getRecipesList(t, joe, server.URL, q)
+		if len(joeFavorites.Items) != 1 || joeFavorites.Items[0].ID != soup.ID || !joeFavorites.Items[0].IsFavorite {
+			t.Fatalf("joe favorites=%+v, want only %s", joeFavorites.Items, recipeTitleChickenSoup)
+		}
+		shannonFavorites := getRecipesList(t, shannon, server.URL, q)
+		if len(shannonFavorites.Items) != 0 {
+			t.Fatalf("shannon favorites=%+v, want none", shannonFavorites.Items)
+		}
+
+		all := getRecipesList(t, shannon, server.URL, url.Values{})
+		if len(all.Items) != 2 || all.Items[0].IsFavorite || all.Items[1].IsFavorite {
+			t.Fatalf("shannon items=%+v, want two non-favorites", all.Items)
+		}
+
+		var detail recipeDetailResponse
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodGet, recipeURL, "", http.StatusOK, &detail)
+		if !detail.IsFavorite {
+			t.Fatalf("joe detail is_favorite=false, want true")
+		}
+
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodDelete, recipeURL+"/favorite", "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodGet, recipeURL, "", http.StatusOK, &detail)
+		if detail.IsFavorite {
+			t.Fatalf("joe detail is_favorite=true after unfavorite")
+		}
+	})
+
+	t.Run("personal notes are private", func(t *testing.T) {
+		noteURL := recipeURL + "/personal-note"
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodGet, noteURL, "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodPut, noteURL, `{"note":"   "}`, http.StatusBadRequest, nil)
+
+		var note recipePersonalNoteResponse
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodPut, noteURL, `{"note":"  Use less salt.  "}`, http.StatusOK, &note)
+		if note.RecipeID != soup.ID || note.Note != "Use less salt." {
+			t.Fatalf("note=%+v", note)
+		}
+		doRevisionsRequest(t, shannon, shannonCSRF, http.MethodPut, noteURL, `{"note":"Double the carrots."}`, http.StatusOK, nil)
+
+		var detail recipeDetailResponse
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodGet, recipeURL, "", http.StatusOK, &detail)
+		if detail.PersonalNote == nil || *detail.PersonalNote != "Use less salt." {
+			t.Fatalf("joe personal_note=%v", detail.PersonalNote)
+		}
+		doRevisionsRequest(t, shannon, shannonCSRF, http.MethodGet, noteURL, "", http.StatusOK, &note)
+		if note.Note != "Double the carrots." {
+			t.Fatalf("shannon note=%+v", note)
+		}
+
+		doRevisionsRequest(t, shannon, shannonCSRF, http.MethodDelete, noteURL, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, shannon, shannonCSRF, http.MethodDelete, noteURL, "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, shannon, shannonCSRF, http.MethodGet, recipeURL, "", http.StatusOK, &detail)
+		if detail.PersonalNote != nil {
+			t.Fatalf("shannon personal_note=%v, want null", *detail.PersonalNote)
+		}
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodGet, noteURL, "", http.StatusOK, nil)
+
+		doRevisionsRequest(t, joe, joeCSRF, http.MethodPut, server.URL+"/api/v1/recipes/"+stew.ID+"/personal-note", `{"note":"Brown the beef first."}`, http.StatusOK, nil)
+	})
+}
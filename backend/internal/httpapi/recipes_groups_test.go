@@ -0,0 +1,146 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+func TestRecipes_Groups(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	payload := func(ingredients, steps string) string {
+		return `{
+  "title":"Pizza",
+  "servings":2,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[` + ingredients + `],
+  "steps":[` + steps + `]
+}`
+	}
+
+	ingredients := `{"position":1,"group_name":"Dough","quantity":500,"unit":"g","item_name":"flour"},` +
+		`{"position":2,"group_name":"Dough","quantity":300,"unit":"ml","item_name":"water"},` +
+		`{"position":3,"group_name":" Sauce ","quantity":1,"unit":"can","item_name":"tomatoes"},` +
+		`{"position":4,"group_name":"","quantity":5,"item_name":"basil leaves"}`
+	steps := `{"step_number":1,"group_name":"Dough","instruction":"Knead."},` +
+		`{"step_number":2,"group_name":"Sauce","instruction":"Simmer."},` +
+		`{"step_number":3,"instruction":"Bake."}`
+
+	var created recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+		payload(ingredients, steps), http.StatusCreated, &created)
+
+	t.Run("detail returns group names", func(t *testing.T) {
+		var got recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+created.ID, "", http.StatusOK, &got)
+		wantIngredients := []string{"Dough", "Dough", "Sauce", ""}
+		if len(got.Ingredients) != len(wantIngredients) {
+			t.Fatalf("ingredients=%+v, want %d", got.Ingredients, len(wantIngredients))
+		}
+		for i, want := range wantIngredients {
+			if groupNameOf(got.Ingredients[i].GroupName) != want {
+				t.Fatalf("ingredient %d group=%v, want %q", i, got.Ingredients[i].GroupName, want)
+			}
+		}
+		wantSteps := []string{"Dough", "Sauce", ""}
+		if len(got.Steps) != len(wantSteps) {
+			t.Fatalf("steps=%+v, want %d", got.Steps, len(wantSteps))
+		}
+		for i, want := range wantSteps {
+			if groupNameOf(got.Steps[i].GroupName) != want {
+				t.Fatalf("step %d group=%v, want %q", i, got.Steps[i].GroupName, want)
+			}
+		}
+	})
+
+	t.Run("rejects split groups", func(t *testing.T) {
+		split := `{"position":1,"group_name":"Dough","item_name":"flour"},` +
+			`{"position":2,"group_name":"Sauce","item_name":"tomatoes"},` +
+			`{"position":3,"group_name":"Dough","item_name":"water"}`
+		doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/recipes/"+created.ID,
+			payload(split, steps), http.StatusBadRequest, nil)
+	})
+
+	t.Run("revision diff reports step group changes", func(t *testing.T) {
+		regrouped := `{"step_number":1,"group_name":"Dough","instruction":"Knead."},` +
+			`{"step_number":2,"group_name":"Sauce","instruction":"Simmer."},` +
+			`{"step_number":3,"group_name":"Assembly","instruction":"Bake."}`
+		doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/recipes/"+created.ID,
+			payload(ingredients, regrouped), http.StatusOK, nil)
+
+		var diff struct {
+			Steps []struct {
+				StepNumber  int     `json:"step_number"`
+				Change      string  `json:"change"`
+				ToGroupName *string `json:"to_group_name"`
+			} `json:"steps"`
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+created.ID+"/revisions/diff?from=1&to=2", "", http.StatusOK, &diff)
+		if len(diff.Steps) != 1 || diff.Steps[0].StepNumber != 3 || groupNameOf(diff.Steps[0].ToGroupName) != "Assembly" {
+			t.Fatalf("steps=%+v, want step 3 moved to Assembly", diff.Steps)
+		}
+	})
+}
+
+func groupNameOf(name *string) string {
+	if name == nil {
+		return ""
+	}
+	return *name
+}
@@ -0,0 +1,386 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/blobstore"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+	"github.com/saiaj/cooking_app/backend/internal/imaging"
+)
+
+const (
+	defaultMaxImageUploadBytes int64 = 10 << 20
+	// maxMultipartOverheadBytes leaves room for part headers and form fields
+	// on top of the image itself.
+	maxMultipartOverheadBytes int64 = 64 << 10
+	recipeThumbnailSize             = 320
+)
+
+type recipeImageResponse struct {
+	ID           string `json:"id"`
+	StepNumber   *int32 `json:"step_number"`
+	ContentType  string `json:"content_type"`
+	ByteSize     int64  `json:"byte_size"`
+	Width        int32  `json:"width"`
+	Height       int32  `json:"height"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	CreatedAt    string `json:"created_at"`
+	CreatedBy    string `json:"created_by"`
+}
+
+// recipeImageUpload holds the parts of a multipart image upload.
+type recipeImageUpload struct {
+	data       []byte
+	stepNumber pgtype.Int4
+}
+
+func (a *App) handleRecipeImagesList(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	recipeID := pgtype.UUID{Bytes: id, Valid: true}
+
+	if _, err := a.queries.GetRecipeDeletedAtByID(r.Context(), recipeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	images, err := a.queries.ListRecipeImagesByRecipeID(r.Context(), recipeID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, recipeImageResponses(images)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/images")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeImagesUpload(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+	if a.blobs == nil {
+		return errBadRequest("image uploads are not enabled")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	recipeID := pgtype.UUID{Bytes: id, Valid: true}
+
+	if _, err := a.queries.GetRecipeDeletedAtByID(r.Context(), recipeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	upload, err := a.readRecipeImageUpload(w, r)
+	if err != nil {
+		return err
+	}
+
+	if upload.stepNumber.Valid {
+		steps, err := a.queries.ListRecipeStepsByRecipeID(r.Context(), recipeID)
+		if err != nil {
+			return errInternal(err)
+		}
+		found := false
+		for _, step := range steps {
+			if step.StepNumber == upload.stepNumber.Int32 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errValidationField("step_number", "step does not exist")
+		}
+	}
+
+	imgInfo, err := imaging.Inspect(upload.data)
+	if err != nil {
+		if errors.Is(err, imaging.ErrTooManyPixels) {
+			return errValidationField("file", "image dimensions too large")
+		}
+		return errValidationField("file", "unsupported image type; use JPEG, PNG, or GIF")
+	}
+	thumbnail, err := imaging.Thumbnail(upload.data, recipeThumbnailSize)
+	if err != nil {
+		return errValidationField("file", "invalid image")
+	}
+
+	imageID := uuid.New()
+	prefix := fmt.Sprintf("recipes/%s/%s", id, imageID)
+	blobKey := prefix + imgInfo.Extension()
+	thumbnailKey := prefix + "_thumb.jpg"
+
+	if err := a.blobs.Put(r.Context(), blobKey, bytes.NewReader(upload.data)); err != nil {
+		return errInternal(err)
+	}
+	if err := a.blobs.Put(r.Context(), thumbnailKey, bytes.NewReader(thumbnail)); err != nil {
+		a.deleteBlobs(r.Context(), blobKey)
+		return errInternal(err)
+	}
+
+	created, err := a.queries.CreateRecipeImage(r.Context(), sqlc.CreateRecipeImageParams{
+		ID:           pgtype.UUID{Bytes: imageID, Valid: true},
+		RecipeID:     recipeID,
+		StepNumber:   upload.stepNumber,
+		ContentType:  imgInfo.ContentType,
+		ByteSize:     int64(len(upload.data)),
+		Width:        int32(imgInfo.Width),  //nolint:gosec // bounded by imaging.MaxPixels
+		Height:       int32(imgInfo.Height), //nolint:gosec // bounded by imaging.MaxPixels
+		BlobKey:      blobKey,
+		ThumbnailKey: thumbnailKey,
+		CreatedBy:    pgtype.UUID{Bytes: info.UserID, Valid: true},
+	})
+	if err != nil {
+		a.deleteBlobs(r.Context(), blobKey, thumbnailKey)
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusCreated, recipeImageResponseFromModel(created)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/images")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeImagesContent(w http.ResponseWriter, r *http.Request) error {
+	return a.serveRecipeImage(w, r, false)
+}
+
+func (a *App) handleRecipeImagesThumbnail(w http.ResponseWriter, r *http.Request) error {
+	return a.serveRecipeImage(w, r, true)
+}
+
+func (a *App) handleRecipeImagesDelete(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	imageID, err := parseUUIDParam(r, "image_id")
+	if err != nil {
+		return err
+	}
+
+	deleted, err := a.queries.DeleteRecipeImage(r.Context(), sqlc.DeleteRecipeImageParams{
+		RecipeID: pgtype.UUID{Bytes: id, Valid: true},
+		ID:       pgtype.UUID{Bytes: imageID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+	if a.blobs != nil {
+		a.deleteBlobs(r.Context(), deleted.BlobKey, deleted.ThumbnailKey)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// serveRecipeImage streams the stored original or its thumbnail.
+func (a *App) serveRecipeImage(w http.ResponseWriter, r *http.Request, thumbnail bool) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+	if a.blobs == nil {
+		return errNotFound()
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	imageID, err := parseUUIDParam(r, "image_id")
+	if err != nil {
+		return err
+	}
+
+	image, err := a.queries.GetRecipeImage(r.Context(), sqlc.GetRecipeImageParams{
+		RecipeID: pgtype.UUID{Bytes: id, Valid: true},
+		ID:       pgtype.UUID{Bytes: imageID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	key, contentType := image.BlobKey, image.ContentType
+	if thumbnail {
+		key, contentType = image.ThumbnailKey, imaging.ThumbnailContentType
+	}
+	blob, err := a.blobs.Get(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+	defer func() {
+		if err := blob.Close(); err != nil {
+			a.logger.Warn("blob close failed", "err", err, "key", key)
+		}
+	}()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// Blob keys are never reused, so image bytes for a URL never change.
+	w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, blob); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", r.URL.Path)
+	}
+	return nil
+}
+
+// readRecipeImageUpload reads the "file" and optional "step_number" parts of
+// a multipart upload, enforcing the configured size limit.
+func (a *App) readRecipeImageUpload(w http.ResponseWriter, r *http.Request) (recipeImageUpload, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, a.maxImageUploadBytes+maxMultipartOverheadBytes)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return recipeImageUpload{}, errBadRequest("expected multipart/form-data body")
+	}
+
+	var upload recipeImageUpload
+	hasFile := false
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return recipeImageUpload{}, multipartReadError(err)
+		}
+
+		switch part.FormName() {
+		case "file":
+			data, err := io.ReadAll(io.LimitReader(part, a.maxImageUploadBytes+1))
+			if err != nil {
+				return recipeImageUpload{}, multipartReadError(err)
+			}
+			if int64(len(data)) > a.maxImageUploadBytes {
+				return recipeImageUpload{}, errRequestTooLarge()
+			}
+			upload.data = data
+			hasFile = len(data) > 0
+		case "step_number":
+			stepNumber, err := readStepNumberPart(part)
+			if err != nil {
+				return recipeImageUpload{}, err
+			}
+			upload.stepNumber = stepNumber
+		}
+		if err := part.Close(); err != nil {
+			return recipeImageUpload{}, multipartReadError(err)
+		}
+	}
+
+	if !hasFile {
+		return recipeImageUpload{}, errValidationField("file", "file is required")
+	}
+	return upload, nil
+}
+
+func readStepNumberPart(part *multipart.Part) (pgtype.Int4, error) {
+	raw, err := io.ReadAll(io.LimitReader(part, 32))
+	if err != nil {
+		return pgtype.Int4{}, multipartReadError(err)
+	}
+	value := strings.TrimSpace(string(raw))
+	if value == "" {
+		return pgtype.Int4{}, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || parsed <= 0 {
+		return pgtype.Int4{}, errValidationField("step_number", "step_number must be a positive integer")
+	}
+	return pgtype.Int4{Int32: int32(parsed), Valid: true}, nil
+}
+
+func multipartReadError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return errRequestTooLarge()
+	}
+	return errBadRequest("invalid multipart body")
+}
+
+// deleteBlobs removes blobs on a best-effort basis; orphaned blobs are
+// harmless, so failures are only logged.
+func (a *App) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := a.blobs.Delete(ctx, key); err != nil {
+			a.logger.Warn("blob delete failed", "err", err, "key", key)
+		}
+	}
+}
+
+func recipeImageResponses(images []sqlc.RecipeImage) []recipeImageResponse {
+	out := make([]recipeImageResponse, 0, len(images))
+	for _, image := range images {
+		out = append(out, recipeImageResponseFromModel(image))
+	}
+	return out
+}
+
+func recipeImageResponseFromModel(image sqlc.RecipeImage) recipeImageResponse {
+	var stepNumber *int32
+	if image.StepNumber.Valid {
+		n := image.StepNumber.Int32
+		stepNumber = &n
+	}
+	recipeID, imageID := uuidString(image.RecipeID), uuidString(image.ID)
+	return recipeImageResponse{
+		ID:           imageID,
+		StepNumber:   stepNumber,
+		ContentType:  image.ContentType,
+		ByteSize:     image.ByteSize,
+		Width:        image.Width,
+		Height:       image.Height,
+		URL:          recipeImageURL(recipeID, imageID),
+		ThumbnailURL: recipeThumbnailURL(recipeID, imageID),
+		CreatedAt:    timeString(image.CreatedAt),
+		CreatedBy:    uuidString(image.CreatedBy),
+	}
+}
+
+func recipeImageURL(recipeID, imageID string) string {
+	return fmt.Sprintf("/api/v1/recipes/%s/images/%s", recipeID, imageID)
+}
+
+func recipeThumbnailURL(recipeID, imageID string) string {
+	return recipeImageURL(recipeID, imageID) + "/thumbnail"
+}
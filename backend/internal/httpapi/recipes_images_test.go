@@ -0,0 +1,261 @@
+package httpapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type recipeImageResponse struct {
+	ID           string `json:"id"`
+	StepNumber   *int   `json:"step_number"`
+	ContentType  string `json:"content_type"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+func TestRecipes_Images(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+		BlobStoreDir:        t.TempDir(),
+		MaxImageUploadBytes: 1 << 20,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	var created recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"Chicken Soup",
+  "servings":4,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[],
+  "steps":[{"step_number":1,"instruction":"Boil."}]
+}`, http.StatusCreated, &created)
+	if created.Images == nil || len(created.Images) != 0 {
+		t.Fatalf("images=%v, want empty list", created.Images)
+	}
+	base := server.URL + "/api/v1/recipes/" + created.ID
+
+	var cover recipeImageResponse
+	t.Run("upload recipe photo", func(t *testing.T) {
+		resp := uploadRecipeImage(t, client, csrf, base+"/images", testPNG(t, 800, 600), "")
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("status=%d, want 201", resp.StatusCode)
+		}
+		decodeImageResponse(t, resp, &cover)
+		if cover.ContentType != "image/png" || cover.Width != 800 || cover.Height != 600 || cover.StepNumber != nil {
+			t.Fatalf("image=%+v", cover)
+		}
+	})
+
+	t.Run("upload step photo", func(t *testing.T) {
+		resp := uploadRecipeImage(t, client, csrf, base+"/images", testPNG(t, 10, 10), "1")
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("status=%d, want 201", resp.StatusCode)
+		}
+		var out recipeImageResponse
+		decodeImageResponse(t, resp, &out)
+		if out.StepNumber == nil || *out.StepNumber != 1 {
+			t.Fatalf("step_number=%v, want 1", out.StepNumber)
+		}
+
+		missing := uploadRecipeImage(t, client, csrf, base+"/images", testPNG(t, 10, 10), "7")
+		_ = missing.Body.Close()
+		if missing.StatusCode != http.StatusBadRequest {
+			t.Fatalf("missing step status=%d, want 400", missing.StatusCode)
+		}
+	})
+
+	t.Run("rejects non-images and oversized uploads", func(t *testing.T) {
+		resp := uploadRecipeImage(t, client, csrf, base+"/images", []byte("<svg></svg>"), "")
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("svg status=%d, want 400", resp.StatusCode)
+		}
+
+		big := uploadRecipeImage(t, client, csrf, base+"/images", make([]byte, (1<<20)+(100<<10)), "")
+		_ = big.Body.Close()
+		if big.StatusCode != http.StatusRequestEntityTooLarge {
+			t.Fatalf("oversized status=%d, want 413", big.StatusCode)
+		}
+	})
+
+	t.Run("serves original and thumbnail", func(t *testing.T) {
+		for url, wantType := range map[string]string{
+			server.URL + cover.URL:          "image/png",
+			server.URL + cover.ThumbnailURL: "image/jpeg",
+		} {
+			resp, err := client.Get(url)
+			if err != nil {
+				t.Fatalf("get %s: %v", url, err)
+			}
+			data, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != wantType {
+				t.Fatalf("get %s status=%d type=%s", url, resp.StatusCode, resp.Header.Get("Content-Type"))
+			}
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("decode %s: %v", url, err)
+			}
+			if url == server.URL+cover.ThumbnailURL && (cfg.Width != 320 || cfg.Height != 240) {
+				t.Fatalf("thumbnail=%dx%d, want 320x240", cfg.Width, cfg.Height)
+			}
+		}
+	})
+
+	t.Run("detail and list include image urls", func(t *testing.T) {
+		var detail recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, base, "", http.StatusOK, &detail)
+		if len(detail.Images) != 2 || detail.Images[0].ID != cover.ID {
+			t.Fatalf("images=%+v, want cover first", detail.Images)
+		}
+
+		var list struct {
+			Items []struct {
+				ID           string  `json:"id"`
+				ThumbnailURL *string `json:"thumbnail_url"`
+			} `json:"items"`
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes", "", http.StatusOK, &list)
+		if len(list.Items) != 1 || list.Items[0].ThumbnailURL == nil || *list.Items[0].ThumbnailURL != cover.ThumbnailURL {
+			t.Fatalf("list=%+v, want cover thumbnail", list.Items)
+		}
+	})
+
+	t.Run("delete image", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, base+"/images/"+cover.ID, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, base+"/images/"+cover.ID, "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodGet, base+"/images/"+cover.ID, "", http.StatusNotFound, nil)
+
+		var out []recipeImageResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, base+"/images", "", http.StatusOK, &out)
+		if len(out) != 1 {
+			t.Fatalf("images=%d, want 1", len(out))
+		}
+	})
+}
+
+func uploadRecipeImage(t *testing.T, client *http.Client, csrf, url string, data []byte, stepNumber string) *http.Response {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if stepNumber != "" {
+		if err := writer.WriteField("step_number", stepNumber); err != nil {
+			t.Fatalf("write field: %v", err)
+		}
+	}
+	part, err := writer.CreateFormFile("file", "photo.png")
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close writer: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, &body)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-CSRF-Token", csrf)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	return resp
+}
+
+func decodeImageResponse(t *testing.T, resp *http.Response, out *recipeImageResponse) {
+	t.Helper()
+
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			t.Errorf("close body: %v", closeErr)
+		}
+	}()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("decode image: %v", err)
+	}
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.SetNRGBA(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
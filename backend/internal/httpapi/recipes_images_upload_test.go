@@ -0,0 +1,93 @@
+package httpapi
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadRecipeImageUpload(t *testing.T) {
+	t.Parallel()
+
+	app := &App{maxImageUploadBytes: 16}
+
+	tests := []struct {
+		name       string
+		step       string
+		file       []byte
+		wantKind   apiErrorKind
+		wantStep   int32
+		wantLength int
+	}{
+		{name: "file only", file: []byte("0123456789"), wantLength: 10},
+		{name: "file and step", step: "3", file: []byte("abc"), wantStep: 3, wantLength: 3},
+		{name: "missing file", step: "1", wantKind: apiErrorValidation},
+		{name: "invalid step", step: "zero", file: []byte("abc"), wantKind: apiErrorValidation},
+		{name: "non-positive step", step: "0", file: []byte("abc"), wantKind: apiErrorValidation},
+		{name: "too large", file: bytes.Repeat([]byte("x"), 17), wantKind: apiErrorRequestTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			if tt.step != "" {
+				if err := writer.WriteField("step_number", tt.step); err != nil {
+					t.Fatalf("write field: %v", err)
+				}
+			}
+			if tt.file != nil {
+				part, err := writer.CreateFormFile("file", "photo.jpg")
+				if err != nil {
+					t.Fatalf("create form file: %v", err)
+				}
+				if _, err := part.Write(tt.file); err != nil {
+					t.Fatalf("write file: %v", err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("close writer: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", &body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			upload, err := app.readRecipeImageUpload(httptest.NewRecorder(), req)
+
+			if tt.wantKind != "" {
+				apiErr, ok := asAPIError(err)
+				if !ok || apiErr.kind != tt.wantKind {
+					t.Fatalf("err=%v, want kind %s", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("read upload: %v", err)
+			}
+			if len(upload.data) != tt.wantLength {
+				t.Fatalf("data length=%d, want %d", len(upload.data), tt.wantLength)
+			}
+			if upload.stepNumber.Valid != (tt.wantStep != 0) || upload.stepNumber.Int32 != tt.wantStep {
+				t.Fatalf("step=%+v, want %d", upload.stepNumber, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestReadRecipeImageUploadRequiresMultipart(t *testing.T) {
+	t.Parallel()
+
+	app := &App{maxImageUploadBytes: 16}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"file":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err := app.readRecipeImageUpload(httptest.NewRecorder(), req)
+	apiErr, ok := asAPIError(err)
+	if !ok || apiErr.kind != apiErrorBadRequest {
+		t.Fatalf("err=%v, want bad request", err)
+	}
+}
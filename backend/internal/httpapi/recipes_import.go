@@ -0,0 +1,172 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+	"github.com/saiaj/cooking_app/backend/internal/instructions"
+	"github.com/saiaj/cooking_app/backend/internal/schemaorg"
+)
+
+const (
+	recipeImportFormatHTML   = "html"
+	recipeImportFormatJSONLD = "jsonld"
+)
+
+type recipeImportRequest struct {
+	Format    string  `json:"format"`
+	Content   string  `json:"content"`
+	SourceURL *string `json:"source_url"`
+	Create    bool    `json:"create"`
+}
+
+// handleRecipesImport parses a schema.org Recipe out of a saved HTML page or
+// a JSON-LD document. By default it returns the mapped create payload as a
+// draft for review; with create=true it creates the recipe directly.
+func (a *App) handleRecipesImport(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	var req recipeImportRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+
+	draft, err := parseRecipeImport(req)
+	if err != nil {
+		return err
+	}
+
+	if !req.Create {
+		if err := response.WriteJSON(w, http.StatusOK, draft); err != nil {
+			a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/import")
+		}
+		return nil
+	}
+
+	if errs := validateCreateRecipeRequest(draft); len(errs) > 0 {
+		return errValidation(errs)
+	}
+
+	ctx := r.Context()
+	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
+
+	recipeID, matches, err := createRecipeUsecase(ctx, a.recipeWorkflows(), userID, draft)
+	if err != nil {
+		return mapRecipeUsecaseError(err)
+	}
+
+	detail, err := a.loadRecipeDetail(ctx, recipeID, userID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusCreated, newRecipeSaveResponse(detail, matches)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/import")
+	}
+	return nil
+}
+
+func parseRecipeImport(req recipeImportRequest) (createRecipeRequest, error) {
+	if strings.TrimSpace(req.Content) == "" {
+		return createRecipeRequest{}, errValidationField("content", "content is required")
+	}
+
+	var (
+		parsed schemaorg.Recipe
+		err    error
+	)
+	switch strings.ToLower(strings.TrimSpace(req.Format)) {
+	case recipeImportFormatHTML:
+		parsed, err = schemaorg.ParseHTML([]byte(req.Content))
+	case recipeImportFormatJSONLD:
+		parsed, err = schemaorg.ParseJSONLD([]byte(req.Content))
+	default:
+		return createRecipeRequest{}, errValidationField("format", "format must be html or jsonld")
+	}
+	if err != nil {
+		if errors.Is(err, schemaorg.ErrNoRecipe) {
+			return createRecipeRequest{}, errValidationField("content", "no schema.org Recipe found")
+		}
+		return createRecipeRequest{}, errInternal(err)
+	}
+
+	sourceURL := parsed.URL
+	if req.SourceURL != nil && strings.TrimSpace(*req.SourceURL) != "" {
+		sourceURL = strings.TrimSpace(*req.SourceURL)
+	}
+	return recipeRequestFromSchemaOrg(parsed, sourceURL), nil
+}
+
+// recipeRequestFromSchemaOrg maps a parsed schema.org Recipe onto the create
+// payload. Ingredient lines are split into quantity, unit, item, prep, and
+// notes by the ingredient parser. Step timings and oven temperatures come
+// from the instruction text.
+func recipeRequestFromSchemaOrg(parsed schemaorg.Recipe, sourceURL string) createRecipeRequest {
+	servings := parsed.Servings
+	if servings <= 0 {
+		servings = 1
+	}
+
+	req := createRecipeRequest{
+		Title:            parsed.Name,
+		Servings:         servings,
+		PrepTimeMinutes:  parsed.PrepMinutes,
+		TotalTimeMinutes: parsed.TotalMinutes,
+		SourceURL:        optionalString(sourceURL),
+		Notes:            optionalString(parsed.Description),
+		TagIDs:           []string{},
+		Ingredients:      make([]recipeIngredientRequest, 0, len(parsed.Ingredients)),
+		Steps:            make([]recipeStepRequest, 0, len(parsed.Instructions)),
+	}
+	for i, line := range parsed.Ingredients {
+		req.Ingredients = append(req.Ingredients, recipeIngredientRequest{
+			Position:     i + 1,
+			OriginalText: optionalString(line),
+		})
+	}
+	fillParsedIngredients(&req)
+	for i, instruction := range parsed.Instructions {
+		cues := storableStepCues(instructions.Extract(instruction))
+		req.Steps = append(req.Steps, recipeStepRequest{
+			StepNumber:         i + 1,
+			Instruction:        instruction,
+			DurationSeconds:    cues.DurationSeconds,
+			DurationMaxSeconds: cues.DurationMaxSeconds,
+			Temperature:        cues.Temperature,
+			TemperatureUnit:    optionalString(cues.TemperatureUnit),
+		})
+	}
+	return req
+}
+
+// storableStepCues drops extracted cues that recipe validation would reject,
+// so an odd step ("proof for 200 hours") cannot fail a whole import. A range
+// whose upper bound is too long is clamped instead.
+func storableStepCues(cues instructions.Cues) instructions.Cues {
+	if cues.DurationSeconds != nil && *cues.DurationSeconds > maxStepDurationSeconds {
+		cues.DurationSeconds = nil
+		cues.DurationMaxSeconds = nil
+	}
+	if cues.DurationMaxSeconds != nil && *cues.DurationMaxSeconds > maxStepDurationSeconds {
+		upper := int32(maxStepDurationSeconds)
+		cues.DurationMaxSeconds = &upper
+	}
+	if cues.Temperature != nil && (*cues.Temperature < minStepTemperature || *cues.Temperature > maxStepTemperature) {
+		cues.Temperature = nil
+		cues.TemperatureUnit = ""
+	}
+	return cues
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,131 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+func TestParseRecipeImportMapsSchemaOrgRecipe(t *testing.T) {
+	t.Parallel()
+
+	req, err := parseRecipeImport(recipeImportRequest{
+		Format: "html",
+		Content: `<html><head><script type="application/ld+json">
+{"@type":"Recipe","name":"Pancakes","description":"Fluffy.","url":"https://example.com/pancakes",
+ "recipeYield":"4 servings","prepTime":"PT10M","totalTime":"PT25M",
+ "recipeIngredient":["2 cups flour","1 egg"],
+ "recipeInstructions":[{"@type":"HowToStep","text":"Mix."},{"@type":"HowToStep","text":"Cook 2-3 minutes per side at 375°F."}]}
+</script></head></html>`,
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if errs := validateCreateRecipeRequest(req); len(errs) > 0 {
+		t.Fatalf("draft is not valid: %+v", errs)
+	}
+	if req.Title != "Pancakes" || req.Servings != 4 || req.PrepTimeMinutes != 10 || req.TotalTimeMinutes != 25 {
+		t.Fatalf("draft=%+v", req)
+	}
+	if req.SourceURL == nil || *req.SourceURL != "https://example.com/pancakes" {
+		t.Fatalf("source_url=%v", req.SourceURL)
+	}
+	if req.Notes == nil || *req.Notes != "Fluffy." {
+		t.Fatalf("notes=%v", req.Notes)
+	}
+	if len(req.Ingredients) != 2 || req.Ingredients[1].Position != 2 || *req.Ingredients[1].ItemName != "egg" || *req.Ingredients[1].OriginalText != "1 egg" {
+		t.Fatalf("ingredients=%+v", req.Ingredients)
+	}
+	if flour := req.Ingredients[0]; flour.Quantity == nil || *flour.Quantity != 2 || flour.Unit == nil || *flour.Unit != "cup" || *flour.ItemName != "flour" {
+		t.Fatalf("flour=%+v", flour)
+	}
+	if len(req.Steps) != 2 || req.Steps[1].StepNumber != 2 || req.Steps[1].Instruction != "Cook 2-3 minutes per side at 375°F." {
+		t.Fatalf("steps=%+v", req.Steps)
+	}
+	if req.Steps[0].DurationSeconds != nil || req.Steps[0].Temperature != nil {
+		t.Fatalf("step 1=%+v, want no cues", req.Steps[0])
+	}
+	cook := req.Steps[1]
+	if cook.DurationSeconds == nil || *cook.DurationSeconds != 120 || cook.DurationMaxSeconds == nil || *cook.DurationMaxSeconds != 180 {
+		t.Fatalf("step 2 duration=%v..%v, want 120..180", cook.DurationSeconds, cook.DurationMaxSeconds)
+	}
+	if cook.Temperature == nil || *cook.Temperature != 375 || cook.TemperatureUnit == nil || *cook.TemperatureUnit != "F" {
+		t.Fatalf("step 2 temperature=%v %v, want 375 F", cook.Temperature, cook.TemperatureUnit)
+	}
+}
+
+func TestParseRecipeImportDropsOutOfRangeCues(t *testing.T) {
+	t.Parallel()
+
+	req, err := parseRecipeImport(recipeImportRequest{
+		Format: "jsonld",
+		Content: `{"@type":"Recipe","name":"Sourdough","recipeIngredient":["500 g flour"],
+ "recipeInstructions":["Proof for 200 hours at 75 F.","Cold retard 120 to 200 hours.","Bake 40 minutes at 500 F."]}`,
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if errs := validateCreateRecipeRequest(req); len(errs) > 0 {
+		t.Fatalf("draft is not valid: %+v", errs)
+	}
+	proof := req.Steps[0]
+	if proof.DurationSeconds != nil || proof.DurationMaxSeconds != nil || proof.Temperature == nil || *proof.Temperature != 75 {
+		t.Fatalf("proof step=%+v, want only the temperature", proof)
+	}
+	retard := req.Steps[1]
+	if retard.DurationSeconds == nil || *retard.DurationSeconds != 120*60*60 || retard.DurationMaxSeconds == nil || *retard.DurationMaxSeconds != maxStepDurationSeconds {
+		t.Fatalf("retard step=%v..%v, want 120 hours up to the cap", retard.DurationSeconds, retard.DurationMaxSeconds)
+	}
+}
+
+func TestParseRecipeImportSourceURLOverrideAndDefaults(t *testing.T) {
+	t.Parallel()
+
+	override := "https://override.example/recipe"
+	req, err := parseRecipeImport(recipeImportRequest{
+		Format:    "jsonld",
+		Content:   `{"@type":"Recipe","name":"Toast","url":"https://example.com/toast","recipeInstructions":"Toast."}`,
+		SourceURL: &override,
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if req.SourceURL == nil || *req.SourceURL != override {
+		t.Fatalf("source_url=%v, want override", req.SourceURL)
+	}
+	if req.Servings != 1 {
+		t.Fatalf("servings=%d, want default 1", req.Servings)
+	}
+	if req.Notes != nil || req.TagIDs == nil || req.Ingredients == nil {
+		t.Fatalf("draft=%+v", req)
+	}
+}
+
+func TestParseRecipeImportRejectsBadInput(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		req   recipeImportRequest
+		field string
+	}{
+		{name: "empty content", req: recipeImportRequest{Format: "html"}, field: "content"},
+		{name: "unknown format", req: recipeImportRequest{Format: "xml", Content: "<x/>"}, field: "format"},
+		{name: "no recipe", req: recipeImportRequest{Format: "html", Content: "<html></html>"}, field: "content"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseRecipeImport(tc.req)
+			apiErr, ok := asAPIError(err)
+			if !ok || apiErr.kind != apiErrorValidation {
+				t.Fatalf("err=%v, want validation error", err)
+			}
+			details, _ := apiErr.details.([]response.FieldError)
+			if len(details) != 1 || details[0].Field != tc.field {
+				t.Fatalf("details=%+v, want field %q", apiErr.details, tc.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+// recipeCloneRequest copies a recipe. A nil or blank Title names the copy
+// "<title> (copy)".
+type recipeCloneRequest struct {
+	Title *string `json:"title"`
+}
+
+// recipeLineageResponse identifies a parent recipe or one of its variants.
+type recipeLineageResponse struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// recipeParentDiffResponse compares a recipe with the recipe it was copied
+// from, reading the parent as "from" and the variant as "to".
+type recipeParentDiffResponse struct {
+	ParentID    string                   `json:"parent_id"`
+	Fields      []recipeFieldChange      `json:"fields"`
+	Tags        recipeTagsDiff           `json:"tags"`
+	Ingredients []recipeIngredientChange `json:"ingredients"`
+	Steps       []recipeStepChange       `json:"steps"`
+}
+
+func (a *App) handleRecipesClone(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req recipeCloneRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
+	parentID := pgtype.UUID{Bytes: id, Valid: true}
+
+	deletedAt, err := a.queries.GetRecipeDeletedAtByID(ctx, parentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+	if deletedAt.Valid {
+		return errConflict("recipe is deleted; restore before cloning")
+	}
+
+	clone, err := a.loadRecipeSnapshot(ctx, parentID)
+	if err != nil {
+		return errInternal(err)
+	}
+	title := ""
+	if req.Title != nil {
+		title = strings.TrimSpace(*req.Title)
+	}
+	if title == "" {
+		title = clone.Title + " (copy)"
+	}
+	clone.Title = title
+	parent := id.String()
+	clone.ParentRecipeID = &parent
+	if errs := validateCreateRecipeRequest(clone); len(errs) > 0 {
+		return errValidation(errs)
+	}
+
+	recipeID, _, err := createRecipeUsecase(ctx, a.recipeWorkflows(), userID, clone)
+	if err != nil {
+		return mapRecipeUsecaseError(err)
+	}
+
+	a.audit(r, "recipe.cloned", "recipe_id", uuidString(recipeID), "parent_recipe_id", parent)
+
+	detail, err := a.loadRecipeDetail(ctx, recipeID, userID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusCreated, detail); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/clone")
+	}
+	return nil
+}
+
+func (a *App) handleRecipesParentDiff(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	row, err := a.queries.GetRecipeByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+	if !row.ParentRecipeID.Valid {
+		return errConflict("recipe has no parent recipe")
+	}
+
+	parent, err := a.loadRecipeSnapshot(ctx, row.ParentRecipeID)
+	if err != nil {
+		return errInternal(err)
+	}
+	variant, err := a.loadRecipeSnapshot(ctx, row.ID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	diff := diffRecipeSnapshots(parent, variant)
+	resp := recipeParentDiffResponse{
+		ParentID:    uuidString(row.ParentRecipeID),
+		Fields:      diff.Fields,
+		Tags:        diff.Tags,
+		Ingredients: diff.Ingredients,
+		Steps:       diff.Steps,
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/parent/diff")
+	}
+	return nil
+}
+
+// loadRecipeSnapshot reads a recipe's current state in revision snapshot
+// form. A missing recipe is reported as pgx.ErrNoRows.
+func (a *App) loadRecipeSnapshot(ctx context.Context, id pgtype.UUID) (createRecipeRequest, error) {
+	raw, err := a.queries.GetRecipeSnapshot(ctx, id)
+	if err != nil {
+		return createRecipeRequest{}, err
+	}
+	if raw == nil {
+		return createRecipeRequest{}, pgx.ErrNoRows
+	}
+	var snapshot createRecipeRequest
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return createRecipeRequest{}, err
+	}
+	return snapshot, nil
+}
+
+// loadRecipeLineage returns the recipe a recipe was copied from, if any, and
+// the live recipes copied from it.
+func (a *App) loadRecipeLineage(ctx context.Context, row sqlc.Recipe) (*recipeLineageResponse, []recipeLineageResponse, error) {
+	var parent *recipeLineageResponse
+	if row.ParentRecipeID.Valid {
+		parentRow, err := a.queries.GetRecipeByID(ctx, row.ParentRecipeID)
+		if err != nil {
+			return nil, nil, err
+		}
+		parent = &recipeLineageResponse{ID: uuidString(parentRow.ID), Title: parentRow.Title}
+	}
+
+	rows, err := a.queries.ListRecipeVariants(ctx, row.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	variants := make([]recipeLineageResponse, 0, len(rows))
+	for _, variant := range rows {
+		variants = append(variants, recipeLineageResponse{ID: uuidString(variant.ID), Title: variant.Title})
+	}
+	return parent, variants, nil
+}
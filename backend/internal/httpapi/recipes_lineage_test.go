@@ -0,0 +1,168 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type recipeLineageResponse struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type testRecipeParentDiff struct {
+	ParentID string `json:"parent_id"`
+	Fields   []struct {
+		Field string `json:"field"`
+	} `json:"fields"`
+	Ingredients []struct {
+		Position int    `json:"position"`
+		Change   string `json:"change"`
+	} `json:"ingredients"`
+	Steps []struct {
+		StepNumber int    `json:"step_number"`
+		Change     string `json:"change"`
+	} `json:"steps"`
+}
+
+func TestRecipes_CloneLineage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	var chili recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"Grandma's chili",
+  "servings":6,
+  "prep_time_minutes":15,
+  "total_time_minutes":90,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[
+    {"position":1,"quantity":2,"unit":"lb","item_name":"ground beef"},
+    {"position":2,"quantity":1,"unit":"can","item_name":"kidney beans"}
+  ],
+  "steps":[{"step_number":1,"instruction":"Brown the beef."},{"step_number":2,"instruction":"Simmer with the beans."}]
+}`, http.StatusCreated, &chili)
+
+	var variant recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+chili.ID+"/clone",
+		`{"title":"Grandma's chili (no beans)"}`, http.StatusCreated, &variant)
+	if variant.Title != "Grandma's chili (no beans)" || variant.ParentRecipe == nil || variant.ParentRecipe.ID != chili.ID {
+		t.Fatalf("variant=%+v, want titled clone of %s", variant, chili.ID)
+	}
+	if len(variant.Ingredients) != 2 || len(variant.Steps) != 2 {
+		t.Fatalf("variant ingredients=%d steps=%d, want a full copy", len(variant.Ingredients), len(variant.Steps))
+	}
+
+	var copied recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+chili.ID+"/clone",
+		`{}`, http.StatusCreated, &copied)
+	if copied.Title != "Grandma's chili (copy)" {
+		t.Fatalf("title=%q, want default copy title", copied.Title)
+	}
+
+	var parent recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+chili.ID, "", http.StatusOK, &parent)
+	if parent.ParentRecipe != nil || len(parent.Variants) != 2 {
+		t.Fatalf("parent lineage=%+v/%+v, want two variants", parent.ParentRecipe, parent.Variants)
+	}
+
+	doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/recipes/"+variant.ID, `{
+  "title":"Grandma's chili (no beans)",
+  "servings":6,
+  "prep_time_minutes":15,
+  "total_time_minutes":90,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[{"position":1,"quantity":2,"unit":"lb","item_name":"ground beef"}],
+  "steps":[{"step_number":1,"instruction":"Brown the beef."},{"step_number":2,"instruction":"Simmer."}]
+}`, http.StatusOK, &variant)
+	if variant.ParentRecipe == nil || variant.ParentRecipe.ID != chili.ID {
+		t.Fatalf("parent after update=%+v, want lineage kept", variant.ParentRecipe)
+	}
+
+	var diff testRecipeParentDiff
+	doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+variant.ID+"/parent/diff", "", http.StatusOK, &diff)
+	if diff.ParentID != chili.ID || len(diff.Fields) != 1 || diff.Fields[0].Field != "title" {
+		t.Fatalf("diff=%+v, want only the title field changed", diff)
+	}
+	if len(diff.Ingredients) != 1 || diff.Ingredients[0].Position != 2 || diff.Ingredients[0].Change != "removed" {
+		t.Fatalf("ingredients=%+v, want beans removed", diff.Ingredients)
+	}
+	if len(diff.Steps) != 1 || diff.Steps[0].StepNumber != 2 || diff.Steps[0].Change != "changed" {
+		t.Fatalf("steps=%+v, want step 2 changed", diff.Steps)
+	}
+
+	doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+chili.ID+"/parent/diff", "", http.StatusConflict, nil)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"Orphan",
+  "servings":1,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "tag_ids":[],
+  "ingredients":[],
+  "steps":[{"step_number":1,"instruction":"Eat."}],
+  "parent_recipe_id":"00000000-0000-0000-0000-000000000001"
+}`, http.StatusBadRequest, nil)
+
+	doRevisionsRequest(t, client, csrf, http.MethodDelete, server.URL+"/api/v1/recipes/"+copied.ID, "", http.StatusNoContent, nil)
+	doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+chili.ID, "", http.StatusOK, &parent)
+	if len(parent.Variants) != 1 || parent.Variants[0].ID != variant.ID {
+		t.Fatalf("variants=%+v, want deleted copy hidden", parent.Variants)
+	}
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+copied.ID+"/clone", `{}`, http.StatusConflict, nil)
+}
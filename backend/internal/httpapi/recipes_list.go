@@ -1,11 +1,9 @@
 package httpapi
 
 import (
-	"encoding/base64"
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -23,8 +21,13 @@ type recipeListItemResponse struct {
 	Notes            *string             `json:"notes"`
 	RecipeBookID     *string             `json:"recipe_book_id"`
 	Tags             []recipeTagResponse `json:"tags"`
+	ImageURL         *string             `json:"image_url"`
+	ThumbnailURL     *string             `json:"thumbnail_url"`
+	IsFavorite       bool                `json:"is_favorite"`
 	DeletedAt        *string             `json:"deleted_at"`
 	UpdatedAt        string              `json:"updated_at"`
+	recipeCookStatsResponse
+	recipeDietaryResponse
 }
 
 type recipesListResponse struct {
@@ -33,7 +36,8 @@ type recipesListResponse struct {
 }
 
 func (a *App) handleRecipesList(w http.ResponseWriter, r *http.Request) error {
-	if _, ok := authInfoFromRequest(r); !ok {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
 		return errUnauthorized("unauthorized")
 	}
 
@@ -59,14 +63,9 @@ func (a *App) handleRecipesList(w http.ResponseWriter, r *http.Request) error {
 		bookID = pgtype.UUID{Bytes: parsed, Valid: true}
 	}
 
-	tagIDStr := strings.TrimSpace(qp.Get("tag_id"))
-	var tagID pgtype.UUID
-	if tagIDStr != "" {
-		parsed, err := uuid.Parse(tagIDStr)
-		if err != nil {
-			return errValidationField("tag_id", "invalid id")
-		}
-		tagID = pgtype.UUID{Bytes: parsed, Valid: true}
+	filters, err := parseRecipesListFilters(qp)
+	if err != nil {
+		return err
 	}
 
 	limit := 50
@@ -81,25 +80,47 @@ func (a *App) handleRecipesList(w http.ResponseWriter, r *http.Request) error {
 		limit = parsed
 	}
 
-	var cursorUpdatedAt pgtype.Timestamptz
-	var cursorID pgtype.UUID
-	if cursor := strings.TrimSpace(qp.Get("cursor")); cursor != "" {
-		updatedAt, id, ok := parseRecipesCursor(cursor)
-		if !ok {
+	sort, ok := parseRecipesSort(strings.TrimSpace(qp.Get("sort")))
+	if !ok {
+		return errValidationField("sort", "invalid sort")
+	}
+
+	var cursor recipesCursor
+	hasCursor := false
+	if v := strings.TrimSpace(qp.Get("cursor")); v != "" {
+		parsed, ok := parseRecipesCursor(v)
+		if !ok || parsed.sort != sort.String() {
 			return errValidationField("cursor", "invalid cursor")
 		}
-		cursorUpdatedAt = updatedAt
-		cursorID = id
+		cursor = parsed
+		hasCursor = true
 	}
 
 	rows, err := a.queries.ListRecipes(r.Context(), sqlc.ListRecipesParams{
-		Q:               q,
-		BookID:          bookID,
-		TagID:           tagID,
-		IncludeDeleted:  includeDeleted,
-		CursorUpdatedAt: cursorUpdatedAt,
-		CursorID:        cursorID,
-		PageLimit:       int32(limit + 1), //nolint:gosec // limit is bounded (<=200) above
+		Q:                 q,
+		Fuzzy:             recipesSearchFuzzy(q),
+		BookID:            bookID,
+		TagIds:            filters.tagIDs,
+		TagMatchAll:       filters.tagMatchAll,
+		WithItemIds:       filters.withItemIDs,
+		WithoutItemIds:    filters.withoutItemIDs,
+		ExcludeAttributes: filters.excludeAttributes,
+		MaxTotalTime:      filters.maxTotalTime,
+		MaxPrepTime:       filters.maxPrepTime,
+		ServingsMin:       filters.servingsMin,
+		ServingsMax:       filters.servingsMax,
+		IncludeDeleted:    includeDeleted,
+		FavoritesOnly:     filters.favoritesOnly,
+		UserID:            pgtype.UUID{Bytes: info.UserID, Valid: true},
+		HasCursor:         hasCursor,
+		SortKey:           sort.key,
+		SortDesc:          sort.desc,
+		CursorText:        cursor.text,
+		CursorInt:         cursor.number,
+		CursorTime:        cursor.time,
+		CursorRank:        cursor.rank,
+		CursorID:          cursor.id,
+		PageLimit:         int32(limit + 1), //nolint:gosec // limit is bounded (<=200) above
 	})
 	if err != nil {
 		return errInternal(err)
@@ -111,6 +132,8 @@ func (a *App) handleRecipesList(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	tagsByRecipeID := map[string][]recipeTagResponse{}
+	coverImageByRecipeID := map[string]string{}
+	dietaryByRecipeID := map[string]recipeDietaryItems{}
 	if len(rows) > 0 {
 		recipeIDs := make([]pgtype.UUID, 0, len(rows))
 		for _, row := range rows {
@@ -128,6 +151,19 @@ func (a *App) handleRecipesList(w http.ResponseWriter, r *http.Request) error {
 				Name: tr.Name,
 			})
 		}
+
+		coverRows, err := a.queries.ListRecipeCoverImagesByRecipeIDs(r.Context(), recipeIDs)
+		if err != nil {
+			return errInternal(err)
+		}
+		for _, cr := range coverRows {
+			coverImageByRecipeID[uuidString(cr.RecipeID)] = uuidString(cr.ID)
+		}
+
+		dietaryByRecipeID, err = a.loadRecipeDietaryItems(r.Context(), recipeIDs)
+		if err != nil {
+			return errInternal(err)
+		}
 	}
 
 	items := make([]recipeListItemResponse, 0, len(rows))
@@ -137,6 +173,16 @@ func (a *App) handleRecipesList(w http.ResponseWriter, r *http.Request) error {
 		if tags == nil {
 			tags = []recipeTagResponse{}
 		}
+		var imageURL, thumbnailURL *string
+		if imageID, ok := coverImageByRecipeID[id]; ok {
+			u, tu := recipeImageURL(id, imageID), recipeThumbnailURL(id, imageID)
+			imageURL, thumbnailURL = &u, &tu
+		}
+
+		cookStats, err := recipeCookStats(row.LastCookedAt, row.TimesCooked, row.AvgRating)
+		if err != nil {
+			return errInternal(err)
+		}
 
 		items = append(items, recipeListItemResponse{
 			ID:               id,
@@ -148,16 +194,21 @@ func (a *App) handleRecipesList(w http.ResponseWriter, r *http.Request) error {
 			Notes:            textStringPtr(row.Notes),
 			RecipeBookID:     uuidStringPtr(row.RecipeBookID),
 			Tags:             tags,
+			ImageURL:         imageURL,
+			ThumbnailURL:     thumbnailURL,
+			IsFavorite:       row.IsFavorite,
 			DeletedAt:        timeStringPtr(row.DeletedAt),
 			UpdatedAt:        timeString(row.UpdatedAt),
+
+			recipeCookStatsResponse: cookStats,
+			recipeDietaryResponse:   dietaryLabelsFromItems(dietaryByRecipeID[id]),
 		})
 	}
 
 	var nextCursor *string
 	if hasNext && len(rows) > 0 {
-		last := rows[len(rows)-1]
-		cursor := encodeRecipesCursor(last.UpdatedAt, last.ID)
-		nextCursor = &cursor
+		encoded := encodeRecipesCursor(newRecipesCursor(sort, rows[len(rows)-1]))
+		nextCursor = &encoded
 	}
 
 	if err := response.WriteJSON(w, http.StatusOK, recipesListResponse{Items: items, NextCursor: nextCursor}); err != nil {
@@ -165,31 +216,3 @@ func (a *App) handleRecipesList(w http.ResponseWriter, r *http.Request) error {
 	}
 	return nil
 }
-
-func encodeRecipesCursor(updatedAt pgtype.Timestamptz, id pgtype.UUID) string {
-	if !updatedAt.Valid || !id.Valid {
-		return ""
-	}
-	payload := strconv.FormatInt(updatedAt.Time.UTC().UnixNano(), 10) + ":" + uuidString(id)
-	return base64.RawURLEncoding.EncodeToString([]byte(payload))
-}
-
-func parseRecipesCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, bool) {
-	raw, err := base64.RawURLEncoding.DecodeString(cursor)
-	if err != nil {
-		return pgtype.Timestamptz{}, pgtype.UUID{}, false
-	}
-	parts := strings.SplitN(string(raw), ":", 2)
-	if len(parts) != 2 {
-		return pgtype.Timestamptz{}, pgtype.UUID{}, false
-	}
-	nanos, err := strconv.ParseInt(parts[0], 10, 64)
-	if err != nil {
-		return pgtype.Timestamptz{}, pgtype.UUID{}, false
-	}
-	id, err := uuid.Parse(parts[1])
-	if err != nil {
-		return pgtype.Timestamptz{}, pgtype.UUID{}, false
-	}
-	return pgtype.Timestamptz{Time: time.Unix(0, nanos).UTC(), Valid: true}, pgtype.UUID{Bytes: id, Valid: true}, true
-}
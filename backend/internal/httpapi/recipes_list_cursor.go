@@ -0,0 +1,165 @@
+package httpapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+)
+
+// Sort keys accepted by the recipes list. Prefixing a key with "-" reverses
+// it to descending order; relevance is always best match first. Recipes that
+// were never cooked sort before all others by last_cooked.
+const (
+	recipesSortTitle      = "title"
+	recipesSortTotalTime  = "total_time"
+	recipesSortPrepTime   = "prep_time"
+	recipesSortCreatedAt  = "created_at"
+	recipesSortUpdatedAt  = "updated_at"
+	recipesSortLastCooked = "last_cooked"
+	recipesSortRelevance  = "relevance"
+)
+
+// recipesFuzzyMinLength is the shortest query that enables typo tolerance;
+// shorter strings share too few trigrams to compare meaningfully.
+const recipesFuzzyMinLength = 3
+
+// recipesSearchFuzzy reports whether a query should also match titles by
+// trigram similarity. Only single words are eligible: typos in short queries
+// defeat stemming, while longer phrases are served well by full-text search.
+func recipesSearchFuzzy(q string) bool {
+	return utf8.RuneCountInString(q) >= recipesFuzzyMinLength && !strings.ContainsFunc(q, unicode.IsSpace)
+}
+
+// recipesSort is a parsed sort query parameter.
+type recipesSort struct {
+	key  string
+	desc bool
+}
+
+// parseRecipesSort parses the sort query parameter; the default is most
+// recently updated first.
+func parseRecipesSort(v string) (recipesSort, bool) {
+	switch v {
+	case "":
+		return recipesSort{key: recipesSortUpdatedAt, desc: true}, true
+	case recipesSortRelevance:
+		return recipesSort{key: recipesSortRelevance, desc: true}, true
+	}
+	key := strings.TrimPrefix(v, "-")
+	switch key {
+	case recipesSortTitle, recipesSortTotalTime, recipesSortPrepTime, recipesSortCreatedAt, recipesSortUpdatedAt, recipesSortLastCooked:
+		return recipesSort{key: key, desc: key != v}, true
+	default:
+		return recipesSort{}, false
+	}
+}
+
+// String returns the canonical sort parameter value.
+func (s recipesSort) String() string {
+	if s.desc && s.key != recipesSortRelevance {
+		return "-" + s.key
+	}
+	return s.key
+}
+
+// recipesCursor is the keyset position of the last row on a page. Only the
+// fields used by the cursor's sort are populated.
+type recipesCursor struct {
+	sort   string
+	id     pgtype.UUID
+	text   string
+	number int32
+	time   pgtype.Timestamptz
+	rank   float64
+}
+
+// recipesCursorPayload is the JSON form of a recipesCursor before base64
+// encoding. Times are Unix nanoseconds.
+type recipesCursorPayload struct {
+	Sort   string  `json:"s"`
+	ID     string  `json:"id"`
+	Text   string  `json:"t,omitempty"`
+	Number int32   `json:"n,omitempty"`
+	Time   int64   `json:"ts,omitempty"`
+	Rank   float64 `json:"r,omitempty"`
+}
+
+// newRecipesCursor captures the sort values of row for the given ordering.
+func newRecipesCursor(sort recipesSort, row sqlc.ListRecipesRow) recipesCursor {
+	c := recipesCursor{sort: sort.String(), id: row.ID}
+	switch sort.key {
+	case recipesSortTitle:
+		c.text = row.Title
+	case recipesSortTotalTime:
+		c.number = row.TotalTimeMinutes
+	case recipesSortPrepTime:
+		c.number = row.PrepTimeMinutes
+	case recipesSortCreatedAt:
+		c.time = row.CreatedAt
+	case recipesSortLastCooked:
+		c.time = row.LastCookedAt
+	case recipesSortRelevance:
+		c.rank = row.Rank
+		c.time = row.UpdatedAt
+	default:
+		c.time = row.UpdatedAt
+	}
+	return c
+}
+
+func encodeRecipesCursor(c recipesCursor) string {
+	if !c.id.Valid {
+		return ""
+	}
+	payload := recipesCursorPayload{
+		Sort:   c.sort,
+		ID:     uuidString(c.id),
+		Text:   c.text,
+		Number: c.number,
+		Rank:   c.rank,
+	}
+	if c.time.Valid {
+		payload.Time = c.time.Time.UTC().UnixNano()
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func parseRecipesCursor(cursor string) (recipesCursor, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return recipesCursor{}, false
+	}
+	var payload recipesCursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return recipesCursor{}, false
+	}
+	if _, ok := parseRecipesSort(payload.Sort); !ok || payload.Sort == "" {
+		return recipesCursor{}, false
+	}
+	id, err := uuid.Parse(payload.ID)
+	if err != nil {
+		return recipesCursor{}, false
+	}
+	c := recipesCursor{
+		sort:   payload.Sort,
+		id:     pgtype.UUID{Bytes: id, Valid: true},
+		text:   payload.Text,
+		number: payload.Number,
+		rank:   payload.Rank,
+	}
+	if payload.Time != 0 {
+		c.time = pgtype.Timestamptz{Time: time.Unix(0, payload.Time).UTC(), Valid: true}
+	}
+	return c, true
+}
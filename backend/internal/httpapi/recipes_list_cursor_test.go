@@ -0,0 +1,113 @@
+package httpapi
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+)
+
+func TestParseRecipesSort(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]recipesSort{
+		"":             {key: recipesSortUpdatedAt, desc: true},
+		"title":        {key: recipesSortTitle},
+		"-title":       {key: recipesSortTitle, desc: true},
+		"total_time":   {key: recipesSortTotalTime},
+		"-prep_time":   {key: recipesSortPrepTime, desc: true},
+		"created_at":   {key: recipesSortCreatedAt},
+		"-updated_at":  {key: recipesSortUpdatedAt, desc: true},
+		"relevance":    {key: recipesSortRelevance, desc: true},
+		"last_cooked":  {key: recipesSortLastCooked},
+		"-last_cooked": {key: recipesSortLastCooked, desc: true},
+	}
+	for raw, want := range cases {
+		got, ok := parseRecipesSort(raw)
+		if !ok || got != want {
+			t.Fatalf("parseRecipesSort(%q)=%+v,%v want %+v", raw, got, ok, want)
+		}
+	}
+
+	for _, raw := range []string{"name", "-relevance", "--title", "Title"} {
+		if _, ok := parseRecipesSort(raw); ok {
+			t.Fatalf("parseRecipesSort(%q) ok, want failure", raw)
+		}
+	}
+}
+
+func TestRecipesCursorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	row := sqlc.ListRecipesRow{
+		ID:               pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Title:            "Chicken: Soup",
+		PrepTimeMinutes:  15,
+		TotalTimeMinutes: 60,
+		CreatedAt:        pgtype.Timestamptz{Time: time.Date(2025, 12, 12, 9, 0, 0, 1000, time.UTC), Valid: true},
+		UpdatedAt:        pgtype.Timestamptz{Time: time.Date(2025, 12, 13, 10, 0, 0, 123000, time.UTC), Valid: true},
+		LastCookedAt:     pgtype.Timestamptz{Time: time.Date(2025, 12, 14, 18, 30, 0, 0, time.UTC), Valid: true},
+		Rank:             0.6079271,
+	}
+	for _, raw := range []string{"", "title", "-total_time", "prep_time", "-created_at", "updated_at", "relevance", "-last_cooked"} {
+		t.Run(raw, func(t *testing.T) {
+			t.Parallel()
+
+			sort, ok := parseRecipesSort(raw)
+			if !ok {
+				t.Fatalf("parse sort %q", raw)
+			}
+			want := newRecipesCursor(sort, row)
+			got, ok := parseRecipesCursor(encodeRecipesCursor(want))
+			if !ok {
+				t.Fatalf("parse cursor failed")
+			}
+			if got.sort != sort.String() || got.id != want.id || got.text != want.text || got.number != want.number || got.rank != want.rank {
+				t.Fatalf("got %+v, want %+v", got, want)
+			}
+			if got.time.Valid != want.time.Valid || !got.time.Time.Equal(want.time.Time) {
+				t.Fatalf("time=%v, want %v", got.time, want.time)
+			}
+		})
+	}
+}
+
+func TestParseRecipesCursorRejectsInvalid(t *testing.T) {
+	t.Parallel()
+
+	encode := func(payload string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(payload))
+	}
+	for _, raw := range []string{
+		"",
+		"not base64!",
+		encode("123:" + uuid.NewString()),
+		encode(`{"s":"title","id":"not-a-uuid"}`),
+		encode(`{"s":"name","id":"` + uuid.NewString() + `"}`),
+		encode(`{"id":"` + uuid.NewString() + `"}`),
+	} {
+		if _, ok := parseRecipesCursor(raw); ok {
+			t.Fatalf("parseRecipesCursor(%q) ok, want failure", raw)
+		}
+	}
+}
+
+func TestRecipesSearchFuzzy(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		"":             false,
+		"eg":           false,
+		"chiken":       true,
+		"crème":        true,
+		"chicken soup": false,
+	}
+	for q, want := range cases {
+		if got := recipesSearchFuzzy(q); got != want {
+			t.Fatalf("recipesSearchFuzzy(%q)=%v, want %v", q, got, want)
+		}
+	}
+}
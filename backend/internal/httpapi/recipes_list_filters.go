@@ -0,0 +1,135 @@
+package httpapi
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxRecipesListFilterIDs caps repeated id filters so a single request cannot
+// expand into an unbounded array comparison.
+const maxRecipesListFilterIDs = 50
+
+const (
+	recipesTagModeAll = "all"
+	recipesTagModeAny = "any"
+)
+
+// recipesListFilters holds the optional narrowing filters for the recipes list.
+type recipesListFilters struct {
+	tagIDs            []pgtype.UUID
+	tagMatchAll       bool
+	withItemIDs       []pgtype.UUID
+	withoutItemIDs    []pgtype.UUID
+	excludeAttributes []string
+	maxTotalTime      pgtype.Int4
+	maxPrepTime       pgtype.Int4
+	servingsMin       pgtype.Int4
+	servingsMax       pgtype.Int4
+	favoritesOnly     bool
+}
+
+// parseRecipesListFilters reads tag, time, servings, ingredient, dietary, and
+// favorites filters. tag_id, with_item, without_item, diet, and
+// exclude_allergen may be repeated; tags default to matching all of the given
+// ids.
+func parseRecipesListFilters(qp url.Values) (recipesListFilters, error) {
+	var filters recipesListFilters
+	var err error
+
+	if filters.tagIDs, err = parseUUIDListQuery(qp, "tag_id"); err != nil {
+		return recipesListFilters{}, err
+	}
+	switch mode := strings.TrimSpace(qp.Get("tag_mode")); mode {
+	case "", recipesTagModeAll:
+		filters.tagMatchAll = true
+	case recipesTagModeAny:
+	default:
+		return recipesListFilters{}, errValidationField("tag_mode", "invalid tag_mode")
+	}
+	if filters.withItemIDs, err = parseUUIDListQuery(qp, "with_item"); err != nil {
+		return recipesListFilters{}, err
+	}
+	if filters.withoutItemIDs, err = parseUUIDListQuery(qp, "without_item"); err != nil {
+		return recipesListFilters{}, err
+	}
+
+	if filters.excludeAttributes, err = parseDietaryQuery(qp); err != nil {
+		return recipesListFilters{}, err
+	}
+
+	if filters.maxTotalTime, err = parseInt4Query(qp, "max_total_time", 0); err != nil {
+		return recipesListFilters{}, err
+	}
+	if filters.maxPrepTime, err = parseInt4Query(qp, "max_prep_time", 0); err != nil {
+		return recipesListFilters{}, err
+	}
+	if filters.servingsMin, err = parseInt4Query(qp, "servings_min", 1); err != nil {
+		return recipesListFilters{}, err
+	}
+	if filters.servingsMax, err = parseInt4Query(qp, "servings_max", 1); err != nil {
+		return recipesListFilters{}, err
+	}
+	if filters.servingsMin.Valid && filters.servingsMax.Valid && filters.servingsMin.Int32 > filters.servingsMax.Int32 {
+		return recipesListFilters{}, errValidationField("servings_max", "servings_max must be at least servings_min")
+	}
+
+	if v := strings.TrimSpace(qp.Get("favorites")); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return recipesListFilters{}, errValidationField("favorites", "invalid boolean")
+		}
+		filters.favoritesOnly = parsed
+	}
+
+	return filters, nil
+}
+
+// parseUUIDListQuery parses every value of a repeatable id query parameter,
+// ignoring blanks.
+func parseUUIDListQuery(qp url.Values, name string) ([]pgtype.UUID, error) {
+	ids, err := uuidsToPG(qp[name])
+	if err != nil {
+		return nil, errValidationField(name, "invalid id")
+	}
+	if len(ids) > maxRecipesListFilterIDs {
+		return nil, errValidationField(name, "too many ids")
+	}
+	return ids, nil
+}
+
+// parseDietaryQuery turns diet and exclude_allergen values into the item
+// attributes a matching recipe must not contain.
+func parseDietaryQuery(qp url.Values) ([]string, error) {
+	diets, invalid, ok := normalizeDietaryValues(qp["diet"], dietNames)
+	if !ok {
+		return nil, errValidationField("diet", "unknown diet "+invalid)
+	}
+	allergens, invalid, ok := normalizeDietaryValues(qp["exclude_allergen"], dietaryAllergens)
+	if !ok {
+		return nil, errValidationField("exclude_allergen", "unknown allergen "+invalid)
+	}
+
+	excluded := allergens
+	for _, diet := range diets {
+		excluded = append(excluded, dietExclusions[diet]...)
+	}
+	slices.Sort(excluded)
+	return slices.Compact(excluded), nil
+}
+
+// parseInt4Query parses an optional integer query parameter no smaller than minValue.
+func parseInt4Query(qp url.Values, name string, minValue int) (pgtype.Int4, error) {
+	v := strings.TrimSpace(qp.Get(name))
+	if v == "" {
+		return pgtype.Int4{}, nil
+	}
+	parsed, err := strconv.Atoi(v)
+	if err != nil || parsed < minValue || parsed > maxInt32 {
+		return pgtype.Int4{}, errValidationField(name, "invalid "+name)
+	}
+	return pgtype.Int4{Int32: int32(parsed), Valid: true}, nil //nolint:gosec // bounds checked above
+}
@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+func TestParseRecipesListFilters(t *testing.T) {
+	t.Parallel()
+
+	tagA, tagB, item := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	qp := url.Values{
+		"tag_id":           {tagA, " ", tagB},
+		"tag_mode":         {"any"},
+		"without_item":     {item},
+		"max_total_time":   {"30"},
+		"servings_min":     {"2"},
+		"servings_max":     {"2"},
+		"favorites":        {"true"},
+		"diet":             {"vegetarian", "nut-free"},
+		"exclude_allergen": {"Nuts", "sesame"},
+	}
+
+	filters, err := parseRecipesListFilters(qp)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(filters.tagIDs) != 2 || filters.tagMatchAll {
+		t.Fatalf("tags=%v matchAll=%v, want 2 ids matching any", filters.tagIDs, filters.tagMatchAll)
+	}
+	if len(filters.withItemIDs) != 0 || len(filters.withoutItemIDs) != 1 {
+		t.Fatalf("with=%v without=%v", filters.withItemIDs, filters.withoutItemIDs)
+	}
+	if !filters.maxTotalTime.Valid || filters.maxTotalTime.Int32 != 30 || filters.maxPrepTime.Valid {
+		t.Fatalf("max_total_time=%v max_prep_time=%v", filters.maxTotalTime, filters.maxPrepTime)
+	}
+	if !filters.favoritesOnly {
+		t.Fatalf("favoritesOnly=false, want true")
+	}
+	if want := []string{"fish", "meat", "nuts", "peanuts", "sesame", "shellfish"}; !slices.Equal(filters.excludeAttributes, want) {
+		t.Fatalf("excludeAttributes=%v, want %v", filters.excludeAttributes, want)
+	}
+
+	defaults, err := parseRecipesListFilters(url.Values{})
+	if err != nil {
+		t.Fatalf("parse defaults: %v", err)
+	}
+	if !defaults.tagMatchAll || defaults.tagIDs == nil || defaults.favoritesOnly {
+		t.Fatalf("defaults=%+v, want match-all with empty tag ids", defaults)
+	}
+}
+
+func TestParseRecipesListFiltersErrors(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make([]string, maxRecipesListFilterIDs+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.NewString()
+	}
+	cases := map[string]struct {
+		qp    url.Values
+		field string
+	}{
+		"bad tag id":        {url.Values{"tag_id": {"nope"}}, "tag_id"},
+		"bad tag mode":      {url.Values{"tag_mode": {"some"}}, "tag_mode"},
+		"bad with_item":     {url.Values{"with_item": {"nope"}}, "with_item"},
+		"too many items":    {url.Values{"without_item": tooMany}, "without_item"},
+		"negative time":     {url.Values{"max_total_time": {"-1"}}, "max_total_time"},
+		"zero servings":     {url.Values{"servings_min": {"0"}}, "servings_min"},
+		"inverted servings": {url.Values{"servings_min": {"4"}, "servings_max": {"2"}}, "servings_max"},
+		"bad favorites":     {url.Values{"favorites": {"maybe"}}, "favorites"},
+		"unknown diet":      {url.Values{"diet": {"keto"}}, "diet"},
+		"meat allergen":     {url.Values{"exclude_allergen": {"meat"}}, "exclude_allergen"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseRecipesListFilters(tc.qp)
+			var apiErr *apiError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err=%v, want *apiError", err)
+			}
+			details, ok := apiErr.details.([]response.FieldError)
+			if !ok || len(details) != 1 || details[0].Field != tc.field {
+				t.Fatalf("details=%v, want field %s", apiErr.details, tc.field)
+			}
+		})
+	}
+}
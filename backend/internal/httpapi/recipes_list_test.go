@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
 	"github.com/saiaj/cooking_app/backend/internal/config"
 	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
@@ -93,6 +94,7 @@ func TestRecipes_List(t *testing.T) {
 		Servings:         6,
 		PrepTimeMinutes:  20,
 		TotalTimeMinutes: 90,
+		Notes:            pgtype.Text{String: "Serve with a side of soup.", Valid: true},
 		RecipeBookID:     bookB.ID,
 		CreatedBy:        user.ID,
 		UpdatedBy:        user.ID,
@@ -140,6 +142,30 @@ func TestRecipes_List(t *testing.T) {
 		t.Fatalf("create beef tag: %v", tagErr)
 	}
 
+	carrot, carrotErr := queries.CreateItem(ctx, sqlc.CreateItemParams{
+		Name:      "Carrot",
+		CreatedBy: user.ID,
+		UpdatedBy: user.ID,
+	})
+	if carrotErr != nil {
+		t.Fatalf("create carrot: %v", carrotErr)
+	}
+	if ingredientErr := queries.CreateRecipeIngredient(ctx, sqlc.CreateRecipeIngredientParams{
+		RecipeID:  recipeBeef.ID,
+		Position:  1,
+		ItemID:    carrot.ID,
+		CreatedBy: user.ID,
+		UpdatedBy: user.ID,
+	}); ingredientErr != nil {
+		t.Fatalf("create beef ingredient: %v", ingredientErr)
+	}
+
+	for _, id := range []pgtype.UUID{recipeChicken.ID, recipeBeef.ID, recipeDeleted.ID} {
+		if refreshErr := queries.RefreshRecipeSearchDocument(ctx, id); refreshErr != nil {
+			t.Fatalf("refresh search document: %v", refreshErr)
+		}
+	}
+
 	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
 		DatabaseURL:         postgres.DatabaseURL,
 		LogLevel:            "error",
@@ -208,6 +234,75 @@ func TestRecipes_List(t *testing.T) {
 		}
 	})
 
+	t.Run("q searches notes", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("q", "soup")
+		out := getRecipesList(t, client, server.URL, q)
+		if len(out.Items) != 2 || out.Items[0].Title != recipeTitleBeefStew {
+			t.Fatalf("items=%v, want [Beef Stew, Chicken Soup]", out.Items)
+		}
+	})
+
+	t.Run("sort=relevance ranks title matches first", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("q", "soup")
+		q.Set("sort", "relevance")
+		q.Set("limit", "1")
+		page1 := getRecipesList(t, client, server.URL, q)
+		if len(page1.Items) != 1 || page1.Items[0].Title != recipeTitleChickenSoup {
+			t.Fatalf("page1 items=%v, want %s", page1.Items, recipeTitleChickenSoup)
+		}
+		if page1.NextCursor == nil {
+			t.Fatalf("page1 next_cursor missing")
+		}
+
+		q.Set("cursor", *page1.NextCursor)
+		page2 := getRecipesList(t, client, server.URL, q)
+		if len(page2.Items) != 1 || page2.Items[0].Title != recipeTitleBeefStew {
+			t.Fatalf("page2 items=%v, want %s", page2.Items, recipeTitleBeefStew)
+		}
+	})
+
+	t.Run("sort=title paginates alphabetically", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("sort", "title")
+		q.Set("limit", "1")
+		page1 := getRecipesList(t, client, server.URL, q)
+		if len(page1.Items) != 1 || page1.Items[0].Title != recipeTitleBeefStew {
+			t.Fatalf("page1 items=%v, want %s", page1.Items, recipeTitleBeefStew)
+		}
+		if page1.NextCursor == nil {
+			t.Fatalf("page1 next_cursor missing")
+		}
+
+		q.Set("cursor", *page1.NextCursor)
+		page2 := getRecipesList(t, client, server.URL, q)
+		if len(page2.Items) != 1 || page2.Items[0].Title != recipeTitleChickenSoup {
+			t.Fatalf("page2 items=%v, want %s", page2.Items, recipeTitleChickenSoup)
+		}
+		if page2.NextCursor != nil {
+			t.Fatalf("page2 next_cursor=%v, want nil", *page2.NextCursor)
+		}
+	})
+
+	t.Run("sort=-total_time orders longest first", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("sort", "-total_time")
+		out := getRecipesList(t, client, server.URL, q)
+		if len(out.Items) != 2 || out.Items[0].Title != recipeTitleBeefStew || out.Items[1].Title != recipeTitleChickenSoup {
+			t.Fatalf("items=%v, want [Beef Stew, Chicken Soup]", out.Items)
+		}
+	})
+
+	t.Run("q tolerates typos in single words", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("q", "chiken")
+		out := getRecipesList(t, client, server.URL, q)
+		if len(out.Items) != 1 || out.Items[0].Title != recipeTitleChickenSoup {
+			t.Fatalf("items=%v, want %s", out.Items, recipeTitleChickenSoup)
+		}
+	})
+
 	t.Run("book_id filters by recipe book", func(t *testing.T) {
 		q := url.Values{}
 		q.Set("book_id", uuid.UUID(bookB.ID.Bytes).String())
@@ -229,6 +324,57 @@ func TestRecipes_List(t *testing.T) {
 		}
 	})
 
+	t.Run("repeated tag_id matches all tags by default", func(t *testing.T) {
+		q := url.Values{}
+		q.Add("tag_id", uuid.UUID(tagSoup.ID.Bytes).String())
+		q.Add("tag_id", uuid.UUID(tagBeef.ID.Bytes).String())
+		out := getRecipesList(t, client, server.URL, q)
+		if len(out.Items) != 0 {
+			t.Fatalf("items=%v, want none", out.Items)
+		}
+
+		q.Set("tag_mode", "any")
+		out = getRecipesList(t, client, server.URL, q)
+		if len(out.Items) != 2 {
+			t.Fatalf("items=%v, want 2", out.Items)
+		}
+	})
+
+	t.Run("time and servings limits", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("max_total_time", "60")
+		out := getRecipesList(t, client, server.URL, q)
+		if len(out.Items) != 1 || out.Items[0].Title != recipeTitleChickenSoup {
+			t.Fatalf("max_total_time items=%v, want %s", out.Items, recipeTitleChickenSoup)
+		}
+
+		q = url.Values{}
+		q.Set("servings_min", "5")
+		q.Set("max_prep_time", "20")
+		out = getRecipesList(t, client, server.URL, q)
+		if len(out.Items) != 1 || out.Items[0].Title != recipeTitleBeefStew {
+			t.Fatalf("servings_min items=%v, want %s", out.Items, recipeTitleBeefStew)
+		}
+	})
+
+	t.Run("with_item and without_item filter by ingredient", func(t *testing.T) {
+		carrotID := uuid.UUID(carrot.ID.Bytes).String()
+
+		q := url.Values{}
+		q.Set("with_item", carrotID)
+		out := getRecipesList(t, client, server.URL, q)
+		if len(out.Items) != 1 || out.Items[0].Title != recipeTitleBeefStew {
+			t.Fatalf("with_item items=%v, want %s", out.Items, recipeTitleBeefStew)
+		}
+
+		q = url.Values{}
+		q.Set("without_item", carrotID)
+		out = getRecipesList(t, client, server.URL, q)
+		if len(out.Items) != 1 || out.Items[0].Title != recipeTitleChickenSoup {
+			t.Fatalf("without_item items=%v, want %s", out.Items, recipeTitleChickenSoup)
+		}
+	})
+
 	t.Run("cursor pagination", func(t *testing.T) {
 		q := url.Values{}
 		q.Set("limit", "1")
@@ -286,10 +432,16 @@ type recipeListTagResponse struct {
 }
 
 type recipeListItemResponse struct {
-	ID        string                  `json:"id"`
-	Title     string                  `json:"title"`
-	Tags      []recipeListTagResponse `json:"tags"`
-	UpdatedAt string                  `json:"updated_at"`
+	ID           string                  `json:"id"`
+	Title        string                  `json:"title"`
+	Tags         []recipeListTagResponse `json:"tags"`
+	UpdatedAt    string                  `json:"updated_at"`
+	LastCookedAt *string                 `json:"last_cooked_at"`
+	TimesCooked  int                     `json:"times_cooked"`
+	AvgRating    *float64                `json:"avg_rating"`
+	IsFavorite   bool                    `json:"is_favorite"`
+	Allergens    []string                `json:"allergens"`
+	Diets        []string                `json:"diets"`
 }
 
 type recipesListResponse struct {
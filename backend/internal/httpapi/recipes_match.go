@@ -0,0 +1,290 @@
+package httpapi
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+const (
+	defaultRecipeMatchLimit = 20
+	maxRecipeMatchLimit     = 100
+	maxRecipeMatchItems     = 200
+
+	// recipeMatchCoveragePrecision rounds coverage to three decimal places.
+	recipeMatchCoveragePrecision = 1000
+)
+
+// stapleItemNames lists items most kitchens always have. With ignore_staples
+// they neither count towards a recipe's coverage nor show up as missing.
+var stapleItemNames = map[string]struct{}{
+	"black pepper":        {},
+	"ground black pepper": {},
+	"ice":                 {},
+	"kosher salt":         {},
+	"salt":                {},
+	"sea salt":            {},
+	"table salt":          {},
+	"water":               {},
+}
+
+// recipeMatchRequest lists the items on hand by id, by name, or both.
+type recipeMatchRequest struct {
+	ItemIDs       []string `json:"item_ids"`
+	ItemNames     []string `json:"item_names"`
+	IgnoreStaples bool     `json:"ignore_staples"`
+	MaxMissing    *int     `json:"max_missing"`
+	Limit         *int     `json:"limit"`
+}
+
+type recipeMatchItemResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// recipeMatchRecipeResponse is one recipe that uses at least one item on
+// hand. Coverage is have_count / ingredient_count.
+type recipeMatchRecipeResponse struct {
+	ID              string                    `json:"id"`
+	Title           string                    `json:"title"`
+	IngredientCount int                       `json:"ingredient_count"`
+	HaveCount       int                       `json:"have_count"`
+	Coverage        float64                   `json:"coverage"`
+	Missing         []recipeMatchItemResponse `json:"missing"`
+}
+
+// recipeMatchResponse echoes the resolved items, names that matched no item,
+// and the ranked recipes.
+type recipeMatchResponse struct {
+	Items        []recipeMatchItemResponse   `json:"items"`
+	UnknownNames []string                    `json:"unknown_names"`
+	Recipes      []recipeMatchRecipeResponse `json:"recipes"`
+}
+
+// recipeMatchOptions controls ranking.
+type recipeMatchOptions struct {
+	ignoreStaples bool
+	maxMissing    int
+	limit         int
+}
+
+// handleRecipesMatch ranks recipes by how many of their ingredient items are
+// on hand.
+func (a *App) handleRecipesMatch(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	var req recipeMatchRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+
+	itemIDs, names, opts, err := validateRecipeMatchRequest(req)
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	lookups, requested, priorities := recipeMatchNameLookups(names)
+	have, err := a.queries.ListItemsByIDsOrNames(ctx, sqlc.ListItemsByIDsOrNamesParams{
+		Lookups:    lookups,
+		Requested:  requested,
+		Priorities: priorities,
+		ItemIds:    itemIDs,
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+	if missing := missingRecipeMatchItemID(itemIDs, have); missing != "" {
+		return errValidationField("item_ids", "item "+missing+" does not exist")
+	}
+
+	rows, err := a.queries.ListRecipeMatchItems(ctx)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	resp := recipeMatchResponse{
+		Items:        make([]recipeMatchItemResponse, 0, len(have)),
+		UnknownNames: unknownRecipeMatchNames(names, have),
+		Recipes:      rankRecipeMatches(rows, have, opts),
+	}
+	for _, item := range have {
+		resp.Items = append(resp.Items, recipeMatchItemResponse{ID: uuidString(item.ID), Name: item.Name})
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/match")
+	}
+	return nil
+}
+
+// validateRecipeMatchRequest parses ids, lowercases and de-duplicates names,
+// and applies option defaults. max_missing defaults to no limit.
+func validateRecipeMatchRequest(req recipeMatchRequest) ([]pgtype.UUID, []string, recipeMatchOptions, error) {
+	opts := recipeMatchOptions{
+		ignoreStaples: req.IgnoreStaples,
+		maxMissing:    math.MaxInt,
+		limit:         defaultRecipeMatchLimit,
+	}
+
+	var errs []response.FieldError
+	itemIDs, err := uuidsToPG(req.ItemIDs)
+	if err != nil {
+		errs = append(errs, response.FieldError{Field: "item_ids", Message: "invalid id"})
+	}
+	names := make([]string, 0, len(req.ItemNames))
+	seen := make(map[string]struct{}, len(req.ItemNames))
+	for _, raw := range req.ItemNames {
+		name := strings.ToLower(strings.TrimSpace(raw))
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	switch total := len(itemIDs) + len(names); {
+	case total == 0 && err == nil:
+		errs = append(errs, response.FieldError{Field: "item_ids", Message: "item_ids or item_names is required"})
+	case total > maxRecipeMatchItems:
+		errs = append(errs, response.FieldError{Field: "item_ids", Message: "too many items"})
+	}
+	if req.MaxMissing != nil {
+		if *req.MaxMissing < 0 {
+			errs = append(errs, response.FieldError{Field: "max_missing", Message: "max_missing must be >= 0"})
+		}
+		opts.maxMissing = *req.MaxMissing
+	}
+	if req.Limit != nil {
+		if *req.Limit <= 0 {
+			errs = append(errs, response.FieldError{Field: "limit", Message: "invalid limit"})
+		}
+		opts.limit = min(*req.Limit, maxRecipeMatchLimit)
+	}
+	if len(errs) > 0 {
+		return nil, nil, recipeMatchOptions{}, errValidation(errs)
+	}
+	return itemIDs, names, opts, nil
+}
+
+// recipeMatchNameLookups expands each requested name into the names it is
+// looked up by: itself, then its singular or plural forms, matching how
+// ingredient names resolve to items. The three slices are parallel.
+func recipeMatchNameLookups(names []string) ([]string, []string, []int32) {
+	lookups := make([]string, 0, len(names))
+	requested := make([]string, 0, len(names))
+	priorities := make([]int32, 0, len(names))
+	for _, name := range names {
+		for i, lookup := range append([]string{name}, itemNameVariants(name)...) {
+			lookups = append(lookups, lookup)
+			requested = append(requested, name)
+			priorities = append(priorities, int32(i)) //nolint:gosec // a handful of variants per name
+		}
+	}
+	return lookups, requested, priorities
+}
+
+// missingRecipeMatchItemID returns the first requested id that matched no
+// item, or "" when all were found.
+func missingRecipeMatchItemID(itemIDs []pgtype.UUID, have []sqlc.ListItemsByIDsOrNamesRow) string {
+	found := make(map[[16]byte]struct{}, len(have))
+	for _, item := range have {
+		found[item.ID.Bytes] = struct{}{}
+	}
+	for _, id := range itemIDs {
+		if _, ok := found[id.Bytes]; !ok {
+			return uuidString(id)
+		}
+	}
+	return ""
+}
+
+// unknownRecipeMatchNames returns the requested names that matched no item.
+func unknownRecipeMatchNames(names []string, have []sqlc.ListItemsByIDsOrNamesRow) []string {
+	found := make(map[string]struct{}, len(have))
+	for _, item := range have {
+		for _, name := range item.RequestedNames {
+			found[name] = struct{}{}
+		}
+	}
+	unknown := []string{}
+	for _, name := range names {
+		if _, ok := found[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// rankRecipeMatches scores each recipe by the share of its items on hand.
+// Recipes using none of the items, or missing more than opts.maxMissing, are
+// dropped. The best coverage comes first, then the fewest missing items.
+func rankRecipeMatches(rows []sqlc.ListRecipeMatchItemsRow, have []sqlc.ListItemsByIDsOrNamesRow, opts recipeMatchOptions) []recipeMatchRecipeResponse {
+	onHand := make(map[[16]byte]struct{}, len(have))
+	for _, item := range have {
+		onHand[item.ID.Bytes] = struct{}{}
+	}
+
+	byRecipe := map[[16]byte]*recipeMatchRecipeResponse{}
+	order := [][16]byte{}
+	for _, row := range rows {
+		if opts.ignoreStaples {
+			if _, staple := stapleItemNames[strings.ToLower(row.ItemName)]; staple {
+				continue
+			}
+		}
+		match, ok := byRecipe[row.RecipeID.Bytes]
+		if !ok {
+			match = &recipeMatchRecipeResponse{
+				ID:      uuidString(row.RecipeID),
+				Title:   row.RecipeTitle,
+				Missing: []recipeMatchItemResponse{},
+			}
+			byRecipe[row.RecipeID.Bytes] = match
+			order = append(order, row.RecipeID.Bytes)
+		}
+		match.IngredientCount++
+		if _, ok := onHand[row.ItemID.Bytes]; ok {
+			match.HaveCount++
+			continue
+		}
+		match.Missing = append(match.Missing, recipeMatchItemResponse{ID: uuidString(row.ItemID), Name: row.ItemName})
+	}
+
+	out := []recipeMatchRecipeResponse{}
+	for _, id := range order {
+		match := byRecipe[id]
+		if match.HaveCount == 0 || len(match.Missing) > opts.maxMissing {
+			continue
+		}
+		coverage := float64(match.HaveCount) / float64(match.IngredientCount)
+		match.Coverage = math.Round(coverage*recipeMatchCoveragePrecision) / recipeMatchCoveragePrecision
+		out = append(out, *match)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Coverage != out[j].Coverage {
+			return out[i].Coverage > out[j].Coverage
+		}
+		if len(out[i].Missing) != len(out[j].Missing) {
+			return len(out[i].Missing) < len(out[j].Missing)
+		}
+		if out[i].Title != out[j].Title {
+			return out[i].Title < out[j].Title
+		}
+		return out[i].ID < out[j].ID
+	})
+	if len(out) > opts.limit {
+		out = out[:opts.limit]
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package httpapi
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+)
+
+func TestRankRecipeMatches(t *testing.T) {
+	t.Parallel()
+
+	item := func(name string) sqlc.ListItemsByIDsOrNamesRow {
+		return sqlc.ListItemsByIDsOrNamesRow{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Name: name}
+	}
+	eggs, spinach, feta, salt, flour, milk := item("Eggs"), item("Spinach"), item("Feta"), item("Salt"), item("Flour"), item("Milk")
+
+	var rows []sqlc.ListRecipeMatchItemsRow
+	recipe := func(title string, items ...sqlc.ListItemsByIDsOrNamesRow) {
+		id := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		for _, it := range items {
+			rows = append(rows, sqlc.ListRecipeMatchItemsRow{RecipeID: id, RecipeTitle: title, ItemID: it.ID, ItemName: it.Name})
+		}
+	}
+	recipe("Spinach Omelette", eggs, spinach, feta, salt)
+	recipe("Pancakes", eggs, flour, milk, salt)
+	recipe("Crepes", flour, milk, eggs)
+	recipe("Buttered Flour", flour)
+
+	have := []sqlc.ListItemsByIDsOrNamesRow{eggs, spinach, feta}
+	opts := recipeMatchOptions{maxMissing: math.MaxInt, limit: defaultRecipeMatchLimit}
+
+	got := rankRecipeMatches(rows, have, opts)
+	if len(got) != 3 {
+		t.Fatalf("matches=%+v, want 3 recipes using eggs", got)
+	}
+	if got[0].Title != "Spinach Omelette" || got[0].Coverage != 0.75 || len(got[0].Missing) != 1 || got[0].Missing[0].Name != "Salt" {
+		t.Fatalf("first=%+v, want omelette missing salt", got[0])
+	}
+	if got[1].Title != "Crepes" || got[1].Coverage != 0.333 {
+		t.Fatalf("second=%+v, want crepes at 0.333", got[1])
+	}
+
+	opts.ignoreStaples = true
+	got = rankRecipeMatches(rows, have, opts)
+	if got[0].Coverage != 1 || got[0].IngredientCount != 3 || len(got[0].Missing) != 0 {
+		t.Fatalf("first=%+v, want a full match without salt", got[0])
+	}
+
+	opts.maxMissing = 1
+	if got = rankRecipeMatches(rows, have, opts); len(got) != 1 {
+		t.Fatalf("matches=%+v, want only the omelette with max_missing=1", got)
+	}
+}
+
+func TestRecipeMatchNameLookups(t *testing.T) {
+	t.Parallel()
+
+	lookups, requested, priorities := recipeMatchNameLookups([]string{"eggs", "tomato"})
+	if want := []string{"eggs", "egg", "tomato", "tomatoes", "tomatos"}; !slices.Equal(lookups, want) {
+		t.Fatalf("lookups=%v, want %v", lookups, want)
+	}
+	if want := []string{"eggs", "eggs", "tomato", "tomato", "tomato"}; !slices.Equal(requested, want) {
+		t.Fatalf("requested=%v, want %v", requested, want)
+	}
+	if want := []int32{0, 1, 0, 1, 2}; !slices.Equal(priorities, want) {
+		t.Fatalf("priorities=%v, want %v", priorities, want)
+	}
+
+	have := []sqlc.ListItemsByIDsOrNamesRow{{Name: "Eggs", RequestedNames: []string{"egg"}}}
+	if got := unknownRecipeMatchNames([]string{"egg", "dragonfruit"}, have); !slices.Equal(got, []string{"dragonfruit"}) {
+		t.Fatalf("unknown=%v, want [dragonfruit]", got)
+	}
+}
+
+func TestValidateRecipeMatchRequest(t *testing.T) {
+	t.Parallel()
+
+	_, names, opts, err := validateRecipeMatchRequest(recipeMatchRequest{ItemNames: []string{" Eggs ", "eggs", "", "Feta"}})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(names) != 2 || names[0] != "eggs" || names[1] != "feta" {
+		t.Fatalf("names=%v, want [eggs feta]", names)
+	}
+	if opts.limit != defaultRecipeMatchLimit || opts.maxMissing != math.MaxInt {
+		t.Fatalf("opts=%+v", opts)
+	}
+
+	negative := -1
+	for _, req := range []recipeMatchRequest{
+		{},
+		{ItemIDs: []string{"not-a-uuid"}},
+		{ItemNames: []string{"eggs"}, MaxMissing: &negative},
+		{ItemNames: []string{"eggs"}, Limit: &negative},
+	} {
+		if _, _, _, err := validateRecipeMatchRequest(req); err == nil {
+			t.Fatalf("validate(%+v) succeeded, want error", req)
+		}
+	}
+}
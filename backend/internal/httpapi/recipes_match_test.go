@@ -0,0 +1,156 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type recipeMatchItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type recipeMatchResponse struct {
+	Items        []recipeMatchItem `json:"items"`
+	UnknownNames []string          `json:"unknown_names"`
+	Recipes      []struct {
+		ID              string            `json:"id"`
+		Title           string            `json:"title"`
+		IngredientCount int               `json:"ingredient_count"`
+		HaveCount       int               `json:"have_count"`
+		Coverage        float64           `json:"coverage"`
+		Missing         []recipeMatchItem `json:"missing"`
+	} `json:"recipes"`
+}
+
+func TestRecipes_Match(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, err := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); err != nil {
+		t.Fatalf("bootstrap user: %v", err)
+	}
+
+	app, err := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   testSessionCookieName,
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if err != nil {
+		t.Fatalf("new app: %v", err)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookie jar: %v", err)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	recipePayload := func(title, ingredients string) string {
+		return `{
+  "title":"` + title + `",
+  "servings":2,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[` + ingredients + `],
+  "steps":[{"step_number":1,"instruction":"Cook."}]
+}`
+	}
+
+	var sauce, omelette recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+		recipePayload("Cheese sauce", `{"position":1,"quantity":100,"unit":"g","item_name":"Feta"},{"position":2,"quantity_text":"to taste","item_name":"Salt"}`), http.StatusCreated, &sauce)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+		recipePayload("Spinach omelette", `{"position":1,"quantity":3,"item_name":"Eggs"},{"position":2,"quantity":100,"unit":"g","item_name":"Spinach"},{"position":3,"quantity":1,"sub_recipe_id":"`+sauce.ID+`"}`), http.StatusCreated, &omelette)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+		recipePayload("Pancakes", `{"position":1,"quantity":2,"item_name":"Eggs"},{"position":2,"quantity":200,"unit":"g","item_name":"Flour"},{"position":3,"quantity":300,"unit":"ml","item_name":"Milk"}`), http.StatusCreated, nil)
+	eggsID := omelette.Ingredients[0].Item.ID
+
+	matchURL := server.URL + "/api/v1/recipes/match"
+
+	t.Run("validation", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPost, matchURL, `{}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, matchURL, `{"item_ids":["nope"]}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, matchURL, `{"item_ids":["`+uuid.NewString()+`"]}`, http.StatusBadRequest, nil)
+	})
+
+	t.Run("ranks by coverage and lists missing items", func(t *testing.T) {
+		var got recipeMatchResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, matchURL,
+			`{"item_ids":["`+eggsID+`"],"item_names":["spinach","FETA","dragonfruit"]}`, http.StatusOK, &got)
+		if len(got.Items) != 3 || len(got.UnknownNames) != 1 || got.UnknownNames[0] != "dragonfruit" {
+			t.Fatalf("items=%+v unknown=%v", got.Items, got.UnknownNames)
+		}
+		if len(got.Recipes) != 3 {
+			t.Fatalf("recipes=%+v, want omelette, sauce, and pancakes", got.Recipes)
+		}
+		first := got.Recipes[0]
+		if first.Title != "Spinach omelette" || first.IngredientCount != 4 || first.HaveCount != 3 || len(first.Missing) != 1 || first.Missing[0].Name != "Salt" {
+			t.Fatalf("first=%+v, want omelette missing only salt from its sub-recipe", first)
+		}
+		if last := got.Recipes[2]; last.Title != "Pancakes" || len(last.Missing) != 2 {
+			t.Fatalf("last=%+v, want pancakes missing flour and milk", last)
+		}
+	})
+
+	t.Run("ignore staples and max missing", func(t *testing.T) {
+		var got recipeMatchResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, matchURL,
+			`{"item_names":["eggs","spinach","feta"],"ignore_staples":true,"max_missing":0}`, http.StatusOK, &got)
+		if len(got.Recipes) != 2 || got.Recipes[0].Coverage != 1 || got.Recipes[1].Coverage != 1 {
+			t.Fatalf("recipes=%+v, want omelette and sauce fully covered", got.Recipes)
+		}
+	})
+
+	t.Run("names resolve through aliases and plurals", func(t *testing.T) {
+		fetaID := sauce.Ingredients[0].Item.ID
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/items/"+fetaID+"/aliases",
+			`{"alias":"sheep cheese"}`, http.StatusCreated, nil)
+
+		var got recipeMatchResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, matchURL,
+			`{"item_ids":["`+eggsID+`"],"item_names":["egg","Sheep Cheese","spinaches"]}`, http.StatusOK, &got)
+		if len(got.Items) != 3 || len(got.UnknownNames) != 0 {
+			t.Fatalf("items=%+v unknown=%v, want eggs, feta, and spinach", got.Items, got.UnknownNames)
+		}
+		if len(got.Recipes) == 0 || got.Recipes[0].Title != "Spinach omelette" || got.Recipes[0].HaveCount != 3 {
+			t.Fatalf("recipes=%+v, want the omelette first", got.Recipes)
+		}
+	})
+}
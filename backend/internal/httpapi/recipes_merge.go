@@ -0,0 +1,200 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+// recipeMergeRequest names the duplicate to fold into the recipe in the path.
+type recipeMergeRequest struct {
+	DuplicateID string `json:"duplicate_id"`
+}
+
+type recipeMergeResponse struct {
+	Recipe               recipeDetailResponse `json:"recipe"`
+	MergedRecipeID       string               `json:"merged_recipe_id"`
+	TagsMoved            int64                `json:"tags_moved"`
+	MealPlanEntriesMoved int64                `json:"meal_plan_entries_moved"`
+	SubRecipeLinesMoved  int64                `json:"sub_recipe_lines_moved"`
+	VariantsMoved        int64                `json:"variants_moved"`
+}
+
+// recipeMergeResult counts what a merge moved onto the kept recipe.
+type recipeMergeResult struct {
+	TagsMoved            int64
+	MealPlanEntriesMoved int64
+	SubRecipeLinesMoved  int64
+	VariantsMoved        int64
+}
+
+func (a *App) handleRecipesMerge(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req recipeMergeRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+	duplicateID, err := uuid.Parse(strings.TrimSpace(req.DuplicateID))
+	if err != nil {
+		return errValidationField("duplicate_id", "invalid id")
+	}
+
+	ctx := r.Context()
+	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
+	recipeID := pgtype.UUID{Bytes: id, Valid: true}
+
+	result, err := mergeRecipesUsecase(ctx, a.recipeWorkflows(), userID, recipeID, pgtype.UUID{Bytes: duplicateID, Valid: true})
+	if err != nil {
+		return mapRecipeUsecaseError(err)
+	}
+
+	detail, err := a.loadRecipeDetail(ctx, recipeID, userID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	resp := recipeMergeResponse{
+		Recipe:               detail,
+		MergedRecipeID:       duplicateID.String(),
+		TagsMoved:            result.TagsMoved,
+		MealPlanEntriesMoved: result.MealPlanEntriesMoved,
+		SubRecipeLinesMoved:  result.SubRecipeLinesMoved,
+		VariantsMoved:        result.VariantsMoved,
+	}
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/merge")
+	}
+	return nil
+}
+
+// mergeRecipesUsecase keeps targetID and folds duplicateID into it: tags and
+// meal-plan entries move to the target, sub-recipe lines and variants that
+// pointed at the duplicate point at the target, then the duplicate is
+// soft-deleted so it can still be restored. An entry whose user already
+// planned the target on the same date is dropped rather than duplicated.
+// Every recipe whose sub-recipe lines change gets a revision.
+func mergeRecipesUsecase(ctx context.Context, w recipeWorkflows, actorID, targetID, duplicateID pgtype.UUID) (recipeMergeResult, error) {
+	if targetID == duplicateID {
+		return recipeMergeResult{}, recipeValidationField("duplicate_id", "cannot merge a recipe into itself")
+	}
+
+	var result recipeMergeResult
+	err := w.WithinTx(ctx, func(q recipeWorkflowQueries) error {
+		deletedAt, err := q.GetRecipeDeletedAtByID(ctx, targetID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return &recipeNotFoundError{}
+			}
+			return err
+		}
+		if deletedAt.Valid {
+			return &recipeConflictError{Message: "recipe is deleted; restore before merging"}
+		}
+
+		duplicateDeletedAt, err := q.GetRecipeDeletedAtByID(ctx, duplicateID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return recipeValidationField("duplicate_id", "recipe does not exist")
+			}
+			return err
+		}
+		if duplicateDeletedAt.Valid {
+			return recipeValidationField("duplicate_id", "recipe is deleted")
+		}
+
+		move := sqlc.MoveRecipeTagsParams{TargetID: targetID, UserID: actorID, SourceID: duplicateID}
+		tagsMoved, err := q.MoveRecipeTags(ctx, move)
+		if err != nil {
+			return err
+		}
+		if err := q.DeleteRecipeTagsByRecipeID(ctx, duplicateID); err != nil {
+			return err
+		}
+
+		entriesMoved, err := q.MoveMealPlanEntries(ctx, sqlc.MoveMealPlanEntriesParams(move))
+		if err != nil {
+			return err
+		}
+		if err := q.DeleteMealPlanEntriesByRecipeID(ctx, duplicateID); err != nil {
+			return err
+		}
+
+		referencing, err := q.ListRecipeIDsBySubRecipeID(ctx, duplicateID)
+		if err != nil {
+			return err
+		}
+		for _, referencingID := range referencing {
+			if referencingID == targetID {
+				return &recipeConflictError{Message: "recipe uses the duplicate as a sub-recipe"}
+			}
+			cycle, err := q.SubRecipeTreeContains(ctx, sqlc.SubRecipeTreeContainsParams{
+				SubRecipeID: targetID,
+				RecipeID:    referencingID,
+			})
+			if err != nil {
+				return err
+			}
+			if cycle {
+				return &recipeConflictError{Message: "a recipe using the duplicate as a sub-recipe is part of this recipe"}
+			}
+		}
+		linesMoved, err := q.MoveSubRecipeReferences(ctx, sqlc.MoveSubRecipeReferencesParams(move))
+		if err != nil {
+			return err
+		}
+		variantsMoved, err := q.MoveRecipeVariants(ctx, sqlc.MoveRecipeVariantsParams(move))
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.SoftDeleteRecipeByID(ctx, sqlc.SoftDeleteRecipeByIDParams{
+			ID:        duplicateID,
+			UpdatedBy: actorID,
+		}); err != nil {
+			return err
+		}
+
+		result = recipeMergeResult{
+			TagsMoved:            tagsMoved,
+			MealPlanEntriesMoved: entriesMoved,
+			SubRecipeLinesMoved:  linesMoved,
+			VariantsMoved:        variantsMoved,
+		}
+		changed := referencing
+		if tagsMoved > 0 {
+			changed = append(changed, targetID)
+		}
+		for _, recipeID := range changed {
+			if err := q.RefreshRecipeSearchDocument(ctx, recipeID); err != nil {
+				return err
+			}
+			if err := q.CreateRecipeRevision(ctx, sqlc.CreateRecipeRevisionParams{
+				RecipeID:  recipeID,
+				CreatedBy: actorID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return recipeMergeResult{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,222 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type testRecipeDuplicate struct {
+	Recipe struct {
+		ID string `json:"id"`
+	} `json:"recipe"`
+	Duplicate struct {
+		ID string `json:"id"`
+	} `json:"duplicate"`
+	Score             float64 `json:"score"`
+	IngredientOverlap float64 `json:"ingredient_overlap"`
+}
+
+func TestRecipes_DuplicatesAndMerge(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	var dinner, quick struct {
+		ID string `json:"id"`
+	}
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/tags", `{"name":"Dinner"}`, http.StatusOK, &dinner)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/tags", `{"name":"Quick"}`, http.StatusOK, &quick)
+
+	recipePayload := func(title, tagIDs, ingredients string) string {
+		return `{
+  "title":"` + title + `",
+  "servings":4,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[` + tagIDs + `],
+  "ingredients":[` + ingredients + `],
+  "steps":[{"step_number":1,"instruction":"Simmer."}]
+}`
+	}
+	chiliIngredients := `{"position":1,"quantity":1,"unit":"lb","item_name":"ground beef"},{"position":2,"quantity":1,"unit":"can","item_name":"kidney beans"},{"position":3,"quantity":2,"unit":"tbsp","item_name":"chili powder"}`
+
+	var kept, duplicate, other recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+		recipePayload("Beef Chili", `"`+dinner.ID+`"`, chiliIngredients), http.StatusCreated, &kept)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+		recipePayload("Beef Chili (imported)", `"`+dinner.ID+`","`+quick.ID+`"`, chiliIngredients), http.StatusCreated, &duplicate)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+		recipePayload("Lemon Cake", "", `{"position":1,"quantity":2,"unit":"cup","item_name":"flour"}`), http.StatusCreated, &other)
+
+	t.Run("finds likely duplicates", func(t *testing.T) {
+		var pairs []testRecipeDuplicate
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/duplicates", "", http.StatusOK, &pairs)
+		if len(pairs) != 1 || pairs[0].IngredientOverlap != 1 || pairs[0].Score < 0.5 {
+			t.Fatalf("pairs=%+v, want one chili pair", pairs)
+		}
+
+		var forRecipe []testRecipeDuplicate
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/duplicates?recipe_id="+duplicate.ID, "", http.StatusOK, &forRecipe)
+		if len(forRecipe) != 1 || forRecipe[0].Recipe.ID != duplicate.ID || forRecipe[0].Duplicate.ID != kept.ID {
+			t.Fatalf("pairs=%+v, want duplicate paired with kept", forRecipe)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/duplicates?min_score=2", "", http.StatusBadRequest, nil)
+	})
+
+	t.Run("merge moves tags and meal plans", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/meal-plans",
+			`{"date":"2025-02-01","recipe_id":"`+duplicate.ID+`"}`, http.StatusOK, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/meal-plans",
+			`{"date":"2025-02-02","recipe_id":"`+duplicate.ID+`"}`, http.StatusOK, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/meal-plans",
+			`{"date":"2025-02-02","recipe_id":"`+kept.ID+`"}`, http.StatusOK, nil)
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+kept.ID+"/merge",
+			`{"duplicate_id":"`+kept.ID+`"}`, http.StatusBadRequest, nil)
+
+		var merged struct {
+			Recipe               recipeDetailResponse `json:"recipe"`
+			MergedRecipeID       string               `json:"merged_recipe_id"`
+			TagsMoved            int64                `json:"tags_moved"`
+			MealPlanEntriesMoved int64                `json:"meal_plan_entries_moved"`
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+kept.ID+"/merge",
+			`{"duplicate_id":"`+duplicate.ID+`"}`, http.StatusOK, &merged)
+		if merged.MergedRecipeID != duplicate.ID || merged.TagsMoved != 1 || merged.MealPlanEntriesMoved != 1 {
+			t.Fatalf("merge=%+v, want 1 tag and 1 entry moved", merged)
+		}
+		if len(merged.Recipe.Tags) != 2 {
+			t.Fatalf("tags=%+v, want Dinner and Quick", merged.Recipe.Tags)
+		}
+
+		var deleted recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+duplicate.ID, "", http.StatusOK, &deleted)
+		if deleted.DeletedAt == nil || len(deleted.Tags) != 0 {
+			t.Fatalf("duplicate=%+v, want soft-deleted without tags", deleted)
+		}
+
+		var plans struct {
+			Items []struct {
+				Date   string `json:"date"`
+				Recipe struct {
+					ID string `json:"id"`
+				} `json:"recipe"`
+			} `json:"items"`
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/meal-plans?start=2025-02-01&end=2025-02-28", "", http.StatusOK, &plans)
+		if len(plans.Items) != 2 {
+			t.Fatalf("plans=%+v, want one entry per date", plans.Items)
+		}
+		for _, entry := range plans.Items {
+			if entry.Recipe.ID != kept.ID {
+				t.Fatalf("entry=%+v, want kept recipe", entry)
+			}
+		}
+
+		var pairs []testRecipeDuplicate
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/duplicates", "", http.StatusOK, &pairs)
+		if len(pairs) != 0 {
+			t.Fatalf("pairs=%+v, want none after merge", pairs)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+other.ID+"/merge",
+			`{"duplicate_id":"`+duplicate.ID+`"}`, http.StatusBadRequest, nil)
+	})
+
+	t.Run("merge repoints sub-recipes and variants", func(t *testing.T) {
+		sauceIngredients := `{"position":1,"quantity":800,"unit":"g","item_name":"tomatoes"}`
+		var sauce, sauceCopy, lasagna, marinara, variant recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+			recipePayload("Tomato Sauce", "", sauceIngredients), http.StatusCreated, &sauce)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+			recipePayload("Tomato Sauce (copy)", "", sauceIngredients), http.StatusCreated, &sauceCopy)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+			recipePayload("Lasagna", "", `{"position":1,"quantity":2,"sub_recipe_id":"`+sauceCopy.ID+`"}`), http.StatusCreated, &lasagna)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+			recipePayload("Marinara", "", `{"position":1,"quantity":1,"sub_recipe_id":"`+sauceCopy.ID+`"}`), http.StatusCreated, &marinara)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+sauceCopy.ID+"/clone",
+			`{"title":"Spicy Tomato Sauce"}`, http.StatusCreated, &variant)
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+marinara.ID+"/merge",
+			`{"duplicate_id":"`+sauceCopy.ID+`"}`, http.StatusConflict, nil)
+
+		var merged struct {
+			SubRecipeLinesMoved int64 `json:"sub_recipe_lines_moved"`
+			VariantsMoved       int64 `json:"variants_moved"`
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+sauce.ID+"/merge",
+			`{"duplicate_id":"`+sauceCopy.ID+`"}`, http.StatusOK, &merged)
+		if merged.SubRecipeLinesMoved != 2 || merged.VariantsMoved != 1 {
+			t.Fatalf("merge=%+v, want 2 sub-recipe lines and 1 variant moved", merged)
+		}
+
+		var got recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+lasagna.ID, "", http.StatusOK, &got)
+		if sub := got.Ingredients[0].SubRecipe; sub == nil || sub.ID != sauce.ID {
+			t.Fatalf("lasagna ingredients=%+v, want the kept sauce", got.Ingredients)
+		}
+		var revisions []recipeRevisionSummary
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+lasagna.ID+"/revisions", "", http.StatusOK, &revisions)
+		if len(revisions) != 2 {
+			t.Fatalf("revisions=%+v, want one for the merge", revisions)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+variant.ID, "", http.StatusOK, &got)
+		if got.ParentRecipe == nil || got.ParentRecipe.ID != sauce.ID {
+			t.Fatalf("variant parent=%+v, want the kept sauce", got.ParentRecipe)
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package httpapi
+
+import (
+	"math"
+	"strings"
+
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/units"
+)
+
+// Reasons an ingredient is left out of a recipe's nutrition totals.
+const (
+	nutritionGapMissingData     = "missing_nutrition"
+	nutritionGapMissingQuantity = "missing_quantity"
+	nutritionGapUnitMismatch    = "unit_mismatch"
+	nutritionGapSubRecipe       = "sub_recipe"
+)
+
+const nutritionPrecision = 10
+
+type recipeNutritionGapResponse struct {
+	IngredientID string `json:"ingredient_id"`
+	ItemName     string `json:"item_name"`
+	Reason       string `json:"reason"`
+}
+
+// recipeNutritionResponse reports per-serving nutrition. PerServing only sums
+// the ingredients that could be counted; Complete is false when Uncounted
+// lists any.
+type recipeNutritionResponse struct {
+	PerServing nutritionFacts               `json:"per_serving"`
+	Complete   bool                         `json:"complete"`
+	Uncounted  []recipeNutritionGapResponse `json:"uncounted"`
+}
+
+// itemNutritionReference is an item's nutrition facts for a reference amount.
+type itemNutritionReference struct {
+	quantity float64
+	unit     string
+	facts    nutritionFacts
+}
+
+func itemNutritionReferences(rows []sqlc.ItemNutrition) (map[string]itemNutritionReference, error) {
+	refs := make(map[string]itemNutritionReference, len(rows))
+	for _, row := range rows {
+		resp, err := itemNutritionResponseFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		refs[resp.ItemID] = itemNutritionReference{
+			quantity: resp.ReferenceQuantity,
+			unit:     resp.ReferenceUnit,
+			facts:    resp.nutritionFacts,
+		}
+	}
+	return refs, nil
+}
+
+// expandedIngredient is an item line reached from a recipe, directly or
+// through sub-recipes. factor scales its quantity to one batch of the root
+// recipe.
+type expandedIngredient struct {
+	ingredient recipeIngredientResponse
+	factor     float64
+}
+
+// expandRecipeIngredients flattens sub-recipes into their item lines. A
+// sub-recipe quantity is servings of the sub-recipe; without one the whole
+// batch is used. Sub-recipes that would repeat along a path, that have no
+// servings to scale by, or that were deleted are returned unexpanded.
+func expandRecipeIngredients(recipeID string, ingredients []recipeIngredientResponse) ([]expandedIngredient, []recipeIngredientResponse) {
+	var (
+		lines      []expandedIngredient
+		unexpanded []recipeIngredientResponse
+	)
+	path := map[string]bool{recipeID: true}
+	var walk func(ingredients []recipeIngredientResponse, factor float64)
+	walk = func(ingredients []recipeIngredientResponse, factor float64) {
+		for _, ing := range ingredients {
+			sub := ing.SubRecipe
+			if sub == nil {
+				lines = append(lines, expandedIngredient{ingredient: ing, factor: factor})
+				continue
+			}
+			if path[sub.ID] || sub.Servings <= 0 || sub.Deleted {
+				unexpanded = append(unexpanded, ing)
+				continue
+			}
+			batches := 1.0
+			if ing.Quantity != nil {
+				batches = *ing.Quantity / float64(sub.Servings)
+			}
+			path[sub.ID] = true
+			walk(sub.Ingredients, factor*batches)
+			delete(path, sub.ID)
+		}
+	}
+	walk(ingredients, 1)
+	return lines, unexpanded
+}
+
+// computeRecipeNutrition sums item nutrition across ingredients, including
+// those of sub-recipes, and divides by servings.
+func computeRecipeNutrition(recipeID string, servings int32, ingredients []recipeIngredientResponse, refs map[string]itemNutritionReference) recipeNutritionResponse {
+	out := recipeNutritionResponse{Uncounted: []recipeNutritionGapResponse{}}
+	lines, unexpanded := expandRecipeIngredients(recipeID, ingredients)
+	var total nutritionFacts
+	for _, line := range lines {
+		ing := line.ingredient
+		reason := ""
+		ref, ok := refs[ing.Item.ID]
+		switch {
+		case !ok:
+			reason = nutritionGapMissingData
+		case ing.Quantity == nil:
+			reason = nutritionGapMissingQuantity
+		default:
+			factor, convertible := referenceFactor(*ing.Quantity, ing.Unit, ref.quantity, ref.unit)
+			if !convertible {
+				reason = nutritionGapUnitMismatch
+				break
+			}
+			total = total.add(ref.facts.scale(factor * line.factor))
+		}
+		if reason != "" {
+			out.Uncounted = append(out.Uncounted, recipeNutritionGapResponse{
+				IngredientID: ing.ID,
+				ItemName:     ing.Item.Name,
+				Reason:       reason,
+			})
+		}
+	}
+	for _, ing := range unexpanded {
+		out.Uncounted = append(out.Uncounted, recipeNutritionGapResponse{
+			IngredientID: ing.ID,
+			ItemName:     ing.SubRecipe.Title,
+			Reason:       nutritionGapSubRecipe,
+		})
+	}
+
+	if servings > 0 {
+		total = total.scale(1 / float64(servings))
+	}
+	out.PerServing = total.rounded()
+	out.Complete = len(out.Uncounted) == 0
+	return out
+}
+
+// referenceFactor returns how many reference amounts (nutrition facts or a
+// priced package) a quantity is. Quantities without a unit count as pieces.
+// Units outside the registry only match the same unit name.
+func referenceFactor(quantity float64, unit *string, refQuantity float64, refUnit string) (float64, bool) {
+	name := "piece"
+	if unit != nil && strings.TrimSpace(*unit) != "" {
+		name = *unit
+	}
+	from, fromOK := units.Lookup(name)
+	to, toOK := units.Lookup(refUnit)
+	switch {
+	case fromOK && toOK:
+		factor, err := units.Factor(from, to)
+		if err != nil {
+			return 0, false
+		}
+		return quantity * factor / refQuantity, true
+	case !fromOK && !toOK && strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(refUnit)):
+		return quantity / refQuantity, true
+	default:
+		return 0, false
+	}
+}
+
+func (f nutritionFacts) add(other nutritionFacts) nutritionFacts {
+	return nutritionFacts{
+		Calories: f.Calories + other.Calories,
+		ProteinG: f.ProteinG + other.ProteinG,
+		FatG:     f.FatG + other.FatG,
+		CarbsG:   f.CarbsG + other.CarbsG,
+		FiberG:   f.FiberG + other.FiberG,
+		SodiumMg: f.SodiumMg + other.SodiumMg,
+	}
+}
+
+func (f nutritionFacts) scale(factor float64) nutritionFacts {
+	return nutritionFacts{
+		Calories: f.Calories * factor,
+		ProteinG: f.ProteinG * factor,
+		FatG:     f.FatG * factor,
+		CarbsG:   f.CarbsG * factor,
+		FiberG:   f.FiberG * factor,
+		SodiumMg: f.SodiumMg * factor,
+	}
+}
+
+func (f nutritionFacts) rounded() nutritionFacts {
+	round := func(v float64) float64 {
+		return math.Round(v*nutritionPrecision) / nutritionPrecision
+	}
+	return nutritionFacts{
+		Calories: round(f.Calories),
+		ProteinG: round(f.ProteinG),
+		FatG:     round(f.FatG),
+		CarbsG:   round(f.CarbsG),
+		FiberG:   round(f.FiberG),
+		SodiumMg: round(f.SodiumMg),
+	}
+}
@@ -0,0 +1,201 @@
+package httpapi
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+func TestComputeRecipeNutrition(t *testing.T) {
+	t.Parallel()
+
+	qty := func(v float64) *float64 { return &v }
+	refs := map[string]itemNutritionReference{
+		"flour": {quantity: 100, unit: "g", facts: nutritionFacts{Calories: 364, ProteinG: 10, FatG: 1, CarbsG: 76, FiberG: 2.7, SodiumMg: 2}},
+		"egg":   {quantity: 1, unit: "piece", facts: nutritionFacts{Calories: 72, ProteinG: 6.3, FatG: 4.8, CarbsG: 0.4, SodiumMg: 71}},
+		"milk":  {quantity: 1, unit: "cup", facts: nutritionFacts{Calories: 149}},
+		"broth": {quantity: 1, unit: "carton", facts: nutritionFacts{Calories: 40}},
+	}
+	ingredients := []recipeIngredientResponse{
+		{ID: "ing-flour", Quantity: qty(0.5), Unit: stringPtr("kg"), Item: &itemResponse{ID: "flour", Name: "Flour"}},
+		{ID: "ing-egg", Quantity: qty(2), Item: &itemResponse{ID: "egg", Name: "Egg"}},
+		{ID: "ing-milk", Quantity: qty(200), Unit: stringPtr("g"), Item: &itemResponse{ID: "milk", Name: "Milk"}},
+		{ID: "ing-broth", Quantity: qty(2), Unit: stringPtr("Carton"), Item: &itemResponse{ID: "broth", Name: "Broth"}},
+		{ID: "ing-salt", QuantityText: stringPtr("to taste"), Item: &itemResponse{ID: "egg", Name: "Egg"}},
+		{ID: "ing-sugar", Quantity: qty(1), Unit: stringPtr("cup"), Item: &itemResponse{ID: "sugar", Name: "Sugar"}},
+		// One serving of a two-serving dough uses half of its 200 g of flour.
+		{ID: "ing-dough", Quantity: qty(1), SubRecipe: &recipeSubRecipeResponse{ID: "dough", Title: "Pizza dough", Servings: 2, Ingredients: []recipeIngredientResponse{
+			{ID: "ing-dough-flour", Quantity: qty(200), Unit: stringPtr("g"), Item: &itemResponse{ID: "flour", Name: "Flour"}},
+			{ID: "ing-dough-yeast", Quantity: qty(7), Unit: stringPtr("g"), Item: &itemResponse{ID: "yeast", Name: "Yeast"}},
+		}}},
+	}
+
+	got := computeRecipeNutrition("pizza", 4, ingredients, refs)
+
+	// (5*364 + 2*72 + 2*40 + 364) / 4 calories.
+	if got.PerServing.Calories != 602 {
+		t.Fatalf("calories=%v, want 602", got.PerServing.Calories)
+	}
+	if got.PerServing.SodiumMg != 38.5 {
+		t.Fatalf("per serving=%+v", got.PerServing)
+	}
+	if got.Complete {
+		t.Fatalf("complete=true, want false")
+	}
+	wantGaps := map[string]string{
+		"ing-milk":        nutritionGapUnitMismatch,
+		"ing-salt":        nutritionGapMissingQuantity,
+		"ing-sugar":       nutritionGapMissingData,
+		"ing-dough-yeast": nutritionGapMissingData,
+	}
+	if len(got.Uncounted) != len(wantGaps) {
+		t.Fatalf("uncounted=%+v", got.Uncounted)
+	}
+	for _, gap := range got.Uncounted {
+		if wantGaps[gap.IngredientID] != gap.Reason {
+			t.Fatalf("gap %s reason=%q, want %q", gap.IngredientID, gap.Reason, wantGaps[gap.IngredientID])
+		}
+	}
+}
+
+func TestComputeRecipeNutritionStopsAtCycles(t *testing.T) {
+	t.Parallel()
+
+	qty := func(v float64) *float64 { return &v }
+	refs := map[string]itemNutritionReference{
+		"egg": {quantity: 1, unit: "piece", facts: nutritionFacts{Calories: 72}},
+	}
+	// sauce lists the root recipe again; the loop is reported, not followed.
+	got := computeRecipeNutrition("pasta", 1, []recipeIngredientResponse{
+		{ID: "ing-sauce", SubRecipe: &recipeSubRecipeResponse{ID: "sauce", Title: "Sauce", Servings: 1, Ingredients: []recipeIngredientResponse{
+			{ID: "ing-egg", Quantity: qty(1), Item: &itemResponse{ID: "egg", Name: "Egg"}},
+			{ID: "ing-pasta", Quantity: qty(1), SubRecipe: &recipeSubRecipeResponse{ID: "pasta", Title: "Pasta", Servings: 1}},
+		}}},
+	}, refs)
+	if got.PerServing.Calories != 72 || len(got.Uncounted) != 1 || got.Uncounted[0].IngredientID != "ing-pasta" || got.Uncounted[0].Reason != nutritionGapSubRecipe {
+		t.Fatalf("nutrition=%+v", got)
+	}
+}
+
+func TestComputeRecipeNutritionCompleteWhenEverythingCounts(t *testing.T) {
+	t.Parallel()
+
+	one := 1.0
+	got := computeRecipeNutrition("recipe", 1, []recipeIngredientResponse{
+		{ID: "ing-1", Quantity: &one, Unit: stringPtr("tbsp"), Item: &itemResponse{ID: "oil"}},
+	}, map[string]itemNutritionReference{
+		"oil": {quantity: 1, unit: "tbsp", facts: nutritionFacts{Calories: 119, FatG: 13.5}},
+	})
+	if !got.Complete || len(got.Uncounted) != 0 || got.PerServing.Calories != 119 {
+		t.Fatalf("nutrition=%+v", got)
+	}
+}
+
+func TestParseItemNutritionCSV(t *testing.T) {
+	t.Parallel()
+
+	body := "\ufeffItem,reference_quantity,reference_unit,calories,protein_g,fat_g,carbs_g,fiber_g,sodium_mg,source\n" +
+		"Flour,100,grams,364,10,1,76,2.7,2,usda\n" +
+		"\"Milk, whole\",1,cup,149,7.7,7.9,11.7,0,105,\n"
+	rows, err := parseItemNutritionCSV(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows=%d, want 2", len(rows))
+	}
+	if rows[0].item != "Flour" || rows[0].line != 2 || rows[0].req.ReferenceUnit != "g" || *rows[0].req.FiberG != 2.7 {
+		t.Fatalf("row 0=%+v", rows[0])
+	}
+	if rows[1].item != "Milk, whole" || rows[1].line != 3 || rows[1].req.ReferenceQuantity != 1 {
+		t.Fatalf("row 1=%+v", rows[1])
+	}
+}
+
+func TestParseItemNutritionCSVRejectsBadRows(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		body  string
+		field string
+	}{
+		{
+			name:  "missing column",
+			body:  "item,reference_quantity,reference_unit,calories\nFlour,100,g,364\n",
+			field: "header",
+		},
+		{
+			name:  "bad number",
+			body:  "item,reference_quantity,reference_unit,calories,protein_g,fat_g,carbs_g,fiber_g,sodium_mg\nFlour,100,g,lots,10,1,76,2.7,2\n",
+			field: "lines[2].calories",
+		},
+		{
+			name:  "zero reference",
+			body:  "item,reference_quantity,reference_unit,calories,protein_g,fat_g,carbs_g,fiber_g,sodium_mg\nFlour,0,g,364,10,1,76,2.7,2\n",
+			field: "lines[2].reference_quantity",
+		},
+		{
+			name:  "NaN",
+			body:  "item,reference_quantity,reference_unit,calories,protein_g,fat_g,carbs_g,fiber_g,sodium_mg\nFlour,100,g,NaN,10,1,76,2.7,2\n",
+			field: "lines[2].calories",
+		},
+		{
+			name:  "Inf",
+			body:  "item,reference_quantity,reference_unit,calories,protein_g,fat_g,carbs_g,fiber_g,sodium_mg\nFlour,Inf,g,364,10,1,76,2.7,2\n",
+			field: "lines[2].reference_quantity",
+		},
+		{
+			name:  "out of range",
+			body:  "item,reference_quantity,reference_unit,calories,protein_g,fat_g,carbs_g,fiber_g,sodium_mg\nFlour,100,g,364,1e400,1,76,2.7,2\n",
+			field: "lines[2].protein_g",
+		},
+		{
+			name:  "no rows",
+			body:  "item,reference_quantity,reference_unit,calories,protein_g,fat_g,carbs_g,fiber_g,sodium_mg\n",
+			field: "body",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseItemNutritionCSV(strings.NewReader(tc.body))
+			apiErr, ok := asAPIError(err)
+			if !ok || apiErr.kind != apiErrorValidation {
+				t.Fatalf("err=%v, want validation error", err)
+			}
+			details, _ := apiErr.details.([]response.FieldError)
+			if len(details) == 0 || details[0].Field != tc.field {
+				t.Fatalf("details=%+v, want field %q", apiErr.details, tc.field)
+			}
+		})
+	}
+}
+
+func TestValidateItemNutritionRequestRejectsNonFinite(t *testing.T) {
+	t.Parallel()
+
+	nan := math.NaN()
+	inf := math.Inf(1)
+	zero := 0.0
+	errs := validateItemNutritionRequest("", itemNutritionRequest{
+		ReferenceQuantity: math.Inf(-1),
+		ReferenceUnit:     "g",
+		Calories:          &nan,
+		ProteinG:          &inf,
+		FatG:              &zero,
+		CarbsG:            &zero,
+		FiberG:            &zero,
+		SodiumMg:          &zero,
+	})
+	fields := make([]string, 0, len(errs))
+	for _, fieldErr := range errs {
+		fields = append(fields, fieldErr.Field)
+	}
+	if strings.Join(fields, ",") != "reference_quantity,calories,protein_g" {
+		t.Fatalf("fields=%v", fields)
+	}
+}
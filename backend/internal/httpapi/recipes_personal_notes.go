@@ -0,0 +1,134 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+const maxPersonalNoteRunes = 10000
+
+// recipePersonalNoteRequest sets the caller's private note on a recipe.
+// Unlike the shared recipe notes, each user has their own and only its
+// author can read it.
+type recipePersonalNoteRequest struct {
+	Note string `json:"note"`
+}
+
+type recipePersonalNoteResponse struct {
+	RecipeID  string `json:"recipe_id"`
+	Note      string `json:"note"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func (a *App) handleRecipePersonalNoteGet(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	row, err := a.queries.GetRecipeUserNote(r.Context(), sqlc.GetRecipeUserNoteParams{
+		UserID:   pgtype.UUID{Bytes: info.UserID, Valid: true},
+		RecipeID: pgtype.UUID{Bytes: id, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, recipePersonalNoteResponseFromRow(row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/personal-note")
+	}
+	return nil
+}
+
+func (a *App) handleRecipePersonalNotePut(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req recipePersonalNoteRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return errValidationField("note", "note is required")
+	}
+	if len([]rune(note)) > maxPersonalNoteRunes {
+		return errValidationField("note", "note is too long")
+	}
+
+	row, err := a.queries.UpsertRecipeUserNote(r.Context(), sqlc.UpsertRecipeUserNoteParams{
+		UserID:   pgtype.UUID{Bytes: info.UserID, Valid: true},
+		RecipeID: pgtype.UUID{Bytes: id, Valid: true},
+		Note:     note,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, recipePersonalNoteResponseFromRow(row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/personal-note")
+	}
+	return nil
+}
+
+func (a *App) handleRecipePersonalNoteDelete(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeleteRecipeUserNote(r.Context(), sqlc.DeleteRecipeUserNoteParams{
+		UserID:   pgtype.UUID{Bytes: info.UserID, Valid: true},
+		RecipeID: pgtype.UUID{Bytes: id, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+	if affected == 0 {
+		return errNotFound()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func recipePersonalNoteResponseFromRow(row sqlc.RecipeUserNote) recipePersonalNoteResponse {
+	return recipePersonalNoteResponse{
+		RecipeID:  uuidString(row.RecipeID),
+		Note:      row.Note,
+		CreatedAt: timeString(row.CreatedAt),
+		UpdatedAt: timeString(row.UpdatedAt),
+	}
+}
@@ -0,0 +1,213 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+type recipeRevisionSummaryResponse struct {
+	Revision  int32  `json:"revision"`
+	Title     string `json:"title"`
+	CreatedAt string `json:"created_at"`
+	CreatedBy string `json:"created_by"`
+}
+
+type recipeRevisionResponse struct {
+	Revision  int32               `json:"revision"`
+	CreatedAt string              `json:"created_at"`
+	CreatedBy string              `json:"created_by"`
+	Recipe    createRecipeRequest `json:"recipe"`
+}
+
+func (a *App) handleRecipeRevisionsList(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	recipeID := pgtype.UUID{Bytes: id, Valid: true}
+
+	if _, err := a.queries.GetRecipeDeletedAtByID(r.Context(), recipeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	rows, err := a.queries.ListRecipeRevisions(r.Context(), recipeID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	out := make([]recipeRevisionSummaryResponse, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, recipeRevisionSummaryResponse{
+			Revision:  row.RevisionNumber,
+			Title:     row.Title,
+			CreatedAt: timeString(row.CreatedAt),
+			CreatedBy: uuidString(row.CreatedBy),
+		})
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, out); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/revisions")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeRevisionsGet(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	n, err := parseRevisionNumber("n", chi.URLParam(r, "n"))
+	if err != nil {
+		return err
+	}
+
+	revision, err := a.loadRecipeRevision(r.Context(), pgtype.UUID{Bytes: id, Valid: true}, n)
+	if err != nil {
+		return err
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, revision); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/revisions/{n}")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeRevisionsDiff(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	recipeID := pgtype.UUID{Bytes: id, Valid: true}
+
+	qp := r.URL.Query()
+	fromN, err := parseRevisionNumber("from", qp.Get("from"))
+	if err != nil {
+		return err
+	}
+	toN, err := parseRevisionNumber("to", qp.Get("to"))
+	if err != nil {
+		return err
+	}
+
+	from, err := a.loadRecipeRevision(r.Context(), recipeID, fromN)
+	if err != nil {
+		return err
+	}
+	to, err := a.loadRecipeRevision(r.Context(), recipeID, toN)
+	if err != nil {
+		return err
+	}
+
+	diff := diffRecipeSnapshots(from.Recipe, to.Recipe)
+	diff.From = fromN
+	diff.To = toN
+
+	if err := response.WriteJSON(w, http.StatusOK, diff); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/revisions/diff")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeRevisionsRevert(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	recipeID := pgtype.UUID{Bytes: id, Valid: true}
+	n, err := parseRevisionNumber("n", chi.URLParam(r, "n"))
+	if err != nil {
+		return err
+	}
+
+	revision, err := a.loadRecipeRevision(r.Context(), recipeID, n)
+	if err != nil {
+		return err
+	}
+	if errs := validateCreateRecipeRequest(revision.Recipe); len(errs) > 0 {
+		return errValidation(errs)
+	}
+
+	ctx := r.Context()
+	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
+
+	// Reverting writes the old snapshot as a new revision, so history is
+	// never rewritten.
+	if _, updateErr := updateRecipeUsecase(ctx, a.recipeWorkflows(), userID, recipeID, revision.Recipe); updateErr != nil {
+		return mapRecipeUsecaseError(updateErr)
+	}
+
+	detail, err := a.loadRecipeDetail(ctx, recipeID, userID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, detail); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/revisions/{n}/revert")
+	}
+	return nil
+}
+
+// loadRecipeRevision fetches and decodes one revision, mapping a missing row
+// to a not-found API error.
+func (a *App) loadRecipeRevision(ctx context.Context, recipeID pgtype.UUID, n int32) (recipeRevisionResponse, error) {
+	row, err := a.queries.GetRecipeRevision(ctx, sqlc.GetRecipeRevisionParams{
+		RecipeID:       recipeID,
+		RevisionNumber: n,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return recipeRevisionResponse{}, errNotFound()
+		}
+		return recipeRevisionResponse{}, errInternal(err)
+	}
+
+	var snapshot createRecipeRequest
+	if err := json.Unmarshal(row.Snapshot, &snapshot); err != nil {
+		return recipeRevisionResponse{}, errInternal(err)
+	}
+	return recipeRevisionResponse{
+		Revision:  row.RevisionNumber,
+		CreatedAt: timeString(row.CreatedAt),
+		CreatedBy: uuidString(row.CreatedBy),
+		Recipe:    snapshot,
+	}, nil
+}
+
+// parseRevisionNumber parses a required, positive revision number.
+func parseRevisionNumber(field, raw string) (int32, error) {
+	parsed, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || parsed <= 0 || parsed > maxInt32 {
+		return 0, errValidationField(field, "invalid revision")
+	}
+	return int32(parsed), nil //nolint:gosec // bounds checked above
+}
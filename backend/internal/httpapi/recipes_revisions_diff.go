@@ -0,0 +1,172 @@
+package httpapi
+
+import (
+	"reflect"
+	"slices"
+)
+
+const (
+	revisionChangeAdded   = "added"
+	revisionChangeRemoved = "removed"
+	revisionChangeChanged = "changed"
+)
+
+type recipeFieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type recipeTagsDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+type recipeIngredientChange struct {
+	Position int                      `json:"position"`
+	Change   string                   `json:"change"`
+	From     *recipeIngredientRequest `json:"from"`
+	To       *recipeIngredientRequest `json:"to"`
+}
+
+// recipeStepChange compares one step across two revisions. A change to only
+// the step's timing or temperature is reported with identical instructions.
+type recipeStepChange struct {
+	StepNumber    int     `json:"step_number"`
+	Change        string  `json:"change"`
+	From          *string `json:"from"`
+	To            *string `json:"to"`
+	FromGroupName *string `json:"from_group_name"`
+	ToGroupName   *string `json:"to_group_name"`
+}
+
+type recipeRevisionDiffResponse struct {
+	From        int32                    `json:"from"`
+	To          int32                    `json:"to"`
+	Fields      []recipeFieldChange      `json:"fields"`
+	Tags        recipeTagsDiff           `json:"tags"`
+	Ingredients []recipeIngredientChange `json:"ingredients"`
+	Steps       []recipeStepChange       `json:"steps"`
+}
+
+// diffRecipeSnapshots compares two recipe snapshots. Ingredients are matched
+// by position and steps by step number, so a reordering shows as changes.
+func diffRecipeSnapshots(from, to createRecipeRequest) recipeRevisionDiffResponse {
+	diff := recipeRevisionDiffResponse{
+		Fields:      []recipeFieldChange{},
+		Tags:        recipeTagsDiff{Added: []string{}, Removed: []string{}},
+		Ingredients: []recipeIngredientChange{},
+		Steps:       []recipeStepChange{},
+	}
+
+	diff.Fields = appendFieldChange(diff.Fields, "title", from.Title, to.Title)
+	diff.Fields = appendFieldChange(diff.Fields, "servings", from.Servings, to.Servings)
+	diff.Fields = appendFieldChange(diff.Fields, "prep_time_minutes", from.PrepTimeMinutes, to.PrepTimeMinutes)
+	diff.Fields = appendFieldChange(diff.Fields, "total_time_minutes", from.TotalTimeMinutes, to.TotalTimeMinutes)
+	diff.Fields = appendOptionalFieldChange(diff.Fields, "source_url", from.SourceURL, to.SourceURL)
+	diff.Fields = appendOptionalFieldChange(diff.Fields, "notes", from.Notes, to.Notes)
+	diff.Fields = appendOptionalFieldChange(diff.Fields, "recipe_book_id", from.RecipeBookID, to.RecipeBookID)
+
+	for _, id := range to.TagIDs {
+		if !slices.Contains(from.TagIDs, id) {
+			diff.Tags.Added = append(diff.Tags.Added, id)
+		}
+	}
+	for _, id := range from.TagIDs {
+		if !slices.Contains(to.TagIDs, id) {
+			diff.Tags.Removed = append(diff.Tags.Removed, id)
+		}
+	}
+	slices.Sort(diff.Tags.Added)
+	slices.Sort(diff.Tags.Removed)
+
+	fromIngredients := map[int]recipeIngredientRequest{}
+	toIngredients := map[int]recipeIngredientRequest{}
+	positions := []int{}
+	for _, ing := range from.Ingredients {
+		fromIngredients[ing.Position] = ing
+		positions = append(positions, ing.Position)
+	}
+	for _, ing := range to.Ingredients {
+		toIngredients[ing.Position] = ing
+		positions = append(positions, ing.Position)
+	}
+	slices.Sort(positions)
+	for _, position := range slices.Compact(positions) {
+		before, hadBefore := fromIngredients[position]
+		after, hasAfter := toIngredients[position]
+		switch {
+		case !hadBefore:
+			diff.Ingredients = append(diff.Ingredients, recipeIngredientChange{Position: position, Change: revisionChangeAdded, To: &after})
+		case !hasAfter:
+			diff.Ingredients = append(diff.Ingredients, recipeIngredientChange{Position: position, Change: revisionChangeRemoved, From: &before})
+		case !reflect.DeepEqual(before, after):
+			diff.Ingredients = append(diff.Ingredients, recipeIngredientChange{Position: position, Change: revisionChangeChanged, From: &before, To: &after})
+		}
+	}
+
+	fromSteps := map[int]recipeStepRequest{}
+	toSteps := map[int]recipeStepRequest{}
+	stepNumbers := []int{}
+	for _, step := range from.Steps {
+		fromSteps[step.StepNumber] = step
+		stepNumbers = append(stepNumbers, step.StepNumber)
+	}
+	for _, step := range to.Steps {
+		toSteps[step.StepNumber] = step
+		stepNumbers = append(stepNumbers, step.StepNumber)
+	}
+	slices.Sort(stepNumbers)
+	for _, number := range slices.Compact(stepNumbers) {
+		before, hadBefore := fromSteps[number]
+		after, hasAfter := toSteps[number]
+		switch {
+		case !hadBefore:
+			diff.Steps = append(diff.Steps, recipeStepChange{
+				StepNumber:  number,
+				Change:      revisionChangeAdded,
+				To:          &after.Instruction,
+				ToGroupName: after.GroupName,
+			})
+		case !hasAfter:
+			diff.Steps = append(diff.Steps, recipeStepChange{
+				StepNumber:    number,
+				Change:        revisionChangeRemoved,
+				From:          &before.Instruction,
+				FromGroupName: before.GroupName,
+			})
+		case !reflect.DeepEqual(before, after):
+			diff.Steps = append(diff.Steps, recipeStepChange{
+				StepNumber:    number,
+				Change:        revisionChangeChanged,
+				From:          &before.Instruction,
+				To:            &after.Instruction,
+				FromGroupName: before.GroupName,
+				ToGroupName:   after.GroupName,
+			})
+		}
+	}
+
+	return diff
+}
+
+func appendFieldChange[T comparable](changes []recipeFieldChange, field string, from, to T) []recipeFieldChange {
+	if from == to {
+		return changes
+	}
+	return append(changes, recipeFieldChange{Field: field, From: from, To: to})
+}
+
+func appendOptionalFieldChange(changes []recipeFieldChange, field string, from, to *string) []recipeFieldChange {
+	if optionalStringsEqual(from, to) {
+		return changes
+	}
+	return append(changes, recipeFieldChange{Field: field, From: from, To: to})
+}
+
+func optionalStringsEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,78 @@
+package httpapi
+
+import (
+	"testing"
+)
+
+func TestDiffRecipeSnapshots(t *testing.T) {
+	t.Parallel()
+
+	from := validCreateRecipeRequest()
+	from.TagIDs = []string{"tag-a", "tag-b"}
+	from.Ingredients = append(from.Ingredients, recipeIngredientRequest{Position: 2, ItemName: stringPtr("Salsa")})
+
+	to := validCreateRecipeRequest()
+	to.Title = "Fish Tacos"
+	to.Notes = stringPtr("Use corn tortillas.")
+	to.TagIDs = []string{"tag-b", "tag-c"}
+	to.Ingredients[0].Quantity = floatPtr(8)
+	to.Steps = append(to.Steps, recipeStepRequest{StepNumber: 2, Instruction: "Serve."})
+
+	diff := diffRecipeSnapshots(from, to)
+
+	if len(diff.Fields) != 2 || diff.Fields[0].Field != "title" || diff.Fields[1].Field != "notes" {
+		t.Fatalf("fields=%+v, want title and notes", diff.Fields)
+	}
+	if len(diff.Tags.Added) != 1 || diff.Tags.Added[0] != "tag-c" || len(diff.Tags.Removed) != 1 || diff.Tags.Removed[0] != "tag-a" {
+		t.Fatalf("tags=%+v, want +tag-c -tag-a", diff.Tags)
+	}
+	if len(diff.Ingredients) != 2 {
+		t.Fatalf("ingredients=%+v, want 2 changes", diff.Ingredients)
+	}
+	if diff.Ingredients[0].Position != 1 || diff.Ingredients[0].Change != revisionChangeChanged {
+		t.Fatalf("ingredient[0]=%+v, want position 1 changed", diff.Ingredients[0])
+	}
+	if diff.Ingredients[1].Position != 2 || diff.Ingredients[1].Change != revisionChangeRemoved || diff.Ingredients[1].To != nil {
+		t.Fatalf("ingredient[1]=%+v, want position 2 removed", diff.Ingredients[1])
+	}
+	if len(diff.Steps) != 1 || diff.Steps[0].StepNumber != 2 || diff.Steps[0].Change != revisionChangeAdded {
+		t.Fatalf("steps=%+v, want step 2 added", diff.Steps)
+	}
+}
+
+func TestDiffRecipeSnapshotsIdentical(t *testing.T) {
+	t.Parallel()
+
+	diff := diffRecipeSnapshots(validCreateRecipeRequest(), validCreateRecipeRequest())
+	if len(diff.Fields)+len(diff.Tags.Added)+len(diff.Tags.Removed)+len(diff.Ingredients)+len(diff.Steps) != 0 {
+		t.Fatalf("diff=%+v, want empty", diff)
+	}
+}
+
+func TestDiffRecipeSnapshotsStepGroup(t *testing.T) {
+	t.Parallel()
+
+	to := validCreateRecipeRequest()
+	to.Steps[0].GroupName = stringPtr("Filling")
+
+	diff := diffRecipeSnapshots(validCreateRecipeRequest(), to)
+	if len(diff.Steps) != 1 || diff.Steps[0].Change != revisionChangeChanged {
+		t.Fatalf("steps=%+v, want step 1 changed", diff.Steps)
+	}
+	if diff.Steps[0].FromGroupName != nil || diff.Steps[0].ToGroupName == nil || *diff.Steps[0].ToGroupName != "Filling" {
+		t.Fatalf("step=%+v, want group moved to Filling", diff.Steps[0])
+	}
+}
+
+func TestParseRevisionNumber(t *testing.T) {
+	t.Parallel()
+
+	if n, err := parseRevisionNumber("n", " 3 "); err != nil || n != 3 {
+		t.Fatalf("parseRevisionNumber(3)=%d,%v", n, err)
+	}
+	for _, raw := range []string{"", "0", "-1", "abc", "99999999999"} {
+		if _, err := parseRevisionNumber("n", raw); err == nil {
+			t.Fatalf("parseRevisionNumber(%q) ok, want error", raw)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package httpapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type recipeRevisionSummary struct {
+	Revision int    `json:"revision"`
+	Title    string `json:"title"`
+}
+
+type recipeRevision struct {
+	Revision int `json:"revision"`
+	Recipe   struct {
+		Title       string `json:"title"`
+		Ingredients []struct {
+			ItemName *string `json:"item_name"`
+		} `json:"ingredients"`
+		Steps []struct {
+			Instruction string `json:"instruction"`
+		} `json:"steps"`
+	} `json:"recipe"`
+}
+
+type recipeRevisionDiff struct {
+	From   int `json:"from"`
+	To     int `json:"to"`
+	Fields []struct {
+		Field string `json:"field"`
+		From  any    `json:"from"`
+		To    any    `json:"to"`
+	} `json:"fields"`
+	Steps []struct {
+		StepNumber int    `json:"step_number"`
+		Change     string `json:"change"`
+	} `json:"steps"`
+}
+
+func TestRecipes_Revisions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	recipeBody := func(title, step string) string {
+		return fmt.Sprintf(`{
+  "title":%q,
+  "servings":4,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[{"position":1,"quantity":1.5,"quantity_text":null,"unit":"lb","item_name":"chicken","prep":null,"notes":null,"original_text":null}],
+  "steps":[{"step_number":1,"instruction":%q}]
+}`, title, step)
+	}
+
+	var created recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", recipeBody(recipeTitleChickenSoup, "Boil."), http.StatusCreated, &created)
+	base := server.URL + "/api/v1/recipes/" + created.ID
+
+	var updated recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPut, base, recipeBody("Chicken Stew", "Simmer."), http.StatusOK, &updated)
+
+	t.Run("list revisions newest first", func(t *testing.T) {
+		var out []recipeRevisionSummary
+		doRevisionsRequest(t, client, csrf, http.MethodGet, base+"/revisions", "", http.StatusOK, &out)
+		if len(out) != 2 || out[0].Revision != 2 || out[0].Title != "Chicken Stew" || out[1].Title != recipeTitleChickenSoup {
+			t.Fatalf("revisions=%+v, want [2 Chicken Stew, 1 Chicken Soup]", out)
+		}
+	})
+
+	t.Run("get revision snapshot", func(t *testing.T) {
+		var out recipeRevision
+		doRevisionsRequest(t, client, csrf, http.MethodGet, base+"/revisions/1", "", http.StatusOK, &out)
+		if out.Recipe.Title != recipeTitleChickenSoup || len(out.Recipe.Steps) != 1 || out.Recipe.Steps[0].Instruction != "Boil." {
+			t.Fatalf("revision 1=%+v", out)
+		}
+		if len(out.Recipe.Ingredients) != 1 || out.Recipe.Ingredients[0].ItemName == nil || *out.Recipe.Ingredients[0].ItemName != "chicken" {
+			t.Fatalf("revision 1 ingredients=%+v", out.Recipe.Ingredients)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodGet, base+"/revisions/9", "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodGet, base+"/revisions/0", "", http.StatusBadRequest, nil)
+	})
+
+	t.Run("diff revisions", func(t *testing.T) {
+		var out recipeRevisionDiff
+		doRevisionsRequest(t, client, csrf, http.MethodGet, base+"/revisions/diff?from=1&to=2", "", http.StatusOK, &out)
+		if out.From != 1 || out.To != 2 {
+			t.Fatalf("diff range=%d..%d, want 1..2", out.From, out.To)
+		}
+		if len(out.Fields) != 1 || out.Fields[0].Field != "title" || out.Fields[0].To != "Chicken Stew" {
+			t.Fatalf("fields=%+v, want title change", out.Fields)
+		}
+		if len(out.Steps) != 1 || out.Steps[0].Change != "changed" {
+			t.Fatalf("steps=%+v, want one changed step", out.Steps)
+		}
+	})
+
+	t.Run("revert records a new revision", func(t *testing.T) {
+		var reverted recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, base+"/revisions/1/revert", "", http.StatusOK, &reverted)
+		if reverted.Title != recipeTitleChickenSoup || reverted.Steps[0].Instruction != "Boil." {
+			t.Fatalf("reverted=%+v", reverted)
+		}
+
+		var out []recipeRevisionSummary
+		doRevisionsRequest(t, client, csrf, http.MethodGet, base+"/revisions", "", http.StatusOK, &out)
+		if len(out) != 3 || out[0].Revision != 3 || out[0].Title != recipeTitleChickenSoup {
+			t.Fatalf("revisions=%+v, want revision 3 restoring %s", out, recipeTitleChickenSoup)
+		}
+	})
+}
+
+func doRevisionsRequest(t *testing.T, client *http.Client, csrf, method, url, body string, wantStatus int, out any) {
+	t.Helper()
+
+	req := newJSONRequest(t, method, url, body)
+	req.Header.Set("X-CSRF-Token", csrf)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			t.Errorf("close body: %v", closeErr)
+		}
+	}()
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("%s %s status=%d, want %d", method, url, resp.StatusCode, wantStatus)
+	}
+	if out == nil {
+		return
+	}
+	if decodeErr := json.NewDecoder(resp.Body).Decode(out); decodeErr != nil {
+		t.Fatalf("decode %s %s: %v", method, url, decodeErr)
+	}
+}
@@ -0,0 +1,70 @@
+package httpapi
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// quantityScalePrecision bounds scaled quantities to four decimal places so
+// float noise (e.g. 0.30000000000000004) never reaches clients or the DB.
+const quantityScalePrecision = 10000
+
+// parseServingsQuery reads the optional servings query parameter.
+// It returns 0 when the parameter is absent.
+func parseServingsQuery(r *http.Request) (int32, error) {
+	v := strings.TrimSpace(r.URL.Query().Get("servings"))
+	if v == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.Atoi(v)
+	if err != nil || parsed <= 0 || parsed > maxInt32 {
+		return 0, errValidationField("servings", "invalid servings")
+	}
+	return int32(parsed), nil //nolint:gosec // bounds checked above
+}
+
+// servingsScaleFactor returns the multiplier that converts base servings to target servings.
+func servingsScaleFactor(target, base int32) float64 {
+	if target <= 0 || base <= 0 || target == base {
+		return 1
+	}
+	return float64(target) / float64(base)
+}
+
+// scaleQuantity multiplies a quantity by factor; free-text quantities are left to the caller.
+func scaleQuantity(value *float64, factor float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	if factor == 1 {
+		v := *value
+		return &v
+	}
+	scaled := math.Round(*value*factor*quantityScalePrecision) / quantityScalePrecision
+	return &scaled
+}
+
+// scaleRecipeDetail rescales ingredient quantities and the cost total to the
+// requested servings. Quantity text that mirrors a numeric quantity is dropped
+// because it would be stale; free-text-only quantities ("to taste") are left
+// untouched.
+func scaleRecipeDetail(detail *recipeDetailResponse, servings int32) {
+	if servings <= 0 || servings == detail.Servings {
+		return
+	}
+	factor := servingsScaleFactor(servings, detail.Servings)
+	for i := range detail.Ingredients {
+		ing := &detail.Ingredients[i]
+		if ing.Quantity == nil {
+			continue
+		}
+		ing.Quantity = scaleQuantity(ing.Quantity, factor)
+		ing.QuantityText = nil
+	}
+	detail.Cost.Total = roundCost(detail.Cost.Total * factor)
+	base := detail.Servings
+	detail.ScaledFromServings = &base
+	detail.Servings = servings
+}
@@ -0,0 +1,195 @@
+package httpapi
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+)
+
+func TestParseServingsQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		query   string
+		want    int32
+		wantErr bool
+	}{
+		{name: "absent", query: "", want: 0},
+		{name: "valid", query: "?servings=6", want: 6},
+		{name: "zero", query: "?servings=0", wantErr: true},
+		{name: "negative", query: "?servings=-2", wantErr: true},
+		{name: "not a number", query: "?servings=two", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest("GET", "/api/v1/recipes/x"+tc.query, nil)
+			got, err := parseServingsQuery(r)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %d", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseServingsQuery: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("servings=%d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScaleRecipeDetail(t *testing.T) {
+	t.Parallel()
+
+	one := 1.0
+	third := 0.3333
+	detail := recipeDetailResponse{
+		Servings: 3,
+		Ingredients: []recipeIngredientResponse{
+			{Quantity: &one, QuantityText: stringPtr("1")},
+			{Quantity: &third},
+			{Quantity: nil, QuantityText: stringPtr("to taste")},
+		},
+		Cost: recipeCostResponse{Total: 4.5, PerServing: 1.5},
+	}
+
+	scaleRecipeDetail(&detail, 6)
+
+	if detail.Servings != 6 {
+		t.Fatalf("servings=%d, want 6", detail.Servings)
+	}
+	if detail.ScaledFromServings == nil || *detail.ScaledFromServings != 3 {
+		t.Fatalf("scaled_from_servings=%v, want 3", detail.ScaledFromServings)
+	}
+	if got := *detail.Ingredients[0].Quantity; got != 2 {
+		t.Fatalf("quantity[0]=%v, want 2", got)
+	}
+	if detail.Ingredients[0].QuantityText != nil {
+		t.Fatalf("quantity_text[0]=%q, want nil", *detail.Ingredients[0].QuantityText)
+	}
+	if got := *detail.Ingredients[1].Quantity; got != 0.6666 {
+		t.Fatalf("quantity[1]=%v, want 0.6666", got)
+	}
+	if detail.Ingredients[2].Quantity != nil || *detail.Ingredients[2].QuantityText != "to taste" {
+		t.Fatalf("free-text ingredient changed: %+v", detail.Ingredients[2])
+	}
+	if detail.Cost.Total != 9 || detail.Cost.PerServing != 1.5 {
+		t.Fatalf("cost=%+v, want total 9 and 1.5 per serving", detail.Cost)
+	}
+	if one != 1.0 {
+		t.Fatalf("source quantity mutated to %v", one)
+	}
+}
+
+func TestScaleRecipeDetail_SameServingsIsNoop(t *testing.T) {
+	t.Parallel()
+
+	qty := 1.5
+	detail := recipeDetailResponse{
+		Servings:    4,
+		Ingredients: []recipeIngredientResponse{{Quantity: &qty}},
+	}
+
+	scaleRecipeDetail(&detail, 4)
+
+	if detail.ScaledFromServings != nil {
+		t.Fatalf("scaled_from_servings=%v, want nil", *detail.ScaledFromServings)
+	}
+	if *detail.Ingredients[0].Quantity != 1.5 {
+		t.Fatalf("quantity=%v, want 1.5", *detail.Ingredients[0].Quantity)
+	}
+}
+
+func TestAggregateRecipeIngredients_ScalesPerRecipe(t *testing.T) {
+	t.Parallel()
+
+	itemID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	soupID := uuid.New()
+	stewID := uuid.New()
+	half, err := numericPtrFromFloat64(floatPtr(0.5))
+	if err != nil {
+		t.Fatalf("numeric: %v", err)
+	}
+	unit := pgtype.Text{String: "lb", Valid: true}
+
+	rows := []sqlc.ListRecipeIngredientsByRecipeIDsRow{
+		{RecipeID: pgtype.UUID{Bytes: soupID, Valid: true}, ItemID: itemID, Quantity: half, Unit: unit, Factor: 1, RecipeServings: 2},
+		{RecipeID: pgtype.UUID{Bytes: stewID, Valid: true}, ItemID: itemID, Quantity: half, Unit: unit, Factor: 1, RecipeServings: 4},
+	}
+
+	items, err := aggregateRecipeIngredients(rows, map[uuid.UUID]int32{soupID: 6})
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("items=%d, want 1", len(items))
+	}
+	// Soup tripled (1.5) plus stew unscaled (0.5).
+	if items[0].quantity == nil || *items[0].quantity != 2 {
+		t.Fatalf("quantity=%v, want 2", items[0].quantity)
+	}
+}
+
+func TestAggregateRecipeIngredients_ScalesSubRecipeLines(t *testing.T) {
+	t.Parallel()
+
+	flour := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	pizzaID := uuid.New()
+	grams, err := numericPtrFromFloat64(floatPtr(500))
+	if err != nil {
+		t.Fatalf("numeric: %v", err)
+	}
+
+	// The pizza uses a quarter batch of dough, and is doubled from 4 to 8 servings.
+	rows := []sqlc.ListRecipeIngredientsByRecipeIDsRow{
+		{RecipeID: pgtype.UUID{Bytes: pizzaID, Valid: true}, ItemID: flour, Quantity: grams, Unit: pgtype.Text{String: "g", Valid: true}, Factor: 0.25, RecipeServings: 4},
+	}
+
+	items, err := aggregateRecipeIngredients(rows, map[uuid.UUID]int32{pizzaID: 8})
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("items=%d, want 1", len(items))
+	}
+	if items[0].quantity == nil || *items[0].quantity != 250 {
+		t.Fatalf("quantity=%v, want 250", items[0].quantity)
+	}
+}
+
+func TestParseRecipeServingsOverrides(t *testing.T) {
+	t.Parallel()
+
+	recipeID := uuid.New()
+	recipeIDs := []pgtype.UUID{{Bytes: recipeID, Valid: true}}
+
+	got, err := parseRecipeServingsOverrides(map[string]int32{recipeID.String(): 8}, recipeIDs)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got[recipeID] != 8 {
+		t.Fatalf("servings=%d, want 8", got[recipeID])
+	}
+
+	if _, err := parseRecipeServingsOverrides(map[string]int32{uuid.NewString(): 2}, recipeIDs); err == nil {
+		t.Fatalf("expected error for recipe outside recipe_ids")
+	}
+	if _, err := parseRecipeServingsOverrides(map[string]int32{recipeID.String(): 0}, recipeIDs); err == nil {
+		t.Fatalf("expected error for non-positive servings")
+	}
+	if _, err := parseRecipeServingsOverrides(map[string]int32{"nope": 2}, recipeIDs); err == nil {
+		t.Fatalf("expected error for invalid id")
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,218 @@
+package httpapi
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+// recipeShareRequest creates a share link. A nil ExpiresAt never expires.
+type recipeShareRequest struct {
+	ExpiresAt *string `json:"expires_at"`
+}
+
+// recipeShareResponse describes a share link. Token is only returned when
+// the share is created; afterwards only its hash is kept.
+type recipeShareResponse struct {
+	ID        string  `json:"id"`
+	RecipeID  string  `json:"recipe_id"`
+	Token     *string `json:"token,omitempty"`
+	Path      *string `json:"path,omitempty"`
+	APIPath   *string `json:"api_path,omitempty"`
+	ExpiresAt *string `json:"expires_at"`
+	CreatedAt string  `json:"created_at"`
+}
+
+func (a *App) handleRecipeSharesList(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	rows, err := a.queries.ListRecipeSharesByRecipeID(r.Context(), sqlc.ListRecipeSharesByRecipeIDParams{
+		RecipeID:  pgtype.UUID{Bytes: id, Valid: true},
+		CreatedBy: pgtype.UUID{Bytes: info.UserID, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+
+	out := make([]recipeShareResponse, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, recipeShareResponseFromRow(row))
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, out); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/shares")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeSharesCreate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req recipeShareRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+
+	var expiresAt pgtype.Timestamptz
+	if req.ExpiresAt != nil {
+		parsed, parseErr := time.Parse(time.RFC3339, strings.TrimSpace(*req.ExpiresAt))
+		if parseErr != nil {
+			return errValidationField("expires_at", "expires_at must be RFC3339")
+		}
+		if !parsed.After(time.Now()) {
+			return errValidationField("expires_at", "expires_at must be in the future")
+		}
+		expiresAt = pgtype.Timestamptz{Time: parsed, Valid: true}
+	}
+
+	ctx := r.Context()
+	recipeID := pgtype.UUID{Bytes: id, Valid: true}
+	deletedAt, err := a.queries.GetRecipeDeletedAtByID(ctx, recipeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+	if deletedAt.Valid {
+		return errConflict("recipe is deleted; restore before sharing")
+	}
+
+	token, hash, err := newRecipeShareToken()
+	if err != nil {
+		return errInternal(err)
+	}
+
+	row, err := a.queries.CreateRecipeShare(ctx, sqlc.CreateRecipeShareParams{
+		RecipeID:  recipeID,
+		TokenHash: hash,
+		ExpiresAt: expiresAt,
+		CreatedBy: pgtype.UUID{Bytes: info.UserID, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+
+	a.audit(r, "recipe_share.created", "share_id", uuidString(row.ID), "recipe_id", uuidString(row.RecipeID), "expires_at", timeString(row.ExpiresAt))
+
+	resp := recipeShareResponseFromRow(row)
+	path := "/shared/recipes/" + token
+	apiPath := "/api/v1/shared/recipes/" + token
+	resp.Token = &token
+	resp.Path = &path
+	resp.APIPath = &apiPath
+
+	if err := response.WriteJSON(w, http.StatusCreated, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}/shares")
+	}
+	return nil
+}
+
+func (a *App) handleRecipeSharesDelete(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	shareID, err := parseUUIDParam(r, "share_id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeleteRecipeShare(r.Context(), sqlc.DeleteRecipeShareParams{
+		RecipeID:  pgtype.UUID{Bytes: id, Valid: true},
+		ID:        pgtype.UUID{Bytes: shareID, Valid: true},
+		CreatedBy: pgtype.UUID{Bytes: info.UserID, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+	if affected == 0 {
+		return errNotFound()
+	}
+
+	a.audit(r, "recipe_share.revoked", "share_id", shareID.String(), "recipe_id", id.String())
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// handleRecipeSharesDeleteAll revokes every share link the caller created
+// for the recipe.
+func (a *App) handleRecipeSharesDeleteAll(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeleteRecipeSharesByRecipeID(r.Context(), sqlc.DeleteRecipeSharesByRecipeIDParams{
+		RecipeID:  pgtype.UUID{Bytes: id, Valid: true},
+		CreatedBy: pgtype.UUID{Bytes: info.UserID, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+
+	a.audit(r, "recipe_share.revoked_all", "recipe_id", id.String(), "count", affected)
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func recipeShareResponseFromRow(row sqlc.RecipeShare) recipeShareResponse {
+	return recipeShareResponse{
+		ID:        uuidString(row.ID),
+		RecipeID:  uuidString(row.RecipeID),
+		ExpiresAt: timeStringPtr(row.ExpiresAt),
+		CreatedAt: timeString(row.CreatedAt),
+	}
+}
+
+// newRecipeShareToken returns a random URL-safe share token and the sha256
+// hex hash that is stored in its place.
+func newRecipeShareToken() (string, string, error) {
+	var raw [32]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw[:])
+	return token, hashRecipeShareToken(token), nil
+}
+
+func hashRecipeShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
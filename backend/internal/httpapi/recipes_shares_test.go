@@ -0,0 +1,155 @@
+package httpapi_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type testRecipeShare struct {
+	ID        string  `json:"id"`
+	RecipeID  string  `json:"recipe_id"`
+	Token     string  `json:"token"`
+	Path      string  `json:"path"`
+	APIPath   string  `json:"api_path"`
+	ExpiresAt *string `json:"expires_at"`
+}
+
+func TestRecipes_Shares(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+	anonymous := &http.Client{}
+
+	var recipe recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", `{
+  "title":"Grandma's Soup",
+  "servings":4,
+  "prep_time_minutes":10,
+  "total_time_minutes":60,
+  "source_url":null,
+  "notes":"Better the next day.",
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[{"position":1,"quantity":2,"unit":"cup","item_name":"carrots","prep":"diced"}],
+  "steps":[{"step_number":1,"instruction":"Simmer for 1 hour."}]
+}`, http.StatusCreated, &recipe)
+
+	var share testRecipeShare
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+recipe.ID+"/shares",
+		`{"expires_at":null}`, http.StatusCreated, &share)
+	if share.Token == "" || share.Path != "/shared/recipes/"+share.Token || share.APIPath != "/api/v1/shared/recipes/"+share.Token {
+		t.Fatalf("share=%+v, want token and paths", share)
+	}
+
+	t.Run("anonymous readers get the recipe without ids", func(t *testing.T) {
+		var shared map[string]any
+		doRevisionsRequest(t, anonymous, "", http.MethodGet, server.URL+share.APIPath+"?servings=8", "", http.StatusOK, &shared)
+		if shared["title"] != "Grandma's Soup" || shared["servings"] != float64(8) {
+			t.Fatalf("shared=%v, want scaled soup", shared)
+		}
+		if _, ok := shared["id"]; ok {
+			t.Fatalf("shared recipe exposes id: %v", shared)
+		}
+
+		resp, err := anonymous.Get(server.URL + share.Path)
+		if err != nil {
+			t.Fatalf("get page: %v", err)
+		}
+		body, readErr := io.ReadAll(resp.Body)
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			t.Errorf("close body: %v", closeErr)
+		}
+		if readErr != nil {
+			t.Fatalf("read page: %v", readErr)
+		}
+		if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+			t.Fatalf("page status=%d content-type=%q", resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		if !strings.Contains(string(body), "Grandma&#39;s Soup") || !strings.Contains(string(body), "2 cup carrots, diced") {
+			t.Fatalf("page missing recipe:\n%s", body)
+		}
+	})
+
+	t.Run("unknown and expired tokens are not found", func(t *testing.T) {
+		doRevisionsRequest(t, anonymous, "", http.MethodGet, server.URL+"/api/v1/shared/recipes/not-a-token", "", http.StatusNotFound, nil)
+
+		past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/"+recipe.ID+"/shares",
+			`{"expires_at":"`+past+`"}`, http.StatusBadRequest, nil)
+
+		if _, err := pool.Exec(ctx, `UPDATE recipe_shares SET expires_at = now() - interval '1 minute' WHERE id = $1`, share.ID); err != nil {
+			t.Fatalf("expire share: %v", err)
+		}
+		doRevisionsRequest(t, anonymous, "", http.MethodGet, server.URL+share.APIPath, "", http.StatusNotFound, nil)
+		if _, err := pool.Exec(ctx, `UPDATE recipe_shares SET expires_at = NULL WHERE id = $1`, share.ID); err != nil {
+			t.Fatalf("unexpire share: %v", err)
+		}
+	})
+
+	t.Run("revoking a share disables its link", func(t *testing.T) {
+		var listed []testRecipeShare
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+recipe.ID+"/shares", "", http.StatusOK, &listed)
+		if len(listed) != 1 || listed[0].ID != share.ID || listed[0].Token != "" {
+			t.Fatalf("listed=%+v, want one share without its token", listed)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, server.URL+"/api/v1/recipes/"+recipe.ID+"/shares/"+share.ID, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, anonymous, "", http.MethodGet, server.URL+share.APIPath, "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, server.URL+"/api/v1/recipes/"+recipe.ID+"/shares/"+share.ID, "", http.StatusNotFound, nil)
+	})
+
+	t.Run("share management requires auth", func(t *testing.T) {
+		doRevisionsRequest(t, anonymous, "", http.MethodGet, server.URL+"/api/v1/recipes/"+recipe.ID+"/shares", "", http.StatusUnauthorized, nil)
+	})
+}
@@ -0,0 +1,124 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+func TestRecipes_StepCues(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	t.Run("parse proposes timings", func(t *testing.T) {
+		var parsed struct {
+			Items []struct {
+				Instruction        string  `json:"instruction"`
+				DurationSeconds    *int    `json:"duration_seconds"`
+				DurationMaxSeconds *int    `json:"duration_max_seconds"`
+				Temperature        *int    `json:"temperature"`
+				TemperatureUnit    *string `json:"temperature_unit"`
+			} `json:"items"`
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/steps/parse",
+			`{"lines":["Bake 25–30 minutes at 400°F.","Serve."]}`, http.StatusOK, &parsed)
+		if len(parsed.Items) != 2 {
+			t.Fatalf("items=%+v, want 2", parsed.Items)
+		}
+		bake := parsed.Items[0]
+		if bake.DurationSeconds == nil || *bake.DurationSeconds != 1500 || bake.DurationMaxSeconds == nil || *bake.DurationMaxSeconds != 1800 {
+			t.Fatalf("bake=%+v, want 1500..1800 seconds", bake)
+		}
+		if bake.Temperature == nil || *bake.Temperature != 400 || bake.TemperatureUnit == nil || *bake.TemperatureUnit != "F" {
+			t.Fatalf("bake=%+v, want 400F", bake)
+		}
+		if serve := parsed.Items[1]; serve.DurationSeconds != nil || serve.Temperature != nil {
+			t.Fatalf("serve=%+v, want no cues", serve)
+		}
+	})
+
+	t.Run("detail returns stored timings", func(t *testing.T) {
+		body := `{
+  "title":"Roast potatoes",
+  "servings":4,
+  "prep_time_minutes":10,
+  "total_time_minutes":60,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[{"position":1,"quantity":1,"unit":"kg","item_name":"potatoes"}],
+  "steps":[
+    {"step_number":1,"instruction":"Parboil.","duration_seconds":600},
+    {"step_number":2,"instruction":"Roast.","duration_seconds":2400,"duration_max_seconds":3000,"temperature":220,"temperature_unit":"c"}
+  ]
+}`
+		var created recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes", body, http.StatusCreated, &created)
+
+		var got recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+created.ID, "", http.StatusOK, &got)
+		if len(got.Steps) != 2 {
+			t.Fatalf("steps=%+v, want 2", got.Steps)
+		}
+		if parboil := got.Steps[0]; parboil.DurationSeconds == nil || *parboil.DurationSeconds != 600 || parboil.DurationMaxSeconds != nil || parboil.Temperature != nil {
+			t.Fatalf("parboil=%+v", parboil)
+		}
+		roast := got.Steps[1]
+		if roast.DurationMaxSeconds == nil || *roast.DurationMaxSeconds != 3000 || roast.Temperature == nil || *roast.Temperature != 220 {
+			t.Fatalf("roast=%+v", roast)
+		}
+		if roast.TemperatureUnit == nil || *roast.TemperatureUnit != "C" {
+			t.Fatalf("roast unit=%v, want C", roast.TemperatureUnit)
+		}
+	})
+}
@@ -0,0 +1,217 @@
+package httpapi_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+func TestRecipes_SubRecipes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, userErr := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); userErr != nil {
+		t.Fatalf("bootstrap user: %v", userErr)
+	}
+
+	app, appErr := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   "cooking_app_session",
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if appErr != nil {
+		t.Fatalf("new app: %v", appErr)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, jarErr := cookiejar.New(nil)
+	if jarErr != nil {
+		t.Fatalf("cookie jar: %v", jarErr)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	recipePayload := func(title, servings, ingredients string) string {
+		return `{
+  "title":"` + title + `",
+  "servings":` + servings + `,
+  "prep_time_minutes":0,
+  "total_time_minutes":0,
+  "source_url":null,
+  "notes":null,
+  "recipe_book_id":null,
+  "tag_ids":[],
+  "ingredients":[` + ingredients + `],
+  "steps":[{"step_number":1,"instruction":"Cook."}]
+}`
+	}
+
+	var dough recipeDetailResponse
+	doughIngredients := `{"position":1,"quantity":500,"unit":"g","item_name":"flour"},{"position":2,"quantity":300,"unit":"ml","item_name":"water"}`
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+		recipePayload("Pizza dough", "4", doughIngredients), http.StatusCreated, &dough)
+
+	// Two servings of a four-serving dough: half a batch per pizza.
+	pizzaIngredients := `{"position":1,"quantity":2,"sub_recipe_id":"` + dough.ID + `"},{"position":2,"quantity":200,"unit":"g","item_name":"mozzarella"}`
+	var pizza recipeDetailResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+		recipePayload("Margherita", "2", pizzaIngredients), http.StatusCreated, &pizza)
+
+	t.Run("detail shows the nested component", func(t *testing.T) {
+		var got recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+pizza.ID, "", http.StatusOK, &got)
+		if len(got.Ingredients) != 2 {
+			t.Fatalf("ingredients=%+v, want 2", got.Ingredients)
+		}
+		line := got.Ingredients[0]
+		if line.Item != nil || line.SubRecipe == nil || line.SubRecipe.ID != dough.ID || line.SubRecipe.Title != "Pizza dough" {
+			t.Fatalf("sub-recipe line=%+v, want dough component", line)
+		}
+		if line.SubRecipe.Servings != 4 || len(line.SubRecipe.Ingredients) != 2 || line.SubRecipe.Ingredients[0].Item.Name != "flour" {
+			t.Fatalf("sub-recipe=%+v, want dough ingredients", line.SubRecipe)
+		}
+		if got.Ingredients[1].Item == nil || got.Ingredients[1].Item.Name != "mozzarella" {
+			t.Fatalf("item line=%+v, want mozzarella", got.Ingredients[1])
+		}
+		// Nutrition expands the dough, so its own items are what go uncounted.
+		flourLine := line.SubRecipe.Ingredients[0].ID
+		found := false
+		for _, gap := range got.Nutrition.Uncounted {
+			if gap.Reason == "sub_recipe" {
+				t.Fatalf("uncounted=%+v, want the sub-recipe expanded", got.Nutrition.Uncounted)
+			}
+			if gap.IngredientID == flourLine && gap.Reason == "missing_nutrition" {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("uncounted=%+v, want the dough's flour line", got.Nutrition.Uncounted)
+		}
+	})
+
+	t.Run("rejects self references and cycles", func(t *testing.T) {
+		selfRef := `{"position":1,"quantity":1,"sub_recipe_id":"` + pizza.ID + `"}`
+		doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/recipes/"+pizza.ID,
+			recipePayload("Margherita", "2", selfRef), http.StatusBadRequest, nil)
+
+		cycle := doughIngredients + `,{"position":3,"quantity":1,"sub_recipe_id":"` + pizza.ID + `"}`
+		doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/recipes/"+dough.ID,
+			recipePayload("Pizza dough", "4", cycle), http.StatusBadRequest, nil)
+
+		missing := `{"position":1,"quantity":1,"sub_recipe_id":"00000000-0000-0000-0000-000000000000"}`
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes",
+			recipePayload("Calzone", "2", missing), http.StatusBadRequest, nil)
+	})
+
+	t.Run("shopping lists expand sub-recipes", func(t *testing.T) {
+		var list testShoppingListResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists",
+			`{"list_date":"2025-03-01","name":"Pizza night","notes":null}`, http.StatusCreated, &list)
+
+		// Doubling the pizza to four servings uses a full batch of dough.
+		var items []testShoppingListItem
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists/"+list.ID+"/items/from-recipes",
+			`{"recipe_ids":["`+pizza.ID+`"],"servings":{"`+pizza.ID+`":4}}`, http.StatusOK, &items)
+		assertListQuantity(t, items, "flour", 500)
+		assertListQuantity(t, items, "water", 300)
+		assertListQuantity(t, items, "mozzarella", 400)
+
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/meal-plans",
+			`{"date":"2025-03-02","recipe_id":"`+pizza.ID+`"}`, http.StatusOK, nil)
+		var planList testShoppingListResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists",
+			`{"list_date":"2025-03-02","name":"Plan","notes":null}`, http.StatusCreated, &planList)
+		var planItems []testShoppingListItem
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists/"+planList.ID+"/items/from-meal-plan",
+			`{"date":"2025-03-02"}`, http.StatusOK, &planItems)
+		assertListQuantity(t, planItems, "flour", 250)
+		assertListQuantity(t, planItems, "mozzarella", 200)
+	})
+
+	t.Run("search indexes sub-recipe titles", func(t *testing.T) {
+		var found recipesListResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes?q=dough", "", http.StatusOK, &found)
+		if len(found.Items) != 2 {
+			t.Fatalf("search=%+v, want the pizza and the dough", found.Items)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/recipes/"+dough.ID,
+			recipePayload("Pizza base", "4", doughIngredients), http.StatusOK, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes?q=base", "", http.StatusOK, &found)
+		if len(found.Items) != 2 {
+			t.Fatalf("search=%+v, want the renamed sub-recipe indexed in its parent", found.Items)
+		}
+	})
+
+	t.Run("deleted sub-recipes are not expanded", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, server.URL+"/api/v1/recipes/"+dough.ID, "", http.StatusNoContent, nil)
+
+		var list testShoppingListResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists",
+			`{"list_date":"2025-03-03","name":"After delete","notes":null}`, http.StatusCreated, &list)
+		var items []testShoppingListItem
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists/"+list.ID+"/items/from-recipes",
+			`{"recipe_ids":["`+pizza.ID+`"]}`, http.StatusOK, &items)
+		if len(items) != 1 || findListItem(items, "mozzarella") == nil {
+			t.Fatalf("items=%+v, want only mozzarella", items)
+		}
+
+		var detail recipeDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/recipes/"+pizza.ID, "", http.StatusOK, &detail)
+		sub := detail.Ingredients[0].SubRecipe
+		if sub == nil || !sub.Deleted || len(sub.Ingredients) != 0 {
+			t.Fatalf("sub-recipe=%+v, want it marked deleted and unexpanded", sub)
+		}
+		if len(detail.Unclassified) != 1 || detail.Unclassified[0].Name != "mozzarella" {
+			t.Fatalf("unclassified=%+v, want only mozzarella", detail.Unclassified)
+		}
+
+		var match recipeMatchResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/recipes/match",
+			`{"item_names":["mozzarella"]}`, http.StatusOK, &match)
+		if len(match.Recipes) != 1 || match.Recipes[0].ID != pizza.ID || match.Recipes[0].Coverage != 1 {
+			t.Fatalf("recipes=%+v, want the pizza covered by mozzarella alone", match.Recipes)
+		}
+	})
+}
+
+func assertListQuantity(t *testing.T, items []testShoppingListItem, name string, want float64) {
+	t.Helper()
+
+	entry := findListItem(items, name)
+	if entry == nil || entry.Quantity == nil {
+		t.Fatalf("missing %s entry in %+v", name, items)
+	}
+	if math.Abs(*entry.Quantity-want) > 0.001 {
+		t.Fatalf("%s quantity=%v, want %v", name, *entry.Quantity, want)
+	}
+}
@@ -24,6 +24,7 @@ func (a *App) handleRecipesUpdate(w http.ResponseWriter, r *http.Request) error
 		return decodeErr
 	}
 
+	fillParsedIngredients(&req)
 	if errs := validateCreateRecipeRequest(req); len(errs) > 0 {
 		return errValidation(errs)
 	}
@@ -31,16 +32,17 @@ func (a *App) handleRecipesUpdate(w http.ResponseWriter, r *http.Request) error
 	ctx := r.Context()
 	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
 
-	if updateErr := updateRecipeUsecase(ctx, a.recipeWorkflows(), userID, recipeID, req); updateErr != nil {
+	matches, updateErr := updateRecipeUsecase(ctx, a.recipeWorkflows(), userID, recipeID, req)
+	if updateErr != nil {
 		return mapRecipeUsecaseError(updateErr)
 	}
 
-	detail, err := a.loadRecipeDetail(ctx, recipeID)
+	detail, err := a.loadRecipeDetail(ctx, recipeID, userID)
 	if err != nil {
 		return errInternal(err)
 	}
 
-	if err := response.WriteJSON(w, http.StatusOK, detail); err != nil {
+	if err := response.WriteJSON(w, http.StatusOK, newRecipeSaveResponse(detail, matches)); err != nil {
 		a.logger.Warn("write failed", "err", err, "path", "/api/v1/recipes/{id}")
 	}
 	return nil
@@ -22,7 +22,7 @@ type recipeWorkflows interface {
 }
 
 // recipeWorkflowQueries are the query methods used inside a transaction for
-// recipe create/update workflows.
+// recipe create/update/merge workflows.
 type recipeWorkflowQueries interface {
 	CreateRecipe(ctx context.Context, arg sqlc.CreateRecipeParams) (sqlc.Recipe, error)
 	CreateRecipeIngredient(ctx context.Context, arg sqlc.CreateRecipeIngredientParams) error
@@ -31,13 +31,27 @@ type recipeWorkflowQueries interface {
 
 	GetItemByID(ctx context.Context, id pgtype.UUID) (sqlc.GetItemByIDRow, error)
 	GetItemByName(ctx context.Context, name string) (sqlc.Item, error)
+	GetItemByAlias(ctx context.Context, alias string) (sqlc.GetItemByAliasRow, error)
+	FindSimilarItem(ctx context.Context, arg sqlc.FindSimilarItemParams) (sqlc.FindSimilarItemRow, error)
 	CreateItem(ctx context.Context, arg sqlc.CreateItemParams) (sqlc.Item, error)
 
 	GetRecipeDeletedAtByID(ctx context.Context, id pgtype.UUID) (pgtype.Timestamptz, error)
+	SubRecipeTreeContains(ctx context.Context, arg sqlc.SubRecipeTreeContainsParams) (bool, error)
 	UpdateRecipeByID(ctx context.Context, arg sqlc.UpdateRecipeByIDParams) (sqlc.Recipe, error)
 	DeleteRecipeIngredientsByRecipeID(ctx context.Context, recipeID pgtype.UUID) error
 	DeleteRecipeStepsByRecipeID(ctx context.Context, recipeID pgtype.UUID) error
 	DeleteRecipeTagsByRecipeID(ctx context.Context, recipeID pgtype.UUID) error
+
+	MoveRecipeTags(ctx context.Context, arg sqlc.MoveRecipeTagsParams) (int64, error)
+	MoveMealPlanEntries(ctx context.Context, arg sqlc.MoveMealPlanEntriesParams) (int64, error)
+	DeleteMealPlanEntriesByRecipeID(ctx context.Context, recipeID pgtype.UUID) error
+	ListRecipeIDsBySubRecipeID(ctx context.Context, subRecipeID pgtype.UUID) ([]pgtype.UUID, error)
+	MoveSubRecipeReferences(ctx context.Context, arg sqlc.MoveSubRecipeReferencesParams) (int64, error)
+	MoveRecipeVariants(ctx context.Context, arg sqlc.MoveRecipeVariantsParams) (int64, error)
+	SoftDeleteRecipeByID(ctx context.Context, arg sqlc.SoftDeleteRecipeByIDParams) (int64, error)
+
+	RefreshRecipeSearchDocument(ctx context.Context, recipeID pgtype.UUID) error
+	CreateRecipeRevision(ctx context.Context, arg sqlc.CreateRecipeRevisionParams) error
 }
 
 // recipeValidationError is returned by recipes use-cases when the request is
@@ -94,8 +108,11 @@ func mapRecipeUsecaseError(err error) error {
 	return errInternal(err)
 }
 
-// resolveIngredientItemID validates item references and creates items when needed.
-func resolveIngredientItemID(ctx context.Context, q recipeWorkflowQueries, actorID pgtype.UUID, ingredient recipeIngredientRequest, index int) (pgtype.UUID, error) {
+// resolveIngredientItemID validates item references and resolves item names.
+// A name without an exact item match may still resolve to an existing item by
+// alias, singular/plural form, or similarity; the returned match records that.
+// Only names that match nothing create a new item.
+func resolveIngredientItemID(ctx context.Context, q recipeWorkflowQueries, actorID pgtype.UUID, ingredient recipeIngredientRequest, index int) (pgtype.UUID, *ingredientItemMatch, error) {
 	itemID := ""
 	if ingredient.ItemID != nil {
 		itemID = strings.TrimSpace(*ingredient.ItemID)
@@ -103,16 +120,16 @@ func resolveIngredientItemID(ctx context.Context, q recipeWorkflowQueries, actor
 	if itemID != "" {
 		parsed, err := uuid.Parse(itemID)
 		if err != nil {
-			return pgtype.UUID{}, recipeValidationField(fmt.Sprintf("ingredients[%d].item_id", index), "invalid item_id")
+			return pgtype.UUID{}, nil, recipeValidationField(fmt.Sprintf("ingredients[%d].item_id", index), "invalid item_id")
 		}
 		pgID := pgtype.UUID{Bytes: parsed, Valid: true}
 		if _, err := q.GetItemByID(ctx, pgID); err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
-				return pgtype.UUID{}, recipeValidationField(fmt.Sprintf("ingredients[%d].item_id", index), "item does not exist")
+				return pgtype.UUID{}, nil, recipeValidationField(fmt.Sprintf("ingredients[%d].item_id", index), "item does not exist")
 			}
-			return pgtype.UUID{}, err
+			return pgtype.UUID{}, nil, err
 		}
-		return pgID, nil
+		return pgID, nil, nil
 	}
 
 	itemName := ""
@@ -120,60 +137,138 @@ func resolveIngredientItemID(ctx context.Context, q recipeWorkflowQueries, actor
 		itemName = strings.TrimSpace(*ingredient.ItemName)
 	}
 	if itemName == "" {
-		return pgtype.UUID{}, recipeValidationField(fmt.Sprintf("ingredients[%d].item_name", index), "item_name is required")
+		return pgtype.UUID{}, nil, recipeValidationField(fmt.Sprintf("ingredients[%d].item_name", index), "item_name is required")
 	}
 
 	row, err := q.GetItemByName(ctx, itemName)
+	if err == nil {
+		return row.ID, nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, nil, err
+	}
+
+	match, ok, err := findExistingItem(ctx, q, itemName)
 	if err != nil {
-		if !errors.Is(err, pgx.ErrNoRows) {
-			return pgtype.UUID{}, err
-		}
-		created, createErr := q.CreateItem(ctx, sqlc.CreateItemParams{
-			Name:      itemName,
-			StoreUrl:  pgtype.Text{},
-			AisleID:   pgtype.UUID{},
-			CreatedBy: actorID,
-			UpdatedBy: actorID,
-		})
-		if createErr != nil {
-			if isPGUniqueViolation(createErr) {
-				existing, lookupErr := q.GetItemByName(ctx, itemName)
-				if lookupErr != nil {
-					return pgtype.UUID{}, lookupErr
-				}
-				return existing.ID, nil
+		return pgtype.UUID{}, nil, err
+	}
+	if ok {
+		match.Position = ingredient.Position
+		return match.ItemID, &match, nil
+	}
+
+	created, createErr := q.CreateItem(ctx, sqlc.CreateItemParams{
+		Name:      itemName,
+		StoreUrl:  pgtype.Text{},
+		AisleID:   pgtype.UUID{},
+		CreatedBy: actorID,
+		UpdatedBy: actorID,
+	})
+	if createErr != nil {
+		if isPGUniqueViolation(createErr) {
+			existing, lookupErr := q.GetItemByName(ctx, itemName)
+			if lookupErr != nil {
+				return pgtype.UUID{}, nil, lookupErr
 			}
-			return pgtype.UUID{}, createErr
+			return existing.ID, nil, nil
 		}
-		return created.ID, nil
+		return pgtype.UUID{}, nil, createErr
+	}
+	return created.ID, nil, nil
+}
+
+// resolveIngredientReference resolves an ingredient line to either an item or
+// a sub-recipe; exactly one of the returned IDs is valid. The match is set
+// when an item name resolved other than by exact name.
+func resolveIngredientReference(ctx context.Context, q recipeWorkflowQueries, actorID, recipeID pgtype.UUID, ingredient recipeIngredientRequest, index int) (pgtype.UUID, pgtype.UUID, *ingredientItemMatch, error) {
+	if trimPtr(ingredient.SubRecipeID) == nil {
+		itemID, match, err := resolveIngredientItemID(ctx, q, actorID, ingredient, index)
+		return itemID, pgtype.UUID{}, match, err
+	}
+	subRecipeID, err := resolveIngredientSubRecipeID(ctx, q, recipeID, ingredient, index)
+	return pgtype.UUID{}, subRecipeID, nil, err
+}
+
+// resolveIngredientSubRecipeID validates a sub-recipe reference and rejects
+// references that would make the recipe include itself, directly or through
+// another sub-recipe.
+func resolveIngredientSubRecipeID(ctx context.Context, q recipeWorkflowQueries, recipeID pgtype.UUID, ingredient recipeIngredientRequest, index int) (pgtype.UUID, error) {
+	field := fmt.Sprintf("ingredients[%d].sub_recipe_id", index)
+	parsed, err := uuid.Parse(strings.TrimSpace(*ingredient.SubRecipeID))
+	if err != nil {
+		return pgtype.UUID{}, recipeValidationField(field, "invalid sub_recipe_id")
+	}
+	subRecipeID := pgtype.UUID{Bytes: parsed, Valid: true}
+	if subRecipeID == recipeID {
+		return pgtype.UUID{}, recipeValidationField(field, "a recipe cannot include itself")
+	}
+
+	deletedAt, err := q.GetRecipeDeletedAtByID(ctx, subRecipeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.UUID{}, recipeValidationField(field, "recipe does not exist")
+		}
+		return pgtype.UUID{}, err
+	}
+	if deletedAt.Valid {
+		return pgtype.UUID{}, recipeValidationField(field, "recipe is deleted")
 	}
-	return row.ID, nil
+
+	cycle, err := q.SubRecipeTreeContains(ctx, sqlc.SubRecipeTreeContainsParams{
+		SubRecipeID: subRecipeID,
+		RecipeID:    recipeID,
+	})
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	if cycle {
+		return pgtype.UUID{}, recipeValidationField(field, "sub-recipe already includes this recipe")
+	}
+	return subRecipeID, nil
 }
 
-// createRecipeUsecase performs the create-recipe transactional workflow.
-func createRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID pgtype.UUID, req createRecipeRequest) (pgtype.UUID, error) {
+// createRecipeUsecase performs the create-recipe transactional workflow. It
+// returns the new recipe's ID and the ingredient names matched fuzzily.
+func createRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID pgtype.UUID, req createRecipeRequest) (pgtype.UUID, []ingredientItemMatch, error) {
 	recipeBookID, err := uuidPtrToPG(req.RecipeBookID)
 	if err != nil {
-		return pgtype.UUID{}, recipeValidationField("recipe_book_id", "invalid id")
+		return pgtype.UUID{}, nil, recipeValidationField("recipe_book_id", "invalid id")
 	}
 
 	tagUUIDs, err := uuidsToPG(req.TagIDs)
 	if err != nil {
-		return pgtype.UUID{}, recipeValidationField("tag_ids", "invalid id")
+		return pgtype.UUID{}, nil, recipeValidationField("tag_ids", "invalid id")
+	}
+
+	parentRecipeID, err := uuidPtrToPG(req.ParentRecipeID)
+	if err != nil {
+		return pgtype.UUID{}, nil, recipeValidationField("parent_recipe_id", "invalid id")
 	}
 
 	if len(tagUUIDs) > 0 {
 		count, countErr := workflows.CountTagsByIDs(ctx, tagUUIDs)
 		if countErr != nil {
-			return pgtype.UUID{}, countErr
+			return pgtype.UUID{}, nil, countErr
 		}
 		if int(count) != len(tagUUIDs) {
-			return pgtype.UUID{}, recipeValidationField("tag_ids", "one or more tags do not exist")
+			return pgtype.UUID{}, nil, recipeValidationField("tag_ids", "one or more tags do not exist")
 		}
 	}
 
-	var recipeID pgtype.UUID
+	var (
+		recipeID pgtype.UUID
+		matches  []ingredientItemMatch
+	)
 	err = workflows.WithinTx(ctx, func(q recipeWorkflowQueries) error {
+		if parentRecipeID.Valid {
+			if _, parentErr := q.GetRecipeDeletedAtByID(ctx, parentRecipeID); parentErr != nil {
+				if errors.Is(parentErr, pgx.ErrNoRows) {
+					return recipeValidationField("parent_recipe_id", "parent recipe does not exist")
+				}
+				return parentErr
+			}
+		}
+
 		servings32, ok := intToInt32Checked(req.Servings)
 		if !ok {
 			return recipeValidationField("servings", "servings is too large")
@@ -195,6 +290,7 @@ func createRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID
 			SourceUrl:        textPtrToPG(req.SourceURL),
 			Notes:            textPtrToPG(req.Notes),
 			RecipeBookID:     recipeBookID,
+			ParentRecipeID:   parentRecipeID,
 			CreatedBy:        actorID,
 			UpdatedBy:        actorID,
 		})
@@ -216,17 +312,22 @@ func createRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID
 			if quantityErr != nil {
 				return recipeValidationField("ingredients.quantity", "invalid quantity")
 			}
-			itemID, itemErr := resolveIngredientItemID(ctx, q, actorID, ing, i)
-			if itemErr != nil {
-				return itemErr
+			itemID, subRecipeID, match, refErr := resolveIngredientReference(ctx, q, actorID, recipeID, ing, i)
+			if refErr != nil {
+				return refErr
+			}
+			if match != nil {
+				matches = append(matches, *match)
 			}
 			if createIngredientErr := q.CreateRecipeIngredient(ctx, sqlc.CreateRecipeIngredientParams{
 				RecipeID:     recipeID,
 				Position:     position32,
+				GroupName:    textPtrToPG(ing.GroupName),
 				Quantity:     quantity,
 				QuantityText: textPtrToPG(ing.QuantityText),
 				Unit:         textPtrToPG(ing.Unit),
 				ItemID:       itemID,
+				SubRecipeID:  subRecipeID,
 				Prep:         textPtrToPG(ing.Prep),
 				Notes:        textPtrToPG(ing.Notes),
 				OriginalText: textPtrToPG(ing.OriginalText),
@@ -243,11 +344,16 @@ func createRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID
 				return recipeValidationField("steps.step_number", "step_number is too large")
 			}
 			if createStepErr := q.CreateRecipeStep(ctx, sqlc.CreateRecipeStepParams{
-				RecipeID:    recipeID,
-				StepNumber:  stepNumber32,
-				Instruction: strings.TrimSpace(step.Instruction),
-				CreatedBy:   actorID,
-				UpdatedBy:   actorID,
+				RecipeID:           recipeID,
+				StepNumber:         stepNumber32,
+				GroupName:          textPtrToPG(step.GroupName),
+				Instruction:        strings.TrimSpace(step.Instruction),
+				DurationSeconds:    int32PtrToPG(step.DurationSeconds),
+				DurationMaxSeconds: int32PtrToPG(step.DurationMaxSeconds),
+				Temperature:        int32PtrToPG(step.Temperature),
+				TemperatureUnit:    temperatureUnitToPG(step.TemperatureUnit),
+				CreatedBy:          actorID,
+				UpdatedBy:          actorID,
 			}); createStepErr != nil {
 				return createStepErr
 			}
@@ -264,38 +370,46 @@ func createRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID
 			}
 		}
 
-		return nil
+		if err := q.RefreshRecipeSearchDocument(ctx, recipeID); err != nil {
+			return err
+		}
+		return q.CreateRecipeRevision(ctx, sqlc.CreateRecipeRevisionParams{
+			RecipeID:  recipeID,
+			CreatedBy: actorID,
+		})
 	})
 	if err != nil {
-		return pgtype.UUID{}, err
+		return pgtype.UUID{}, nil, err
 	}
 
-	return recipeID, nil
+	return recipeID, matches, nil
 }
 
-// updateRecipeUsecase performs the update-recipe transactional workflow.
-func updateRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID pgtype.UUID, recipeID pgtype.UUID, req createRecipeRequest) error {
+// updateRecipeUsecase performs the update-recipe transactional workflow. It
+// returns the ingredient names matched fuzzily.
+func updateRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID pgtype.UUID, recipeID pgtype.UUID, req createRecipeRequest) ([]ingredientItemMatch, error) {
 	recipeBookID, err := uuidPtrToPG(req.RecipeBookID)
 	if err != nil {
-		return recipeValidationField("recipe_book_id", "invalid id")
+		return nil, recipeValidationField("recipe_book_id", "invalid id")
 	}
 
 	tagUUIDs, err := uuidsToPG(req.TagIDs)
 	if err != nil {
-		return recipeValidationField("tag_ids", "invalid id")
+		return nil, recipeValidationField("tag_ids", "invalid id")
 	}
 
 	if len(tagUUIDs) > 0 {
 		count, countErr := workflows.CountTagsByIDs(ctx, tagUUIDs)
 		if countErr != nil {
-			return countErr
+			return nil, countErr
 		}
 		if int(count) != len(tagUUIDs) {
-			return recipeValidationField("tag_ids", "one or more tags do not exist")
+			return nil, recipeValidationField("tag_ids", "one or more tags do not exist")
 		}
 	}
 
-	return workflows.WithinTx(ctx, func(q recipeWorkflowQueries) error {
+	var matches []ingredientItemMatch
+	err = workflows.WithinTx(ctx, func(q recipeWorkflowQueries) error {
 		deletedAt, err := q.GetRecipeDeletedAtByID(ctx, recipeID)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
@@ -357,17 +471,22 @@ func updateRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID
 			if quantityErr != nil {
 				return recipeValidationField("ingredients.quantity", "invalid quantity")
 			}
-			itemID, itemErr := resolveIngredientItemID(ctx, q, actorID, ing, i)
-			if itemErr != nil {
-				return itemErr
+			itemID, subRecipeID, match, refErr := resolveIngredientReference(ctx, q, actorID, recipeID, ing, i)
+			if refErr != nil {
+				return refErr
+			}
+			if match != nil {
+				matches = append(matches, *match)
 			}
 			if createIngredientErr := q.CreateRecipeIngredient(ctx, sqlc.CreateRecipeIngredientParams{
 				RecipeID:     recipeID,
 				Position:     position32,
+				GroupName:    textPtrToPG(ing.GroupName),
 				Quantity:     quantity,
 				QuantityText: textPtrToPG(ing.QuantityText),
 				Unit:         textPtrToPG(ing.Unit),
 				ItemID:       itemID,
+				SubRecipeID:  subRecipeID,
 				Prep:         textPtrToPG(ing.Prep),
 				Notes:        textPtrToPG(ing.Notes),
 				OriginalText: textPtrToPG(ing.OriginalText),
@@ -384,11 +503,16 @@ func updateRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID
 				return recipeValidationField("steps.step_number", "step_number is too large")
 			}
 			if createStepErr := q.CreateRecipeStep(ctx, sqlc.CreateRecipeStepParams{
-				RecipeID:    recipeID,
-				StepNumber:  stepNumber32,
-				Instruction: strings.TrimSpace(step.Instruction),
-				CreatedBy:   actorID,
-				UpdatedBy:   actorID,
+				RecipeID:           recipeID,
+				StepNumber:         stepNumber32,
+				GroupName:          textPtrToPG(step.GroupName),
+				Instruction:        strings.TrimSpace(step.Instruction),
+				DurationSeconds:    int32PtrToPG(step.DurationSeconds),
+				DurationMaxSeconds: int32PtrToPG(step.DurationMaxSeconds),
+				Temperature:        int32PtrToPG(step.Temperature),
+				TemperatureUnit:    temperatureUnitToPG(step.TemperatureUnit),
+				CreatedBy:          actorID,
+				UpdatedBy:          actorID,
 			}); createStepErr != nil {
 				return createStepErr
 			}
@@ -405,8 +529,18 @@ func updateRecipeUsecase(ctx context.Context, workflows recipeWorkflows, actorID
 			}
 		}
 
-		return nil
+		if err := q.RefreshRecipeSearchDocument(ctx, recipeID); err != nil {
+			return err
+		}
+		return q.CreateRecipeRevision(ctx, sqlc.CreateRecipeRevisionParams{
+			RecipeID:  recipeID,
+			CreatedBy: actorID,
+		})
 	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
 }
 
 type appRecipeWorkflows struct {
@@ -40,12 +40,24 @@ type fakeRecipeWorkflowQueries struct {
 	createRecipeTag        func(ctx context.Context, arg sqlc.CreateRecipeTagParams) error
 	getItemByID            func(ctx context.Context, id pgtype.UUID) (sqlc.GetItemByIDRow, error)
 	getItemByName          func(ctx context.Context, name string) (sqlc.Item, error)
+	getItemByAlias         func(ctx context.Context, alias string) (sqlc.GetItemByAliasRow, error)
+	findSimilarItem        func(ctx context.Context, arg sqlc.FindSimilarItemParams) (sqlc.FindSimilarItemRow, error)
 	createItem             func(ctx context.Context, arg sqlc.CreateItemParams) (sqlc.Item, error)
 	getRecipeDeletedAtByID func(ctx context.Context, id pgtype.UUID) (pgtype.Timestamptz, error)
+	subRecipeTreeContains  func(ctx context.Context, arg sqlc.SubRecipeTreeContainsParams) (bool, error)
 	updateRecipeByID       func(ctx context.Context, arg sqlc.UpdateRecipeByIDParams) (sqlc.Recipe, error)
 	deleteIngredientsByID  func(ctx context.Context, recipeID pgtype.UUID) error
 	deleteStepsByID        func(ctx context.Context, recipeID pgtype.UUID) error
 	deleteTagsByID         func(ctx context.Context, recipeID pgtype.UUID) error
+	moveRecipeTags         func(ctx context.Context, arg sqlc.MoveRecipeTagsParams) (int64, error)
+	moveMealPlanEntries    func(ctx context.Context, arg sqlc.MoveMealPlanEntriesParams) (int64, error)
+	deleteMealPlanEntries  func(ctx context.Context, recipeID pgtype.UUID) error
+	listSubRecipeUsers     func(ctx context.Context, subRecipeID pgtype.UUID) ([]pgtype.UUID, error)
+	moveSubRecipeRefs      func(ctx context.Context, arg sqlc.MoveSubRecipeReferencesParams) (int64, error)
+	moveRecipeVariants     func(ctx context.Context, arg sqlc.MoveRecipeVariantsParams) (int64, error)
+	softDeleteRecipe       func(ctx context.Context, arg sqlc.SoftDeleteRecipeByIDParams) (int64, error)
+	refreshSearchDocument  func(ctx context.Context, recipeID pgtype.UUID) error
+	createRecipeRevision   func(ctx context.Context, arg sqlc.CreateRecipeRevisionParams) error
 }
 
 func (f fakeRecipeWorkflowQueries) CreateRecipe(ctx context.Context, arg sqlc.CreateRecipeParams) (sqlc.Recipe, error) {
@@ -92,6 +104,22 @@ func (f fakeRecipeWorkflowQueries) GetItemByName(ctx context.Context, name strin
 	return f.getItemByName(ctx, name)
 }
 
+// GetItemByAlias delegates to the configured fake handler.
+func (f fakeRecipeWorkflowQueries) GetItemByAlias(ctx context.Context, alias string) (sqlc.GetItemByAliasRow, error) {
+	if f.getItemByAlias == nil {
+		return sqlc.GetItemByAliasRow{}, errors.New("GetItemByAlias not implemented")
+	}
+	return f.getItemByAlias(ctx, alias)
+}
+
+// FindSimilarItem delegates to the configured fake handler.
+func (f fakeRecipeWorkflowQueries) FindSimilarItem(ctx context.Context, arg sqlc.FindSimilarItemParams) (sqlc.FindSimilarItemRow, error) {
+	if f.findSimilarItem == nil {
+		return sqlc.FindSimilarItemRow{}, errors.New("FindSimilarItem not implemented")
+	}
+	return f.findSimilarItem(ctx, arg)
+}
+
 // CreateItem delegates to the configured fake handler.
 func (f fakeRecipeWorkflowQueries) CreateItem(ctx context.Context, arg sqlc.CreateItemParams) (sqlc.Item, error) {
 	if f.createItem == nil {
@@ -107,6 +135,13 @@ func (f fakeRecipeWorkflowQueries) GetRecipeDeletedAtByID(ctx context.Context, i
 	return f.getRecipeDeletedAtByID(ctx, id)
 }
 
+func (f fakeRecipeWorkflowQueries) SubRecipeTreeContains(ctx context.Context, arg sqlc.SubRecipeTreeContainsParams) (bool, error) {
+	if f.subRecipeTreeContains == nil {
+		return false, errors.New("SubRecipeTreeContains not implemented")
+	}
+	return f.subRecipeTreeContains(ctx, arg)
+}
+
 func (f fakeRecipeWorkflowQueries) UpdateRecipeByID(ctx context.Context, arg sqlc.UpdateRecipeByIDParams) (sqlc.Recipe, error) {
 	if f.updateRecipeByID == nil {
 		return sqlc.Recipe{}, errors.New("UpdateRecipeByID not implemented")
@@ -135,6 +170,69 @@ func (f fakeRecipeWorkflowQueries) DeleteRecipeTagsByRecipeID(ctx context.Contex
 	return f.deleteTagsByID(ctx, recipeID)
 }
 
+func (f fakeRecipeWorkflowQueries) MoveRecipeTags(ctx context.Context, arg sqlc.MoveRecipeTagsParams) (int64, error) {
+	if f.moveRecipeTags == nil {
+		return 0, errors.New("MoveRecipeTags not implemented")
+	}
+	return f.moveRecipeTags(ctx, arg)
+}
+
+func (f fakeRecipeWorkflowQueries) MoveMealPlanEntries(ctx context.Context, arg sqlc.MoveMealPlanEntriesParams) (int64, error) {
+	if f.moveMealPlanEntries == nil {
+		return 0, errors.New("MoveMealPlanEntries not implemented")
+	}
+	return f.moveMealPlanEntries(ctx, arg)
+}
+
+func (f fakeRecipeWorkflowQueries) DeleteMealPlanEntriesByRecipeID(ctx context.Context, recipeID pgtype.UUID) error {
+	if f.deleteMealPlanEntries == nil {
+		return errors.New("DeleteMealPlanEntriesByRecipeID not implemented")
+	}
+	return f.deleteMealPlanEntries(ctx, recipeID)
+}
+
+func (f fakeRecipeWorkflowQueries) ListRecipeIDsBySubRecipeID(ctx context.Context, subRecipeID pgtype.UUID) ([]pgtype.UUID, error) {
+	if f.listSubRecipeUsers == nil {
+		return nil, errors.New("ListRecipeIDsBySubRecipeID not implemented")
+	}
+	return f.listSubRecipeUsers(ctx, subRecipeID)
+}
+
+func (f fakeRecipeWorkflowQueries) MoveSubRecipeReferences(ctx context.Context, arg sqlc.MoveSubRecipeReferencesParams) (int64, error) {
+	if f.moveSubRecipeRefs == nil {
+		return 0, errors.New("MoveSubRecipeReferences not implemented")
+	}
+	return f.moveSubRecipeRefs(ctx, arg)
+}
+
+func (f fakeRecipeWorkflowQueries) MoveRecipeVariants(ctx context.Context, arg sqlc.MoveRecipeVariantsParams) (int64, error) {
+	if f.moveRecipeVariants == nil {
+		return 0, errors.New("MoveRecipeVariants not implemented")
+	}
+	return f.moveRecipeVariants(ctx, arg)
+}
+
+func (f fakeRecipeWorkflowQueries) SoftDeleteRecipeByID(ctx context.Context, arg sqlc.SoftDeleteRecipeByIDParams) (int64, error) {
+	if f.softDeleteRecipe == nil {
+		return 0, errors.New("SoftDeleteRecipeByID not implemented")
+	}
+	return f.softDeleteRecipe(ctx, arg)
+}
+
+func (f fakeRecipeWorkflowQueries) RefreshRecipeSearchDocument(ctx context.Context, recipeID pgtype.UUID) error {
+	if f.refreshSearchDocument == nil {
+		return errors.New("RefreshRecipeSearchDocument not implemented")
+	}
+	return f.refreshSearchDocument(ctx, recipeID)
+}
+
+func (f fakeRecipeWorkflowQueries) CreateRecipeRevision(ctx context.Context, arg sqlc.CreateRecipeRevisionParams) error {
+	if f.createRecipeRevision == nil {
+		return errors.New("CreateRecipeRevision not implemented")
+	}
+	return f.createRecipeRevision(ctx, arg)
+}
+
 const recipeBookIDField = "recipe_book_id"
 
 func validCreateRecipeRequest() createRecipeRequest {
@@ -175,7 +273,7 @@ func TestCreateRecipeUsecase_ValidationErrors(t *testing.T) {
 			},
 		}
 
-		_, err := createRecipeUsecase(context.Background(), workflows, actorID, req)
+		_, _, err := createRecipeUsecase(context.Background(), workflows, actorID, req)
 		var v *recipeValidationError
 		if !errors.As(err, &v) {
 			t.Fatalf("expected *recipeValidationError, got %T (%v)", err, err)
@@ -202,7 +300,7 @@ func TestCreateRecipeUsecase_ValidationErrors(t *testing.T) {
 			},
 		}
 
-		_, err := createRecipeUsecase(context.Background(), workflows, actorID, req)
+		_, _, err := createRecipeUsecase(context.Background(), workflows, actorID, req)
 		var v *recipeValidationError
 		if !errors.As(err, &v) {
 			t.Fatalf("expected *recipeValidationError, got %T (%v)", err, err)
@@ -228,7 +326,7 @@ func TestCreateRecipeUsecase_ValidationErrors(t *testing.T) {
 			},
 		}
 
-		_, err := createRecipeUsecase(context.Background(), workflows, actorID, req)
+		_, _, err := createRecipeUsecase(context.Background(), workflows, actorID, req)
 		var v *recipeValidationError
 		if !errors.As(err, &v) {
 			t.Fatalf("expected *recipeValidationError, got %T (%v)", err, err)
@@ -238,6 +336,61 @@ func TestCreateRecipeUsecase_ValidationErrors(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid parent recipe id", func(t *testing.T) {
+		t.Parallel()
+
+		req := validCreateRecipeRequest()
+		invalid := "not-a-uuid"
+		req.ParentRecipeID = &invalid
+
+		workflows := fakeRecipeWorkflows{
+			withinTx: func(ctx context.Context, fn func(q recipeWorkflowQueries) error) error {
+				t.Fatalf("WithinTx should not be called")
+				return nil
+			},
+		}
+
+		_, _, err := createRecipeUsecase(context.Background(), workflows, actorID, req)
+		var v *recipeValidationError
+		if !errors.As(err, &v) {
+			t.Fatalf("expected *recipeValidationError, got %T (%v)", err, err)
+		}
+		if len(v.FieldErrors) != 1 || v.FieldErrors[0].Field != "parent_recipe_id" {
+			t.Fatalf("unexpected field errors: %#v", v.FieldErrors)
+		}
+	})
+
+	t.Run("parent recipe does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		req := validCreateRecipeRequest()
+		parentID := uuid.NewString()
+		req.ParentRecipeID = &parentID
+
+		workflows := fakeRecipeWorkflows{
+			withinTx: func(ctx context.Context, fn func(q recipeWorkflowQueries) error) error {
+				return fn(fakeRecipeWorkflowQueries{
+					getRecipeDeletedAtByID: func(ctx context.Context, id pgtype.UUID) (pgtype.Timestamptz, error) {
+						return pgtype.Timestamptz{}, pgx.ErrNoRows
+					},
+					createRecipe: func(ctx context.Context, arg sqlc.CreateRecipeParams) (sqlc.Recipe, error) {
+						t.Fatalf("CreateRecipe should not be called")
+						return sqlc.Recipe{}, nil
+					},
+				})
+			},
+		}
+
+		_, _, err := createRecipeUsecase(context.Background(), workflows, actorID, req)
+		var v *recipeValidationError
+		if !errors.As(err, &v) {
+			t.Fatalf("expected *recipeValidationError, got %T (%v)", err, err)
+		}
+		if len(v.FieldErrors) != 1 || v.FieldErrors[0].Field != "parent_recipe_id" {
+			t.Fatalf("unexpected field errors: %#v", v.FieldErrors)
+		}
+	})
+
 	t.Run("recipe book fk missing maps to validation", func(t *testing.T) {
 		t.Parallel()
 
@@ -258,7 +411,7 @@ func TestCreateRecipeUsecase_ValidationErrors(t *testing.T) {
 			},
 		}
 
-		_, err := createRecipeUsecase(context.Background(), workflows, actorID, req)
+		_, _, err := createRecipeUsecase(context.Background(), workflows, actorID, req)
 		var v *recipeValidationError
 		if !errors.As(err, &v) {
 			t.Fatalf("expected *recipeValidationError, got %T (%v)", err, err)
@@ -269,6 +422,57 @@ func TestCreateRecipeUsecase_ValidationErrors(t *testing.T) {
 	})
 }
 
+func TestCreateRecipeUsecase_RefreshesSearchDocumentAndRecordsRevision(t *testing.T) {
+	t.Parallel()
+
+	actorID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	recipeID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	itemID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	var refreshed pgtype.UUID
+	var revised sqlc.CreateRecipeRevisionParams
+	workflows := fakeRecipeWorkflows{
+		withinTx: func(ctx context.Context, fn func(q recipeWorkflowQueries) error) error {
+			return fn(fakeRecipeWorkflowQueries{
+				createRecipe: func(ctx context.Context, arg sqlc.CreateRecipeParams) (sqlc.Recipe, error) {
+					return sqlc.Recipe{ID: recipeID}, nil
+				},
+				getItemByName: func(ctx context.Context, name string) (sqlc.Item, error) {
+					return sqlc.Item{ID: itemID, Name: name}, nil
+				},
+				createRecipeIngredient: func(ctx context.Context, arg sqlc.CreateRecipeIngredientParams) error {
+					return nil
+				},
+				createRecipeStep: func(ctx context.Context, arg sqlc.CreateRecipeStepParams) error {
+					return nil
+				},
+				refreshSearchDocument: func(ctx context.Context, id pgtype.UUID) error {
+					refreshed = id
+					return nil
+				},
+				createRecipeRevision: func(ctx context.Context, arg sqlc.CreateRecipeRevisionParams) error {
+					revised = arg
+					return nil
+				},
+			})
+		},
+	}
+
+	got, _, err := createRecipeUsecase(context.Background(), workflows, actorID, validCreateRecipeRequest())
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got != recipeID {
+		t.Fatalf("recipe id=%v, want %v", got, recipeID)
+	}
+	if refreshed != recipeID {
+		t.Fatalf("refreshed=%v, want %v", refreshed, recipeID)
+	}
+	if revised.RecipeID != recipeID || revised.CreatedBy != actorID {
+		t.Fatalf("revision=%+v, want recipe %v by %v", revised, recipeID, actorID)
+	}
+}
+
 func TestUpdateRecipeUsecase_StateErrors(t *testing.T) {
 	t.Parallel()
 
@@ -291,7 +495,7 @@ func TestUpdateRecipeUsecase_StateErrors(t *testing.T) {
 			},
 		}
 
-		err := updateRecipeUsecase(context.Background(), workflows, actorID, recipeID, validCreateRecipeRequest())
+		_, err := updateRecipeUsecase(context.Background(), workflows, actorID, recipeID, validCreateRecipeRequest())
 		var nf *recipeNotFoundError
 		if !errors.As(err, &nf) {
 			t.Fatalf("expected *recipeNotFoundError, got %T (%v)", err, err)
@@ -314,7 +518,7 @@ func TestUpdateRecipeUsecase_StateErrors(t *testing.T) {
 			},
 		}
 
-		err := updateRecipeUsecase(context.Background(), workflows, actorID, recipeID, validCreateRecipeRequest())
+		_, err := updateRecipeUsecase(context.Background(), workflows, actorID, recipeID, validCreateRecipeRequest())
 		var cf *recipeConflictError
 		if !errors.As(err, &cf) {
 			t.Fatalf("expected *recipeConflictError, got %T (%v)", err, err)
@@ -344,7 +548,7 @@ func TestUpdateRecipeUsecase_StateErrors(t *testing.T) {
 			},
 		}
 
-		err := updateRecipeUsecase(context.Background(), workflows, actorID, recipeID, req)
+		_, err := updateRecipeUsecase(context.Background(), workflows, actorID, recipeID, req)
 		var v *recipeValidationError
 		if !errors.As(err, &v) {
 			t.Fatalf("expected *recipeValidationError, got %T (%v)", err, err)
@@ -355,6 +559,266 @@ func TestUpdateRecipeUsecase_StateErrors(t *testing.T) {
 	})
 }
 
+func TestUpdateRecipeUsecase_SubRecipeReferences(t *testing.T) {
+	t.Parallel()
+
+	actorID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	recipeID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	subRecipeID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	run := func(t *testing.T, ref pgtype.UUID, cycle bool) ([]sqlc.CreateRecipeIngredientParams, error) {
+		t.Helper()
+
+		req := validCreateRecipeRequest()
+		req.Ingredients = []recipeIngredientRequest{
+			{Position: 1, Quantity: floatPtr(2), SubRecipeID: stringPtr(uuid.UUID(ref.Bytes).String())},
+		}
+
+		var created []sqlc.CreateRecipeIngredientParams
+		workflows := fakeRecipeWorkflows{
+			withinTx: func(ctx context.Context, fn func(q recipeWorkflowQueries) error) error {
+				return fn(fakeRecipeWorkflowQueries{
+					getRecipeDeletedAtByID: func(ctx context.Context, id pgtype.UUID) (pgtype.Timestamptz, error) {
+						return pgtype.Timestamptz{}, nil
+					},
+					subRecipeTreeContains: func(ctx context.Context, arg sqlc.SubRecipeTreeContainsParams) (bool, error) {
+						if arg.SubRecipeID != ref || arg.RecipeID != recipeID {
+							t.Fatalf("cycle check=%+v, want %v in %v", arg, recipeID, ref)
+						}
+						return cycle, nil
+					},
+					updateRecipeByID: func(ctx context.Context, arg sqlc.UpdateRecipeByIDParams) (sqlc.Recipe, error) {
+						return sqlc.Recipe{ID: recipeID}, nil
+					},
+					deleteIngredientsByID: func(ctx context.Context, id pgtype.UUID) error { return nil },
+					deleteStepsByID:       func(ctx context.Context, id pgtype.UUID) error { return nil },
+					deleteTagsByID:        func(ctx context.Context, id pgtype.UUID) error { return nil },
+					createRecipeIngredient: func(ctx context.Context, arg sqlc.CreateRecipeIngredientParams) error {
+						created = append(created, arg)
+						return nil
+					},
+					createRecipeStep:      func(ctx context.Context, arg sqlc.CreateRecipeStepParams) error { return nil },
+					refreshSearchDocument: func(ctx context.Context, id pgtype.UUID) error { return nil },
+					createRecipeRevision:  func(ctx context.Context, arg sqlc.CreateRecipeRevisionParams) error { return nil },
+				})
+			},
+		}
+		_, err := updateRecipeUsecase(context.Background(), workflows, actorID, recipeID, req)
+		return created, err
+	}
+
+	t.Run("stores the sub-recipe reference", func(t *testing.T) {
+		t.Parallel()
+
+		created, err := run(t, subRecipeID, false)
+		if err != nil {
+			t.Fatalf("update: %v", err)
+		}
+		if len(created) != 1 || created[0].SubRecipeID != subRecipeID || created[0].ItemID.Valid {
+			t.Fatalf("ingredients=%+v, want one sub-recipe line", created)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		ref   pgtype.UUID
+		cycle bool
+	}{
+		"rejects self reference": {ref: recipeID},
+		"rejects cycles":         {ref: subRecipeID, cycle: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := run(t, tc.ref, tc.cycle)
+			var v *recipeValidationError
+			if !errors.As(err, &v) {
+				t.Fatalf("expected *recipeValidationError, got %T (%v)", err, err)
+			}
+			if len(v.FieldErrors) != 1 || v.FieldErrors[0].Field != "ingredients[0].sub_recipe_id" {
+				t.Fatalf("unexpected field errors: %#v", v.FieldErrors)
+			}
+		})
+	}
+}
+
+func TestMergeRecipesUsecase(t *testing.T) {
+	t.Parallel()
+
+	actorID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	targetID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	duplicateID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	t.Run("rejects merging a recipe into itself", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := mergeRecipesUsecase(context.Background(), fakeRecipeWorkflows{}, actorID, targetID, targetID)
+		var v *recipeValidationError
+		if !errors.As(err, &v) || v.FieldErrors[0].Field != "duplicate_id" {
+			t.Fatalf("expected duplicate_id validation error, got %T (%v)", err, err)
+		}
+	})
+
+	t.Run("deleted duplicate returns validation", func(t *testing.T) {
+		t.Parallel()
+
+		workflows := fakeRecipeWorkflows{
+			withinTx: func(ctx context.Context, fn func(q recipeWorkflowQueries) error) error {
+				return fn(fakeRecipeWorkflowQueries{
+					getRecipeDeletedAtByID: func(ctx context.Context, id pgtype.UUID) (pgtype.Timestamptz, error) {
+						if id == duplicateID {
+							return pgtype.Timestamptz{Time: time.Now(), Valid: true}, nil
+						}
+						return pgtype.Timestamptz{}, nil
+					},
+				})
+			},
+		}
+
+		_, err := mergeRecipesUsecase(context.Background(), workflows, actorID, targetID, duplicateID)
+		var v *recipeValidationError
+		if !errors.As(err, &v) || v.FieldErrors[0].Message != "recipe is deleted" {
+			t.Fatalf("expected deleted duplicate validation error, got %T (%v)", err, err)
+		}
+	})
+
+	t.Run("moves tags, entries and references then soft-deletes the duplicate", func(t *testing.T) {
+		t.Parallel()
+
+		parentID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		var softDeleted pgtype.UUID
+		var revised, refreshed []pgtype.UUID
+		var repointed, reparented bool
+		workflows := fakeRecipeWorkflows{
+			withinTx: func(ctx context.Context, fn func(q recipeWorkflowQueries) error) error {
+				return fn(fakeRecipeWorkflowQueries{
+					getRecipeDeletedAtByID: func(ctx context.Context, id pgtype.UUID) (pgtype.Timestamptz, error) {
+						return pgtype.Timestamptz{}, nil
+					},
+					moveRecipeTags: func(ctx context.Context, arg sqlc.MoveRecipeTagsParams) (int64, error) {
+						if arg.TargetID != targetID || arg.SourceID != duplicateID {
+							t.Fatalf("move tags=%+v, want %v <- %v", arg, targetID, duplicateID)
+						}
+						return 2, nil
+					},
+					deleteTagsByID: func(ctx context.Context, recipeID pgtype.UUID) error {
+						return nil
+					},
+					moveMealPlanEntries: func(ctx context.Context, arg sqlc.MoveMealPlanEntriesParams) (int64, error) {
+						return 3, nil
+					},
+					deleteMealPlanEntries: func(ctx context.Context, recipeID pgtype.UUID) error {
+						return nil
+					},
+					listSubRecipeUsers: func(ctx context.Context, subRecipeID pgtype.UUID) ([]pgtype.UUID, error) {
+						return []pgtype.UUID{parentID}, nil
+					},
+					subRecipeTreeContains: func(ctx context.Context, arg sqlc.SubRecipeTreeContainsParams) (bool, error) {
+						if arg.SubRecipeID != targetID || arg.RecipeID != parentID {
+							t.Fatalf("tree check=%+v, want %v in %v", arg, parentID, targetID)
+						}
+						return false, nil
+					},
+					moveSubRecipeRefs: func(ctx context.Context, arg sqlc.MoveSubRecipeReferencesParams) (int64, error) {
+						if softDeleted.Valid {
+							t.Fatal("sub-recipe lines moved after the duplicate was deleted")
+						}
+						repointed = arg.TargetID == targetID && arg.SourceID == duplicateID
+						return 1, nil
+					},
+					moveRecipeVariants: func(ctx context.Context, arg sqlc.MoveRecipeVariantsParams) (int64, error) {
+						reparented = arg.TargetID == targetID && arg.SourceID == duplicateID
+						return 4, nil
+					},
+					softDeleteRecipe: func(ctx context.Context, arg sqlc.SoftDeleteRecipeByIDParams) (int64, error) {
+						softDeleted = arg.ID
+						return 1, nil
+					},
+					refreshSearchDocument: func(ctx context.Context, recipeID pgtype.UUID) error {
+						refreshed = append(refreshed, recipeID)
+						return nil
+					},
+					createRecipeRevision: func(ctx context.Context, arg sqlc.CreateRecipeRevisionParams) error {
+						revised = append(revised, arg.RecipeID)
+						return nil
+					},
+				})
+			},
+		}
+
+		result, err := mergeRecipesUsecase(context.Background(), workflows, actorID, targetID, duplicateID)
+		if err != nil {
+			t.Fatalf("merge: %v", err)
+		}
+		want := recipeMergeResult{TagsMoved: 2, MealPlanEntriesMoved: 3, SubRecipeLinesMoved: 1, VariantsMoved: 4}
+		if result != want {
+			t.Fatalf("result=%+v, want %+v", result, want)
+		}
+		if softDeleted != duplicateID {
+			t.Fatalf("soft deleted %v, want %v", softDeleted, duplicateID)
+		}
+		if !repointed || !reparented {
+			t.Fatalf("repointed=%v reparented=%v, want both onto the target", repointed, reparented)
+		}
+		if len(revised) != 2 || revised[0] != parentID || revised[1] != targetID {
+			t.Fatalf("revisions=%v, want %v and %v", revised, parentID, targetID)
+		}
+		if len(refreshed) != 2 {
+			t.Fatalf("refreshed=%v, want 2 recipes", refreshed)
+		}
+	})
+
+	t.Run("rejects repointing that would create a cycle", func(t *testing.T) {
+		t.Parallel()
+
+		parentID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		for name, tc := range map[string]struct {
+			users []pgtype.UUID
+			cycle bool
+		}{
+			"target uses the duplicate":    {users: []pgtype.UUID{targetID}},
+			"target includes a user of it": {users: []pgtype.UUID{parentID}, cycle: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				workflows := fakeRecipeWorkflows{
+					withinTx: func(ctx context.Context, fn func(q recipeWorkflowQueries) error) error {
+						return fn(fakeRecipeWorkflowQueries{
+							getRecipeDeletedAtByID: func(ctx context.Context, id pgtype.UUID) (pgtype.Timestamptz, error) {
+								return pgtype.Timestamptz{}, nil
+							},
+							moveRecipeTags: func(ctx context.Context, arg sqlc.MoveRecipeTagsParams) (int64, error) {
+								return 0, nil
+							},
+							deleteTagsByID: func(ctx context.Context, recipeID pgtype.UUID) error {
+								return nil
+							},
+							moveMealPlanEntries: func(ctx context.Context, arg sqlc.MoveMealPlanEntriesParams) (int64, error) {
+								return 0, nil
+							},
+							deleteMealPlanEntries: func(ctx context.Context, recipeID pgtype.UUID) error {
+								return nil
+							},
+							listSubRecipeUsers: func(ctx context.Context, subRecipeID pgtype.UUID) ([]pgtype.UUID, error) {
+								return tc.users, nil
+							},
+							subRecipeTreeContains: func(ctx context.Context, arg sqlc.SubRecipeTreeContainsParams) (bool, error) {
+								return tc.cycle, nil
+							},
+						})
+					},
+				}
+
+				_, err := mergeRecipesUsecase(context.Background(), workflows, actorID, targetID, duplicateID)
+				var c *recipeConflictError
+				if !errors.As(err, &c) {
+					t.Fatalf("expected *recipeConflictError, got %T (%v)", err, err)
+				}
+			})
+		}
+	})
+}
+
 func TestMapRecipeUsecaseError(t *testing.T) {
 	t.Parallel()
 
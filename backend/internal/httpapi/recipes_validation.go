@@ -11,21 +11,39 @@ import (
 
 const maxInt32 = int(^uint32(0) >> 1)
 
+// maxRecipeGroupNameLength caps ingredient and step group names.
+const maxRecipeGroupNameLength = 100
+
+const (
+	// maxStepDurationSeconds allows multi-day steps such as curing or
+	// fermenting.
+	maxStepDurationSeconds = 7 * 24 * 60 * 60
+	minStepTemperature     = -100
+	maxStepTemperature     = 1000
+)
+
 type recipeIngredientRequest struct {
 	Position     int      `json:"position"`
+	GroupName    *string  `json:"group_name"`
 	Quantity     *float64 `json:"quantity"`
 	QuantityText *string  `json:"quantity_text"`
 	Unit         *string  `json:"unit"`
 	ItemID       *string  `json:"item_id"`
 	ItemName     *string  `json:"item_name"`
+	SubRecipeID  *string  `json:"sub_recipe_id"`
 	Prep         *string  `json:"prep"`
 	Notes        *string  `json:"notes"`
 	OriginalText *string  `json:"original_text"`
 }
 
 type recipeStepRequest struct {
-	StepNumber  int    `json:"step_number"`
-	Instruction string `json:"instruction"`
+	StepNumber         int     `json:"step_number"`
+	GroupName          *string `json:"group_name"`
+	Instruction        string  `json:"instruction"`
+	DurationSeconds    *int32  `json:"duration_seconds"`
+	DurationMaxSeconds *int32  `json:"duration_max_seconds"`
+	Temperature        *int32  `json:"temperature"`
+	TemperatureUnit    *string `json:"temperature_unit"`
 }
 
 type createRecipeRequest struct {
@@ -39,6 +57,9 @@ type createRecipeRequest struct {
 	TagIDs           []string                  `json:"tag_ids"`
 	Ingredients      []recipeIngredientRequest `json:"ingredients"`
 	Steps            []recipeStepRequest       `json:"steps"`
+	// ParentRecipeID records the recipe this one was copied from. It is only
+	// read on create; updates and reverts keep the existing lineage.
+	ParentRecipeID *string `json:"parent_recipe_id,omitempty"`
 }
 
 func validateCreateRecipeRequest(req createRecipeRequest) []response.FieldError {
@@ -86,6 +107,12 @@ func validateCreateRecipeRequest(req createRecipeRequest) []response.FieldError
 			}
 			positionsSeen[ing.Position] = struct{}{}
 		}
+		if msg := validateRecipeGroupName(ing.GroupName); msg != "" {
+			errs = append(errs, response.FieldError{
+				Field:   fmt.Sprintf("ingredients[%d].group_name", i),
+				Message: msg,
+			})
+		}
 
 		itemID := ""
 		if ing.ItemID != nil {
@@ -96,12 +123,31 @@ func validateCreateRecipeRequest(req createRecipeRequest) []response.FieldError
 			itemName = strings.TrimSpace(*ing.ItemName)
 		}
 
-		if itemID == "" && itemName == "" {
+		subRecipeID := ""
+		if ing.SubRecipeID != nil {
+			subRecipeID = strings.TrimSpace(*ing.SubRecipeID)
+		}
+
+		if itemID == "" && itemName == "" && subRecipeID == "" {
 			errs = append(errs, response.FieldError{
 				Field:   fmt.Sprintf("ingredients[%d].item_id", i),
-				Message: "item_id or item_name is required",
+				Message: "item_id, item_name, or sub_recipe_id is required",
 			})
 		}
+		if subRecipeID != "" {
+			if itemID != "" || itemName != "" {
+				errs = append(errs, response.FieldError{
+					Field:   fmt.Sprintf("ingredients[%d].sub_recipe_id", i),
+					Message: "sub_recipe_id cannot be combined with an item",
+				})
+			}
+			if _, err := uuid.Parse(subRecipeID); err != nil {
+				errs = append(errs, response.FieldError{
+					Field:   fmt.Sprintf("ingredients[%d].sub_recipe_id", i),
+					Message: "sub_recipe_id is invalid",
+				})
+			}
+		}
 		if itemID != "" {
 			if _, err := uuid.Parse(itemID); err != nil {
 				errs = append(errs, response.FieldError{
@@ -111,6 +157,13 @@ func validateCreateRecipeRequest(req createRecipeRequest) []response.FieldError
 			}
 		}
 	}
+	ingredientOrder := make([]recipeGroupedLine, 0, len(req.Ingredients))
+	for _, ing := range req.Ingredients {
+		ingredientOrder = append(ingredientOrder, recipeGroupedLine{order: ing.Position, groupName: ing.GroupName})
+	}
+	if !recipeGroupsContiguous(ingredientOrder) {
+		errs = append(errs, response.FieldError{Field: "ingredients", Message: "ingredients in a group must be consecutive"})
+	}
 
 	if len(req.Steps) == 0 {
 		errs = append(errs, response.FieldError{Field: "steps", Message: "at least one step is required"})
@@ -129,12 +182,19 @@ func validateCreateRecipeRequest(req createRecipeRequest) []response.FieldError
 					Message: "step_number is too large",
 				})
 			}
+			if msg := validateRecipeGroupName(s.GroupName); msg != "" {
+				errs = append(errs, response.FieldError{
+					Field:   fmt.Sprintf("steps[%d].group_name", i),
+					Message: msg,
+				})
+			}
 			if strings.TrimSpace(s.Instruction) == "" {
 				errs = append(errs, response.FieldError{
 					Field:   fmt.Sprintf("steps[%d].instruction", i),
 					Message: "instruction is required",
 				})
 			}
+			errs = append(errs, validateRecipeStepCues(s, i)...)
 			if _, ok := stepSeen[s.StepNumber]; ok {
 				errs = append(errs, response.FieldError{Field: "steps", Message: "step numbers must be unique"})
 			}
@@ -149,6 +209,13 @@ func validateCreateRecipeRequest(req createRecipeRequest) []response.FieldError
 				break
 			}
 		}
+		stepOrder := make([]recipeGroupedLine, 0, len(req.Steps))
+		for _, s := range req.Steps {
+			stepOrder = append(stepOrder, recipeGroupedLine{order: s.StepNumber, groupName: s.GroupName})
+		}
+		if !recipeGroupsContiguous(stepOrder) {
+			errs = append(errs, response.FieldError{Field: "steps", Message: "steps in a group must be consecutive"})
+		}
 	}
 
 	tagSeen := map[string]struct{}{}
@@ -170,3 +237,106 @@ func validateCreateRecipeRequest(req createRecipeRequest) []response.FieldError
 
 	return errs
 }
+
+// validateRecipeGroupName returns a validation message for an ingredient or
+// step group name, or "" when the name is acceptable. A blank name means the
+// line is ungrouped.
+func validateRecipeGroupName(name *string) string {
+	if name == nil {
+		return ""
+	}
+	if len([]rune(strings.TrimSpace(*name))) > maxRecipeGroupNameLength {
+		return fmt.Sprintf("group_name must be at most %d characters", maxRecipeGroupNameLength)
+	}
+	return ""
+}
+
+// validateRecipeStepCues checks a step's optional duration and temperature.
+func validateRecipeStepCues(s recipeStepRequest, index int) []response.FieldError {
+	var errs []response.FieldError
+	field := func(name string) string { return fmt.Sprintf("steps[%d].%s", index, name) }
+
+	if s.DurationSeconds != nil && (*s.DurationSeconds < 1 || *s.DurationSeconds > maxStepDurationSeconds) {
+		errs = append(errs, response.FieldError{
+			Field:   field("duration_seconds"),
+			Message: fmt.Sprintf("duration_seconds must be between 1 and %d", maxStepDurationSeconds),
+		})
+	}
+	if s.DurationMaxSeconds != nil {
+		switch {
+		case s.DurationSeconds == nil:
+			errs = append(errs, response.FieldError{
+				Field:   field("duration_max_seconds"),
+				Message: "duration_max_seconds requires duration_seconds",
+			})
+		case *s.DurationMaxSeconds < *s.DurationSeconds || *s.DurationMaxSeconds > maxStepDurationSeconds:
+			errs = append(errs, response.FieldError{
+				Field:   field("duration_max_seconds"),
+				Message: fmt.Sprintf("duration_max_seconds must be between duration_seconds and %d", maxStepDurationSeconds),
+			})
+		}
+	}
+
+	unit := ""
+	if s.TemperatureUnit != nil {
+		unit = strings.TrimSpace(*s.TemperatureUnit)
+	}
+	switch {
+	case s.Temperature == nil && unit != "":
+		errs = append(errs, response.FieldError{
+			Field:   field("temperature"),
+			Message: "temperature is required with temperature_unit",
+		})
+	case s.Temperature != nil && unit == "":
+		errs = append(errs, response.FieldError{
+			Field:   field("temperature_unit"),
+			Message: "temperature_unit is required with temperature",
+		})
+	case s.Temperature != nil && (*s.Temperature < minStepTemperature || *s.Temperature > maxStepTemperature):
+		errs = append(errs, response.FieldError{
+			Field:   field("temperature"),
+			Message: fmt.Sprintf("temperature must be between %d and %d", minStepTemperature, maxStepTemperature),
+		})
+	}
+	if unit != "" && !strings.EqualFold(unit, "F") && !strings.EqualFold(unit, "C") {
+		errs = append(errs, response.FieldError{
+			Field:   field("temperature_unit"),
+			Message: "temperature_unit must be F or C",
+		})
+	}
+	return errs
+}
+
+// recipeGroupedLine is an ingredient position or step number with its group.
+type recipeGroupedLine struct {
+	order     int
+	groupName *string
+}
+
+// recipeGroupsContiguous reports whether each named group occupies one
+// unbroken run of lines once they are sorted by order. Ungrouped lines may
+// appear anywhere.
+func recipeGroupsContiguous(lines []recipeGroupedLine) bool {
+	sorted := append([]recipeGroupedLine(nil), lines...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].order < sorted[j].order })
+
+	closed := map[string]struct{}{}
+	current := ""
+	for _, line := range sorted {
+		name := ""
+		if line.groupName != nil {
+			name = strings.TrimSpace(*line.groupName)
+		}
+		if name == current {
+			continue
+		}
+		if current != "" {
+			closed[current] = struct{}{}
+		}
+		if _, ok := closed[name]; ok && name != "" {
+			return false
+		}
+		current = name
+	}
+	return true
+}
@@ -1,8 +1,10 @@
 package httpapi
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
 )
 
@@ -159,6 +161,101 @@ func TestValidateCreateRecipeRequest(t *testing.T) {
 		}
 	})
 
+	t.Run("rejects sub_recipe_id combined with an item", func(t *testing.T) {
+		req := createRecipeRequest{
+			Title:            "x",
+			Servings:         1,
+			PrepTimeMinutes:  0,
+			TotalTimeMinutes: 0,
+			Ingredients: []recipeIngredientRequest{
+				{Position: 1, ItemName: stringPtr("dough"), SubRecipeID: stringPtr(uuid.NewString())},
+				{Position: 2, SubRecipeID: stringPtr("not-a-uuid")},
+			},
+			Steps: []recipeStepRequest{
+				{StepNumber: 1, Instruction: "ok"},
+			},
+		}
+		errs := validateCreateRecipeRequest(req)
+		if !hasFieldError(errs, "ingredients[0].sub_recipe_id") || !hasFieldError(errs, "ingredients[1].sub_recipe_id") {
+			t.Fatalf("errs=%v, want sub_recipe_id errors", errs)
+		}
+		if hasFieldError(errs, "ingredients[1].item_id") {
+			t.Fatalf("errs=%v, sub-recipe line should not require an item", errs)
+		}
+	})
+
+	t.Run("accepts consecutive groups", func(t *testing.T) {
+		req := createRecipeRequest{
+			Title:            "x",
+			Servings:         1,
+			PrepTimeMinutes:  0,
+			TotalTimeMinutes: 0,
+			Ingredients: []recipeIngredientRequest{
+				{Position: 3, GroupName: stringPtr("Sauce"), ItemName: stringPtr("tomato")},
+				{Position: 1, GroupName: stringPtr("Dough"), ItemName: stringPtr("flour")},
+				{Position: 2, GroupName: stringPtr("Dough"), ItemName: stringPtr("water")},
+				{Position: 4, ItemName: stringPtr("basil")},
+			},
+			Steps: []recipeStepRequest{
+				{StepNumber: 1, GroupName: stringPtr("Dough"), Instruction: "knead"},
+				{StepNumber: 2, GroupName: stringPtr(" "), Instruction: "bake"},
+			},
+		}
+		if errs := validateCreateRecipeRequest(req); len(errs) != 0 {
+			t.Fatalf("errs=%v, want none", errs)
+		}
+	})
+
+	t.Run("rejects split or long groups", func(t *testing.T) {
+		req := createRecipeRequest{
+			Title:            "x",
+			Servings:         1,
+			PrepTimeMinutes:  0,
+			TotalTimeMinutes: 0,
+			Ingredients: []recipeIngredientRequest{
+				{Position: 1, GroupName: stringPtr("Dough"), ItemName: stringPtr("flour")},
+				{Position: 2, GroupName: stringPtr("Sauce"), ItemName: stringPtr("tomato")},
+				{Position: 3, GroupName: stringPtr("Dough"), ItemName: stringPtr("water")},
+			},
+			Steps: []recipeStepRequest{
+				{StepNumber: 1, GroupName: stringPtr(strings.Repeat("x", maxRecipeGroupNameLength+1)), Instruction: "knead"},
+			},
+		}
+		errs := validateCreateRecipeRequest(req)
+		if !hasFieldError(errs, "ingredients") || !hasFieldError(errs, "steps[0].group_name") {
+			t.Fatalf("errs=%v, want group errors", errs)
+		}
+	})
+
+	t.Run("validates step timings and temperatures", func(t *testing.T) {
+		req := createRecipeRequest{
+			Title:            "x",
+			Servings:         1,
+			PrepTimeMinutes:  0,
+			TotalTimeMinutes: 0,
+			Steps: []recipeStepRequest{
+				{StepNumber: 1, Instruction: "bake", DurationSeconds: int32Ptr(1500), DurationMaxSeconds: int32Ptr(1800), Temperature: int32Ptr(200), TemperatureUnit: stringPtr("c")},
+				{StepNumber: 2, Instruction: "rest", DurationSeconds: int32Ptr(600), DurationMaxSeconds: int32Ptr(300)},
+				{StepNumber: 3, Instruction: "chill", DurationMaxSeconds: int32Ptr(300), Temperature: int32Ptr(4)},
+				{StepNumber: 4, Instruction: "fry", Temperature: int32Ptr(180), TemperatureUnit: stringPtr("K")},
+			},
+		}
+		errs := validateCreateRecipeRequest(req)
+		for _, field := range []string{
+			"steps[1].duration_max_seconds",
+			"steps[2].duration_max_seconds",
+			"steps[2].temperature_unit",
+			"steps[3].temperature_unit",
+		} {
+			if !hasFieldError(errs, field) {
+				t.Fatalf("errs=%v, want %s error", errs, field)
+			}
+		}
+		if hasFieldError(errs, "steps[0].duration_max_seconds") || hasFieldError(errs, "steps[0].temperature_unit") {
+			t.Fatalf("errs=%v, step 0 should be valid", errs)
+		}
+	})
+
 	t.Run("requires unique tag ids", func(t *testing.T) {
 		req := createRecipeRequest{
 			Title:            "x",
@@ -190,3 +287,7 @@ func hasFieldError(errs []response.FieldError, field string) bool {
 func stringPtr(value string) *string {
 	return &value
 }
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
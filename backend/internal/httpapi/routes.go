@@ -25,6 +25,10 @@ func routes(app *App) http.Handler {
 		}
 	})
 
+	// Share links are public: the token in the path is the credential, so
+	// these routes deliberately sit outside authMiddleware.
+	r.Get("/shared/recipes/{token}", app.handle(app.handleSharedRecipePage))
+
 	r.Route("/api/v1", func(r chi.Router) {
 		r.NotFound(func(w http.ResponseWriter, r *http.Request) {
 			app.writeError(w, r, errNotFound())
@@ -60,6 +64,8 @@ func routes(app *App) http.Handler {
 			r.Get("/", app.handle(app.handleUsersList))
 			r.Post("/", app.handle(app.handleUsersCreate))
 			r.Put("/{id}/deactivate", app.handle(app.handleUsersDeactivate))
+			r.Get("/{id}/dietary-restrictions", app.handle(app.handleUserDietaryRestrictionsGet))
+			r.Put("/{id}/dietary-restrictions", app.handle(app.handleUserDietaryRestrictionsPut))
 		})
 
 		r.Route("/tags", func(r chi.Router) {
@@ -68,6 +74,7 @@ func routes(app *App) http.Handler {
 			r.Post("/", app.handle(app.handleTagsCreate))
 			r.Put("/{id}", app.handle(app.handleTagsUpdate))
 			r.Delete("/{id}", app.handle(app.handleTagsDelete))
+			r.Post("/{id}/merge-into/{target}", app.handle(app.handleTagsMerge))
 		})
 
 		r.Route("/aisles", func(r chi.Router) {
@@ -79,6 +86,21 @@ func routes(app *App) http.Handler {
 			r.Delete("/{id}", app.handle(app.handleAislesDelete))
 		})
 
+		r.Route("/stores", func(r chi.Router) {
+			r.Use(app.authMiddleware)
+			r.Get("/", app.handle(app.handleStoresList))
+			r.Post("/", app.handle(app.handleStoresCreate))
+			r.Put("/{id}", app.handle(app.handleStoresUpdate))
+			r.Delete("/{id}", app.handle(app.handleStoresDelete))
+			r.Get("/{id}/aisles", app.handle(app.handleStoreAislesList))
+			r.Post("/{id}/aisles", app.handle(app.handleStoreAislesCreate))
+			r.Put("/{id}/aisles/{aisle_id}", app.handle(app.handleStoreAislesUpdate))
+			r.Delete("/{id}/aisles/{aisle_id}", app.handle(app.handleStoreAislesDelete))
+			r.Get("/{id}/items", app.handle(app.handleStoreItemsList))
+			r.Put("/{id}/items/{item_id}", app.handle(app.handleStoreItemsPut))
+			r.Delete("/{id}/items/{item_id}", app.handle(app.handleStoreItemsDelete))
+		})
+
 		r.Route("/items", func(r chi.Router) {
 			r.Use(app.authMiddleware)
 			r.Get("/", app.handle(app.handleItemsList))
@@ -86,6 +108,19 @@ func routes(app *App) http.Handler {
 			r.Post("/", app.handle(app.handleItemsCreate))
 			r.Put("/{id}", app.handle(app.handleItemsUpdate))
 			r.Delete("/{id}", app.handle(app.handleItemsDelete))
+			r.Post("/{id}/merge-into/{target}", app.handle(app.handleItemsMerge))
+			r.Post("/nutrition/import", app.handle(app.handleItemNutritionImport))
+			r.Get("/{id}/nutrition", app.handle(app.handleItemNutritionGet))
+			r.Put("/{id}/nutrition", app.handle(app.handleItemNutritionPut))
+			r.Delete("/{id}/nutrition", app.handle(app.handleItemNutritionDelete))
+			r.Get("/{id}/attributes", app.handle(app.handleItemAttributesGet))
+			r.Put("/{id}/attributes", app.handle(app.handleItemAttributesPut))
+			r.Get("/{id}/prices", app.handle(app.handleItemPricesList))
+			r.Post("/{id}/prices", app.handle(app.handleItemPricesCreate))
+			r.Delete("/{id}/prices/{price_id}", app.handle(app.handleItemPricesDelete))
+			r.Get("/{id}/aliases", app.handle(app.handleItemAliasesList))
+			r.Post("/{id}/aliases", app.handle(app.handleItemAliasesCreate))
+			r.Delete("/{id}/aliases/{alias_id}", app.handle(app.handleItemAliasesDelete))
 		})
 
 		r.Route("/shopping-lists", func(r chi.Router) {
@@ -103,22 +138,75 @@ func routes(app *App) http.Handler {
 			r.Delete("/{id}/items/{item_id}", app.handle(app.handleShoppingListItemsDelete))
 		})
 
+		r.Route("/pantry-items", func(r chi.Router) {
+			r.Use(app.authMiddleware)
+			r.Get("/", app.handle(app.handlePantryItemsList))
+			r.Post("/", app.handle(app.handlePantryItemsCreate))
+			r.Put("/{id}", app.handle(app.handlePantryItemsUpdate))
+			r.Delete("/{id}", app.handle(app.handlePantryItemsDelete))
+		})
+
 		r.Route("/recipe-books", func(r chi.Router) {
 			r.Use(app.authMiddleware)
 			r.Get("/", app.handle(app.handleRecipeBooksList))
 			r.Post("/", app.handle(app.handleRecipeBooksCreate))
 			r.Put("/{id}", app.handle(app.handleRecipeBooksUpdate))
 			r.Delete("/{id}", app.handle(app.handleRecipeBooksDelete))
+			r.Post("/{id}/merge-into/{target}", app.handle(app.handleRecipeBooksMerge))
+		})
+
+		r.Route("/ingredients", func(r chi.Router) {
+			r.Use(app.authMiddleware)
+			r.Post("/parse", app.handle(app.handleIngredientsParse))
+		})
+
+		r.Route("/steps", func(r chi.Router) {
+			r.Use(app.authMiddleware)
+			r.Post("/parse", app.handle(app.handleStepsParse))
+		})
+
+		r.Route("/shared", func(r chi.Router) {
+			// No authMiddleware: see the share page route above.
+			r.Get("/recipes/{token}", app.handle(app.handleSharedRecipeGet))
 		})
 
 		r.Route("/recipes", func(r chi.Router) {
 			r.Use(app.authMiddleware)
 			r.Get("/", app.handle(app.handleRecipesList))
+			r.Get("/duplicates", app.handle(app.handleRecipesDuplicates))
 			r.Get("/{id}", app.handle(app.handleRecipesGet))
 			r.Post("/", app.handle(app.handleRecipesCreate))
+			r.Post("/import", app.handle(app.handleRecipesImport))
+			r.Post("/match", app.handle(app.handleRecipesMatch))
 			r.Put("/{id}", app.handle(app.handleRecipesUpdate))
 			r.Delete("/{id}", app.handle(app.handleRecipesDelete))
 			r.Put("/{id}/restore", app.handle(app.handleRecipesRestore))
+			r.Post("/{id}/merge", app.handle(app.handleRecipesMerge))
+			r.Post("/{id}/clone", app.handle(app.handleRecipesClone))
+			r.Get("/{id}/parent/diff", app.handle(app.handleRecipesParentDiff))
+			r.Get("/{id}/revisions", app.handle(app.handleRecipeRevisionsList))
+			r.Get("/{id}/revisions/diff", app.handle(app.handleRecipeRevisionsDiff))
+			r.Get("/{id}/revisions/{n}", app.handle(app.handleRecipeRevisionsGet))
+			r.Post("/{id}/revisions/{n}/revert", app.handle(app.handleRecipeRevisionsRevert))
+			r.Get("/{id}/images", app.handle(app.handleRecipeImagesList))
+			r.Post("/{id}/images", app.handle(app.handleRecipeImagesUpload))
+			r.Get("/{id}/images/{image_id}", app.handle(app.handleRecipeImagesContent))
+			r.Get("/{id}/images/{image_id}/thumbnail", app.handle(app.handleRecipeImagesThumbnail))
+			r.Delete("/{id}/images/{image_id}", app.handle(app.handleRecipeImagesDelete))
+			r.Get("/{id}/cooks", app.handle(app.handleRecipeCooksList))
+			r.Post("/{id}/cooks", app.handle(app.handleRecipeCooksCreate))
+			r.Get("/{id}/cooks/{cook_id}", app.handle(app.handleRecipeCooksGet))
+			r.Put("/{id}/cooks/{cook_id}", app.handle(app.handleRecipeCooksUpdate))
+			r.Delete("/{id}/cooks/{cook_id}", app.handle(app.handleRecipeCooksDelete))
+			r.Put("/{id}/favorite", app.handle(app.handleRecipeFavoritePut))
+			r.Delete("/{id}/favorite", app.handle(app.handleRecipeFavoriteDelete))
+			r.Get("/{id}/personal-note", app.handle(app.handleRecipePersonalNoteGet))
+			r.Put("/{id}/personal-note", app.handle(app.handleRecipePersonalNotePut))
+			r.Delete("/{id}/personal-note", app.handle(app.handleRecipePersonalNoteDelete))
+			r.Get("/{id}/shares", app.handle(app.handleRecipeSharesList))
+			r.Post("/{id}/shares", app.handle(app.handleRecipeSharesCreate))
+			r.Delete("/{id}/shares", app.handle(app.handleRecipeSharesDeleteAll))
+			r.Delete("/{id}/shares/{share_id}", app.handle(app.handleRecipeSharesDelete))
 		})
 
 		r.Route("/meal-plans", func(r chi.Router) {
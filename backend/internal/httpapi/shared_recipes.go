@@ -0,0 +1,315 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+// maxRecipeShareTokenLength rejects obviously bogus tokens before hashing.
+const maxRecipeShareTokenLength = 128
+
+// sharedRecipeResponse is the public view of a shared recipe. It carries no
+// internal IDs, audit fields, or per-user data.
+type sharedRecipeResponse struct {
+	Title              string                           `json:"title"`
+	Servings           int32                            `json:"servings"`
+	ScaledFromServings *int32                           `json:"scaled_from_servings"`
+	PrepTimeMinutes    int32                            `json:"prep_time_minutes"`
+	TotalTimeMinutes   int32                            `json:"total_time_minutes"`
+	SourceURL          *string                          `json:"source_url"`
+	Notes              *string                          `json:"notes"`
+	Tags               []string                         `json:"tags"`
+	Ingredients        []sharedRecipeIngredientResponse `json:"ingredients"`
+	Steps              []sharedRecipeStepResponse       `json:"steps"`
+}
+
+// sharedRecipeIngredientResponse is one ingredient line. Item is the item
+// name, or the sub-recipe title when SubRecipe is set.
+type sharedRecipeIngredientResponse struct {
+	GroupName    *string                  `json:"group_name"`
+	Quantity     *float64                 `json:"quantity"`
+	QuantityText *string                  `json:"quantity_text"`
+	Unit         *string                  `json:"unit"`
+	Item         string                   `json:"item"`
+	Prep         *string                  `json:"prep"`
+	Notes        *string                  `json:"notes"`
+	SubRecipe    *sharedSubRecipeResponse `json:"sub_recipe"`
+}
+
+type sharedSubRecipeResponse struct {
+	Title       string                           `json:"title"`
+	Servings    int32                            `json:"servings"`
+	Ingredients []sharedRecipeIngredientResponse `json:"ingredients"`
+}
+
+type sharedRecipeStepResponse struct {
+	StepNumber         int     `json:"step_number"`
+	GroupName          *string `json:"group_name"`
+	Instruction        string  `json:"instruction"`
+	DurationSeconds    *int32  `json:"duration_seconds"`
+	DurationMaxSeconds *int32  `json:"duration_max_seconds"`
+	Temperature        *int32  `json:"temperature"`
+	TemperatureUnit    *string `json:"temperature_unit"`
+}
+
+// handleSharedRecipeGet is public: the share token is the credential, so it
+// is routed outside authMiddleware.
+func (a *App) handleSharedRecipeGet(w http.ResponseWriter, r *http.Request) error {
+	servings, err := parseServingsQuery(r)
+	if err != nil {
+		return err
+	}
+
+	shared, err := a.loadSharedRecipe(r.Context(), chi.URLParam(r, "token"), servings)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if err := response.WriteJSON(w, http.StatusOK, shared); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/shared/recipes/{token}")
+	}
+	return nil
+}
+
+// handleSharedRecipePage renders a shared recipe as a standalone HTML page
+// for people without an account. Like handleSharedRecipeGet it is public.
+func (a *App) handleSharedRecipePage(w http.ResponseWriter, r *http.Request) error {
+	servings, err := parseServingsQuery(r)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	shared, err := a.loadSharedRecipe(r.Context(), chi.URLParam(r, "token"), servings)
+	if err != nil {
+		if apiErr, ok := asAPIError(err); ok && apiErr.kind == apiErrorNotFound {
+			http.Error(w, "This recipe link is invalid, expired, or was revoked.", http.StatusNotFound)
+			return nil
+		}
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := sharedRecipeTemplate.Execute(w, sharedRecipePageFromResponse(shared)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/shared/recipes/{token}")
+	}
+	return nil
+}
+
+// loadSharedRecipe resolves a share token to the public recipe view. Unknown,
+// expired, and revoked tokens are all reported as not found.
+func (a *App) loadSharedRecipe(ctx context.Context, token string, servings int32) (sharedRecipeResponse, error) {
+	token = strings.TrimSpace(token)
+	if token == "" || len(token) > maxRecipeShareTokenLength {
+		return sharedRecipeResponse{}, errNotFound()
+	}
+
+	recipeID, err := a.queries.GetSharedRecipeIDByTokenHash(ctx, hashRecipeShareToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sharedRecipeResponse{}, errNotFound()
+		}
+		return sharedRecipeResponse{}, errInternal(err)
+	}
+
+	// No viewer, so the favorite flag and personal note stay empty.
+	detail, err := a.loadRecipeDetail(ctx, recipeID, pgtype.UUID{})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sharedRecipeResponse{}, errNotFound()
+		}
+		return sharedRecipeResponse{}, errInternal(err)
+	}
+	scaleRecipeDetail(&detail, servings)
+	return sharedRecipeFromDetail(detail), nil
+}
+
+func sharedRecipeFromDetail(detail recipeDetailResponse) sharedRecipeResponse {
+	tags := make([]string, 0, len(detail.Tags))
+	for _, tag := range detail.Tags {
+		tags = append(tags, tag.Name)
+	}
+	steps := make([]sharedRecipeStepResponse, 0, len(detail.Steps))
+	for _, step := range detail.Steps {
+		steps = append(steps, sharedRecipeStepResponse{
+			StepNumber:         step.StepNumber,
+			GroupName:          step.GroupName,
+			Instruction:        step.Instruction,
+			DurationSeconds:    step.DurationSeconds,
+			DurationMaxSeconds: step.DurationMaxSeconds,
+			Temperature:        step.Temperature,
+			TemperatureUnit:    step.TemperatureUnit,
+		})
+	}
+	return sharedRecipeResponse{
+		Title:              detail.Title,
+		Servings:           detail.Servings,
+		ScaledFromServings: detail.ScaledFromServings,
+		PrepTimeMinutes:    detail.PrepTimeMinutes,
+		TotalTimeMinutes:   detail.TotalTimeMinutes,
+		SourceURL:          detail.SourceURL,
+		Notes:              detail.Notes,
+		Tags:               tags,
+		Ingredients:        sharedIngredients(detail.Ingredients),
+		Steps:              steps,
+	}
+}
+
+func sharedIngredients(in []recipeIngredientResponse) []sharedRecipeIngredientResponse {
+	out := make([]sharedRecipeIngredientResponse, 0, len(in))
+	for _, ingredient := range in {
+		line := sharedRecipeIngredientResponse{
+			GroupName:    ingredient.GroupName,
+			Quantity:     ingredient.Quantity,
+			QuantityText: ingredient.QuantityText,
+			Unit:         ingredient.Unit,
+			Prep:         ingredient.Prep,
+			Notes:        ingredient.Notes,
+		}
+		switch {
+		case ingredient.SubRecipe != nil:
+			line.Item = ingredient.SubRecipe.Title
+			line.SubRecipe = &sharedSubRecipeResponse{
+				Title:       ingredient.SubRecipe.Title,
+				Servings:    ingredient.SubRecipe.Servings,
+				Ingredients: sharedIngredients(ingredient.SubRecipe.Ingredients),
+			}
+		case ingredient.Item != nil:
+			line.Item = ingredient.Item.Name
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// sharedRecipePage is the HTML template's view model: lines are pre-formatted
+// and split into their named groups.
+type sharedRecipePage struct {
+	Recipe           sharedRecipeResponse
+	IngredientGroups []sharedRecipePageGroup
+	StepGroups       []sharedRecipePageGroup
+}
+
+type sharedRecipePageGroup struct {
+	Name  string
+	Lines []string
+}
+
+func sharedRecipePageFromResponse(shared sharedRecipeResponse) sharedRecipePage {
+	page := sharedRecipePage{Recipe: shared}
+	for _, ingredient := range shared.Ingredients {
+		page.IngredientGroups = appendSharedPageLine(page.IngredientGroups, ingredient.GroupName, sharedIngredientText(ingredient))
+	}
+	for _, step := range shared.Steps {
+		page.StepGroups = appendSharedPageLine(page.StepGroups, step.GroupName, step.Instruction)
+	}
+	return page
+}
+
+// appendSharedPageLine adds line to the last group, starting a new group when
+// the group name changes.
+func appendSharedPageLine(groups []sharedRecipePageGroup, groupName *string, line string) []sharedRecipePageGroup {
+	name := ""
+	if groupName != nil {
+		name = *groupName
+	}
+	if len(groups) == 0 || groups[len(groups)-1].Name != name {
+		groups = append(groups, sharedRecipePageGroup{Name: name})
+	}
+	last := &groups[len(groups)-1]
+	last.Lines = append(last.Lines, line)
+	return groups
+}
+
+// sharedIngredientText formats an ingredient as a single readable line,
+// e.g. "1.5 cup onion, chopped (divided)".
+func sharedIngredientText(ingredient sharedRecipeIngredientResponse) string {
+	parts := make([]string, 0, 3)
+	switch {
+	case ingredient.QuantityText != nil && strings.TrimSpace(*ingredient.QuantityText) != "":
+		parts = append(parts, strings.TrimSpace(*ingredient.QuantityText))
+	case ingredient.Quantity != nil:
+		parts = append(parts, strconv.FormatFloat(*ingredient.Quantity, 'f', -1, 64))
+	}
+	if ingredient.Unit != nil && strings.TrimSpace(*ingredient.Unit) != "" {
+		parts = append(parts, strings.TrimSpace(*ingredient.Unit))
+	}
+	if ingredient.Item != "" {
+		parts = append(parts, ingredient.Item)
+	}
+	text := strings.Join(parts, " ")
+	if ingredient.Prep != nil && strings.TrimSpace(*ingredient.Prep) != "" {
+		text += ", " + strings.TrimSpace(*ingredient.Prep)
+	}
+	if ingredient.Notes != nil && strings.TrimSpace(*ingredient.Notes) != "" {
+		text += " (" + strings.TrimSpace(*ingredient.Notes) + ")"
+	}
+	return text
+}
+
+var sharedRecipeTemplate = template.Must(template.New("shared-recipe").Parse(`<!doctype html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Recipe.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; line-height: 1.5; max-width: 40rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+h1 { margin-bottom: 0.25rem; }
+.meta, .tags { color: #555; }
+h3 { margin-bottom: 0.25rem; }
+</style>
+</head>
+<body>
+<h1>{{.Recipe.Title}}</h1>
+<p class="meta">Serves {{.Recipe.Servings}}{{with .Recipe.ScaledFromServings}} (scaled from {{.}}){{end}}{{if .Recipe.PrepTimeMinutes}} · Prep {{.Recipe.PrepTimeMinutes}} min{{end}}{{if .Recipe.TotalTimeMinutes}} · Total {{.Recipe.TotalTimeMinutes}} min{{end}}</p>
+{{- if .Recipe.Tags}}
+<p class="tags">{{range $i, $tag := .Recipe.Tags}}{{if $i}}, {{end}}{{$tag}}{{end}}</p>
+{{- end}}
+<h2>Ingredients</h2>
+{{- range .IngredientGroups}}
+{{- if .Name}}
+<h3>{{.Name}}</h3>
+{{- end}}
+<ul>
+{{- range .Lines}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+<h2>Steps</h2>
+{{- range .StepGroups}}
+{{- if .Name}}
+<h3>{{.Name}}</h3>
+{{- end}}
+<ol>
+{{- range .Lines}}
+<li>{{.}}</li>
+{{- end}}
+</ol>
+{{- end}}
+{{- with .Recipe.Notes}}
+<h2>Notes</h2>
+<p>{{.}}</p>
+{{- end}}
+{{- with .Recipe.SourceURL}}
+<p class="meta">Source: <a href="{{.}}" rel="noopener noreferrer">{{.}}</a></p>
+{{- end}}
+</body>
+</html>
+`))
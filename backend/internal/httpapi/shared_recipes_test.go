@@ -0,0 +1,100 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSharedRecipeFromDetail_OmitsInternalFields(t *testing.T) {
+	t.Parallel()
+
+	quantity := 1.5
+	unit := "cup"
+	prep := "chopped"
+	dough := "Dough"
+	note := "Kids prefer it mild"
+	detail := recipeDetailResponse{
+		ID:       "11111111-1111-1111-1111-111111111111",
+		Title:    "Onion Tart",
+		Servings: 4,
+		Tags:     []recipeTagResponse{{ID: "tag-1", Name: "Dinner"}},
+		Ingredients: []recipeIngredientResponse{
+			{ID: "ing-1", Position: 1, GroupName: &dough, SubRecipe: &recipeSubRecipeResponse{
+				ID: "sub-1", Title: "Shortcrust", Servings: 8,
+				Ingredients: []recipeIngredientResponse{{ID: "ing-3", Item: &itemResponse{ID: "item-3", Name: "butter"}}},
+			}},
+			{ID: "ing-2", Position: 2, Quantity: &quantity, Unit: &unit, Item: &itemResponse{ID: "item-1", Name: "onion"}, Prep: &prep},
+		},
+		Steps:        []recipeStepResponse{{ID: "step-1", StepNumber: 1, Instruction: "Bake."}},
+		IsFavorite:   true,
+		PersonalNote: &note,
+		CreatedBy:    "22222222-2222-2222-2222-222222222222",
+	}
+
+	shared := sharedRecipeFromDetail(detail)
+	raw, err := json.Marshal(shared)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, leaked := range []string{detail.ID, detail.CreatedBy, "tag-1", "ing-", "item-", "sub-1", "step-1", note, `"id"`} {
+		if strings.Contains(string(raw), leaked) {
+			t.Fatalf("shared recipe leaks %q: %s", leaked, raw)
+		}
+	}
+	if len(shared.Tags) != 1 || shared.Tags[0] != "Dinner" {
+		t.Fatalf("tags=%v, want [Dinner]", shared.Tags)
+	}
+	if shared.Ingredients[0].Item != "Shortcrust" || shared.Ingredients[0].SubRecipe.Ingredients[0].Item != "butter" {
+		t.Fatalf("sub-recipe line=%+v, want Shortcrust with butter", shared.Ingredients[0])
+	}
+	if got := sharedIngredientText(shared.Ingredients[1]); got != "1.5 cup onion, chopped" {
+		t.Fatalf("ingredient text=%q, want %q", got, "1.5 cup onion, chopped")
+	}
+}
+
+func TestSharedRecipeTemplate_EscapesAndGroups(t *testing.T) {
+	t.Parallel()
+
+	dough := "Dough"
+	shared := sharedRecipeResponse{
+		Title:    "<script>alert(1)</script>",
+		Servings: 2,
+		Ingredients: []sharedRecipeIngredientResponse{
+			{GroupName: &dough, Item: "flour"},
+			{Item: "salt"},
+		},
+		Steps: []sharedRecipeStepResponse{{StepNumber: 1, Instruction: "Mix."}},
+	}
+
+	var buf bytes.Buffer
+	if err := sharedRecipeTemplate.Execute(&buf, sharedRecipePageFromResponse(shared)); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "<script>") {
+		t.Fatalf("title not escaped:\n%s", out)
+	}
+	for _, want := range []string{"<h3>Dough</h3>", "<li>flour</li>", "<li>salt</li>", "<li>Mix.</li>"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("page missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSafePath_RedactsShareTokens(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"/shared/recipes/abc123":        "/shared/recipes/{token}",
+		"/api/v1/shared/recipes/abc123": "/api/v1/shared/recipes/{token}",
+		"/api/v1/recipes/abc123":        "/api/v1/recipes/abc123",
+	}
+	for path, want := range tests {
+		if got := safePath(httptest.NewRequest("GET", path, nil)); got != want {
+			t.Fatalf("safePath(%q)=%q, want %q", path, got, want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package httpapi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
@@ -13,12 +14,29 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
 	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+	"github.com/saiaj/cooking_app/backend/internal/units"
 )
 
+// shoppingListRequest captures list create/update payloads. StoreID picks the
+// store whose aisle layout orders the list; null uses the default layout.
+// An update that leaves store_id out keeps the list's current store.
 type shoppingListRequest struct {
-	ListDate string  `json:"list_date"`
-	Name     string  `json:"name"`
-	Notes    *string `json:"notes"`
+	ListDate string       `json:"list_date"`
+	Name     string       `json:"name"`
+	Notes    *string      `json:"notes"`
+	StoreID  storeIDField `json:"store_id"`
+}
+
+// storeIDField records whether store_id was present in the request, so an
+// omitted field can be told apart from an explicit null.
+type storeIDField struct {
+	Set   bool
+	Value *string
+}
+
+func (f *storeIDField) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	return json.Unmarshal(data, &f.Value)
 }
 
 // shoppingListResponse represents a shopping list without its items.
@@ -27,6 +45,7 @@ type shoppingListResponse struct {
 	ListDate  string  `json:"list_date"`
 	Name      string  `json:"name"`
 	Notes     *string `json:"notes"`
+	StoreID   *string `json:"store_id"`
 	CreatedAt string  `json:"created_at"`
 	UpdatedAt string  `json:"updated_at"`
 }
@@ -37,20 +56,24 @@ type shoppingListDetailResponse struct {
 	ListDate  string                     `json:"list_date"`
 	Name      string                     `json:"name"`
 	Notes     *string                    `json:"notes"`
+	StoreID   *string                    `json:"store_id"`
 	Items     []shoppingListItemResponse `json:"items"`
+	Cost      shoppingListCostResponse   `json:"cost"`
 	CreatedAt string                     `json:"created_at"`
 	UpdatedAt string                     `json:"updated_at"`
 }
 
-// shoppingListItemResponse represents a shopping list item with live item details.
+// shoppingListItemResponse represents a shopping list item with live item
+// details. EstimatedCost is null when the item cannot be priced.
 type shoppingListItemResponse struct {
-	ID           string       `json:"id"`
-	Item         itemResponse `json:"item"`
-	Quantity     *float64     `json:"quantity"`
-	QuantityText *string      `json:"quantity_text"`
-	Unit         *string      `json:"unit"`
-	IsPurchased  bool         `json:"is_purchased"`
-	PurchasedAt  *string      `json:"purchased_at"`
+	ID            string       `json:"id"`
+	Item          itemResponse `json:"item"`
+	Quantity      *float64     `json:"quantity"`
+	QuantityText  *string      `json:"quantity_text"`
+	Unit          *string      `json:"unit"`
+	IsPurchased   bool         `json:"is_purchased"`
+	PurchasedAt   *string      `json:"purchased_at"`
+	EstimatedCost *float64     `json:"estimated_cost"`
 }
 
 type shoppingListItemInput struct {
@@ -64,16 +87,34 @@ type shoppingListItemsAddRequest struct {
 	Items []shoppingListItemInput `json:"items"`
 }
 
+// shoppingListRecipesAddRequest generates list items from recipes.
+// ExcludePantry subtracts what the pantry already holds.
 type shoppingListRecipesAddRequest struct {
-	RecipeIDs []string `json:"recipe_ids"`
+	RecipeIDs     []string         `json:"recipe_ids"`
+	Servings      map[string]int32 `json:"servings"`
+	ExcludePantry bool             `json:"exclude_pantry"`
 }
 
+// shoppingListMealPlanAddRequest generates list items from a meal plan date.
+// ExcludePantry subtracts what the pantry already holds.
 type shoppingListMealPlanAddRequest struct {
-	Date string `json:"date"`
+	Date          string `json:"date"`
+	ExcludePantry bool   `json:"exclude_pantry"`
 }
 
+// shoppingListItemPurchaseRequest sets purchase state. RestockPantry adds the
+// item to the pantry at PantryLocation when it becomes purchased.
 type shoppingListItemPurchaseRequest struct {
-	IsPurchased bool `json:"is_purchased"`
+	IsPurchased    bool    `json:"is_purchased"`
+	RestockPantry  bool    `json:"restock_pantry"`
+	PantryLocation *string `json:"pantry_location"`
+}
+
+// shoppingListItemPurchaseResponse is the updated list item and, when the
+// purchase restocked the pantry, the resulting pantry entry.
+type shoppingListItemPurchaseResponse struct {
+	shoppingListItemResponse
+	PantryItem *pantryItemResponse `json:"pantry_item,omitempty"`
 }
 
 // handleShoppingListsList returns shopping lists within a date range.
@@ -136,16 +177,25 @@ func (a *App) handleShoppingListsCreate(w http.ResponseWriter, r *http.Request)
 	if name == "" {
 		return errValidationField("name", "name is required")
 	}
+	storeID, err := uuidPtrToPG(req.StoreID.Value)
+	if err != nil {
+		return errValidationField("store_id", "invalid id")
+	}
 
 	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
 	row, err := a.queries.CreateShoppingList(r.Context(), sqlc.CreateShoppingListParams{
 		ListDate:  listDate,
 		Name:      name,
 		Notes:     textPtrToPG(req.Notes),
+		StoreID:   storeID,
 		CreatedBy: userID,
 		UpdatedBy: userID,
 	})
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errValidationField("store_id", "store does not exist")
+		}
 		return errInternal(err)
 	}
 
@@ -179,7 +229,7 @@ func (a *App) handleShoppingListsGet(w http.ResponseWriter, r *http.Request) err
 		return errInternal(err)
 	}
 
-	items, err := a.loadShoppingListItems(ctx, pgtype.UUID{Bytes: id, Valid: true}, info.UserID)
+	items, cost, err := a.loadShoppingListItems(ctx, pgtype.UUID{Bytes: id, Valid: true}, info.UserID)
 	if err != nil {
 		return err
 	}
@@ -189,7 +239,9 @@ func (a *App) handleShoppingListsGet(w http.ResponseWriter, r *http.Request) err
 		ListDate:  mealPlanDateString(row.ListDate),
 		Name:      row.Name,
 		Notes:     textStringPtr(row.Notes),
+		StoreID:   uuidStringPtr(row.StoreID),
 		Items:     items,
+		Cost:      cost,
 		CreatedAt: timeString(row.CreatedAt),
 		UpdatedAt: timeString(row.UpdatedAt),
 	}
@@ -225,6 +277,10 @@ func (a *App) handleShoppingListsUpdate(w http.ResponseWriter, r *http.Request)
 	if name == "" {
 		return errValidationField("name", "name is required")
 	}
+	storeID, err := uuidPtrToPG(req.StoreID.Value)
+	if err != nil {
+		return errValidationField("store_id", "invalid id")
+	}
 
 	row, err := a.queries.UpdateShoppingListByID(r.Context(), sqlc.UpdateShoppingListByIDParams{
 		ID:        pgtype.UUID{Bytes: id, Valid: true},
@@ -232,12 +288,18 @@ func (a *App) handleShoppingListsUpdate(w http.ResponseWriter, r *http.Request)
 		ListDate:  listDate,
 		Name:      name,
 		Notes:     textPtrToPG(req.Notes),
+		SetStore:  req.StoreID.Set,
+		StoreID:   storeID,
 		UpdatedBy: pgtype.UUID{Bytes: info.UserID, Valid: true},
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return errNotFound()
 		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errValidationField("store_id", "store does not exist")
+		}
 		return errInternal(err)
 	}
 
@@ -286,7 +348,7 @@ func (a *App) handleShoppingListItemsList(w http.ResponseWriter, r *http.Request
 		return err
 	}
 
-	items, err := a.loadShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID)
+	items, _, err := a.loadShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID)
 	if err != nil {
 		return err
 	}
@@ -322,11 +384,11 @@ func (a *App) handleShoppingListItemsAdd(w http.ResponseWriter, r *http.Request)
 		return err
 	}
 
-	if err = a.upsertShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID, items); err != nil {
+	if err = a.upsertShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID, items, nil); err != nil {
 		return err
 	}
 
-	out, err := a.loadShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID)
+	out, _, err := a.loadShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID)
 	if err != nil {
 		return err
 	}
@@ -362,21 +424,32 @@ func (a *App) handleShoppingListItemsAddFromRecipes(w http.ResponseWriter, r *ht
 		return errValidationField("recipe_ids", "invalid id")
 	}
 
+	servings, err := parseRecipeServingsOverrides(req.Servings, recipeIDs)
+	if err != nil {
+		return err
+	}
+
 	rows, err := a.queries.ListRecipeIngredientsByRecipeIDs(r.Context(), recipeIDs)
 	if err != nil {
 		return errInternal(err)
 	}
 
-	items, err := aggregateRecipeIngredients(rows)
+	items, err := aggregateRecipeIngredients(rows, servings)
 	if err != nil {
 		return err
 	}
+	var reservations []pantryReservation
+	if req.ExcludePantry {
+		if items, reservations, err = a.excludePantryStock(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID, items); err != nil {
+			return err
+		}
+	}
 
-	if err = a.upsertShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID, items); err != nil {
+	if err = a.upsertShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID, items, reservations); err != nil {
 		return err
 	}
 
-	out, err := a.loadShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID)
+	out, _, err := a.loadShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID)
 	if err != nil {
 		return err
 	}
@@ -421,12 +494,18 @@ func (a *App) handleShoppingListItemsAddFromMealPlan(w http.ResponseWriter, r *h
 	if err != nil {
 		return err
 	}
+	var reservations []pantryReservation
+	if req.ExcludePantry {
+		if items, reservations, err = a.excludePantryStock(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID, items); err != nil {
+			return err
+		}
+	}
 
-	if err = a.upsertShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID, items); err != nil {
+	if err = a.upsertShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID, items, reservations); err != nil {
 		return err
 	}
 
-	out, err := a.loadShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID)
+	out, _, err := a.loadShoppingListItems(r.Context(), pgtype.UUID{Bytes: listID, Valid: true}, info.UserID)
 	if err != nil {
 		return err
 	}
@@ -437,7 +516,8 @@ func (a *App) handleShoppingListItemsAddFromMealPlan(w http.ResponseWriter, r *h
 	return nil
 }
 
-// handleShoppingListItemsUpdate updates purchase state for a shopping list item.
+// handleShoppingListItemsUpdate updates purchase state for a shopping list item,
+// optionally restocking the pantry.
 func (a *App) handleShoppingListItemsUpdate(w http.ResponseWriter, r *http.Request) error {
 	info, ok := authInfoFromRequest(r)
 	if !ok {
@@ -457,25 +537,46 @@ func (a *App) handleShoppingListItemsUpdate(w http.ResponseWriter, r *http.Reque
 	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
 		return decodeErr
 	}
+	if req.RestockPantry && !req.IsPurchased {
+		return errValidationField("restock_pantry", "restock_pantry requires is_purchased")
+	}
 
-	row, err := a.queries.UpdateShoppingListItemPurchased(r.Context(), sqlc.UpdateShoppingListItemPurchasedParams{
+	params := sqlc.UpdateShoppingListItemPurchasedParams{
 		ID:             pgtype.UUID{Bytes: itemID, Valid: true},
 		ShoppingListID: pgtype.UUID{Bytes: listID, Valid: true},
 		UserID:         pgtype.UUID{Bytes: info.UserID, Valid: true},
 		IsPurchased:    req.IsPurchased,
 		UpdatedBy:      pgtype.UUID{Bytes: info.UserID, Valid: true},
-	})
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return errNotFound()
+	}
+
+	var (
+		row       sqlc.UpdateShoppingListItemPurchasedRow
+		restocked *pantryItemResponse
+	)
+	if req.RestockPantry {
+		location, locationErr := normalizePantryLocation(req.PantryLocation)
+		if locationErr != nil {
+			return errValidationField("pantry_location", "pantry_location must be one of "+strings.Join(pantryLocations, ", "))
+		}
+		row, restocked, err = a.purchaseShoppingListItemAndRestock(r.Context(), params, location, info.UserID)
+		if err != nil {
+			return err
+		}
+	} else {
+		row, err = a.queries.UpdateShoppingListItemPurchased(r.Context(), params)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errNotFound()
+			}
+			return errInternal(err)
 		}
-		return errInternal(err)
 	}
 
-	resp, err := shoppingListItemResponseFromUpdateRow(row)
+	item, err := shoppingListItemResponseFromUpdateRow(row)
 	if err != nil {
 		return err
 	}
+	resp := shoppingListItemPurchaseResponse{shoppingListItemResponse: item, PantryItem: restocked}
 
 	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
 		a.logger.Warn("write failed", "err", err, "path", "/api/v1/shopping-lists/{id}/items/{item_id}")
@@ -483,6 +584,53 @@ func (a *App) handleShoppingListItemsUpdate(w http.ResponseWriter, r *http.Reque
 	return nil
 }
 
+// purchaseShoppingListItemAndRestock marks a list item purchased and adds it
+// to the pantry. Items that were already purchased are not restocked again,
+// so repeating the request does not double the stock.
+func (a *App) purchaseShoppingListItemAndRestock(ctx context.Context, params sqlc.UpdateShoppingListItemPurchasedParams, location string, userID uuid.UUID) (sqlc.UpdateShoppingListItemPurchasedRow, *pantryItemResponse, error) {
+	tx, err := a.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.UpdateShoppingListItemPurchasedRow{}, nil, errInternal(err)
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
+			a.logger.Warn("rollback failed", "err", rollbackErr)
+		}
+	}()
+
+	queries := a.queries.WithTx(tx)
+	current, err := queries.GetShoppingListItemForUpdate(ctx, sqlc.GetShoppingListItemForUpdateParams{
+		ID:             params.ID,
+		ShoppingListID: params.ShoppingListID,
+		UserID:         params.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.UpdateShoppingListItemPurchasedRow{}, nil, errNotFound()
+		}
+		return sqlc.UpdateShoppingListItemPurchasedRow{}, nil, errInternal(err)
+	}
+
+	row, err := queries.UpdateShoppingListItemPurchased(ctx, params)
+	if err != nil {
+		return sqlc.UpdateShoppingListItemPurchasedRow{}, nil, errInternal(err)
+	}
+
+	var restocked *pantryItemResponse
+	if !current.IsPurchased {
+		stock, restockErr := restockPantryFromListItem(ctx, queries, current, location, userID)
+		if restockErr != nil {
+			return sqlc.UpdateShoppingListItemPurchasedRow{}, nil, restockErr
+		}
+		restocked = &stock
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.UpdateShoppingListItemPurchasedRow{}, nil, errInternal(err)
+	}
+	return row, restocked, nil
+}
+
 // handleShoppingListItemsDelete deletes a shopping list item.
 func (a *App) handleShoppingListItemsDelete(w http.ResponseWriter, r *http.Request) error {
 	info, ok := authInfoFromRequest(r)
@@ -521,6 +669,7 @@ func shoppingListResponseFromBase(
 	listDate pgtype.Date,
 	name string,
 	notes pgtype.Text,
+	storeID pgtype.UUID,
 	createdAt pgtype.Timestamptz,
 	updatedAt pgtype.Timestamptz,
 ) shoppingListResponse {
@@ -529,6 +678,7 @@ func shoppingListResponseFromBase(
 		ListDate:  mealPlanDateString(listDate),
 		Name:      name,
 		Notes:     textStringPtr(notes),
+		StoreID:   uuidStringPtr(storeID),
 		CreatedAt: timeString(createdAt),
 		UpdatedAt: timeString(updatedAt),
 	}
@@ -536,39 +686,50 @@ func shoppingListResponseFromBase(
 
 // shoppingListResponseFromListRow maps list rows to responses.
 func shoppingListResponseFromListRow(row sqlc.ListShoppingListsByDateRangeRow) shoppingListResponse {
-	return shoppingListResponseFromBase(row.ID, row.ListDate, row.Name, row.Notes, row.CreatedAt, row.UpdatedAt)
+	return shoppingListResponseFromBase(row.ID, row.ListDate, row.Name, row.Notes, row.StoreID, row.CreatedAt, row.UpdatedAt)
 }
 
 // shoppingListResponseFromCreateRow maps create rows to responses.
 func shoppingListResponseFromCreateRow(row sqlc.CreateShoppingListRow) shoppingListResponse {
-	return shoppingListResponseFromBase(row.ID, row.ListDate, row.Name, row.Notes, row.CreatedAt, row.UpdatedAt)
+	return shoppingListResponseFromBase(row.ID, row.ListDate, row.Name, row.Notes, row.StoreID, row.CreatedAt, row.UpdatedAt)
 }
 
 // shoppingListResponseFromUpdateRow maps update rows to responses.
 func shoppingListResponseFromUpdateRow(row sqlc.UpdateShoppingListByIDRow) shoppingListResponse {
-	return shoppingListResponseFromBase(row.ID, row.ListDate, row.Name, row.Notes, row.CreatedAt, row.UpdatedAt)
+	return shoppingListResponseFromBase(row.ID, row.ListDate, row.Name, row.Notes, row.StoreID, row.CreatedAt, row.UpdatedAt)
 }
 
-// loadShoppingListItems fetches shopping list items with item details.
-func (a *App) loadShoppingListItems(ctx context.Context, listID pgtype.UUID, userID uuid.UUID) ([]shoppingListItemResponse, error) {
+// loadShoppingListItems fetches shopping list items with item details and
+// their estimated costs.
+func (a *App) loadShoppingListItems(ctx context.Context, listID pgtype.UUID, userID uuid.UUID) ([]shoppingListItemResponse, shoppingListCostResponse, error) {
 	rows, err := a.queries.ListShoppingListItemsByListID(ctx, sqlc.ListShoppingListItemsByListIDParams{
 		ShoppingListID: listID,
 		UserID:         pgtype.UUID{Bytes: userID, Valid: true},
 	})
 	if err != nil {
-		return nil, errInternal(err)
+		return nil, shoppingListCostResponse{}, errInternal(err)
 	}
 
 	items := make([]shoppingListItemResponse, 0, len(rows))
 	for _, row := range rows {
 		resp, buildErr := shoppingListItemResponseFromListRow(row)
 		if buildErr != nil {
-			return nil, buildErr
+			return nil, shoppingListCostResponse{}, buildErr
 		}
 		items = append(items, resp)
 	}
 
-	return items, nil
+	priceRows, err := a.queries.ListLatestItemPricesByShoppingListID(ctx, listID)
+	if err != nil {
+		return nil, shoppingListCostResponse{}, errInternal(err)
+	}
+	priceRefs, err := itemPriceReferences(priceRows)
+	if err != nil {
+		return nil, shoppingListCostResponse{}, errInternal(err)
+	}
+	cost := estimateShoppingListCost(items, priceRefs)
+
+	return items, cost, nil
 }
 
 // shoppingListItemResponseFromListRow maps list rows into responses.
@@ -679,9 +840,37 @@ func normalizeShoppingListItemInputs(inputs []shoppingListItemInput) ([]normaliz
 	return items, nil
 }
 
-// aggregateRecipeIngredients aggregates ingredients into list items.
-func aggregateRecipeIngredients(rows []sqlc.ListRecipeIngredientsByRecipeIDsRow) ([]normalizedShoppingListItem, error) {
-	return aggregateRecipeIngredientRows(convertRecipeIngredientRows(rows))
+// parseRecipeServingsOverrides validates per-recipe servings targets.
+// Every key must be one of the requested recipe ids and every value positive.
+func parseRecipeServingsOverrides(raw map[string]int32, recipeIDs []pgtype.UUID) (map[uuid.UUID]int32, error) {
+	out := make(map[uuid.UUID]int32, len(raw))
+	if len(raw) == 0 {
+		return out, nil
+	}
+	requested := make(map[uuid.UUID]struct{}, len(recipeIDs))
+	for _, id := range recipeIDs {
+		requested[uuid.UUID(id.Bytes)] = struct{}{}
+	}
+	for key, servings := range raw {
+		parsed, err := uuid.Parse(strings.TrimSpace(key))
+		if err != nil {
+			return nil, errValidationField("servings", "invalid id")
+		}
+		if _, ok := requested[parsed]; !ok {
+			return nil, errValidationField("servings", "recipe not in recipe_ids")
+		}
+		if servings <= 0 {
+			return nil, errValidationField("servings", "servings must be positive")
+		}
+		out[parsed] = servings
+	}
+	return out, nil
+}
+
+// aggregateRecipeIngredients aggregates ingredients into list items,
+// scaling each recipe to its requested servings first.
+func aggregateRecipeIngredients(rows []sqlc.ListRecipeIngredientsByRecipeIDsRow, servings map[uuid.UUID]int32) ([]normalizedShoppingListItem, error) {
+	return aggregateRecipeIngredientRows(convertRecipeIngredientRows(rows, servings))
 }
 
 // aggregateMealPlanIngredients aggregates meal plan ingredients into list items.
@@ -690,6 +879,8 @@ func aggregateMealPlanIngredients(rows []sqlc.ListRecipeIngredientsByMealPlanDat
 }
 
 // aggregateRecipeIngredientRows aggregates ingredients into list items.
+// Numeric quantities in compatible units (cup/tbsp, g/kg) merge into one line
+// expressed in the larger unit; anything else groups by its unit name.
 func aggregateRecipeIngredientRows(rows []ingredientRow) ([]normalizedShoppingListItem, error) {
 	items := make(map[string]*aggregatedListItem)
 	for _, row := range rows {
@@ -697,6 +888,9 @@ func aggregateRecipeIngredientRows(rows []ingredientRow) ([]normalizedShoppingLi
 		if err != nil {
 			return nil, errInternal(err)
 		}
+		if row.scale > 0 {
+			quantity = scaleQuantity(quantity, row.scale)
+		}
 
 		unit := trimPtr(textStringPtr(row.unit))
 		quantityText := trimPtr(textStringPtr(row.quantityText))
@@ -706,8 +900,18 @@ func aggregateRecipeIngredientRows(rows []ingredientRow) ([]normalizedShoppingLi
 		}
 		key := uuidString(row.itemID)
 		unitKey := ""
+		var canonical units.Unit
+		known := false
 		if unit != nil {
 			unitKey = strings.ToLower(*unit)
+			if canonical, known = units.Lookup(*unit); known {
+				name := canonical.Name
+				unit = &name
+				unitKey = canonical.Name
+				if quantity != nil {
+					unitKey = "base:" + canonical.Base
+				}
+			}
 		}
 		mapKey := key + "|" + unitKey
 		current, ok := items[mapKey]
@@ -717,6 +921,8 @@ func aggregateRecipeIngredientRows(rows []ingredientRow) ([]normalizedShoppingLi
 				quantity:     quantity,
 				quantityText: quantityText,
 				unit:         unit,
+				unitDef:      canonical,
+				knownUnit:    known,
 				count:        1,
 				hasNumeric:   quantity != nil,
 			}
@@ -729,6 +935,14 @@ func aggregateRecipeIngredientRows(rows []ingredientRow) ([]normalizedShoppingLi
 			if current.quantity == nil {
 				current.quantity = quantity
 			} else {
+				if known && current.knownUnit && current.unitDef.Name != canonical.Name {
+					target := units.Larger(current.unitDef, canonical)
+					current.quantity = convertQuantity(current.quantity, current.unitDef, target)
+					quantity = convertQuantity(quantity, canonical, target)
+					name := target.Name
+					current.unit = &name
+					current.unitDef = target
+				}
 				*current.quantity += *quantity
 			}
 		}
@@ -756,12 +970,23 @@ func aggregateRecipeIngredientRows(rows []ingredientRow) ([]normalizedShoppingLi
 	return out, nil
 }
 
+// convertQuantity converts a quantity between compatible units.
+func convertQuantity(value *float64, from, to units.Unit) *float64 {
+	factor, err := units.Factor(from, to)
+	if err != nil {
+		return value
+	}
+	return scaleQuantity(value, factor)
+}
+
 // ingredientRow is a lightweight adapter for ingredient aggregations.
+// A zero scale leaves the quantity unchanged.
 type ingredientRow struct {
 	itemID       pgtype.UUID
 	quantity     pgtype.Numeric
 	quantityText pgtype.Text
 	unit         pgtype.Text
+	scale        float64
 }
 
 type aggregatedListItem struct {
@@ -769,12 +994,16 @@ type aggregatedListItem struct {
 	quantity     *float64
 	quantityText *string
 	unit         *string
+	unitDef      units.Unit
+	knownUnit    bool
 	count        int
 	hasNumeric   bool
 }
 
-// convertRecipeIngredientRows converts sqlc recipe ingredient rows.
-func convertRecipeIngredientRows(rows []sqlc.ListRecipeIngredientsByRecipeIDsRow) []ingredientRow {
+// convertRecipeIngredientRows converts sqlc recipe ingredient rows. Lines
+// expanded from sub-recipes carry their batch fraction on top of the servings
+// scale.
+func convertRecipeIngredientRows(rows []sqlc.ListRecipeIngredientsByRecipeIDsRow, servings map[uuid.UUID]int32) []ingredientRow {
 	out := make([]ingredientRow, 0, len(rows))
 	for _, row := range rows {
 		out = append(out, ingredientRow{
@@ -782,6 +1011,7 @@ func convertRecipeIngredientRows(rows []sqlc.ListRecipeIngredientsByRecipeIDsRow
 			quantity:     row.Quantity,
 			quantityText: row.QuantityText,
 			unit:         row.Unit,
+			scale:        servingsScaleFactor(servings[uuid.UUID(row.RecipeID.Bytes)], row.RecipeServings) * row.Factor,
 		})
 	}
 	return out
@@ -796,14 +1026,16 @@ func convertMealPlanIngredientRows(rows []sqlc.ListRecipeIngredientsByMealPlanDa
 			quantity:     row.Quantity,
 			quantityText: row.QuantityText,
 			unit:         row.Unit,
+			scale:        row.Factor,
 		})
 	}
 	return out
 }
 
-// upsertShoppingListItems inserts or updates list items in a transaction.
-func (a *App) upsertShoppingListItems(ctx context.Context, listID pgtype.UUID, userID uuid.UUID, items []normalizedShoppingListItem) error {
-	if len(items) == 0 {
+// upsertShoppingListItems inserts or updates list items in a transaction,
+// recording any pantry stock set against them.
+func (a *App) upsertShoppingListItems(ctx context.Context, listID pgtype.UUID, userID uuid.UUID, items []normalizedShoppingListItem, reservations []pantryReservation) error {
+	if len(items) == 0 && len(reservations) == 0 {
 		return nil
 	}
 
@@ -849,6 +1081,25 @@ func (a *App) upsertShoppingListItems(ctx context.Context, listID pgtype.UUID, u
 			return errInternal(err)
 		}
 	}
+	for _, reservation := range reservations {
+		quantity, err := numericPtrFromFloat64(&reservation.quantity)
+		if err != nil {
+			return errInternal(err)
+		}
+		affected, err := queries.AddShoppingListPantryReservation(ctx, sqlc.AddShoppingListPantryReservationParams{
+			ItemID:         reservation.itemID,
+			Unit:           reservation.unit,
+			Quantity:       quantity,
+			ShoppingListID: listID,
+			UserID:         actor,
+		})
+		if err != nil {
+			return errInternal(err)
+		}
+		if affected == 0 {
+			return errNotFound()
+		}
+	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return errInternal(err)
@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestAggregateRecipeIngredientRows_MergesCompatibleUnits(t *testing.T) {
+	t.Parallel()
+
+	milk := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	rows := []ingredientRow{
+		testIngredientRow(t, milk, 1, "cup"),
+		testIngredientRow(t, milk, 4, "tbsp"),
+	}
+
+	items, err := aggregateRecipeIngredientRows(rows)
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("items=%d, want 1", len(items))
+	}
+	if items[0].unit == nil || *items[0].unit != "cup" {
+		t.Fatalf("unit=%v, want cup", items[0].unit)
+	}
+	if items[0].quantity == nil || *items[0].quantity != 1.25 {
+		t.Fatalf("quantity=%v, want 1.25", items[0].quantity)
+	}
+}
+
+func TestAggregateRecipeIngredientRows_MergesAliases(t *testing.T) {
+	t.Parallel()
+
+	flour := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	rows := []ingredientRow{
+		testIngredientRow(t, flour, 200, "g"),
+		testIngredientRow(t, flour, 300, "grams"),
+		testIngredientRow(t, flour, 1, "kg"),
+	}
+
+	items, err := aggregateRecipeIngredientRows(rows)
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("items=%d, want 1", len(items))
+	}
+	if *items[0].unit != "kg" || *items[0].quantity != 1.5 {
+		t.Fatalf("got %v %s, want 1.5 kg", *items[0].quantity, *items[0].unit)
+	}
+}
+
+func TestAggregateRecipeIngredientRows_IncompatibleUnitsStaySeparate(t *testing.T) {
+	t.Parallel()
+
+	butter := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	rows := []ingredientRow{
+		testIngredientRow(t, butter, 1, "cup"),
+		testIngredientRow(t, butter, 100, "g"),
+		testIngredientRow(t, butter, 2, "knobs"),
+		testIngredientRow(t, butter, 1, "Knobs"),
+	}
+
+	items, err := aggregateRecipeIngredientRows(rows)
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("items=%d, want 3", len(items))
+	}
+	byUnit := map[string]float64{}
+	for _, item := range items {
+		byUnit[*item.unit] = *item.quantity
+	}
+	if byUnit["cup"] != 1 || byUnit["g"] != 100 || byUnit["knobs"] != 3 {
+		t.Fatalf("quantities=%v, want cup=1 g=100 knobs=3", byUnit)
+	}
+}
+
+func testIngredientRow(t *testing.T, itemID pgtype.UUID, quantity float64, unit string) ingredientRow {
+	t.Helper()
+
+	n, err := numericPtrFromFloat64(&quantity)
+	if err != nil {
+		t.Fatalf("numeric: %v", err)
+	}
+	return ingredientRow{
+		itemID:   itemID,
+		quantity: n,
+		unit:     pgtype.Text{String: unit, Valid: true},
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/cookiejar"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -25,28 +26,32 @@ type testShoppingListResponse struct {
 	ListDate  string  `json:"list_date"`
 	Name      string  `json:"name"`
 	Notes     *string `json:"notes"`
+	StoreID   *string `json:"store_id"`
 	CreatedAt string  `json:"created_at"`
 	UpdatedAt string  `json:"updated_at"`
 }
 
 type testShoppingListDetailResponse struct {
-	ID        string                 `json:"id"`
-	ListDate  string                 `json:"list_date"`
-	Name      string                 `json:"name"`
-	Notes     *string                `json:"notes"`
-	Items     []testShoppingListItem `json:"items"`
-	CreatedAt string                 `json:"created_at"`
-	UpdatedAt string                 `json:"updated_at"`
+	ID        string                   `json:"id"`
+	ListDate  string                   `json:"list_date"`
+	Name      string                   `json:"name"`
+	Notes     *string                  `json:"notes"`
+	StoreID   *string                  `json:"store_id"`
+	Items     []testShoppingListItem   `json:"items"`
+	Cost      shoppingListCostResponse `json:"cost"`
+	CreatedAt string                   `json:"created_at"`
+	UpdatedAt string                   `json:"updated_at"`
 }
 
 type testShoppingListItem struct {
-	ID           string           `json:"id"`
-	Item         testItemResponse `json:"item"`
-	Quantity     *float64         `json:"quantity"`
-	QuantityText *string          `json:"quantity_text"`
-	Unit         *string          `json:"unit"`
-	IsPurchased  bool             `json:"is_purchased"`
-	PurchasedAt  *string          `json:"purchased_at"`
+	ID            string           `json:"id"`
+	Item          testItemResponse `json:"item"`
+	Quantity      *float64         `json:"quantity"`
+	QuantityText  *string          `json:"quantity_text"`
+	Unit          *string          `json:"unit"`
+	IsPurchased   bool             `json:"is_purchased"`
+	PurchasedAt   *string          `json:"purchased_at"`
+	EstimatedCost *float64         `json:"estimated_cost"`
 }
 
 func TestShoppingLists_CRUD(t *testing.T) {
@@ -561,7 +566,7 @@ func mustNumeric(t *testing.T, value float64) pgtype.Numeric {
 	t.Helper()
 
 	var n pgtype.Numeric
-	if scanErr := n.Scan(value); scanErr != nil {
+	if scanErr := n.Scan(strconv.FormatFloat(value, 'f', -1, 64)); scanErr != nil {
 		t.Fatalf("scan numeric: %v", scanErr)
 	}
 	return n
@@ -0,0 +1,63 @@
+package httpapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+	"github.com/saiaj/cooking_app/backend/internal/instructions"
+)
+
+const maxParseStepLines = 200
+
+type parseStepsRequest struct {
+	Lines []string `json:"lines"`
+}
+
+type parsedStepResponse struct {
+	Instruction        string  `json:"instruction"`
+	DurationSeconds    *int32  `json:"duration_seconds"`
+	DurationMaxSeconds *int32  `json:"duration_max_seconds"`
+	Temperature        *int32  `json:"temperature"`
+	TemperatureUnit    *string `json:"temperature_unit"`
+}
+
+type parseStepsResponse struct {
+	Items []parsedStepResponse `json:"items"`
+}
+
+// handleStepsParse proposes durations and temperatures for step text. Nothing
+// is stored; clients copy the values they accept into the recipe payload.
+func (a *App) handleStepsParse(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	var req parseStepsRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+	if len(req.Lines) == 0 {
+		return errValidationField("lines", "at least one line is required")
+	}
+	if len(req.Lines) > maxParseStepLines {
+		return errValidationField("lines", fmt.Sprintf("at most %d lines are allowed", maxParseStepLines))
+	}
+
+	resp := parseStepsResponse{Items: make([]parsedStepResponse, 0, len(req.Lines))}
+	for _, text := range req.Lines {
+		cues := storableStepCues(instructions.Extract(text))
+		resp.Items = append(resp.Items, parsedStepResponse{
+			Instruction:        text,
+			DurationSeconds:    cues.DurationSeconds,
+			DurationMaxSeconds: cues.DurationMaxSeconds,
+			Temperature:        cues.Temperature,
+			TemperatureUnit:    optionalString(cues.TemperatureUnit),
+		})
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/steps/parse")
+	}
+	return nil
+}
@@ -0,0 +1,498 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+// storeRequest captures store create/update payloads.
+type storeRequest struct {
+	Name string `json:"name"`
+}
+
+type storeResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+}
+
+// storeItemAisleRequest places an item in one of a store's aisles.
+type storeItemAisleRequest struct {
+	AisleID string `json:"aisle_id"`
+}
+
+// storeItemAisleResponse is an item's aisle within a store.
+type storeItemAisleResponse struct {
+	ItemID   string               `json:"item_id"`
+	ItemName string               `json:"item_name"`
+	Aisle    groceryAisleResponse `json:"aisle"`
+}
+
+// handleStoresList lists stores by name.
+func (a *App) handleStoresList(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	rows, err := a.queries.ListStores(r.Context())
+	if err != nil {
+		return errInternal(err)
+	}
+
+	out := make([]storeResponse, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, storeResponseFromRow(row))
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, out); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/stores")
+	}
+	return nil
+}
+
+// handleStoresCreate creates a store with an empty aisle layout.
+func (a *App) handleStoresCreate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	var req storeRequest
+	if err := a.decodeJSON(w, r, &req); err != nil {
+		return err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errValidationField("name", "name is required")
+	}
+
+	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
+	row, err := a.queries.CreateStore(r.Context(), sqlc.CreateStoreParams{
+		Name:      req.Name,
+		CreatedBy: userID,
+		UpdatedBy: userID,
+	})
+	if err != nil {
+		if isPGUniqueViolation(err) {
+			return errValidationField("name", "name already exists")
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, storeResponseFromRow(row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/stores")
+	}
+	return nil
+}
+
+// handleStoresUpdate renames a store.
+func (a *App) handleStoresUpdate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req storeRequest
+	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
+		return decodeErr
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errValidationField("name", "name is required")
+	}
+
+	row, err := a.queries.UpdateStoreByID(r.Context(), sqlc.UpdateStoreByIDParams{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		Name:      req.Name,
+		UpdatedBy: pgtype.UUID{Bytes: info.UserID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		if isPGUniqueViolation(err) {
+			return errValidationField("name", "name already exists")
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, storeResponseFromRow(row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/stores/{id}")
+	}
+	return nil
+}
+
+// handleStoresDelete deletes a store with its aisles and item placements.
+// Shopping lists assigned to it fall back to the default layout.
+func (a *App) handleStoresDelete(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeleteStoreByID(r.Context(), pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return errInternal(err)
+	}
+	if affected == 0 {
+		return errNotFound()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// handleStoreAislesList lists a store's aisles in sort order.
+func (a *App) handleStoreAislesList(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	storeID := pgtype.UUID{Bytes: id, Valid: true}
+	if _, err := a.queries.GetStoreByID(ctx, storeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	rows, err := a.queries.ListGroceryAislesByStoreID(ctx, storeID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	aisles := make([]groceryAisleResponse, 0, len(rows))
+	for _, row := range rows {
+		aisles = append(aisles, aisleResponseFromRow(row))
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, aisles); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/stores/{id}/aisles")
+	}
+	return nil
+}
+
+// handleStoreAislesCreate adds an aisle to a store's layout.
+func (a *App) handleStoreAislesCreate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req aisleRequest
+	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
+		return decodeErr
+	}
+
+	parsed, err := normalizeAisleRequest(req)
+	if err != nil {
+		return err
+	}
+
+	userID := pgtype.UUID{Bytes: info.UserID, Valid: true}
+	row, err := a.queries.CreateGroceryAisle(r.Context(), sqlc.CreateGroceryAisleParams{
+		Name:         parsed.name,
+		SortGroup:    parsed.sortGroup,
+		SortOrder:    parsed.sortOrder,
+		NumericValue: parsed.numericValue,
+		StoreID:      pgtype.UUID{Bytes: id, Valid: true},
+		CreatedBy:    userID,
+		UpdatedBy:    userID,
+	})
+	if err != nil {
+		if isPGUniqueViolation(err) {
+			return errValidationField("name", "name already exists")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, aisleResponseFromRow(row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/stores/{id}/aisles")
+	}
+	return nil
+}
+
+// handleStoreAislesUpdate updates an aisle in a store's layout.
+func (a *App) handleStoreAislesUpdate(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	storeID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	aisleID, err := parseUUIDParam(r, "aisle_id")
+	if err != nil {
+		return err
+	}
+
+	var req aisleRequest
+	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
+		return decodeErr
+	}
+
+	parsed, err := normalizeAisleRequest(req)
+	if err != nil {
+		return err
+	}
+
+	row, err := a.queries.UpdateGroceryAisleByID(r.Context(), sqlc.UpdateGroceryAisleByIDParams{
+		ID:           pgtype.UUID{Bytes: aisleID, Valid: true},
+		Name:         parsed.name,
+		SortGroup:    parsed.sortGroup,
+		SortOrder:    parsed.sortOrder,
+		NumericValue: parsed.numericValue,
+		UpdatedBy:    pgtype.UUID{Bytes: info.UserID, Valid: true},
+		StoreID:      pgtype.UUID{Bytes: storeID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		if isPGUniqueViolation(err) {
+			return errValidationField("name", "name already exists")
+		}
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, aisleResponseFromRow(row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/stores/{id}/aisles/{aisle_id}")
+	}
+	return nil
+}
+
+// handleStoreAislesDelete deletes an aisle from a store's layout along with
+// the item placements in it.
+func (a *App) handleStoreAislesDelete(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	storeID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	aisleID, err := parseUUIDParam(r, "aisle_id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeleteGroceryAisleByID(r.Context(), sqlc.DeleteGroceryAisleByIDParams{
+		ID:      pgtype.UUID{Bytes: aisleID, Valid: true},
+		StoreID: pgtype.UUID{Bytes: storeID, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+	if affected == 0 {
+		return errNotFound()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// handleStoreItemsList lists the items placed in a store's aisles, in aisle
+// order.
+func (a *App) handleStoreItemsList(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	storeID := pgtype.UUID{Bytes: id, Valid: true}
+	if _, err := a.queries.GetStoreByID(ctx, storeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	rows, err := a.queries.ListStoreItemAisles(ctx, storeID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	out := make([]storeItemAisleResponse, 0, len(rows))
+	for _, row := range rows {
+		var numericValue *int
+		if row.AisleNumericValue.Valid {
+			value := int(row.AisleNumericValue.Int32)
+			numericValue = &value
+		}
+		out = append(out, storeItemAisleResponse{
+			ItemID:   uuidString(row.ItemID),
+			ItemName: row.ItemName,
+			Aisle: groceryAisleResponse{
+				ID:           uuidString(row.AisleID),
+				Name:         row.AisleName,
+				SortGroup:    int(row.AisleSortGroup),
+				SortOrder:    int(row.AisleSortOrder),
+				NumericValue: numericValue,
+			},
+		})
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, out); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/stores/{id}/items")
+	}
+	return nil
+}
+
+// handleStoreItemsPut places an item in one of the store's aisles, replacing
+// any earlier placement in that store.
+func (a *App) handleStoreItemsPut(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	storeUUID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	itemUUID, err := parseUUIDParam(r, "item_id")
+	if err != nil {
+		return err
+	}
+
+	var req storeItemAisleRequest
+	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
+		return decodeErr
+	}
+	if strings.TrimSpace(req.AisleID) == "" {
+		return errValidationField("aisle_id", "aisle_id is required")
+	}
+	aisleID, err := uuidPtrToPG(&req.AisleID)
+	if err != nil {
+		return errValidationField("aisle_id", "invalid id")
+	}
+
+	ctx := r.Context()
+	storeID := pgtype.UUID{Bytes: storeUUID, Valid: true}
+	itemID := pgtype.UUID{Bytes: itemUUID, Valid: true}
+	if _, err := a.queries.GetStoreByID(ctx, storeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+	item, err := a.queries.GetItemByID(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+	aisle, err := a.queries.GetGroceryAisleByID(ctx, aisleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errValidationField("aisle_id", "aisle does not exist")
+		}
+		return errInternal(err)
+	}
+	if aisle.StoreID != storeID {
+		return errValidationField("aisle_id", "aisle is not in this store")
+	}
+
+	if _, err := a.queries.UpsertStoreItemAisle(ctx, sqlc.UpsertStoreItemAisleParams{
+		StoreID: storeID,
+		ItemID:  itemID,
+		AisleID: aisleID,
+		UserID:  pgtype.UUID{Bytes: info.UserID, Valid: true},
+	}); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			// The store, item, or aisle was deleted concurrently.
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	resp := storeItemAisleResponse{
+		ItemID:   uuidString(item.ID),
+		ItemName: item.Name,
+		Aisle:    aisleResponseFromRow(aisle),
+	}
+	if err := response.WriteJSON(w, http.StatusOK, resp); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/stores/{id}/items/{item_id}")
+	}
+	return nil
+}
+
+// handleStoreItemsDelete removes an item's placement in a store.
+func (a *App) handleStoreItemsDelete(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	storeID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+	itemID, err := parseUUIDParam(r, "item_id")
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.queries.DeleteStoreItemAisle(r.Context(), sqlc.DeleteStoreItemAisleParams{
+		StoreID: pgtype.UUID{Bytes: storeID, Valid: true},
+		ItemID:  pgtype.UUID{Bytes: itemID, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+	if affected == 0 {
+		return errNotFound()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// storeResponseFromRow maps a store row into a response.
+func storeResponseFromRow(row sqlc.Store) storeResponse {
+	return storeResponse{
+		ID:        uuidString(row.ID),
+		Name:      row.Name,
+		CreatedAt: timeString(row.CreatedAt),
+	}
+}
@@ -0,0 +1,192 @@
+package httpapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saiaj/cooking_app/backend/internal/bootstrap"
+	"github.com/saiaj/cooking_app/backend/internal/config"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi"
+	"github.com/saiaj/cooking_app/backend/internal/logging"
+	"github.com/saiaj/cooking_app/backend/internal/testutil/pgtest"
+)
+
+type storeItemAisleResponse struct {
+	ItemID   string            `json:"item_id"`
+	ItemName string            `json:"item_name"`
+	Aisle    testAisleResponse `json:"aisle"`
+}
+
+func TestStores_PerStoreAisleLayouts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+
+	postgres := pgtest.Start(ctx, t)
+	db := postgres.OpenSQL(ctx, t)
+	postgres.MigrateUp(ctx, t, db)
+
+	pool := postgres.NewPool(ctx, t)
+	queries := sqlc.New(pool)
+
+	if _, err := bootstrap.CreateFirstUser(ctx, queries, bootstrap.FirstUserParams{
+		Username:    "joe",
+		Password:    "pw",
+		DisplayName: nil,
+	}); err != nil {
+		t.Fatalf("bootstrap user: %v", err)
+	}
+
+	app, err := httpapi.New(ctx, logging.New("error"), config.Config{
+		DatabaseURL:         postgres.DatabaseURL,
+		LogLevel:            "error",
+		SessionCookieName:   testSessionCookieName,
+		SessionTTL:          24 * time.Hour,
+		SessionCookieSecure: false,
+		MaxJSONBodyBytes:    2 << 20,
+		StrictJSON:          true,
+	})
+	if err != nil {
+		t.Fatalf("new app: %v", err)
+	}
+	t.Cleanup(app.Close)
+
+	server := httptest.NewServer(app.Handler())
+	t.Cleanup(server.Close)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookie jar: %v", err)
+	}
+	client := &http.Client{Jar: jar}
+	csrf := loginAndGetCSRFToken(t, client, server.URL)
+
+	var produce, dairy testAisleResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/aisles", `{"name":"Produce","sort_group":1,"sort_order":1}`, http.StatusOK, &produce)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/aisles", `{"name":"Dairy","sort_group":1,"sort_order":2}`, http.StatusOK, &dairy)
+
+	var milk, kale namedResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/items", `{"name":"Milk","aisle_id":"`+dairy.ID+`"}`, http.StatusOK, &milk)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/items", `{"name":"Kale","aisle_id":"`+produce.ID+`"}`, http.StatusOK, &kale)
+
+	var store namedResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/stores", `{"name":"Corner Market"}`, http.StatusOK, &store)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/stores", `{"name":"corner market"}`, http.StatusBadRequest, nil)
+	storeURL := server.URL + "/api/v1/stores/" + store.ID
+
+	// Store aisles may reuse default aisle names and stay out of the default list.
+	var storeDairy, storeProduce testAisleResponse
+	doRevisionsRequest(t, client, csrf, http.MethodPost, storeURL+"/aisles", `{"name":"Dairy","sort_group":1,"sort_order":1}`, http.StatusOK, &storeDairy)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, storeURL+"/aisles", `{"name":"Produce","sort_group":1,"sort_order":9}`, http.StatusOK, &storeProduce)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, storeURL+"/aisles", `{"name":"Dairy","sort_group":1,"sort_order":3}`, http.StatusBadRequest, nil)
+	doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/stores/"+uuid.NewString()+"/aisles", `{"name":"Bakery","sort_group":1,"sort_order":1}`, http.StatusNotFound, nil)
+
+	var defaults []testAisleResponse
+	doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/aisles", "", http.StatusOK, &defaults)
+	if len(defaults) != 2 {
+		t.Fatalf("default aisles=%+v, want only the default layout", defaults)
+	}
+	var storeAisles []testAisleResponse
+	doRevisionsRequest(t, client, csrf, http.MethodGet, storeURL+"/aisles", "", http.StatusOK, &storeAisles)
+	if len(storeAisles) != 2 || storeAisles[0].ID != storeDairy.ID {
+		t.Fatalf("store aisles=%+v", storeAisles)
+	}
+
+	t.Run("items cannot use store aisles directly", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/items/"+milk.ID, `{"name":"Milk","aisle_id":"`+storeDairy.ID+`"}`, http.StatusBadRequest, nil)
+	})
+
+	t.Run("place items", func(t *testing.T) {
+		doRevisionsRequest(t, client, csrf, http.MethodPut, storeURL+"/items/"+milk.ID, `{"aisle_id":"`+dairy.ID+`"}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, storeURL+"/items/"+uuid.NewString(), `{"aisle_id":"`+storeDairy.ID+`"}`, http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, storeURL+"/items/"+milk.ID, `{"aisle_id":"`+storeProduce.ID+`"}`, http.StatusOK, nil)
+
+		var placed storeItemAisleResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPut, storeURL+"/items/"+milk.ID, `{"aisle_id":"`+storeDairy.ID+`"}`, http.StatusOK, &placed)
+		if placed.ItemName != "Milk" || placed.Aisle.ID != storeDairy.ID {
+			t.Fatalf("placement=%+v", placed)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPut, storeURL+"/items/"+kale.ID, `{"aisle_id":"`+storeProduce.ID+`"}`, http.StatusOK, nil)
+
+		var placements []storeItemAisleResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, storeURL+"/items", "", http.StatusOK, &placements)
+		if len(placements) != 2 || placements[0].ItemID != milk.ID || placements[1].ItemID != kale.ID {
+			t.Fatalf("placements=%+v, want milk then kale", placements)
+		}
+	})
+
+	t.Run("store aisles are managed under their store", func(t *testing.T) {
+		var bakery testAisleResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, storeURL+"/aisles", `{"name":"Bakery","sort_group":1,"sort_order":5}`, http.StatusOK, &bakery)
+		bakeryURL := storeURL + "/aisles/" + bakery.ID
+
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/aisles/"+bakery.ID, "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/aisles/"+bakery.ID, `{"name":"Bread","sort_group":1,"sort_order":5}`, http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, server.URL+"/api/v1/aisles/"+bakery.ID, "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, server.URL+"/api/v1/stores/"+uuid.NewString()+"/aisles/"+bakery.ID, `{"name":"Bread","sort_group":1,"sort_order":5}`, http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, storeURL+"/aisles/"+dairy.ID, `{"name":"Milk","sort_group":1,"sort_order":2}`, http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, bakeryURL, `{"name":"Dairy","sort_group":1,"sort_order":5}`, http.StatusBadRequest, nil)
+
+		var updated testAisleResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPut, bakeryURL, `{"name":"Bread","sort_group":1,"sort_order":6}`, http.StatusOK, &updated)
+		if updated.ID != bakery.ID || updated.Name != "Bread" || updated.SortOrder != 6 {
+			t.Fatalf("updated=%+v, want Bread at 6", updated)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, storeURL+"/aisles/"+dairy.ID, "", http.StatusNotFound, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, bakeryURL, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, bakeryURL, "", http.StatusNotFound, nil)
+	})
+
+	t.Run("list follows its store layout", func(t *testing.T) {
+		var list testShoppingListResponse
+		doRevisionsRequest(t, client, csrf, http.MethodPost, server.URL+"/api/v1/shopping-lists", `{"list_date":"2025-02-09","name":"Shop","notes":null}`, http.StatusCreated, &list)
+		listURL := server.URL + "/api/v1/shopping-lists/" + list.ID
+		doRevisionsRequest(t, client, csrf, http.MethodPost, listURL+"/items",
+			`{"items":[{"item_id":"`+milk.ID+`","quantity":1},{"item_id":"`+kale.ID+`","quantity":1}]}`, http.StatusOK, nil)
+
+		var detail testShoppingListDetailResponse
+		doRevisionsRequest(t, client, csrf, http.MethodGet, listURL, "", http.StatusOK, &detail)
+		if detail.StoreID != nil || detail.Items[0].Item.ID != kale.ID || detail.Items[0].Item.Aisle.ID != produce.ID {
+			t.Fatalf("default layout=%+v, want kale first", detail)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodPut, listURL, `{"list_date":"2025-02-09","name":"Shop","notes":null,"store_id":"`+uuid.NewString()+`"}`, http.StatusBadRequest, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodPut, listURL, `{"list_date":"2025-02-09","name":"Shop","notes":null,"store_id":"`+store.ID+`"}`, http.StatusOK, &list)
+		if list.StoreID == nil || *list.StoreID != store.ID {
+			t.Fatalf("store_id=%v, want %s", list.StoreID, store.ID)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPut, listURL, `{"list_date":"2025-02-09","name":"Weekly shop","notes":null}`, http.StatusOK, &list)
+		if list.StoreID == nil || *list.StoreID != store.ID {
+			t.Fatalf("store_id after omitting it=%v, want %s kept", list.StoreID, store.ID)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPut, listURL, `{"list_date":"2025-02-09","name":"Shop","notes":null,"store_id":null}`, http.StatusOK, &list)
+		if list.StoreID != nil {
+			t.Fatalf("store_id after null=%v, want cleared", list.StoreID)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodPut, listURL, `{"list_date":"2025-02-09","name":"Shop","notes":null,"store_id":"`+store.ID+`"}`, http.StatusOK, &list)
+
+		doRevisionsRequest(t, client, csrf, http.MethodGet, listURL, "", http.StatusOK, &detail)
+		if detail.Items[0].Item.ID != milk.ID || detail.Items[0].Item.Aisle.ID != storeDairy.ID {
+			t.Fatalf("store layout=%+v, want milk first in the store's dairy aisle", detail.Items)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, storeURL+"/items/"+milk.ID, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodGet, listURL, "", http.StatusOK, &detail)
+		if detail.Items[0].Item.ID != milk.ID || detail.Items[0].Item.Aisle == nil || detail.Items[0].Item.Aisle.ID != dairy.ID {
+			t.Fatalf("unplaced=%+v, want milk in its default dairy aisle", detail.Items)
+		}
+
+		doRevisionsRequest(t, client, csrf, http.MethodDelete, storeURL, "", http.StatusNoContent, nil)
+		doRevisionsRequest(t, client, csrf, http.MethodGet, listURL, "", http.StatusOK, &detail)
+		if detail.StoreID != nil || detail.Items[0].Item.Aisle == nil || detail.Items[0].Item.Aisle.ID != produce.ID {
+			t.Fatalf("after store delete=%+v, want the default layout", detail)
+		}
+		doRevisionsRequest(t, client, csrf, http.MethodGet, server.URL+"/api/v1/aisles/"+storeDairy.ID, "", http.StatusNotFound, nil)
+	})
+}
@@ -31,7 +31,13 @@ func cookieValue(t *testing.T, jar http.CookieJar, baseURL, name string) string
 func loginAndGetCSRFToken(t *testing.T, client *http.Client, baseURL string) string {
 	t.Helper()
 
-	resp, err := client.Post(baseURL+"/api/v1/auth/login", "application/json", strings.NewReader(`{"username":"joe","password":"pw"}`))
+	return loginAsAndGetCSRFToken(t, client, baseURL, `{"username":"joe","password":"pw"}`)
+}
+
+func loginAsAndGetCSRFToken(t *testing.T, client *http.Client, baseURL, credentials string) string {
+	t.Helper()
+
+	resp, err := client.Post(baseURL+"/api/v1/auth/login", "application/json", strings.NewReader(credentials))
 	if err != nil {
 		t.Fatalf("post login: %v", err)
 	}
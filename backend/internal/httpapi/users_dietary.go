@@ -0,0 +1,138 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/saiaj/cooking_app/backend/internal/db/sqlc"
+	"github.com/saiaj/cooking_app/backend/internal/httpapi/response"
+)
+
+// userDietaryRestrictionsRequest replaces a user's declared restrictions.
+// Omitted lists are read as empty.
+type userDietaryRestrictionsRequest struct {
+	Diets     []string `json:"diets"`
+	Allergens []string `json:"allergens"`
+}
+
+type userDietaryRestrictionsResponse struct {
+	UserID    string   `json:"user_id"`
+	Diets     []string `json:"diets"`
+	Allergens []string `json:"allergens"`
+	UpdatedAt *string  `json:"updated_at"`
+}
+
+// handleUserDietaryRestrictionsGet returns the diets and allergens a user has
+// declared. Users who never declared any get empty lists.
+func (a *App) handleUserDietaryRestrictionsGet(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authInfoFromRequest(r); !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	userID := pgtype.UUID{Bytes: id, Valid: true}
+	if _, err := a.queries.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	restrictions, err := a.loadUserDietaryRestrictions(ctx, userID)
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, userDietaryRestrictionsResponseFromRow(userID, restrictions)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/users/{id}/dietary-restrictions")
+	}
+	return nil
+}
+
+// handleUserDietaryRestrictionsPut replaces the diets and allergens a user has
+// declared.
+func (a *App) handleUserDietaryRestrictionsPut(w http.ResponseWriter, r *http.Request) error {
+	info, ok := authInfoFromRequest(r)
+	if !ok {
+		return errUnauthorized("unauthorized")
+	}
+
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req userDietaryRestrictionsRequest
+	if decodeErr := a.decodeJSON(w, r, &req); decodeErr != nil {
+		return decodeErr
+	}
+	diets, invalid, ok := normalizeDietaryValues(req.Diets, dietNames)
+	if !ok {
+		return errValidationField("diets", "unknown diet "+invalid)
+	}
+	allergens, invalid, ok := normalizeDietaryValues(req.Allergens, dietaryAllergens)
+	if !ok {
+		return errValidationField("allergens", "unknown allergen "+invalid)
+	}
+
+	ctx := r.Context()
+	userID := pgtype.UUID{Bytes: id, Valid: true}
+	if _, err := a.queries.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNotFound()
+		}
+		return errInternal(err)
+	}
+
+	row, err := a.queries.UpsertUserDietaryRestrictions(ctx, sqlc.UpsertUserDietaryRestrictionsParams{
+		UserID:    userID,
+		Diets:     diets,
+		Allergens: allergens,
+		UpdatedBy: pgtype.UUID{Bytes: info.UserID, Valid: true},
+	})
+	if err != nil {
+		return errInternal(err)
+	}
+
+	if err := response.WriteJSON(w, http.StatusOK, userDietaryRestrictionsResponseFromRow(userID, row)); err != nil {
+		a.logger.Warn("write failed", "err", err, "path", "/api/v1/users/{id}/dietary-restrictions")
+	}
+	return nil
+}
+
+// loadUserDietaryRestrictions returns a user's restrictions, or an empty row
+// when none were declared.
+func (a *App) loadUserDietaryRestrictions(ctx context.Context, userID pgtype.UUID) (sqlc.UserDietaryRestriction, error) {
+	row, err := a.queries.GetUserDietaryRestrictions(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.UserDietaryRestriction{UserID: userID}, nil
+		}
+		return sqlc.UserDietaryRestriction{}, err
+	}
+	return row, nil
+}
+
+func userDietaryRestrictionsResponseFromRow(userID pgtype.UUID, row sqlc.UserDietaryRestriction) userDietaryRestrictionsResponse {
+	resp := userDietaryRestrictionsResponse{
+		UserID:    uuidString(userID),
+		Diets:     row.Diets,
+		Allergens: row.Allergens,
+		UpdatedAt: timeStringPtr(row.UpdatedAt),
+	}
+	if resp.Diets == nil {
+		resp.Diets = []string{}
+	}
+	if resp.Allergens == nil {
+		resp.Allergens = []string{}
+	}
+	return resp
+}
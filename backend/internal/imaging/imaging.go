@@ -0,0 +1,160 @@
+// Package imaging validates uploaded images and renders JPEG thumbnails using
+// the standard library decoders (JPEG, PNG, GIF).
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register GIF decoder
+	"image/jpeg"
+	_ "image/png" // register PNG decoder
+	"net/http"
+)
+
+// MaxPixels bounds the decoded size of an upload to guard against
+// decompression bombs.
+const MaxPixels = 40_000_000
+
+// ThumbnailContentType is the content type of every generated thumbnail.
+const ThumbnailContentType = "image/jpeg"
+
+var (
+	// ErrUnsupportedType is returned for uploads that are not JPEG, PNG, or GIF.
+	ErrUnsupportedType = errors.New("unsupported image type")
+	// ErrTooManyPixels is returned when an image exceeds MaxPixels.
+	ErrTooManyPixels = errors.New("image dimensions too large")
+)
+
+// formatsByContentType maps sniffed content types to decoder format names.
+var formatsByContentType = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// Info describes an uploaded image.
+type Info struct {
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Extension returns the file extension conventionally used for the type.
+func (i Info) Extension() string {
+	switch i.ContentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	default:
+		return ""
+	}
+}
+
+// Inspect sniffs the content type from the data itself and reads the image
+// dimensions without decoding pixel data.
+func Inspect(data []byte) (Info, error) {
+	contentType := http.DetectContentType(data)
+	format, ok := formatsByContentType[contentType]
+	if !ok {
+		return Info{}, ErrUnsupportedType
+	}
+
+	cfg, decodedFormat, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decodedFormat != format {
+		return Info{}, ErrUnsupportedType
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return Info{}, ErrUnsupportedType
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return Info{}, ErrTooManyPixels
+	}
+
+	return Info{ContentType: contentType, Width: cfg.Width, Height: cfg.Height}, nil
+}
+
+// Thumbnail decodes data and returns a JPEG that fits within maxSize on both
+// sides. Images that already fit are re-encoded at their original size.
+// Transparent pixels are flattened onto white.
+func Thumbnail(data []byte, maxSize int) ([]byte, error) {
+	if maxSize <= 0 {
+		return nil, errors.New("thumbnail size must be positive")
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, downscale(src, maxSize), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// thumbnailSize scales width and height to fit within maxSize, keeping the
+// aspect ratio and never upscaling.
+func thumbnailSize(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+	if width >= height {
+		return maxSize, max(1, height*maxSize/width)
+	}
+	return max(1, width*maxSize/height), maxSize
+}
+
+// samplesPerAxis caps how many source pixels are averaged per destination
+// pixel along each axis, keeping large images cheap to thumbnail.
+const samplesPerAxis = 4
+
+// downscale resizes src with a sampled box filter.
+func downscale(src image.Image, maxSize int) *image.RGBA {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	dw, dh := thumbnailSize(sw, sh, maxSize)
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for dy := range dh {
+		y0 := bounds.Min.Y + dy*sh/dh
+		y1 := max(y0+1, bounds.Min.Y+(dy+1)*sh/dh)
+		for dx := range dw {
+			x0 := bounds.Min.X + dx*sw/dw
+			x1 := max(x0+1, bounds.Min.X+(dx+1)*sw/dw)
+			dst.SetRGBA(dx, dy, sampleBox(src, x0, y0, x1, y1))
+		}
+	}
+	return dst
+}
+
+// sampleBox averages up to samplesPerAxis² pixels evenly spread over the
+// box [x0,x1)×[y0,y1), compositing them onto a white background.
+func sampleBox(src image.Image, x0, y0, x1, y1 int) color.RGBA {
+	stepX := max(1, (x1-x0)/samplesPerAxis)
+	stepY := max(1, (y1-y0)/samplesPerAxis)
+
+	var r, g, b, n uint64
+	for y := y0; y < y1; y += stepY {
+		for x := x0; x < x1; x += stepX {
+			pr, pg, pb, pa := src.At(x, y).RGBA()
+			// Colors are alpha-premultiplied, so adding the missing alpha
+			// composites the pixel over white.
+			r += uint64(pr + 0xffff - pa)
+			g += uint64(pg + 0xffff - pa)
+			b += uint64(pb + 0xffff - pa)
+			n++
+		}
+	}
+	return color.RGBA{
+		R: uint8(r / n >> 8), //nolint:gosec // average of 16-bit channels fits in 8 bits after shifting
+		G: uint8(g / n >> 8), //nolint:gosec // see above
+		B: uint8(b / n >> 8), //nolint:gosec // see above
+		A: 0xff,
+	}
+}
@@ -0,0 +1,91 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func TestInspectDetectsTypeAndSize(t *testing.T) {
+	t.Parallel()
+
+	info, err := Inspect(encodePNG(t, 640, 480))
+	if err != nil {
+		t.Fatalf("inspect: %v", err)
+	}
+	if info.ContentType != "image/png" || info.Width != 640 || info.Height != 480 {
+		t.Fatalf("info=%+v, want image/png 640x480", info)
+	}
+	if info.Extension() != ".png" {
+		t.Fatalf("extension=%q, want .png", info.Extension())
+	}
+}
+
+func TestInspectRejectsNonImages(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range [][]byte{
+		[]byte("not an image"),
+		[]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"),
+		append([]byte("\x89PNG\r\n\x1a\n"), []byte("truncated")...),
+	} {
+		if _, err := Inspect(data); !errors.Is(err, ErrUnsupportedType) {
+			t.Fatalf("inspect %q err=%v, want ErrUnsupportedType", data, err)
+		}
+	}
+}
+
+func TestThumbnailFitsWithinBounds(t *testing.T) {
+	t.Parallel()
+
+	thumb, err := Thumbnail(encodePNG(t, 800, 200), 320)
+	if err != nil {
+		t.Fatalf("thumbnail: %v", err)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(thumb))
+	if err != nil {
+		t.Fatalf("decode thumbnail: %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(320, 80) {
+		t.Fatalf("size=%v, want 320x80", got)
+	}
+}
+
+func TestThumbnailSizeNeverUpscales(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		w, h, max    int
+		wantW, wantH int
+	}{
+		{w: 100, h: 50, max: 320, wantW: 100, wantH: 50},
+		{w: 1000, h: 2000, max: 320, wantW: 160, wantH: 320},
+		{w: 5000, h: 1, max: 320, wantW: 320, wantH: 1},
+	}
+	for _, tt := range tests {
+		w, h := thumbnailSize(tt.w, tt.h, tt.max)
+		if w != tt.wantW || h != tt.wantH {
+			t.Fatalf("thumbnailSize(%d,%d,%d)=%dx%d, want %dx%d", tt.w, tt.h, tt.max, w, h, tt.wantW, tt.wantH)
+		}
+	}
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255}) //nolint:gosec // test pattern
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
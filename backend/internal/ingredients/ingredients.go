@@ -0,0 +1,279 @@
+// Package ingredients parses free-text ingredient lines such as
+// "1 1/2 cups finely chopped onion, divided" into structured fields.
+package ingredients
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/saiaj/cooking_app/backend/internal/units"
+)
+
+// Line is a parsed ingredient line. Empty strings mean the part was absent.
+type Line struct {
+	// Quantity is nil when the line has no amount or gives a range.
+	Quantity *float64
+	// QuantityText is the amount as written, with unicode fractions expanded
+	// ("1 1/2", "2-3").
+	QuantityText string
+	// Unit is the canonical unit name from the units registry.
+	Unit string
+	Item string
+	Prep string
+	// Notes collects parenthetical sizes and trailing remarks such as
+	// "divided" or "to taste".
+	Notes        string
+	OriginalText string
+}
+
+var unicodeFractions = map[rune]string{
+	'¼': "1/4", '½': "1/2", '¾': "3/4",
+	'⅐': "1/7", '⅑': "1/9", '⅒': "1/10",
+	'⅓': "1/3", '⅔': "2/3",
+	'⅕': "1/5", '⅖': "2/5", '⅗': "3/5", '⅘': "4/5",
+	'⅙': "1/6", '⅚': "5/6",
+	'⅛': "1/8", '⅜': "3/8", '⅝': "5/8", '⅞': "7/8",
+}
+
+const numberExpr = `(?:\d+\s+\d+/\d+|\d+/\d+|\d*\.\d+|\d+)`
+
+var (
+	quantityPattern     = regexp.MustCompile(`^(` + numberExpr + `)(?:\s*(?:-|to|or)\s*(` + numberExpr + `))?`)
+	parenPattern        = regexp.MustCompile(`\(([^()]*)\)`)
+	spacePattern        = regexp.MustCompile(`\s+`)
+	trailingNotePattern = regexp.MustCompile(`(?i)\s+(to taste|for garnish|for serving|optional)$`)
+)
+
+// prepWords are participles that describe how an ingredient is prepared.
+var prepWords = map[string]struct{}{
+	"beaten": {}, "blanched": {}, "chilled": {}, "chopped": {}, "cooked": {},
+	"crumbled": {}, "crushed": {}, "cubed": {}, "deveined": {}, "diced": {},
+	"drained": {}, "grated": {}, "halved": {}, "julienned": {}, "mashed": {},
+	"melted": {}, "minced": {}, "packed": {}, "peeled": {}, "pitted": {},
+	"quartered": {}, "rinsed": {}, "seeded": {}, "shredded": {}, "sifted": {},
+	"sliced": {}, "softened": {}, "toasted": {}, "trimmed": {}, "zested": {},
+}
+
+// noteLeaders start comma clauses that are remarks rather than preparation.
+var noteLeaders = map[string]struct{}{
+	"about": {}, "at": {}, "divided": {}, "for": {}, "if": {}, "optional": {},
+	"or": {}, "plus": {}, "preferably": {}, "such": {}, "to": {},
+}
+
+// sizeWords are kept out of the item name so "2 large eggs" resolves to eggs.
+var sizeWords = map[string]struct{}{
+	"small": {}, "medium": {}, "large": {}, "extra-large": {}, "jumbo": {},
+}
+
+// Parse splits an ingredient line into its parts. It never fails: text it
+// cannot place ends up in Item.
+func Parse(text string) Line {
+	line := Line{OriginalText: strings.TrimSpace(text)}
+	s := normalize(line.OriginalText)
+
+	var notes []string
+	for _, m := range parenPattern.FindAllStringSubmatch(s, -1) {
+		if note := strings.TrimSpace(m[1]); note != "" {
+			notes = append(notes, note)
+		}
+	}
+	s = collapse(parenPattern.ReplaceAllString(s, " "))
+
+	// "salt to taste" carries its remark without a comma.
+	var trailingNote string
+	if m := trailingNotePattern.FindStringSubmatch(s); m != nil && !strings.Contains(s, ",") {
+		s = strings.TrimSpace(s[:len(s)-len(m[0])])
+		trailingNote = strings.ToLower(m[1])
+	}
+
+	head, tail, _ := strings.Cut(s, ",")
+	head = strings.TrimSpace(head)
+
+	head = line.parseQuantity(head)
+	head = line.parseUnit(head)
+
+	words := strings.Fields(head)
+	var size []string
+	for len(words) > 0 {
+		if _, ok := sizeWords[strings.ToLower(words[0])]; !ok {
+			break
+		}
+		size = append(size, words[0])
+		words = words[1:]
+	}
+	prepCount := leadingPrepWords(words)
+	var prep []string
+	if prepCount > 0 {
+		prep = append(prep, strings.Join(words[:prepCount], " "))
+		words = words[prepCount:]
+	}
+	line.Item = strings.Join(words, " ")
+	if len(size) > 0 {
+		notes = append([]string{strings.ToLower(strings.Join(size, " "))}, notes...)
+	}
+
+	for _, clause := range strings.Split(tail, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		first, _, _ := strings.Cut(strings.ToLower(clause), " ")
+		if _, ok := noteLeaders[first]; ok {
+			notes = append(notes, clause)
+		} else {
+			prep = append(prep, clause)
+		}
+	}
+
+	if trailingNote != "" {
+		notes = append(notes, trailingNote)
+	}
+	line.Prep = strings.Join(prep, ", ")
+	line.Notes = strings.Join(notes, ", ")
+	return line
+}
+
+// parseQuantity consumes a leading amount or range and returns the rest.
+func (l *Line) parseQuantity(s string) string {
+	m := quantityPattern.FindStringSubmatchIndex(s)
+	if m == nil {
+		// "a pinch of salt" reads as one pinch.
+		article, rest, ok := strings.Cut(s, " ")
+		if ok && (strings.EqualFold(article, "a") || strings.EqualFold(article, "an")) && startsWithUnit(rest) {
+			one := 1.0
+			l.Quantity = &one
+			l.QuantityText = article
+			return rest
+		}
+		return s
+	}
+	l.QuantityText = s[m[0]:m[1]]
+	if m[4] < 0 {
+		if value, ok := parseNumber(s[m[2]:m[3]]); ok {
+			l.Quantity = &value
+		}
+	} else {
+		l.QuantityText = s[m[2]:m[3]] + "-" + s[m[4]:m[5]]
+	}
+	return strings.TrimSpace(s[m[1]:])
+}
+
+// parseUnit consumes a leading unit, preferring two-word names such as
+// "fl oz", plus an optional "of".
+func (l *Line) parseUnit(s string) string {
+	if l.QuantityText == "" {
+		return s
+	}
+	words := strings.Fields(s)
+	for n := min(2, len(words)); n >= 1; n-- {
+		unit, ok := units.Lookup(strings.Join(words[:n], " "))
+		if !ok {
+			continue
+		}
+		// A lone "c" is only a cup when more text follows.
+		if n == len(words) {
+			return s
+		}
+		l.Unit = unit.Name
+		rest := words[n:]
+		if len(rest) > 1 && strings.EqualFold(rest[0], "of") {
+			rest = rest[1:]
+		}
+		return strings.Join(rest, " ")
+	}
+	return s
+}
+
+func startsWithUnit(s string) bool {
+	words := strings.Fields(s)
+	for n := min(2, len(words)); n >= 1; n-- {
+		if _, ok := units.Lookup(strings.Join(words[:n], " ")); ok {
+			return n < len(words)
+		}
+	}
+	return false
+}
+
+// leadingPrepWords counts the words at the start of an item that describe
+// preparation, e.g. "finely chopped" or "peeled and diced". Adverbs are only
+// taken when a participle follows, so "freshly ground pepper" stays intact.
+func leadingPrepWords(words []string) int {
+	count := 0
+	for i, word := range words {
+		lower := strings.ToLower(word)
+		if _, ok := prepWords[lower]; ok {
+			count = i + 1
+			continue
+		}
+		if strings.HasSuffix(lower, "ly") || (lower == "and" && i > 0) {
+			continue
+		}
+		break
+	}
+	// Always leave at least one word for the item.
+	if count >= len(words) {
+		return 0
+	}
+	return count
+}
+
+// parseNumber parses "3", "1.5", "1/2", or "1 1/2".
+func parseNumber(s string) (float64, bool) {
+	whole, frac, hasWhole := strings.Cut(s, " ")
+	if !hasWhole {
+		frac = whole
+		whole = ""
+	}
+	var total float64
+	if whole != "" {
+		n, err := strconv.ParseFloat(whole, 64)
+		if err != nil {
+			return 0, false
+		}
+		total = n
+	}
+	if num, den, ok := strings.Cut(frac, "/"); ok {
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, false
+		}
+		d, err := strconv.ParseFloat(den, 64)
+		if err != nil || d == 0 {
+			return 0, false
+		}
+		return total + n/d, true
+	}
+	n, err := strconv.ParseFloat(frac, 64)
+	if err != nil {
+		return 0, false
+	}
+	return total + n, true
+}
+
+// normalize expands unicode fractions and dashes so the patterns above only
+// deal with ASCII.
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if frac, ok := unicodeFractions[r]; ok {
+			b.WriteByte(' ')
+			b.WriteString(frac)
+			b.WriteByte(' ')
+			continue
+		}
+		switch r {
+		case '⁄':
+			b.WriteByte('/')
+		case '–', '—':
+			b.WriteByte('-')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return collapse(b.String())
+}
+
+func collapse(s string) string {
+	return strings.TrimSpace(spacePattern.ReplaceAllString(s, " "))
+}
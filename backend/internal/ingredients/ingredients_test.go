@@ -0,0 +1,56 @@
+package ingredients
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in           string
+		quantity     float64
+		hasQuantity  bool
+		quantityText string
+		unit         string
+		item         string
+		prep         string
+		notes        string
+	}{
+		{
+			in: "1 1/2 cups finely chopped onion, divided", quantity: 1.5, hasQuantity: true,
+			quantityText: "1 1/2", unit: "cup", item: "onion", prep: "finely chopped", notes: "divided",
+		},
+		{in: "1½ tsp. salt", quantity: 1.5, hasQuantity: true, quantityText: "1 1/2", unit: "tsp", item: "salt"},
+		{in: "¾ cup of milk", quantity: 0.75, hasQuantity: true, quantityText: "3/4", unit: "cup", item: "milk"},
+		{in: "2-3 cloves garlic, minced", quantityText: "2-3", unit: "clove", item: "garlic", prep: "minced"},
+		{in: "2 to 3 tablespoons olive oil", quantityText: "2-3", unit: "tbsp", item: "olive oil"},
+		{in: "1–2 carrots", quantityText: "1-2", item: "carrots"},
+		{
+			in: "1 (14.5 oz) can diced tomatoes, drained", quantity: 1, hasQuantity: true,
+			quantityText: "1", unit: "can", item: "tomatoes", prep: "diced, drained", notes: "14.5 oz",
+		},
+		{in: "2 large eggs, beaten", quantity: 2, hasQuantity: true, quantityText: "2", item: "eggs", prep: "beaten", notes: "large"},
+		{in: "3 potatoes, peeled and cubed", quantity: 3, hasQuantity: true, quantityText: "3", item: "potatoes", prep: "peeled and cubed"},
+		{in: "0.5 fl oz vanilla extract", quantity: 0.5, hasQuantity: true, quantityText: "0.5", unit: "fl oz", item: "vanilla extract"},
+		{in: "a pinch of salt", quantity: 1, hasQuantity: true, quantityText: "a", unit: "pinch", item: "salt"},
+		{in: "freshly ground black pepper", item: "freshly ground black pepper"},
+		{in: "salt and pepper to taste", item: "salt and pepper", notes: "to taste"},
+		{in: "Kosher salt, for serving", item: "Kosher salt", notes: "for serving"},
+		{in: "chopped", item: "chopped"},
+		{in: "2 cups", quantity: 2, hasQuantity: true, quantityText: "2", item: "cups"},
+	}
+	for _, tt := range tests {
+		got := Parse(tt.in)
+		if got.OriginalText != tt.in {
+			t.Fatalf("Parse(%q).OriginalText=%q", tt.in, got.OriginalText)
+		}
+		if (got.Quantity != nil) != tt.hasQuantity || (got.Quantity != nil && *got.Quantity != tt.quantity) {
+			t.Fatalf("Parse(%q).Quantity=%v, want %v (set=%t)", tt.in, got.Quantity, tt.quantity, tt.hasQuantity)
+		}
+		if got.QuantityText != tt.quantityText || got.Unit != tt.unit || got.Item != tt.item || got.Prep != tt.prep || got.Notes != tt.notes {
+			t.Fatalf("Parse(%q)=%+v\nwant qtext=%q unit=%q item=%q prep=%q notes=%q",
+				tt.in, got, tt.quantityText, tt.unit, tt.item, tt.prep, tt.notes)
+		}
+	}
+}
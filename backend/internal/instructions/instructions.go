@@ -0,0 +1,160 @@
+// Package instructions extracts cooking times and oven temperatures from
+// free-text recipe steps such as "Bake 25–30 minutes at 400°F".
+package instructions
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Cues are the timing and temperature mentioned in a step. Nil fields were
+// not found.
+type Cues struct {
+	DurationSeconds *int32
+	// DurationMaxSeconds is set when the step gives a range ("25-30 minutes").
+	DurationMaxSeconds *int32
+	Temperature        *int32
+	// TemperatureUnit is "F" or "C" when Temperature is set.
+	TemperatureUnit string
+}
+
+const numberExpr = `(?:\d+\s+\d+/\d+|\d+/\d+|\d*\.\d+|\d+)`
+
+var (
+	durationPattern = regexp.MustCompile(`(?i)\b(` + numberExpr + `|an?|one)(?:\s*(?:-|to|or)\s*(` + numberExpr + `))?\s*` +
+		`(hours?|hrs?|h|minutes?|mins?|seconds?|secs?)\b`)
+	// Temperatures need a degree marker or a unit glued to the number, so
+	// "2 c flour" is not read as Celsius.
+	temperaturePattern = regexp.MustCompile(`(?i)\b(\d{2,3})(?:\s*(?:°|º|degrees?|deg\.?)\s*|)(fahrenheit|celsius|f|c)\b`)
+	// A unit after a space ("350 F") only counts when it is a capital letter
+	// or spelled out, for the same reason.
+	spacedTemperaturePattern = regexp.MustCompile(`\b(\d{2,3})\s+(F|C|(?i:fahrenheit|celsius))\b`)
+	compoundSeparator        = regexp.MustCompile(`(?i)^\s*,?\s*(?:and\s+)?$`)
+)
+
+var unitSeconds = map[string]float64{
+	"h": 3600, "hr": 3600, "hrs": 3600, "hour": 3600, "hours": 3600,
+	"min": 60, "mins": 60, "minute": 60, "minutes": 60,
+	"sec": 1, "secs": 1, "second": 1, "seconds": 1,
+}
+
+// Extract finds the first duration and the first temperature in a step.
+// Adjacent amounts ("1 hour 15 minutes") are added together. It never fails:
+// text it cannot read yields empty Cues.
+func Extract(text string) Cues {
+	s := strings.NewReplacer("–", "-", "—", "-").Replace(text)
+
+	var cues Cues
+	extractDuration(s, &cues)
+
+	extractTemperature(s, &cues)
+	return cues
+}
+
+// extractTemperature takes whichever temperature form appears first.
+func extractTemperature(s string, cues *Cues) {
+	m := temperaturePattern.FindStringSubmatchIndex(s)
+	if spaced := spacedTemperaturePattern.FindStringSubmatchIndex(s); spaced != nil && (m == nil || spaced[0] < m[0]) {
+		m = spaced
+	}
+	if m == nil {
+		return
+	}
+	if value, err := strconv.ParseInt(s[m[2]:m[3]], 10, 32); err == nil {
+		temperature := int32(value)
+		cues.Temperature = &temperature
+		cues.TemperatureUnit = strings.ToUpper(s[m[4] : m[4]+1])
+	}
+}
+
+func extractDuration(s string, cues *Cues) {
+	matches := durationPattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return
+	}
+
+	total, ok := durationAt(s, matches[0], 1)
+	if !ok {
+		return
+	}
+	if matches[0][4] >= 0 {
+		// A range ends the phrase; its upper bound becomes the max.
+		if upper, upperOK := durationAt(s, matches[0], 2); upperOK && upper > total {
+			setDuration(cues, total, upper)
+			return
+		}
+		setDuration(cues, total, 0)
+		return
+	}
+
+	end := matches[0][1]
+	for _, m := range matches[1:] {
+		if m[4] >= 0 || !compoundSeparator.MatchString(s[end:m[0]]) {
+			break
+		}
+		extra, extraOK := durationAt(s, m, 1)
+		if !extraOK {
+			break
+		}
+		total += extra
+		end = m[1]
+	}
+	setDuration(cues, total, 0)
+}
+
+// durationAt returns the seconds for the amount in capture group group.
+func durationAt(s string, m []int, group int) (float64, bool) {
+	amount, ok := parseAmount(s[m[2*group]:m[2*group+1]])
+	if !ok {
+		return 0, false
+	}
+	unit := strings.ToLower(s[m[6]:m[7]])
+	return amount * unitSeconds[unit], true
+}
+
+func setDuration(cues *Cues, seconds, maxSeconds float64) {
+	if seconds > math.MaxInt32 {
+		return
+	}
+	lower := int32(math.Round(seconds))
+	if lower <= 0 {
+		return
+	}
+	cues.DurationSeconds = &lower
+	if maxSeconds > 0 && maxSeconds <= math.MaxInt32 {
+		upper := int32(math.Round(maxSeconds))
+		cues.DurationMaxSeconds = &upper
+	}
+}
+
+// parseAmount reads "2", "1.5", "1/2", "1 1/2", or "a"/"an"/"one".
+func parseAmount(s string) (float64, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "a", "an", "one":
+		return 1, true
+	}
+	whole := 0.0
+	if parts := strings.Fields(s); len(parts) == 2 {
+		w, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			return 0, false
+		}
+		whole, s = w, parts[1]
+	}
+	if num, den, isFraction := strings.Cut(s, "/"); isFraction {
+		n, nErr := strconv.ParseFloat(num, 64)
+		d, dErr := strconv.ParseFloat(den, 64)
+		if nErr != nil || dErr != nil || d == 0 {
+			return 0, false
+		}
+		return whole + n/d, true
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return whole + value, true
+}
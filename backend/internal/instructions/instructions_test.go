@@ -0,0 +1,49 @@
+package instructions
+
+import (
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in          string
+		duration    int32
+		durationMax int32
+		temperature int32
+		unit        string
+	}{
+		{in: "Bake 25–30 minutes at 400°F.", duration: 1500, durationMax: 1800, temperature: 400, unit: "F"},
+		{in: "Roast at 200 °C for 1 hour 15 minutes", duration: 4500, temperature: 200, unit: "C"},
+		{in: "Simmer for 1 1/2 hours, stirring occasionally.", duration: 5400},
+		{in: "Let rest 10 to 12 mins, then slice.", duration: 600, durationMax: 720},
+		{in: "Preheat the oven to 350 degrees Fahrenheit.", temperature: 350, unit: "F"},
+		{in: "Cook for a minute and 30 seconds.", duration: 90},
+		{in: "Whisk in 2 c flour until smooth."},
+		{in: "Microwave 45 sec, stir, then 30 sec more.", duration: 45},
+		{in: "Bake at 425F for 20 minutes, rotating after 10 minutes.", duration: 1200, temperature: 425, unit: "F"},
+		{in: "Bake at 350 F until golden, about 40 minutes.", duration: 2400, temperature: 350, unit: "F"},
+		{in: "Heat the oil to 180 C.", temperature: 180, unit: "C"},
+		{in: "Roast at 220 celsius for 25 minutes", duration: 1500, temperature: 220, unit: "C"},
+		{in: "Fold in 10 c chopped herbs."},
+		{in: "Season to taste."},
+	}
+	for _, tt := range tests {
+		got := Extract(tt.in)
+		if int32Value(got.DurationSeconds) != tt.duration || int32Value(got.DurationMaxSeconds) != tt.durationMax {
+			t.Fatalf("Extract(%q) duration=%v..%v, want %d..%d",
+				tt.in, got.DurationSeconds, got.DurationMaxSeconds, tt.duration, tt.durationMax)
+		}
+		if int32Value(got.Temperature) != tt.temperature || got.TemperatureUnit != tt.unit {
+			t.Fatalf("Extract(%q) temperature=%v%s, want %d%s", tt.in, got.Temperature, got.TemperatureUnit, tt.temperature, tt.unit)
+		}
+	}
+}
+
+func int32Value(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,286 @@
+// Package schemaorg extracts schema.org Recipe data from JSON-LD documents and
+// from HTML pages that embed them in <script type="application/ld+json">.
+package schemaorg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoRecipe is returned when the input contains no schema.org Recipe.
+var ErrNoRecipe = errors.New("no schema.org Recipe found")
+
+// Recipe holds the fields the importer understands, normalized to plain text.
+type Recipe struct {
+	Name        string
+	Description string
+	URL         string
+	// Servings is zero when the page does not state a yield.
+	Servings     int
+	PrepMinutes  int
+	TotalMinutes int
+	Ingredients  []string
+	Instructions []string
+}
+
+var (
+	jsonLDScriptPattern = regexp.MustCompile(`(?is)<script\b[^>]*\btype\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script\s*>`)
+	tagPattern          = regexp.MustCompile(`<[^>]*>`)
+	spacePattern        = regexp.MustCompile(`\s+`)
+	punctSpacePattern   = regexp.MustCompile(`\s+([.,;:!?])`)
+	numberPattern       = regexp.MustCompile(`\d+`)
+	durationPattern     = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+)
+
+// ExtractJSONLD returns the contents of every JSON-LD script block in doc.
+func ExtractJSONLD(doc []byte) [][]byte {
+	matches := jsonLDScriptPattern.FindAllSubmatch(doc, -1)
+	blocks := make([][]byte, 0, len(matches))
+	for _, m := range matches {
+		block := bytes.TrimSpace(m[1])
+		// Some sites wrap the JSON in an HTML comment or CDATA section.
+		block = bytes.TrimPrefix(block, []byte("<!--"))
+		block = bytes.TrimSuffix(block, []byte("-->"))
+		block = bytes.TrimPrefix(block, []byte("//<![CDATA["))
+		block = bytes.TrimSuffix(block, []byte("//]]>"))
+		if block = bytes.TrimSpace(block); len(block) > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// ParseHTML finds the first schema.org Recipe embedded in an HTML page.
+// Malformed JSON-LD blocks are skipped.
+func ParseHTML(doc []byte) (Recipe, error) {
+	for _, block := range ExtractJSONLD(doc) {
+		recipe, err := ParseJSONLD(block)
+		if err == nil {
+			return recipe, nil
+		}
+	}
+	return Recipe{}, ErrNoRecipe
+}
+
+// ParseJSONLD finds the first schema.org Recipe in a JSON-LD document. The
+// Recipe may be the top-level object, an element of a top-level array, or a
+// node in an @graph.
+func ParseJSONLD(data []byte) (Recipe, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Recipe{}, ErrNoRecipe
+	}
+	node, ok := findRecipe(doc)
+	if !ok {
+		return Recipe{}, ErrNoRecipe
+	}
+
+	recipe := Recipe{
+		Name:         cleanText(stringValue(node["name"])),
+		Description:  cleanText(stringValue(node["description"])),
+		URL:          strings.TrimSpace(stringValue(node["url"])),
+		Servings:     parseYield(node["recipeYield"]),
+		Ingredients:  ingredientLines(node),
+		Instructions: instructionSteps(node["recipeInstructions"]),
+	}
+
+	prep, hasPrep := ParseDuration(stringValue(node["prepTime"]))
+	cook, hasCook := ParseDuration(stringValue(node["cookTime"]))
+	total, hasTotal := ParseDuration(stringValue(node["totalTime"]))
+	if !hasTotal && (hasPrep || hasCook) {
+		total = prep + cook
+	}
+	recipe.PrepMinutes = prep
+	recipe.TotalMinutes = total
+
+	return recipe, nil
+}
+
+// ParseDuration converts an ISO-8601 duration such as "PT1H30M" or
+// "P0DT20M" to whole minutes, rounding partial minutes up. Year and month
+// designators are not supported since their length is ambiguous.
+func ParseDuration(value string) (int, bool) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, false
+	}
+	m := durationPattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0, false
+	}
+
+	unitSeconds := []float64{7 * 24 * 3600, 24 * 3600, 3600, 60, 1}
+	var seconds float64
+	for i, part := range m[1:] {
+		if part == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, false
+		}
+		seconds += n * unitSeconds[i]
+	}
+	minutes := math.Ceil(seconds / 60)
+	if minutes > math.MaxInt32 {
+		return 0, false
+	}
+	return int(minutes), true
+}
+
+func findRecipe(v any) (map[string]any, bool) {
+	switch node := v.(type) {
+	case map[string]any:
+		if hasType(node["@type"], "Recipe") {
+			return node, true
+		}
+		for _, key := range []string{"@graph", "mainEntity", "mainEntityOfPage"} {
+			if found, ok := findRecipe(node[key]); ok {
+				return found, true
+			}
+		}
+	case []any:
+		for _, item := range node {
+			if found, ok := findRecipe(item); ok {
+				return found, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// hasType reports whether an @type value (a string or array of strings)
+// names want, ignoring any "schema:" or URL prefix.
+func hasType(v any, want string) bool {
+	switch t := v.(type) {
+	case string:
+		t = t[strings.LastIndexAny(t, "/:")+1:]
+		return strings.EqualFold(t, want)
+	case []any:
+		for _, item := range t {
+			if hasType(item, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func stringValue(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case []any:
+		if len(t) > 0 {
+			return stringValue(t[0])
+		}
+	case map[string]any:
+		// e.g. {"@id": "https://..."} for url
+		if id, ok := t["@id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// parseYield returns the first positive whole number in a recipeYield value,
+// which may be a number, a string such as "Serves 4-6", or an array of either.
+func parseYield(v any) int {
+	switch t := v.(type) {
+	case float64:
+		if t >= 1 && t <= math.MaxInt32 {
+			return int(t)
+		}
+	case string:
+		for _, match := range numberPattern.FindAllString(t, -1) {
+			if n, err := strconv.Atoi(match); err == nil && n > 0 {
+				return n
+			}
+		}
+	case []any:
+		for _, item := range t {
+			if n := parseYield(item); n > 0 {
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+func ingredientLines(node map[string]any) []string {
+	raw, ok := node["recipeIngredient"]
+	if !ok {
+		// Pre-2015 pages use the deprecated "ingredients" property.
+		raw = node["ingredients"]
+	}
+	var items []any
+	switch t := raw.(type) {
+	case []any:
+		items = t
+	case string:
+		items = []any{t}
+	}
+
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		if line := cleanText(stringValue(item)); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// instructionSteps flattens recipeInstructions, which may be a block of
+// text, an array of strings, HowToStep objects, or HowToSection objects
+// that group further steps.
+func instructionSteps(v any) []string {
+	var steps []string
+	var walk func(any)
+	walk = func(v any) {
+		switch t := v.(type) {
+		case string:
+			for _, line := range strings.Split(html.UnescapeString(t), "\n") {
+				if line = cleanText(line); line != "" {
+					steps = append(steps, line)
+				}
+			}
+		case []any:
+			for _, item := range t {
+				walk(item)
+			}
+		case map[string]any:
+			if elements, ok := t["itemListElement"]; ok {
+				walk(elements)
+				return
+			}
+			text := cleanText(stringValue(t["text"]))
+			if text == "" {
+				text = cleanText(stringValue(t["name"]))
+			}
+			if text != "" {
+				steps = append(steps, text)
+			}
+		}
+	}
+	walk(v)
+	return steps
+}
+
+// cleanText decodes HTML entities, strips markup, and collapses whitespace.
+func cleanText(s string) string {
+	s = html.UnescapeString(s)
+	s = tagPattern.ReplaceAllString(s, " ")
+	// Decode again for double-escaped content such as "&amp;amp;".
+	s = html.UnescapeString(s)
+	s = spacePattern.ReplaceAllString(s, " ")
+	// Tags are replaced with a space so "a<br>b" stays two words; undo the
+	// gap that leaves before punctuation in "<b>1 hour</b>.".
+	s = punctSpacePattern.ReplaceAllString(s, "$1")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,117 @@
+package schemaorg
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const testPage = `<!doctype html>
+<html><head>
+<script type="application/ld+json">{"@context":"https://schema.org","@type":"WebSite","name":"Example"}</script>
+<script type="application/ld+json">{not json}</script>
+<script type='application/ld+json'>
+{
+  "@context": "https://schema.org",
+  "@graph": [
+    {"@type": "Organization", "name": "Example"},
+    {
+      "@type": ["Recipe", "NewsArticle"],
+      "name": "Grandma&#39;s <b>Chicken</b> Soup",
+      "description": "Cozy &amp; simple.",
+      "url": "https://example.com/soup",
+      "recipeYield": ["6", "6 bowls"],
+      "prepTime": "PT15M",
+      "cookTime": "PT1H",
+      "recipeIngredient": ["1 whole chicken", "  2  carrots, diced ", ""],
+      "recipeInstructions": [
+        {"@type": "HowToSection", "name": "Broth", "itemListElement": [
+          {"@type": "HowToStep", "text": "Cover the chicken with water."},
+          {"@type": "HowToStep", "text": "Simmer for <strong>1 hour</strong>."}
+        ]},
+        {"@type": "HowToStep", "name": "Add carrots."}
+      ]
+    }
+  ]
+}
+</script>
+</head><body></body></html>`
+
+func TestParseHTML(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseHTML([]byte(testPage))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := Recipe{
+		Name:         "Grandma's Chicken Soup",
+		Description:  "Cozy & simple.",
+		URL:          "https://example.com/soup",
+		Servings:     6,
+		PrepMinutes:  15,
+		TotalMinutes: 75,
+		Ingredients:  []string{"1 whole chicken", "2 carrots, diced"},
+		Instructions: []string{"Cover the chicken with water.", "Simmer for 1 hour.", "Add carrots."},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("recipe=%+v\nwant   %+v", got, want)
+	}
+}
+
+func TestParseJSONLDTextInstructionsAndLegacyIngredients(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseJSONLD([]byte(`[{"@type":"schema:Recipe","name":"Toast","recipeYield":"Serves 2-3","totalTime":"PT5M","ingredients":["bread"],"recipeInstructions":"Toast the bread.\nButter it."}]`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got.Servings != 2 || got.TotalMinutes != 5 || got.PrepMinutes != 0 {
+		t.Fatalf("servings=%d total=%d prep=%d", got.Servings, got.TotalMinutes, got.PrepMinutes)
+	}
+	if !reflect.DeepEqual(got.Ingredients, []string{"bread"}) {
+		t.Fatalf("ingredients=%v", got.Ingredients)
+	}
+	if !reflect.DeepEqual(got.Instructions, []string{"Toast the bread.", "Butter it."}) {
+		t.Fatalf("instructions=%v", got.Instructions)
+	}
+}
+
+func TestParseNoRecipe(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseHTML([]byte("<html><body>No data</body></html>")); !errors.Is(err, ErrNoRecipe) {
+		t.Fatalf("html err=%v, want ErrNoRecipe", err)
+	}
+	if _, err := ParseJSONLD([]byte(`{"@type":"Article"}`)); !errors.Is(err, ErrNoRecipe) {
+		t.Fatalf("jsonld err=%v, want ErrNoRecipe", err)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in     string
+		want   int
+		wantOK bool
+	}{
+		{in: "PT1H30M", want: 90, wantOK: true},
+		{in: "PT90M", want: 90, wantOK: true},
+		{in: "P0DT20M", want: 20, wantOK: true},
+		{in: "P1DT2H", want: 1560, wantOK: true},
+		{in: "PT1.5H", want: 90, wantOK: true},
+		{in: "PT30S", want: 1, wantOK: true},
+		{in: "pt10m", want: 10, wantOK: true},
+		{in: "", wantOK: false},
+		{in: "PT", wantOK: false},
+		{in: "P1M", wantOK: false},
+		{in: "20 minutes", wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDuration(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Fatalf("ParseDuration(%q)=(%d,%t), want (%d,%t)", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
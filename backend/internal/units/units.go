@@ -0,0 +1,171 @@
+// Package units provides a canonical registry of cooking units and the
+// conversion rules between compatible units.
+package units
+
+import (
+	"errors"
+	"strings"
+)
+
+// Dimension groups units that measure the same kind of quantity.
+type Dimension string
+
+const (
+	// Volume covers liquid and dry volume measures.
+	Volume Dimension = "volume"
+	// Mass covers weight measures.
+	Mass Dimension = "mass"
+	// Count covers discrete units (pieces, cloves, cans, ...).
+	Count Dimension = "count"
+)
+
+// System identifies the measurement system a unit belongs to.
+type System string
+
+const (
+	// Metric units (ml, g, ...).
+	Metric System = "metric"
+	// Imperial units, using US customary volumes (cup, tbsp, ...).
+	Imperial System = "imperial"
+	// Neutral units belong to neither system (piece, clove, ...).
+	Neutral System = ""
+)
+
+var (
+	// ErrUnknownUnit is returned when a unit is not in the registry.
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrIncompatible is returned when two units cannot be converted.
+	ErrIncompatible = errors.New("incompatible units")
+)
+
+// Unit describes a canonical unit and how it relates to its base unit.
+type Unit struct {
+	// Name is the canonical short name used when rendering merged quantities.
+	Name      string
+	Dimension Dimension
+	System    System
+	// Base names the unit this one converts through; units convert only
+	// when they share a base. Volume uses ml, mass uses g.
+	Base string
+	// ToBase is the number of base units in one of this unit.
+	ToBase float64
+}
+
+// Compatible reports whether quantities in u and other can be converted.
+func (u Unit) Compatible(other Unit) bool {
+	return u.Base != "" && u.Base == other.Base
+}
+
+type unitSpec struct {
+	unit    Unit
+	aliases []string
+}
+
+var registry = []unitSpec{
+	// Volume (base: ml).
+	{Unit{Name: "ml", Dimension: Volume, System: Metric, Base: "ml", ToBase: 1}, []string{"milliliter", "milliliters", "millilitre", "millilitres", "mls"}},
+	{Unit{Name: "cl", Dimension: Volume, System: Metric, Base: "ml", ToBase: 10}, []string{"centiliter", "centiliters", "centilitre", "centilitres"}},
+	{Unit{Name: "dl", Dimension: Volume, System: Metric, Base: "ml", ToBase: 100}, []string{"deciliter", "deciliters", "decilitre", "decilitres"}},
+	{Unit{Name: "l", Dimension: Volume, System: Metric, Base: "ml", ToBase: 1000}, []string{"liter", "liters", "litre", "litres"}},
+	{Unit{Name: "tsp", Dimension: Volume, System: Imperial, Base: "ml", ToBase: 4.92892159375}, []string{"teaspoon", "teaspoons", "tsps"}},
+	{Unit{Name: "tbsp", Dimension: Volume, System: Imperial, Base: "ml", ToBase: 14.78676478125}, []string{"tablespoon", "tablespoons", "tbsps", "tbs", "tbl"}},
+	{Unit{Name: "fl oz", Dimension: Volume, System: Imperial, Base: "ml", ToBase: 29.5735295625}, []string{"fluid ounce", "fluid ounces", "floz"}},
+	{Unit{Name: "cup", Dimension: Volume, System: Imperial, Base: "ml", ToBase: 236.5882365}, []string{"cups", "c"}},
+	{Unit{Name: "pint", Dimension: Volume, System: Imperial, Base: "ml", ToBase: 473.176473}, []string{"pints", "pt"}},
+	{Unit{Name: "quart", Dimension: Volume, System: Imperial, Base: "ml", ToBase: 946.352946}, []string{"quarts", "qt"}},
+	{Unit{Name: "gallon", Dimension: Volume, System: Imperial, Base: "ml", ToBase: 3785.411784}, []string{"gallons", "gal"}},
+
+	// Mass (base: g).
+	{Unit{Name: "mg", Dimension: Mass, System: Metric, Base: "g", ToBase: 0.001}, []string{"milligram", "milligrams", "milligramme", "milligrammes"}},
+	{Unit{Name: "g", Dimension: Mass, System: Metric, Base: "g", ToBase: 1}, []string{"gram", "grams", "gramme", "grammes"}},
+	{Unit{Name: "kg", Dimension: Mass, System: Metric, Base: "g", ToBase: 1000}, []string{"kilogram", "kilograms", "kilogramme", "kilogrammes", "kilo", "kilos", "kgs"}},
+	{Unit{Name: "oz", Dimension: Mass, System: Imperial, Base: "g", ToBase: 28.349523125}, []string{"ounce", "ounces"}},
+	{Unit{Name: "lb", Dimension: Mass, System: Imperial, Base: "g", ToBase: 453.59237}, []string{"pound", "pounds", "lbs"}},
+
+	// Generic counts (base: piece).
+	{Unit{Name: "piece", Dimension: Count, System: Neutral, Base: "piece", ToBase: 1}, []string{"pieces", "pc", "pcs", "each", "ea", "whole"}},
+	{Unit{Name: "dozen", Dimension: Count, System: Neutral, Base: "piece", ToBase: 12}, []string{"dozens", "doz"}},
+
+	// Discrete units that only merge with their own aliases.
+	{Unit{Name: "clove", Dimension: Count, System: Neutral, Base: "clove", ToBase: 1}, []string{"cloves"}},
+	{Unit{Name: "can", Dimension: Count, System: Neutral, Base: "can", ToBase: 1}, []string{"cans"}},
+	{Unit{Name: "jar", Dimension: Count, System: Neutral, Base: "jar", ToBase: 1}, []string{"jars"}},
+	{Unit{Name: "package", Dimension: Count, System: Neutral, Base: "package", ToBase: 1}, []string{"packages", "pkg", "pkgs", "packet", "packets"}},
+	{Unit{Name: "bunch", Dimension: Count, System: Neutral, Base: "bunch", ToBase: 1}, []string{"bunches"}},
+	{Unit{Name: "head", Dimension: Count, System: Neutral, Base: "head", ToBase: 1}, []string{"heads"}},
+	{Unit{Name: "slice", Dimension: Count, System: Neutral, Base: "slice", ToBase: 1}, []string{"slices"}},
+	{Unit{Name: "stick", Dimension: Count, System: Neutral, Base: "stick", ToBase: 1}, []string{"sticks"}},
+	{Unit{Name: "sprig", Dimension: Count, System: Neutral, Base: "sprig", ToBase: 1}, []string{"sprigs"}},
+	{Unit{Name: "loaf", Dimension: Count, System: Neutral, Base: "loaf", ToBase: 1}, []string{"loaves"}},
+	{Unit{Name: "pinch", Dimension: Count, System: Neutral, Base: "pinch", ToBase: 1}, []string{"pinches"}},
+	{Unit{Name: "dash", Dimension: Count, System: Neutral, Base: "dash", ToBase: 1}, []string{"dashes"}},
+}
+
+var byName = buildIndex()
+
+func buildIndex() map[string]Unit {
+	index := make(map[string]Unit, len(registry)*4)
+	for _, spec := range registry {
+		index[spec.unit.Name] = spec.unit
+		for _, alias := range spec.aliases {
+			index[alias] = spec.unit
+		}
+	}
+	return index
+}
+
+// normalizeName lowercases a unit, drops periods, and collapses whitespace
+// so "Fl. Oz." and "fl oz" resolve to the same entry.
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.ReplaceAll(name, ".", ""))
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Lookup resolves a unit name or alias to its canonical Unit.
+func Lookup(name string) (Unit, bool) {
+	unit, ok := byName[normalizeName(name)]
+	return unit, ok
+}
+
+// All returns every canonical unit in registry order.
+func All() []Unit {
+	out := make([]Unit, 0, len(registry))
+	for _, spec := range registry {
+		out = append(out, spec.unit)
+	}
+	return out
+}
+
+// Factor returns the multiplier that converts an amount in from into to.
+func Factor(from, to Unit) (float64, error) {
+	if !from.Compatible(to) {
+		return 0, ErrIncompatible
+	}
+	return from.ToBase / to.ToBase, nil
+}
+
+// Convert converts amount between two named units.
+func Convert(amount float64, from, to string) (float64, error) {
+	fromUnit, ok := Lookup(from)
+	if !ok {
+		return 0, ErrUnknownUnit
+	}
+	toUnit, ok := Lookup(to)
+	if !ok {
+		return 0, ErrUnknownUnit
+	}
+	factor, err := Factor(fromUnit, toUnit)
+	if err != nil {
+		return 0, err
+	}
+	return amount * factor, nil
+}
+
+// Larger returns whichever of two compatible units is bigger; merged
+// quantities are expressed in it so "1 cup + 4 tbsp" reads as 1.25 cup.
+func Larger(a, b Unit) Unit {
+	if b.ToBase > a.ToBase {
+		return b
+	}
+	return a
+}
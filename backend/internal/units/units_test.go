@@ -0,0 +1,96 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLookupAliases(t *testing.T) {
+	cases := map[string]string{
+		"g":             "g",
+		"Grams":         "g",
+		"kilos":         "kg",
+		"Tbsp.":         "tbsp",
+		"tablespoons":   "tbsp",
+		"Fl. Oz.":       "fl oz",
+		"fluid  ounces": "fl oz",
+		"cups":          "cup",
+		"lbs":           "lb",
+		"cloves":        "clove",
+		"each":          "piece",
+	}
+	for input, want := range cases {
+		got, ok := Lookup(input)
+		if !ok {
+			t.Fatalf("Lookup(%q) not found", input)
+		}
+		if got.Name != want {
+			t.Fatalf("Lookup(%q)=%q, want %q", input, got.Name, want)
+		}
+	}
+
+	if _, ok := Lookup("to taste"); ok {
+		t.Fatalf("Lookup(to taste) should not resolve")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	cases := []struct {
+		amount   float64
+		from, to string
+		want     float64
+	}{
+		{amount: 1, from: "cup", to: "tbsp", want: 16},
+		{amount: 3, from: "tsp", to: "tbsp", want: 1},
+		{amount: 1500, from: "g", to: "kg", want: 1.5},
+		{amount: 1, from: "lb", to: "oz", want: 16},
+		{amount: 1, from: "l", to: "ml", want: 1000},
+		{amount: 2, from: "dozen", to: "pieces", want: 24},
+	}
+	for _, tc := range cases {
+		got, err := Convert(tc.amount, tc.from, tc.to)
+		if err != nil {
+			t.Fatalf("Convert(%v %s -> %s): %v", tc.amount, tc.from, tc.to, err)
+		}
+		if math.Abs(got-tc.want) > 1e-9 {
+			t.Fatalf("Convert(%v %s -> %s)=%v, want %v", tc.amount, tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	if _, err := Convert(1, "cup", "g"); !errors.Is(err, ErrIncompatible) {
+		t.Fatalf("cup->g err=%v, want ErrIncompatible", err)
+	}
+	if _, err := Convert(1, "clove", "piece"); !errors.Is(err, ErrIncompatible) {
+		t.Fatalf("clove->piece err=%v, want ErrIncompatible", err)
+	}
+	if _, err := Convert(1, "handful", "cup"); !errors.Is(err, ErrUnknownUnit) {
+		t.Fatalf("handful->cup err=%v, want ErrUnknownUnit", err)
+	}
+}
+
+func TestRegistryHasNoDuplicateNames(t *testing.T) {
+	seen := map[string]string{}
+	for _, spec := range registry {
+		names := append([]string{spec.unit.Name}, spec.aliases...)
+		for _, name := range names {
+			if owner, ok := seen[name]; ok {
+				t.Fatalf("name %q registered for %q and %q", name, owner, spec.unit.Name)
+			}
+			seen[name] = spec.unit.Name
+		}
+	}
+}
+
+func TestLarger(t *testing.T) {
+	cup, _ := Lookup("cup")
+	tbsp, _ := Lookup("tbsp")
+	if got := Larger(tbsp, cup); got.Name != "cup" {
+		t.Fatalf("Larger(tbsp, cup)=%q, want cup", got.Name)
+	}
+	if got := Larger(cup, tbsp); got.Name != "cup" {
+		t.Fatalf("Larger(cup, tbsp)=%q, want cup", got.Name)
+	}
+}
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE FUNCTION recipe_search_document(target_recipe_id uuid) RETURNS tsvector
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT
+		setweight(to_tsvector('english', r.title), 'A')
+		|| setweight(to_tsvector('english', COALESCE((
+			SELECT string_agg(i.name::text, ' ' ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '')), 'B')
+		|| setweight(to_tsvector('english', COALESCE(r.notes, '')), 'C')
+		|| setweight(to_tsvector('english', COALESCE((
+			SELECT string_agg(rs.instruction, ' ' ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '')), 'D')
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+CREATE TABLE recipe_search_documents (
+	recipe_id uuid PRIMARY KEY REFERENCES recipes (id) ON DELETE CASCADE,
+	document tsvector NOT NULL,
+	updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX recipe_search_documents_document_idx ON recipe_search_documents USING gin (document);
+
+INSERT INTO recipe_search_documents (recipe_id, document)
+SELECT r.id, recipe_search_document(r.id)
+FROM recipes r;
+
+-- +goose Down
+DROP TABLE recipe_search_documents;
+DROP FUNCTION recipe_search_document(uuid);
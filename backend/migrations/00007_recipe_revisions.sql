@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE FUNCTION recipe_snapshot(target_recipe_id uuid) RETURNS jsonb
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT jsonb_build_object(
+		'title', r.title,
+		'servings', r.servings,
+		'prep_time_minutes', r.prep_time_minutes,
+		'total_time_minutes', r.total_time_minutes,
+		'source_url', r.source_url,
+		'notes', r.notes,
+		'recipe_book_id', r.recipe_book_id,
+		'tag_ids', COALESCE((
+			SELECT jsonb_agg(rt.tag_id ORDER BY rt.tag_id)
+			FROM recipe_tags rt
+			WHERE rt.recipe_id = r.id
+		), '[]'::jsonb),
+		'ingredients', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'position', ri.position,
+				'quantity', ri.quantity,
+				'quantity_text', ri.quantity_text,
+				'unit', ri.unit,
+				'item_id', ri.item_id,
+				'item_name', i.name,
+				'prep', ri.prep,
+				'notes', ri.notes,
+				'original_text', ri.original_text
+			) ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '[]'::jsonb),
+		'steps', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'step_number', rs.step_number,
+				'instruction', rs.instruction
+			) ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '[]'::jsonb)
+	)
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+CREATE TABLE recipe_revisions (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	revision_number int NOT NULL CONSTRAINT recipe_revisions_revision_number_positive_chk CHECK (revision_number > 0),
+	snapshot jsonb NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	CONSTRAINT recipe_revisions_recipe_id_revision_number_unique UNIQUE (recipe_id, revision_number)
+);
+
+-- Existing recipes start their history at their current state.
+INSERT INTO recipe_revisions (recipe_id, revision_number, snapshot, created_at, created_by)
+SELECT r.id, 1, recipe_snapshot(r.id), r.updated_at, r.updated_by
+FROM recipes r;
+
+-- +goose Down
+DROP TABLE recipe_revisions;
+DROP FUNCTION recipe_snapshot(uuid);
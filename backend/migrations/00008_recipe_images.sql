@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE recipe_images (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	-- Steps are rewritten on every recipe update, so images point at a step
+	-- number rather than a step row.
+	step_number int CONSTRAINT recipe_images_step_number_positive_chk CHECK (step_number > 0),
+	content_type text NOT NULL,
+	byte_size bigint NOT NULL CONSTRAINT recipe_images_byte_size_positive_chk CHECK (byte_size > 0),
+	width int NOT NULL,
+	height int NOT NULL,
+	blob_key text NOT NULL,
+	thumbnail_key text NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id)
+);
+
+CREATE INDEX recipe_images_recipe_id_idx ON recipe_images (recipe_id);
+
+-- +goose Down
+DROP TABLE recipe_images;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE item_nutrition (
+	item_id uuid PRIMARY KEY REFERENCES items (id) ON DELETE CASCADE,
+	-- Nutrient values describe reference_quantity of reference_unit, e.g. 100 g.
+	reference_quantity numeric NOT NULL CONSTRAINT item_nutrition_reference_quantity_positive_chk CHECK (reference_quantity > 0),
+	reference_unit text NOT NULL,
+	calories numeric NOT NULL CONSTRAINT item_nutrition_calories_nonnegative_chk CHECK (calories >= 0),
+	protein_g numeric NOT NULL CONSTRAINT item_nutrition_protein_g_nonnegative_chk CHECK (protein_g >= 0),
+	fat_g numeric NOT NULL CONSTRAINT item_nutrition_fat_g_nonnegative_chk CHECK (fat_g >= 0),
+	carbs_g numeric NOT NULL CONSTRAINT item_nutrition_carbs_g_nonnegative_chk CHECK (carbs_g >= 0),
+	fiber_g numeric NOT NULL CONSTRAINT item_nutrition_fiber_g_nonnegative_chk CHECK (fiber_g >= 0),
+	sodium_mg numeric NOT NULL CONSTRAINT item_nutrition_sodium_mg_nonnegative_chk CHECK (sodium_mg >= 0),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id)
+);
+
+-- +goose Down
+DROP TABLE item_nutrition;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE recipe_cook_events (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	cooked_at timestamptz NOT NULL DEFAULT now(),
+	servings int CONSTRAINT recipe_cook_events_servings_positive_chk CHECK (servings > 0),
+	rating int CONSTRAINT recipe_cook_events_rating_range_chk CHECK (rating BETWEEN 1 AND 5),
+	notes text,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id)
+);
+
+CREATE INDEX recipe_cook_events_recipe_id_cooked_at_idx ON recipe_cook_events (recipe_id, cooked_at DESC);
+
+-- +goose Down
+DROP TABLE recipe_cook_events;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE recipe_favorites (
+	user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (user_id, recipe_id)
+);
+
+CREATE INDEX recipe_favorites_recipe_id_idx ON recipe_favorites (recipe_id);
+
+CREATE TABLE recipe_user_notes (
+	user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	note text NOT NULL CONSTRAINT recipe_user_notes_note_not_blank_chk CHECK (btrim(note) <> ''),
+	created_at timestamptz NOT NULL DEFAULT now(),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (user_id, recipe_id)
+);
+
+-- +goose Down
+DROP TABLE recipe_user_notes;
+DROP TABLE recipe_favorites;
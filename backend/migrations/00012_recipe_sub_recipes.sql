@@ -0,0 +1,130 @@
+-- +goose Up
+ALTER TABLE recipe_ingredients
+	ALTER COLUMN item_id DROP NOT NULL;
+
+ALTER TABLE recipe_ingredients
+	ADD COLUMN sub_recipe_id uuid REFERENCES recipes (id);
+
+-- A line is either a shopping item or another recipe, never both.
+ALTER TABLE recipe_ingredients
+	ADD CONSTRAINT recipe_ingredients_item_or_sub_recipe_chk CHECK ((item_id IS NULL) <> (sub_recipe_id IS NULL));
+
+ALTER TABLE recipe_ingredients
+	ADD CONSTRAINT recipe_ingredients_sub_recipe_not_self_chk CHECK (sub_recipe_id <> recipe_id);
+
+CREATE INDEX recipe_ingredients_sub_recipe_id_idx ON recipe_ingredients (sub_recipe_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_snapshot(target_recipe_id uuid) RETURNS jsonb
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT jsonb_build_object(
+		'title', r.title,
+		'servings', r.servings,
+		'prep_time_minutes', r.prep_time_minutes,
+		'total_time_minutes', r.total_time_minutes,
+		'source_url', r.source_url,
+		'notes', r.notes,
+		'recipe_book_id', r.recipe_book_id,
+		'tag_ids', COALESCE((
+			SELECT jsonb_agg(rt.tag_id ORDER BY rt.tag_id)
+			FROM recipe_tags rt
+			WHERE rt.recipe_id = r.id
+		), '[]'::jsonb),
+		'ingredients', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'position', ri.position,
+				'quantity', ri.quantity,
+				'quantity_text', ri.quantity_text,
+				'unit', ri.unit,
+				'item_id', ri.item_id,
+				'item_name', i.name,
+				'sub_recipe_id', ri.sub_recipe_id,
+				'prep', ri.prep,
+				'notes', ri.notes,
+				'original_text', ri.original_text
+			) ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			LEFT JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '[]'::jsonb),
+		'steps', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'step_number', rs.step_number,
+				'instruction', rs.instruction
+			) ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '[]'::jsonb)
+	)
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_snapshot(target_recipe_id uuid) RETURNS jsonb
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT jsonb_build_object(
+		'title', r.title,
+		'servings', r.servings,
+		'prep_time_minutes', r.prep_time_minutes,
+		'total_time_minutes', r.total_time_minutes,
+		'source_url', r.source_url,
+		'notes', r.notes,
+		'recipe_book_id', r.recipe_book_id,
+		'tag_ids', COALESCE((
+			SELECT jsonb_agg(rt.tag_id ORDER BY rt.tag_id)
+			FROM recipe_tags rt
+			WHERE rt.recipe_id = r.id
+		), '[]'::jsonb),
+		'ingredients', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'position', ri.position,
+				'quantity', ri.quantity,
+				'quantity_text', ri.quantity_text,
+				'unit', ri.unit,
+				'item_id', ri.item_id,
+				'item_name', i.name,
+				'prep', ri.prep,
+				'notes', ri.notes,
+				'original_text', ri.original_text
+			) ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '[]'::jsonb),
+		'steps', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'step_number', rs.step_number,
+				'instruction', rs.instruction
+			) ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '[]'::jsonb)
+	)
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+DELETE FROM recipe_ingredients
+WHERE sub_recipe_id IS NOT NULL;
+
+DROP INDEX recipe_ingredients_sub_recipe_id_idx;
+
+ALTER TABLE recipe_ingredients
+	DROP CONSTRAINT recipe_ingredients_sub_recipe_not_self_chk;
+
+ALTER TABLE recipe_ingredients
+	DROP CONSTRAINT recipe_ingredients_item_or_sub_recipe_chk;
+
+ALTER TABLE recipe_ingredients
+	DROP COLUMN sub_recipe_id;
+
+ALTER TABLE recipe_ingredients
+	ALTER COLUMN item_id SET NOT NULL;
@@ -0,0 +1,126 @@
+-- +goose Up
+ALTER TABLE recipe_steps
+	ADD COLUMN duration_seconds integer NULL CONSTRAINT recipe_steps_duration_seconds_chk CHECK (duration_seconds > 0),
+	ADD COLUMN duration_max_seconds integer NULL,
+	ADD COLUMN temperature integer NULL,
+	ADD COLUMN temperature_unit text NULL CONSTRAINT recipe_steps_temperature_unit_chk CHECK (temperature_unit IN ('F', 'C')),
+	ADD CONSTRAINT recipe_steps_duration_range_chk CHECK (
+		duration_max_seconds IS NULL
+		OR (duration_seconds IS NOT NULL AND duration_max_seconds >= duration_seconds)
+	),
+	ADD CONSTRAINT recipe_steps_temperature_pair_chk CHECK ((temperature IS NULL) = (temperature_unit IS NULL));
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_snapshot(target_recipe_id uuid) RETURNS jsonb
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT jsonb_build_object(
+		'title', r.title,
+		'servings', r.servings,
+		'prep_time_minutes', r.prep_time_minutes,
+		'total_time_minutes', r.total_time_minutes,
+		'source_url', r.source_url,
+		'notes', r.notes,
+		'recipe_book_id', r.recipe_book_id,
+		'tag_ids', COALESCE((
+			SELECT jsonb_agg(rt.tag_id ORDER BY rt.tag_id)
+			FROM recipe_tags rt
+			WHERE rt.recipe_id = r.id
+		), '[]'::jsonb),
+		'ingredients', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'position', ri.position,
+				'quantity', ri.quantity,
+				'quantity_text', ri.quantity_text,
+				'unit', ri.unit,
+				'item_id', ri.item_id,
+				'item_name', i.name,
+				'sub_recipe_id', ri.sub_recipe_id,
+				'group_name', ri.group_name,
+				'prep', ri.prep,
+				'notes', ri.notes,
+				'original_text', ri.original_text
+			) ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			LEFT JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '[]'::jsonb),
+		'steps', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'step_number', rs.step_number,
+				'instruction', rs.instruction,
+				'group_name', rs.group_name,
+				'duration_seconds', rs.duration_seconds,
+				'duration_max_seconds', rs.duration_max_seconds,
+				'temperature', rs.temperature,
+				'temperature_unit', rs.temperature_unit
+			) ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '[]'::jsonb)
+	)
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_snapshot(target_recipe_id uuid) RETURNS jsonb
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT jsonb_build_object(
+		'title', r.title,
+		'servings', r.servings,
+		'prep_time_minutes', r.prep_time_minutes,
+		'total_time_minutes', r.total_time_minutes,
+		'source_url', r.source_url,
+		'notes', r.notes,
+		'recipe_book_id', r.recipe_book_id,
+		'tag_ids', COALESCE((
+			SELECT jsonb_agg(rt.tag_id ORDER BY rt.tag_id)
+			FROM recipe_tags rt
+			WHERE rt.recipe_id = r.id
+		), '[]'::jsonb),
+		'ingredients', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'position', ri.position,
+				'quantity', ri.quantity,
+				'quantity_text', ri.quantity_text,
+				'unit', ri.unit,
+				'item_id', ri.item_id,
+				'item_name', i.name,
+				'sub_recipe_id', ri.sub_recipe_id,
+				'group_name', ri.group_name,
+				'prep', ri.prep,
+				'notes', ri.notes,
+				'original_text', ri.original_text
+			) ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			LEFT JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '[]'::jsonb),
+		'steps', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'step_number', rs.step_number,
+				'instruction', rs.instruction,
+				'group_name', rs.group_name
+			) ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '[]'::jsonb)
+	)
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE recipe_steps
+	DROP CONSTRAINT recipe_steps_temperature_pair_chk,
+	DROP CONSTRAINT recipe_steps_duration_range_chk,
+	DROP COLUMN temperature_unit,
+	DROP COLUMN temperature,
+	DROP COLUMN duration_max_seconds,
+	DROP COLUMN duration_seconds;
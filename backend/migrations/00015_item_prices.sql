@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE item_prices (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	-- price is what quantity of unit cost, e.g. 3.49 for 1 lb.
+	price numeric NOT NULL CONSTRAINT item_prices_price_nonnegative_chk CHECK (price >= 0),
+	quantity numeric NOT NULL CONSTRAINT item_prices_quantity_positive_chk CHECK (quantity > 0),
+	unit text NOT NULL,
+	store text CONSTRAINT item_prices_store_not_blank_chk CHECK (store IS NULL OR btrim(store) <> ''),
+	priced_on date NOT NULL DEFAULT current_date,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id)
+);
+
+CREATE INDEX item_prices_item_id_priced_on_idx ON item_prices (item_id, priced_on DESC, created_at DESC);
+
+-- +goose Down
+DROP TABLE item_prices;
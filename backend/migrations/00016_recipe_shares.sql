@@ -0,0 +1,17 @@
+-- +goose Up
+-- recipe_shares grants read-only access to one recipe to anyone holding the
+-- token. Only the sha256 hash of the token is stored.
+CREATE TABLE recipe_shares (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	recipe_id uuid NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	token_hash text NOT NULL,
+	expires_at timestamptz NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	CONSTRAINT recipe_shares_token_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX recipe_shares_recipe_id_idx ON recipe_shares (recipe_id);
+
+-- +goose Down
+DROP TABLE recipe_shares;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- parent_recipe_id records the recipe a clone or variant was copied from.
+ALTER TABLE recipes
+	ADD COLUMN parent_recipe_id uuid NULL REFERENCES recipes (id) ON DELETE SET NULL;
+
+ALTER TABLE recipes
+	ADD CONSTRAINT recipes_parent_not_self_chk CHECK (parent_recipe_id <> id);
+
+CREATE INDEX recipes_parent_recipe_id_idx ON recipes (parent_recipe_id);
+
+-- +goose Down
+DROP INDEX recipes_parent_recipe_id_idx;
+
+ALTER TABLE recipes
+	DROP CONSTRAINT recipes_parent_not_self_chk;
+
+ALTER TABLE recipes
+	DROP COLUMN parent_recipe_id;
@@ -0,0 +1,52 @@
+-- +goose Up
+-- item_attributes records what an item contains for allergen and diet
+-- purposes. An item with no rows is treated as containing none of them.
+CREATE TABLE item_attributes (
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	attribute text NOT NULL CONSTRAINT item_attributes_attribute_chk CHECK (
+		attribute IN ('meat', 'fish', 'shellfish', 'dairy', 'eggs', 'honey', 'gluten', 'nuts', 'peanuts', 'soy', 'sesame')
+	),
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	PRIMARY KEY (item_id, attribute)
+);
+
+-- user_dietary_restrictions holds the diets a user follows and the allergens
+-- they avoid. Values are validated by the API against the same vocabulary
+-- used for item attributes.
+CREATE TABLE user_dietary_restrictions (
+	user_id uuid PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+	diets text[] NOT NULL DEFAULT '{}',
+	allergens text[] NOT NULL DEFAULT '{}',
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id)
+);
+
+-- recipe_item_attributes returns the attributes of every item used by a
+-- recipe, including items reached through sub-recipes.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_item_attributes(target_recipe_id uuid) RETURNS SETOF text
+LANGUAGE sql
+STABLE
+AS $$
+	WITH RECURSIVE tree AS (
+		SELECT target_recipe_id AS recipe_id
+		UNION
+		SELECT ri.sub_recipe_id
+		FROM recipe_ingredients ri
+		JOIN tree t ON t.recipe_id = ri.recipe_id
+		WHERE ri.sub_recipe_id IS NOT NULL
+	)
+	SELECT DISTINCT ia.attribute
+	FROM tree t
+	JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+	JOIN item_attributes ia ON ia.item_id = ri.item_id
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION recipe_item_attributes(uuid);
+
+DROP TABLE user_dietary_restrictions;
+
+DROP TABLE item_attributes;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- pantry_items records what the household already has at home. Rows for the
+-- same item, location, unit, and best-before date are one stock entry, so
+-- restocking adds to the existing quantity.
+CREATE TABLE pantry_items (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	quantity numeric NULL CONSTRAINT pantry_items_quantity_positive_chk CHECK (quantity IS NULL OR quantity > 0),
+	unit text NULL,
+	location text NOT NULL DEFAULT 'pantry' CONSTRAINT pantry_items_location_chk CHECK (location IN ('fridge', 'freezer', 'pantry')),
+	best_before date NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id),
+	CONSTRAINT pantry_items_stock_unique UNIQUE NULLS NOT DISTINCT (item_id, location, unit, best_before)
+);
+
+-- +goose Down
+DROP TABLE pantry_items;
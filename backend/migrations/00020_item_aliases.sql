@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE item_aliases (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	-- alias is another name the item goes by, e.g. "scallions" for "green onions".
+	alias citext NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	CONSTRAINT item_aliases_alias_unique UNIQUE (alias)
+);
+
+CREATE INDEX item_aliases_item_id_idx ON item_aliases (item_id);
+CREATE INDEX item_aliases_alias_trgm_idx ON item_aliases USING gin (alias gin_trgm_ops);
+
+-- +goose Down
+DROP TABLE item_aliases;
@@ -0,0 +1,67 @@
+-- +goose Up
+CREATE TABLE stores (
+	id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	name citext NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id),
+	CONSTRAINT stores_name_unique UNIQUE (name)
+);
+
+-- Aisles without a store make up the default layout that items.aisle_id
+-- points into. Each store has its own aisles, named independently.
+ALTER TABLE grocery_aisles
+	ADD COLUMN store_id uuid NULL REFERENCES stores (id) ON DELETE CASCADE;
+
+ALTER TABLE grocery_aisles
+	DROP CONSTRAINT grocery_aisles_name_unique;
+
+ALTER TABLE grocery_aisles
+	ADD CONSTRAINT grocery_aisles_store_name_unique UNIQUE NULLS NOT DISTINCT (store_id, name);
+
+ALTER TABLE grocery_aisles
+	ADD CONSTRAINT grocery_aisles_id_store_unique UNIQUE (id, store_id);
+
+-- store_item_aisles places an item in one of a store's aisles. The composite
+-- key keeps the aisle within the same store.
+CREATE TABLE store_item_aisles (
+	store_id uuid NOT NULL REFERENCES stores (id) ON DELETE CASCADE,
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	aisle_id uuid NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	created_by uuid NOT NULL REFERENCES users (id),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	updated_by uuid NOT NULL REFERENCES users (id),
+	PRIMARY KEY (store_id, item_id),
+	CONSTRAINT store_item_aisles_aisle_fkey FOREIGN KEY (aisle_id, store_id) REFERENCES grocery_aisles (id, store_id) ON DELETE CASCADE
+);
+
+CREATE INDEX store_item_aisles_item_id_idx ON store_item_aisles (item_id);
+
+-- A list assigned to a store is grouped and ordered by that store's aisles.
+ALTER TABLE shopping_lists
+	ADD COLUMN store_id uuid NULL REFERENCES stores (id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE shopping_lists
+	DROP COLUMN store_id;
+
+DROP TABLE store_item_aisles;
+
+DELETE FROM grocery_aisles
+WHERE store_id IS NOT NULL;
+
+ALTER TABLE grocery_aisles
+	DROP CONSTRAINT grocery_aisles_id_store_unique;
+
+ALTER TABLE grocery_aisles
+	DROP CONSTRAINT grocery_aisles_store_name_unique;
+
+ALTER TABLE grocery_aisles
+	ADD CONSTRAINT grocery_aisles_name_unique UNIQUE (name);
+
+ALTER TABLE grocery_aisles
+	DROP COLUMN store_id;
+
+DROP TABLE stores;
@@ -0,0 +1,71 @@
+-- +goose Up
+-- Sub-recipe lines have no item, so index the sub-recipe's title in their place.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_search_document(target_recipe_id uuid) RETURNS tsvector
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT
+		setweight(to_tsvector('english', r.title), 'A')
+		|| setweight(to_tsvector('english', COALESCE((
+			SELECT string_agg(COALESCE(i.name::text, sr.title), ' ' ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			LEFT JOIN items i ON i.id = ri.item_id
+			LEFT JOIN recipes sr ON sr.id = ri.sub_recipe_id
+			WHERE ri.recipe_id = r.id
+		), '')), 'B')
+		|| setweight(to_tsvector('english', COALESCE(r.notes, '')), 'C')
+		|| setweight(to_tsvector('english', COALESCE((
+			SELECT string_agg(rs.instruction, ' ' ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '')), 'D')
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+UPDATE recipe_search_documents d
+SET document = recipe_search_document(d.recipe_id),
+	updated_at = now()
+WHERE EXISTS (
+	SELECT 1
+	FROM recipe_ingredients ri
+	WHERE ri.recipe_id = d.recipe_id
+		AND ri.sub_recipe_id IS NOT NULL
+);
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_search_document(target_recipe_id uuid) RETURNS tsvector
+LANGUAGE sql
+STABLE
+AS $$
+	SELECT
+		setweight(to_tsvector('english', r.title), 'A')
+		|| setweight(to_tsvector('english', COALESCE((
+			SELECT string_agg(i.name::text, ' ' ORDER BY ri.position)
+			FROM recipe_ingredients ri
+			JOIN items i ON i.id = ri.item_id
+			WHERE ri.recipe_id = r.id
+		), '')), 'B')
+		|| setweight(to_tsvector('english', COALESCE(r.notes, '')), 'C')
+		|| setweight(to_tsvector('english', COALESCE((
+			SELECT string_agg(rs.instruction, ' ' ORDER BY rs.step_number)
+			FROM recipe_steps rs
+			WHERE rs.recipe_id = r.id
+		), '')), 'D')
+	FROM recipes r
+	WHERE r.id = target_recipe_id
+$$;
+-- +goose StatementEnd
+
+UPDATE recipe_search_documents d
+SET document = recipe_search_document(d.recipe_id),
+	updated_at = now()
+WHERE EXISTS (
+	SELECT 1
+	FROM recipe_ingredients ri
+	WHERE ri.recipe_id = d.recipe_id
+		AND ri.sub_recipe_id IS NOT NULL
+);
@@ -0,0 +1,48 @@
+-- +goose Up
+-- item_attribute_classifications marks items whose attributes have been set,
+-- so an item that contains none of them can be told apart from one nobody
+-- has looked at. Recipe labels only make claims when every item is classified.
+CREATE TABLE item_attribute_classifications (
+	item_id uuid PRIMARY KEY REFERENCES items (id) ON DELETE CASCADE,
+	classified_at timestamptz NOT NULL DEFAULT now(),
+	classified_by uuid NOT NULL REFERENCES users (id)
+);
+
+-- Items that already have attributes were classified when they were set.
+INSERT INTO item_attribute_classifications (item_id, classified_at, classified_by)
+SELECT DISTINCT ON (ia.item_id) ia.item_id, ia.created_at, ia.created_by
+FROM item_attributes ia
+ORDER BY ia.item_id, ia.created_at;
+
+-- recipe_unclassified_items returns the items used by a recipe, including
+-- items reached through sub-recipes, whose attributes are unknown.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_unclassified_items(target_recipe_id uuid)
+RETURNS TABLE (item_id uuid, item_name text)
+LANGUAGE sql
+STABLE
+AS $$
+	WITH RECURSIVE tree AS (
+		SELECT target_recipe_id AS recipe_id
+		UNION
+		SELECT ri.sub_recipe_id
+		FROM recipe_ingredients ri
+		JOIN tree t ON t.recipe_id = ri.recipe_id
+		WHERE ri.sub_recipe_id IS NOT NULL
+	)
+	SELECT DISTINCT i.id, i.name::text
+	FROM tree t
+	JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+	JOIN items i ON i.id = ri.item_id
+	WHERE NOT EXISTS (
+		SELECT 1
+		FROM item_attribute_classifications c
+		WHERE c.item_id = i.id
+	)
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION recipe_unclassified_items(uuid);
+
+DROP TABLE item_attribute_classifications;
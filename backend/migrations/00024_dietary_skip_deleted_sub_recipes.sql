@@ -0,0 +1,97 @@
+-- +goose Up
+-- Deleted sub-recipes are left off shopping lists, so their items no longer
+-- count toward a recipe's dietary attributes or unknowns either.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_item_attributes(target_recipe_id uuid) RETURNS SETOF text
+LANGUAGE sql
+STABLE
+AS $$
+	WITH RECURSIVE tree AS (
+		SELECT target_recipe_id AS recipe_id
+		UNION
+		SELECT sr.id
+		FROM recipe_ingredients ri
+		JOIN tree t ON t.recipe_id = ri.recipe_id
+		JOIN recipes sr ON sr.id = ri.sub_recipe_id
+		WHERE sr.deleted_at IS NULL
+	)
+	SELECT DISTINCT ia.attribute
+	FROM tree t
+	JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+	JOIN item_attributes ia ON ia.item_id = ri.item_id
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_unclassified_items(target_recipe_id uuid)
+RETURNS TABLE (item_id uuid, item_name text)
+LANGUAGE sql
+STABLE
+AS $$
+	WITH RECURSIVE tree AS (
+		SELECT target_recipe_id AS recipe_id
+		UNION
+		SELECT sr.id
+		FROM recipe_ingredients ri
+		JOIN tree t ON t.recipe_id = ri.recipe_id
+		JOIN recipes sr ON sr.id = ri.sub_recipe_id
+		WHERE sr.deleted_at IS NULL
+	)
+	SELECT DISTINCT i.id, i.name::text
+	FROM tree t
+	JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+	JOIN items i ON i.id = ri.item_id
+	WHERE NOT EXISTS (
+		SELECT 1
+		FROM item_attribute_classifications c
+		WHERE c.item_id = i.id
+	)
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_unclassified_items(target_recipe_id uuid)
+RETURNS TABLE (item_id uuid, item_name text)
+LANGUAGE sql
+STABLE
+AS $$
+	WITH RECURSIVE tree AS (
+		SELECT target_recipe_id AS recipe_id
+		UNION
+		SELECT ri.sub_recipe_id
+		FROM recipe_ingredients ri
+		JOIN tree t ON t.recipe_id = ri.recipe_id
+		WHERE ri.sub_recipe_id IS NOT NULL
+	)
+	SELECT DISTINCT i.id, i.name::text
+	FROM tree t
+	JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+	JOIN items i ON i.id = ri.item_id
+	WHERE NOT EXISTS (
+		SELECT 1
+		FROM item_attribute_classifications c
+		WHERE c.item_id = i.id
+	)
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION recipe_item_attributes(target_recipe_id uuid) RETURNS SETOF text
+LANGUAGE sql
+STABLE
+AS $$
+	WITH RECURSIVE tree AS (
+		SELECT target_recipe_id AS recipe_id
+		UNION
+		SELECT ri.sub_recipe_id
+		FROM recipe_ingredients ri
+		JOIN tree t ON t.recipe_id = ri.recipe_id
+		WHERE ri.sub_recipe_id IS NOT NULL
+	)
+	SELECT DISTINCT ia.attribute
+	FROM tree t
+	JOIN recipe_ingredients ri ON ri.recipe_id = t.recipe_id
+	JOIN item_attributes ia ON ia.item_id = ri.item_id
+$$;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- shopping_list_pantry_reservations records pantry stock already set against
+-- a list when recipes were added with exclude_pantry, in the unit of the need
+-- it covered. Later additions to the same list only use the stock left over.
+CREATE TABLE shopping_list_pantry_reservations (
+	shopping_list_id uuid NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+	item_id uuid NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+	unit text NOT NULL,
+	quantity numeric NOT NULL CHECK (quantity > 0),
+	PRIMARY KEY (shopping_list_id, item_id, unit)
+);
+
+CREATE INDEX shopping_list_pantry_reservations_item_id_idx ON shopping_list_pantry_reservations (item_id);
+
+-- +goose Down
+DROP TABLE shopping_list_pantry_reservations;